@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/cmd/terraci/internal/driftflow"
+	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/planresults"
+)
+
+func newDriftCmd() *cobra.Command {
+	var (
+		failOnDrift bool
+		discover    bool
+		modules     []string
+	)
+	ff := &filter.Flags{}
+
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Collect drift results from plan artifacts",
+		Long: `Collect the results of a drift pipeline and publish a drift report.
+
+This command is designed to run as the final job of a pipeline generated with
+"terraci generate --mode drift". It scans plan.json artifacts in module
+directories, classifies each module as drifted, clean or errored, and writes
+the drift report to the service directory. Generated pipelines pass
+--discover with the filters of "terraci generate", so the command selects the
+same modules the pipeline planned and reports those whose plan job failed as
+errored. The command exits non-zero when any module errored.
+
+Examples:
+  terraci drift
+  terraci drift --discover --filter environment=prod
+  terraci drift --module platform/prod/eu-central-1/vpc
+  terraci drift --fail-on-drift`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			prepared, err := runflow.FromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			result, err := driftflow.Run(cmd.Context(), driftflow.NewRuntime(prepared), driftflow.Request{
+				Modules:  modules,
+				Discover: discover,
+				Filters:  *ff,
+			})
+			if err != nil {
+				return err
+			}
+
+			logDriftSummary(result.Summary)
+			if result.Summary.Errored > 0 {
+				return fmt.Errorf("%d modules could not be checked for drift", result.Summary.Errored)
+			}
+			if failOnDrift && result.Summary.HasDrift() {
+				return errors.New("drift detected")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&failOnDrift, "fail-on-drift", false, "exit non-zero when any module drifted")
+	cmd.Flags().StringArrayVar(&modules, "module", nil, "planned module ID; report it as errored when its plan.json is missing (repeatable)")
+	cmd.Flags().BoolVar(&discover, "discover", false, "treat the modules selected by config and filter flags as planned, like terraci generate --mode drift")
+	registerFilterFlags(cmd, ff)
+
+	return cmd
+}
+
+func logDriftSummary(summary *planresults.DriftSummary) {
+	log.WithField("drifted", summary.Drifted).
+		WithField("clean", summary.Clean).
+		WithField("errored", summary.Errored).
+		Info("drift summary")
+	log.IncreasePadding()
+	for _, module := range summary.Modules {
+		switch module.Status {
+		case planresults.DriftStatusDrifted:
+			log.WithField("add", module.ToAdd).
+				WithField("change", module.ToChange).
+				WithField("destroy", module.ToDestroy).
+				Warn(module.ModulePath)
+		case planresults.DriftStatusErrored:
+			log.WithField("error", module.Error).Error(module.ModulePath)
+		case planresults.DriftStatusClean:
+			log.Debug(module.ModulePath)
+		}
+	}
+	log.DecreasePadding()
+}
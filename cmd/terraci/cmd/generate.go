@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 
@@ -18,14 +20,21 @@ import (
 	"github.com/edelwud/terraci/pkg/pipeline"
 )
 
+// outputFormatIRJSON writes the provider-agnostic pipeline IR instead of a
+// provider pipeline.
+const outputFormatIRJSON = "ir-json"
+
 func newGenerateCmd() *cobra.Command {
 	var (
 		outputFile  string
 		changedOnly bool
 		baseRef     string
 		dryRun      bool
-		dryRunFmt   string
+		format      string
 		planOnly    bool
+		destroy     bool
+		modeName    string
+		check       bool
 	)
 	ff := &filter.Flags{}
 
@@ -41,15 +50,28 @@ Examples:
 	  terraci generate --exclude "*/test/*"
 	  terraci generate --filter environment=stage --filter environment=prod
 	  terraci generate --dry-run
-	  terraci generate --plan-only`,
+	  terraci generate --plan-only
+	  terraci generate --destroy --filter environment=sandbox
+	  terraci generate --mode drift -o drift.yml
+	  terraci generate --check -o .github/workflows/terraform.yml
+	  terraci generate --format ir-json -o pipeline-ir.json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			prepared, err := runflow.FromContext(cmd.Context())
 			if err != nil {
 				return err
 			}
-			mode := generateflow.GenerateModeApply
-			if planOnly {
-				mode = generateflow.GenerateModePlan
+			mode, err := generateMode(modeName, planOnly, destroy)
+			if err != nil {
+				return err
+			}
+			if !dryRun && format != "" && format != outputFormatIRJSON {
+				return fmt.Errorf("unsupported --format %q (want %s, or text/json with --dry-run)", format, outputFormatIRJSON)
+			}
+			if check && outputFile == "" {
+				return errors.New("--check requires --output")
+			}
+			if changedOnly && (mode == generateflow.GenerateModeDestroy || mode == generateflow.GenerateModeDrift) {
+				return fmt.Errorf("--changed-only cannot be combined with %s mode", mode)
 			}
 			result, err := generateflow.Run(cmd.Context(), generateflow.NewRuntime(prepared), generateflow.Request{
 				Filters:     *ff,
@@ -57,6 +79,7 @@ Examples:
 				BaseRef:     baseRef,
 				Mode:        mode,
 				DryRun:      dryRun,
+				IR:          format == outputFormatIRJSON,
 			})
 			if err != nil {
 				return err
@@ -70,9 +93,12 @@ Examples:
 
 			log.WithField("modules", len(result.Project.Targets)).Info("generating pipeline")
 			if dryRun {
-				return renderDryRun(result.DryRun, dryRunFmt)
+				return renderDryRun(result.DryRun, format)
 			}
 
+			if check {
+				return checkPipelineOutput(result.Pipeline, outputFile)
+			}
 			return writePipelineOutput(result.Pipeline, outputFile)
 		},
 	}
@@ -81,13 +107,35 @@ Examples:
 	cmd.Flags().BoolVar(&changedOnly, "changed-only", false, "only include changed modules and their dependents")
 	cmd.Flags().StringVar(&baseRef, "base-ref", "", "base git ref for change detection (default: auto-detect)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be generated without creating output")
-	cmd.Flags().StringVar(&dryRunFmt, "format", "text", "dry-run output format: text or json")
+	cmd.Flags().StringVar(&format, "format", "", "output format: ir-json for the pipeline IR, or text/json with --dry-run")
 	cmd.Flags().BoolVar(&planOnly, "plan-only", false, "generate only plan jobs (no apply jobs)")
+	cmd.Flags().BoolVar(&destroy, "destroy", false, "generate a destroy pipeline in reverse dependency order (every apply requires approval)")
+	cmd.Flags().StringVar(&modeName, "mode", "", "pipeline mode: apply, plan, destroy or drift (default: apply)")
+	cmd.Flags().BoolVar(&check, "check", false, "compare the generated pipeline with --output and fail if it is out of date")
+	cmd.MarkFlagsMutuallyExclusive("plan-only", "destroy", "mode")
+	cmd.MarkFlagsMutuallyExclusive("check", "dry-run")
 	registerFilterFlags(cmd, ff)
 
 	return cmd
 }
 
+// generateMode resolves --mode and its --plan-only / --destroy shorthands.
+func generateMode(name string, planOnly, destroy bool) (generateflow.GenerateMode, error) {
+	switch {
+	case planOnly:
+		return generateflow.GenerateModePlan, nil
+	case destroy:
+		return generateflow.GenerateModeDestroy, nil
+	case name == "":
+		return generateflow.GenerateModeApply, nil
+	}
+	mode := generateflow.GenerateMode(name)
+	if !slices.Contains(generateflow.GenerateModes, mode) {
+		return "", fmt.Errorf("unsupported --mode %q (want apply, plan, destroy or drift)", name)
+	}
+	return mode, nil
+}
+
 func logGenerateProjectDiagnostics(result *projectflow.Result) {
 	if result == nil || result.Workflow == nil {
 		return
@@ -194,13 +242,11 @@ func renderDryRun(result *pipeline.DryRunResult, format string) error {
 }
 
 func writePipelineOutput(p pipeline.GeneratedPipeline, outputFile string) error {
-	yaml, err := p.ToYAML()
+	content, err := generateflow.Render(p)
 	if err != nil {
-		return fmt.Errorf("serialize pipeline: %w", err)
+		return err
 	}
 
-	content := append([]byte("# Generated by terraci\n# DO NOT EDIT - this file is auto-generated\n# https://github.com/edelwud/terraci\n\n"), yaml...)
-
 	if outputFile != "" {
 		if err := os.WriteFile(outputFile, content, 0o600); err != nil {
 			return fmt.Errorf("write output file: %w", err)
@@ -212,3 +258,16 @@ func writePipelineOutput(p pipeline.GeneratedPipeline, outputFile string) error
 
 	return nil
 }
+
+func checkPipelineOutput(p pipeline.GeneratedPipeline, outputFile string) error {
+	result, err := generateflow.Check(p, outputFile)
+	if err != nil {
+		return err
+	}
+	if result.UpToDate {
+		log.WithField("file", outputFile).Info("pipeline is up to date")
+		return nil
+	}
+	fmt.Print(result.Diff)
+	return fmt.Errorf("%s is out of date; run terraci generate -o %s", outputFile, outputFile)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -32,14 +33,19 @@ func newGraphCmd() *cobra.Command {
 Formats:
   - dot:      GraphViz DOT format
   - plantuml: PlantUML format
+  - mermaid:  Mermaid flowchart for Markdown
+  - json:     Modules, edges, library usages and statistics as JSON
   - list:     Simple text list
   - levels:   Execution levels (parallel groups)
+  - html:     Interactive explorer in a single offline HTML file
 
 Examples:
   terraci graph --format dot -o deps.dot
   terraci graph --format dot | dot -Tpng -o deps.png
   terraci graph --format plantuml -o deps.puml
+  terraci graph --format json | jq '.edges[]'
   terraci graph --format levels
+  terraci graph --format html -o graph.html
   terraci graph --stats
 	  terraci graph --module platform/stage/eu-central-1/vpc --dependents`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -69,13 +75,148 @@ Examples:
 	}
 	runflow.MarkCommand(cmd, runflow.CommandPolicy{SkipPreflight: true})
 
-	cmd.Flags().StringVarP(&graphFormat, "format", "F", "dot", "output format: dot, plantuml, list, levels")
+	cmd.Flags().StringVarP(&graphFormat, "format", "F", "dot", "output format: dot, plantuml, mermaid, json, list, levels, html")
 	cmd.Flags().StringVarP(&graphOutput, "output", "o", "", "output file (default: stdout)")
 	cmd.Flags().BoolVar(&showStats, "stats", false, "show graph statistics")
 	cmd.Flags().StringVarP(&moduleID, "module", "m", "", "filter to specific module")
 	cmd.Flags().BoolVar(&showDependents, "dependents", false, "show dependents instead of dependencies (with --module)")
 	registerFilterFlags(cmd, ff)
 
+	cmd.AddCommand(newGraphWhyCmd(), newGraphDiffCmd(), newGraphCriticalPathCmd())
+
+	return cmd
+}
+
+func newGraphWhyCmd() *cobra.Command {
+	var maxPaths int
+	ff := &filter.Flags{}
+
+	cmd := &cobra.Command{
+		Use:   "why <from> <to>",
+		Short: "Explain why one module depends on another",
+		Long: `Print every dependency path between two modules with the evidence for
+each edge: the terraform_remote_state block and where it is defined, the
+resolved state key, and how the state key was matched to the target module.
+
+Modules are given as IDs or module directories. When <from> does not depend
+on <to>, the paths in the opposite direction are shown.
+
+Examples:
+  terraci graph why platform/stage/eu-central-1/app platform/stage/eu-central-1/vpc
+  terraci graph why platform/stage/eu-central-1/app@blue platform/stage/eu-central-1/vpc --max-paths 5`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prepared, err := runflow.FromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			result, err := graphflow.Why(cmd.Context(), graphflow.NewRuntime(prepared), graphflow.WhyRequest{
+				Filters: *ff,
+				From:    args[0],
+				To:      args[1],
+				Limit:   maxPaths,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(graphflow.FormatWhy(result))
+			return nil
+		},
+	}
+	runflow.MarkCommand(cmd, runflow.CommandPolicy{SkipPreflight: true})
+
+	cmd.Flags().IntVar(&maxPaths, "max-paths", graphflow.DefaultWhyLimit, "maximum number of paths to print")
+	registerFilterFlags(cmd, ff)
+
+	return cmd
+}
+
+func newGraphDiffCmd() *cobra.Command {
+	var baseRef string
+	ff := &filter.Flags{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the dependency graph with a base git ref",
+		Long: `Build the dependency graph of the working tree and of the merge base with
+a base git ref, then report added and removed modules and dependencies, new
+cycles and modules whose execution level changed.
+
+The base revision is read through the git plugin. The result is also
+published as the "Dependency Changes" report for the summary comment.
+
+Examples:
+  terraci graph diff
+  terraci graph diff --base origin/main`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			prepared, err := runflow.FromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			result, err := graphflow.Diff(cmd.Context(), graphflow.NewRuntime(prepared), graphflow.DiffRequest{
+				Filters: *ff,
+				BaseRef: baseRef,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(graphflow.FormatDiff(result))
+			return nil
+		},
+	}
+	runflow.MarkCommand(cmd, runflow.CommandPolicy{SkipPreflight: true})
+
+	cmd.Flags().StringVar(&baseRef, "base", "", "base git ref (default: origin/HEAD, origin/main, origin/master or HEAD~1)")
+	registerFilterFlags(cmd, ff)
+
+	return cmd
+}
+
+func newGraphCriticalPathCmd() *cobra.Command {
+	var kinds []string
+	ff := &filter.Flags{}
+
+	cmd := &cobra.Command{
+		Use:   "critical-path",
+		Short: "Show the module chain bounding pipeline time",
+		Long: `Estimate the pipeline wall-clock time from recorded job durations and print
+the critical path: the dependency chain that takes longest when every module
+starts as soon as its dependencies finish. Modules with the highest slack
+cost are the ones whose speedup shortens the pipeline most.
+
+Durations come from the timing history, which must be enabled with the
+timings config section. Each module weighs the median of its recent
+durations, summed over the selected job kinds.
+
+Examples:
+  terraci graph critical-path
+  terraci graph critical-path --kind plan`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			prepared, err := runflow.FromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			result, err := graphflow.CriticalPath(cmd.Context(), graphflow.NewRuntime(prepared), graphflow.CriticalPathRequest{
+				Filters: *ff,
+				Kinds:   kinds,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(graphflow.FormatCriticalPath(result))
+			return nil
+		},
+	}
+	runflow.MarkCommand(cmd, runflow.CommandPolicy{SkipPreflight: true})
+
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil, "job kinds to weigh: plan, apply (default: all recorded)")
+	registerFilterFlags(cmd, ff)
+
 	return cmd
 }
 
@@ -138,6 +279,21 @@ func logGraphStats(result *graphflow.StatsResult) {
 		log.DecreasePadding()
 	}
 
+	if cp := stats.CriticalPath; cp != nil && len(cp.Unmeasured) < len(cp.Timings) {
+		log.WithField("total", cp.Total.Round(time.Second)).
+			WithField("unmeasured", len(cp.Unmeasured)).
+			Info("estimated pipeline time")
+		log.WithField("path", strings.Join(cp.Modules, " → ")).Info("critical path")
+		if len(cp.TopSlackCost) > 0 {
+			log.Info("highest slack cost")
+			log.IncreasePadding()
+			for _, m := range cp.TopSlackCost {
+				log.WithField("saves", m.SlackCost.Round(time.Second)).Info(m.ID)
+			}
+			log.DecreasePadding()
+		}
+	}
+
 	if stats.HasCycles {
 		log.WithField("count", stats.CycleCount).Warn("cycles detected")
 		log.IncreasePadding()
@@ -65,6 +65,7 @@ Features:
 	rootCmd.AddCommand(newGenerateCmd())
 	rootCmd.AddCommand(newGraphCmd())
 	rootCmd.AddCommand(newValidateCmd())
+	rootCmd.AddCommand(newDriftCmd())
 	// Note: summary and policy commands are now provided by plugins
 	rootCmd.AddCommand(newInitCmd())
 	rootCmd.AddCommand(newVersionCmd(app))
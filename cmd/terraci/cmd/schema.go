@@ -11,14 +11,19 @@ import (
 )
 
 func newSchemaCmd() *cobra.Command {
-	var schemaOutputFile string
+	var (
+		schemaOutputFile string
+		irSchema         bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "schema",
 		Short: "Generate JSON Schema for .terraci.yaml",
 		Long: `Generate a JSON Schema file for .terraci.yaml configuration.
 
-The schema can be used for IDE autocompletion and validation.
+The schema can be used for IDE autocompletion and validation. With --ir,
+the schema of pipeline IR documents written by "terraci generate --format
+ir-json" is generated instead.
 
 Examples:
   # Output schema to stdout
@@ -29,13 +34,25 @@ Examples:
 
   # Use in VS Code with YAML extension
   # Add to .terraci.yaml:
-  # yaml-language-server: $schema=./terraci.schema.json`,
+  # yaml-language-server: $schema=./terraci.schema.json
+
+  # Schema of exported pipeline IR
+  terraci schema --ir -o terraci-ir.schema.json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			prepared, err := runflow.FromContext(cmd.Context())
-			if err != nil {
-				return err
+			var (
+				schema string
+				err    error
+			)
+			if irSchema {
+				schema, err = schemaflow.GenerateIR()
+			} else {
+				var prepared *runflow.Prepared
+				prepared, err = runflow.FromContext(cmd.Context())
+				if err != nil {
+					return err
+				}
+				schema, err = schemaflow.Generate(prepared)
 			}
-			schema, err := schemaflow.Generate(prepared)
 			if err != nil {
 				return fmt.Errorf("generate schema: %w", err)
 			}
@@ -55,6 +72,7 @@ Examples:
 	runflow.MarkCommand(cmd, runflow.CommandPolicy{SkipConfig: true})
 
 	cmd.Flags().StringVarP(&schemaOutputFile, "output", "o", "", "output file (default: stdout)")
+	cmd.Flags().BoolVar(&irSchema, "ir", false, "generate the pipeline IR JSON schema instead of the config schema")
 
 	return cmd
 }
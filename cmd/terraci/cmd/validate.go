@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -17,6 +18,10 @@ import (
 
 func newValidateCmd() *cobra.Command {
 	ff := &filter.Flags{}
+	var (
+		changedOnly bool
+		baseRef     string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -32,7 +37,13 @@ This command will:
   - Parse terraform_remote_state references
   - Build the dependency graph
   - Check for circular dependencies
-  - Report any issues found`,
+  - Check that outputs read through terraform_remote_state are declared
+    by the modules they resolve to
+  - Report any issues found
+
+With --changed-only, output contracts are checked for changed modules and
+their dependents, so removing an output that other modules still read
+fails the run.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			prepared, err := runflow.FromContext(cmd.Context())
 			if err != nil {
@@ -42,7 +53,9 @@ This command will:
 			log.Info("validating terraform project structure")
 
 			result, err := validateflow.Run(cmd.Context(), validateflow.NewRuntime(prepared), validateflow.Request{
-				Filters: *ff,
+				Filters:     *ff,
+				ChangedOnly: changedOnly,
+				BaseRef:     baseRef,
 			})
 			if err != nil {
 				return err
@@ -52,6 +65,7 @@ This command will:
 			log.Info("validating dependency graph")
 			logGraphValidation(result)
 			logExecutionOrder(result)
+			logOutputContracts(result, prepared.WorkDir())
 			reportLibraryModules(result.Project.LibrarySummary)
 
 			if !result.Passed {
@@ -66,6 +80,8 @@ This command will:
 	runflow.MarkCommand(cmd, runflow.CommandPolicy{SkipPreflight: true})
 
 	registerFilterFlags(cmd, ff)
+	cmd.Flags().BoolVar(&changedOnly, "changed-only", false, "only check output contracts of changed modules and their dependents")
+	cmd.Flags().StringVar(&baseRef, "base-ref", "", "base git ref for change detection (default: auto-detect)")
 
 	return cmd
 }
@@ -118,6 +134,26 @@ func logExecutionOrder(result *validateflow.Result) {
 	}
 }
 
+func logOutputContracts(result *validateflow.Result, workDir string) {
+	log.Info("checking remote state output contracts")
+	if len(result.MissingOutputs) == 0 {
+		log.Info("all remote state outputs are declared")
+		return
+	}
+	log.WithField("count", len(result.MissingOutputs)).Error("missing remote state outputs")
+	base, _ := filepath.Abs(workDir)
+	log.IncreasePadding()
+	for _, missing := range result.MissingOutputs {
+		file := missing.Range.Filename
+		if rel, err := filepath.Rel(base, file); err == nil {
+			file = rel
+		}
+		log.WithField("at", fmt.Sprintf("%s:%d:%d", file, missing.Range.Start.Line, missing.Range.Start.Column)).
+			Error(missing.String())
+	}
+	log.DecreasePadding()
+}
+
 func reportLibraryModules(summary *projectflow.LibrarySummary) {
 	if summary == nil {
 		return
@@ -0,0 +1,145 @@
+// Package driftflow owns drift report collection for scheduled drift pipelines.
+package driftflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
+	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/cishell"
+	"github.com/edelwud/terraci/pkg/planresults"
+	"github.com/edelwud/terraci/pkg/plugin"
+)
+
+// CollectorJobName is the pipeline job that runs `terraci drift` after the
+// drift plan jobs.
+const CollectorJobName = "terraci-drift"
+
+// Runtime contains immutable dependencies needed to collect drift results.
+type Runtime struct {
+	appCtx  *plugin.AppContext
+	project projectflow.Runtime
+}
+
+// NewRuntime creates a drift runtime from prepared command state.
+func NewRuntime(prepared *runflow.Prepared) Runtime {
+	return Runtime{appCtx: prepared.AppContext(), project: projectflow.NewRuntime(prepared)}
+}
+
+// Request describes one drift collection request.
+type Request struct {
+	// Modules lists the module IDs the drift pipeline planned. Modules
+	// without a plan.json are reported as errored. Empty classifies every
+	// plan.json found under the working directory.
+	Modules []string
+	// Discover adds the modules a drift pipeline generated with Filters
+	// plans, so a collector job does not have to list them.
+	Discover bool
+	Filters  filter.Flags
+}
+
+// Result contains the collected drift summary and its published report.
+type Result struct {
+	Summary *planresults.DriftSummary
+	Report  *ci.Report
+}
+
+// Run scans plan.json artifacts under the working directory, classifies
+// modules as drifted, clean or errored, and publishes the drift report.
+func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
+	appCtx := runtime.appCtx
+	modules := req.Modules
+	if req.Discover {
+		project, err := projectflow.Run(ctx, runtime.project, projectflow.Request{
+			Filters:       req.Filters,
+			SelectTargets: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, module := range project.Targets {
+			modules = append(modules, module.ID())
+		}
+	}
+	summary, err := planresults.ScanDrift(appCtx.WorkDir(), modules)
+	if err != nil {
+		return nil, err
+	}
+	structure := appCtx.Config().Structure()
+	collection, err := planresults.Scan(appCtx.WorkDir(), structure.Segments())
+	if err != nil {
+		return nil, err
+	}
+
+	var report *ci.Report
+	publication, err := ci.NewArtifactPublication(ci.ArtifactPublicationOptions{
+		Producer: planresults.DriftReportProducer,
+		Results:  ci.RawResults(summary),
+		BuildReport: func() (*ci.Report, error) {
+			run, runErr := plugin.NewArtifactRun(appCtx, plugin.ArtifactRunOptions{
+				Producer:   planresults.DriftReportProducer,
+				Collection: collection,
+			})
+			if runErr != nil {
+				return nil, fmt.Errorf("artifact run: %w", runErr)
+			}
+			report, runErr = planresults.BuildDriftReport(summary, run)
+			return report, runErr
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := appCtx.Reports().PublishArtifacts(ctx, publication); err != nil {
+		return nil, fmt.Errorf("publish drift report: %w", err)
+	}
+	return &Result{Summary: summary, Report: report}, nil
+}
+
+// PipelineContributions returns the collector job appended to drift
+// pipelines. It consumes every plan.json, runs even when plan jobs failed,
+// and reports the planned modules that produced no plan.json as errored.
+// The collector rediscovers the planned modules with the generation filters
+// rather than listing them, which keeps its command short in large projects.
+func PipelineContributions(serviceDir string, filters filter.Flags) (pipeline.ContributionSet, error) {
+	job, err := pipeline.NewPluginCommandJob(pipeline.PluginCommandJobOptions{
+		Name:     CollectorJobName,
+		Commands: []string{collectorCommand(filters)},
+		Consumes: []pipeline.ResourceRequest{
+			pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+		},
+		Produces:  pipeline.PluginResultAndReportResources(serviceDir, planresults.DriftReportProducer),
+		AlwaysRun: true,
+	})
+	if err != nil {
+		return pipeline.EmptyContributionSet(), fmt.Errorf("build drift collector job: %w", err)
+	}
+	contribution, err := pipeline.NewContribution(job)
+	if err != nil {
+		return pipeline.EmptyContributionSet(), fmt.Errorf("build drift collector contribution: %w", err)
+	}
+	return pipeline.NewContributionSet(contribution)
+}
+
+func collectorCommand(filters filter.Flags) string {
+	var sb strings.Builder
+	sb.WriteString("terraci drift --discover")
+	for _, flag := range []struct {
+		name   string
+		values []string
+	}{
+		{"--exclude", filters.Excludes},
+		{"--include", filters.Includes},
+		{"--filter", filters.SegmentArgs},
+	} {
+		for _, value := range flag.values {
+			sb.WriteString(" " + flag.name + " " + cishell.Quote(value))
+		}
+	}
+	return sb.String()
+}
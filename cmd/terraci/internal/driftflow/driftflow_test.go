@@ -0,0 +1,82 @@
+package driftflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/planresults"
+)
+
+func TestPipelineContributionsAddsCollectorJob(t *testing.T) {
+	contributions, err := PipelineContributions(".terraci", filter.Flags{
+		Excludes:    []string{"*/sandbox/*"},
+		Includes:    []string{"platform/**"},
+		SegmentArgs: []string{"module=my app"},
+	})
+	if err != nil {
+		t.Fatalf("PipelineContributions() error = %v", err)
+	}
+	jobs := contributions.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("jobs = %d, want 1", len(jobs))
+	}
+	job := jobs[0]
+	if job.Name() != CollectorJobName {
+		t.Fatalf("Name() = %q, want %q", job.Name(), CollectorJobName)
+	}
+	want := []string{"terraci drift --discover --exclude '*/sandbox/*' --include 'platform/**' --filter 'module=my app'"}
+	if !slices.Equal(job.Commands(), want) {
+		t.Fatalf("Commands() = %v, want %v", job.Commands(), want)
+	}
+	if !job.AlwaysRun() {
+		t.Fatal("collector must run even when plan jobs failed")
+	}
+	if len(job.Consumes()) != 1 || len(job.Produces()) != 2 {
+		t.Fatalf("consumes %d / produces %d, want plan JSON in and drift report out", len(job.Consumes()), len(job.Produces()))
+	}
+}
+
+func TestRunDiscoverReportsFilteredModulesWithoutPlans(t *testing.T) {
+	workDir := t.TempDir()
+	if err := config.Default().Save(filepath.Join(workDir, ".terraci.yaml")); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+	for _, module := range []string{"platform/stage/eu-central-1/vpc", "platform/prod/eu-central-1/vpc"} {
+		moduleDir := filepath.Join(workDir, filepath.FromSlash(module))
+		if err := os.MkdirAll(moduleDir, 0o755); err != nil {
+			t.Fatalf("mkdir module: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(moduleDir, "main.tf"), []byte("# test\n"), 0o644); err != nil {
+			t.Fatalf("write module: %v", err)
+		}
+	}
+	prepared, err := runflow.New(runflow.Options{}).Prepare(context.Background(), runflow.Request{
+		CommandName: "driftflow-test",
+		WorkDir:     workDir,
+		Policy:      runflow.CommandPolicy{SkipPreflight: true},
+	})
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	result, err := Run(context.Background(), NewRuntime(prepared), Request{
+		Discover: true,
+		Filters:  filter.Flags{SegmentArgs: []string{"environment=stage"}},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	modules := result.Summary.Modules
+	if len(modules) != 1 || modules[0].ModulePath != "platform/stage/eu-central-1/vpc" {
+		t.Fatalf("Modules = %+v, want only the filtered stage module", modules)
+	}
+	if modules[0].Status != planresults.DriftStatusErrored {
+		t.Fatalf("Status = %q, want errored for a module without plan.json", modules[0].Status)
+	}
+}
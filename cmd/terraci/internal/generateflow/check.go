@@ -0,0 +1,234 @@
+package generateflow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+)
+
+// diffContext is the number of unchanged lines shown around each diff hunk.
+const diffContext = 3
+
+// maxDiffCells bounds the LCS table of diffLines. Larger changed regions are
+// reported as a single replace hunk instead of a minimal diff.
+const maxDiffCells = 1 << 20
+
+// Render serializes a generated pipeline with the terraci banner, exactly as
+// it is written to disk. IR JSON has no comment syntax and gets no banner.
+func Render(p pipeline.GeneratedPipeline) ([]byte, error) {
+	body, err := p.ToYAML()
+	if err != nil {
+		return nil, fmt.Errorf("serialize pipeline: %w", err)
+	}
+	if _, ok := p.(irPipeline); ok {
+		return body, nil
+	}
+	prefix := pipeline.CommentPrefix(p)
+	header := prefix + " Generated by terraci\n" +
+		prefix + " DO NOT EDIT - this file is auto-generated\n" +
+		prefix + " https://github.com/edelwud/terraci\n\n"
+	return append([]byte(header), body...), nil
+}
+
+// CheckResult reports whether a committed pipeline file matches a freshly
+// generated one.
+type CheckResult struct {
+	Path     string
+	UpToDate bool
+	// Diff is a unified diff from the committed file to the generated
+	// pipeline. It is empty when the file is up to date.
+	Diff string
+}
+
+// Check compares the generated pipeline with the file at path. YAML pipelines
+// are compared after normalization, so comments, key order and formatting do
+// not count as drift; other formats (e.g. Jenkinsfiles) are compared as text.
+// A missing file is reported as out of date.
+func Check(generated pipeline.GeneratedPipeline, path string) (*CheckResult, error) {
+	want, err := Render(generated)
+	if err != nil {
+		return nil, err
+	}
+	have, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if pipeline.CommentPrefix(generated) == "#" {
+		if want, err = normalizeYAML(want); err != nil {
+			return nil, fmt.Errorf("normalize generated pipeline: %w", err)
+		}
+		if have, err = normalizeYAML(have); err != nil {
+			return nil, fmt.Errorf("normalize %s: %w", path, err)
+		}
+	}
+
+	result := &CheckResult{Path: path, UpToDate: bytes.Equal(have, want)}
+	if !result.UpToDate {
+		result.Diff = unifiedDiff(path, "generated", splitLines(string(have)), splitLines(string(want)))
+	}
+	return result, nil
+}
+
+// normalizeYAML re-encodes a YAML document through a generic value. Map keys
+// come out sorted and comments are dropped.
+func normalizeYAML(data []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff renders a minimal line diff in unified format.
+func unifiedDiff(fromName, toName string, from, to []string) string {
+	ops := diffLines(from, to)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+		// Extend the hunk while changes are separated by at most twice the
+		// context, so adjacent edits share one header.
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i
+			} else if i-end > 2*diffContext {
+				break
+			}
+		}
+		lo := max(start-diffContext, 0)
+		hi := min(end+diffContext+1, len(ops))
+		writeHunk(&b, ops, lo, hi)
+		start = hi
+	}
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, ops []diffOp, lo, hi int) {
+	fromLine, toLine := 1, 1
+	for _, op := range ops[:lo] {
+		if op.kind != '+' {
+			fromLine++
+		}
+		if op.kind != '-' {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	for _, op := range ops[lo:hi] {
+		if op.kind != '+' {
+			fromCount++
+		}
+		if op.kind != '-' {
+			toCount++
+		}
+	}
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+	for _, op := range ops[lo:hi] {
+		b.WriteByte(op.kind)
+		b.WriteString(op.line)
+		b.WriteByte('\n')
+	}
+}
+
+// diffLines computes a line edit script via longest common subsequence after
+// trimming the shared prefix and suffix, which keeps the quadratic table
+// small for the typical few-line change. When the changed region would still
+// exceed maxDiffCells, all of it is replaced wholesale.
+func diffLines(from, to []string) []diffOp {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	a, c := from[prefix:len(from)-suffix], to[prefix:len(to)-suffix]
+
+	ops := make([]diffOp, 0, len(from)+len(c))
+	ops = appendContext(ops, from[:prefix])
+
+	if len(a)*len(c) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range c {
+			ops = append(ops, diffOp{'+', line})
+		}
+		return appendContext(ops, from[len(from)-suffix:])
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and c[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(c)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(c) - 1; j >= 0; j-- {
+			if a[i] == c[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(c) {
+		switch {
+		case i < len(a) && j < len(c) && a[i] == c[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(c) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', c[j]})
+			j++
+		}
+	}
+
+	return appendContext(ops, from[len(from)-suffix:])
+}
+
+func appendContext(ops []diffOp, lines []string) []diffOp {
+	for _, line := range lines {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
@@ -0,0 +1,142 @@
+package generateflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type yamlPipeline string
+
+func (p yamlPipeline) ToYAML() ([]byte, error) { return []byte(p), nil }
+
+type groovyPipeline string
+
+func (p groovyPipeline) ToYAML() ([]byte, error) { return []byte(p), nil }
+func (groovyPipeline) CommentPrefix() string     { return "//" }
+
+func writeCheckFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pipeline.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestCheckIgnoresKeyOrderAndComments(t *testing.T) {
+	generated := yamlPipeline("stages:\n  - plan\njob:\n  script: terraform plan\n")
+	path := writeCheckFile(t, "# hand-written note\njob:\n  script: terraform plan\nstages: [plan]\n")
+
+	result, err := Check(generated, path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !result.UpToDate {
+		t.Fatalf("UpToDate = false, diff:\n%s", result.Diff)
+	}
+	if result.Diff != "" {
+		t.Fatalf("Diff = %q, want empty", result.Diff)
+	}
+}
+
+func TestCheckReportsDiff(t *testing.T) {
+	generated := yamlPipeline("job:\n  script: terraform apply\nstages:\n  - plan\n")
+	path := writeCheckFile(t, "job:\n  script: terraform plan\nstages:\n  - plan\n")
+
+	result, err := Check(generated, path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if result.UpToDate {
+		t.Fatal("UpToDate = true, want false")
+	}
+	for _, want := range []string{
+		"--- " + path,
+		"+++ generated",
+		"@@ -1,4 +1,4 @@",
+		"-    script: terraform plan",
+		"+    script: terraform apply",
+	} {
+		if !strings.Contains(result.Diff, want) {
+			t.Errorf("Diff missing %q:\n%s", want, result.Diff)
+		}
+	}
+}
+
+func TestUnifiedDiffLargeChangeFallsBackToReplaceHunk(t *testing.T) {
+	// Interleaved edits across the whole file leave no shared prefix or
+	// suffix, so the changed region exceeds the LCS table limit.
+	const lines = 2000
+	from := make([]string, lines)
+	to := make([]string, lines)
+	for i := range lines {
+		from[i] = fmt.Sprintf("line %d", i)
+		to[i] = from[i]
+		if i%2 == 0 {
+			to[i] += " changed"
+		}
+	}
+
+	diff := unifiedDiff("old", "new", from, to)
+	if got := strings.Count(diff, "@@ -"); got != 1 {
+		t.Fatalf("hunks = %d, want 1", got)
+	}
+	if !strings.Contains(diff, fmt.Sprintf("@@ -1,%d +1,%d @@", lines, lines)) {
+		t.Fatalf("unexpected hunk header:\n%s", diff[:200])
+	}
+	if got := strings.Count(diff, "\n-line "); got != lines-1 {
+		t.Fatalf("removed lines = %d, want %d", got, lines-1)
+	}
+	if strings.Index(diff, "\n+line ") < strings.LastIndex(diff, "\n-line ") {
+		t.Fatal("replace hunk should list every removed line before the added ones")
+	}
+}
+
+func TestCheckMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yml")
+
+	result, err := Check(yamlPipeline("stages:\n  - plan\n"), path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if result.UpToDate {
+		t.Fatal("UpToDate = true, want false")
+	}
+	if !strings.Contains(result.Diff, "+stages:") {
+		t.Fatalf("Diff = %q, want added lines", result.Diff)
+	}
+}
+
+func TestCheckComparesNonYAMLAsText(t *testing.T) {
+	generated := groovyPipeline("pipeline {\n  agent any\n}\n")
+	content, err := Render(generated)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.HasPrefix(string(content), "// Generated by terraci") {
+		t.Fatalf("Render() = %q, want // banner", content)
+	}
+	path := writeCheckFile(t, string(content))
+
+	result, err := Check(generated, path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !result.UpToDate {
+		t.Fatalf("UpToDate = false, diff:\n%s", result.Diff)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(string(content), "agent any", "agent none", 1)), 0o600); err != nil {
+		t.Fatalf("rewrite %s: %v", path, err)
+	}
+	result, err = Check(generated, path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if result.UpToDate || !strings.Contains(result.Diff, "-  agent none\n+  agent any\n") {
+		t.Fatalf("Check() = %+v, want text diff", result)
+	}
+}
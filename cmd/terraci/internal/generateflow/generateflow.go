@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/edelwud/terraci/cmd/terraci/internal/driftflow"
 	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
 	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
 	"github.com/edelwud/terraci/pkg/filter"
@@ -18,14 +19,22 @@ type Runtime struct {
 	project  projectflow.Runtime
 }
 
-// GenerateMode declares whether generation should include apply jobs.
+// GenerateMode declares whether generation should include apply jobs and
+// whether those jobs create or destroy infrastructure.
 type GenerateMode string
 
 const (
-	GenerateModeApply GenerateMode = "apply"
-	GenerateModePlan  GenerateMode = "plan"
+	GenerateModeApply   GenerateMode = "apply"
+	GenerateModePlan    GenerateMode = "plan"
+	GenerateModeDestroy GenerateMode = "destroy"
+	// GenerateModeDrift emits plan jobs plus a drift collector job and never
+	// applies anything.
+	GenerateModeDrift GenerateMode = "drift"
 )
 
+// GenerateModes lists every supported generation mode.
+var GenerateModes = []GenerateMode{GenerateModeApply, GenerateModePlan, GenerateModeDestroy, GenerateModeDrift}
+
 // NewRuntime creates a generation runtime from prepared command state.
 func NewRuntime(prepared *runflow.Prepared) Runtime {
 	return Runtime{
@@ -41,6 +50,9 @@ type Request struct {
 	BaseRef     string
 	Mode        GenerateMode
 	DryRun      bool
+	// IR emits the provider-agnostic pipeline IR as versioned JSON instead
+	// of a provider pipeline. No CI provider is resolved.
+	IR bool
 }
 
 // Result contains the pipeline generation outcome.
@@ -73,7 +85,15 @@ func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
 	if mode == "" {
 		mode = GenerateModeApply
 	}
-	generator, err := newPipelineGenerator(runtime, project, mode)
+	ir, err := buildPipelineIR(runtime, project, mode, req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if req.IR && !req.DryRun {
+		result.Pipeline = irPipeline{ir: ir}
+		return result, nil
+	}
+	generator, err := newPipelineGenerator(runtime, ir)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +114,19 @@ func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
 	return result, nil
 }
 
-func newPipelineGenerator(runtime Runtime, project *projectflow.Result, mode GenerateMode) (pipeline.Generator, error) {
-	appCtx := runtime.prepared.AppContext()
-	provider, err := appCtx.CIResolver().ResolveCIProvider()
+func newPipelineGenerator(runtime Runtime, ir *pipeline.IR) (pipeline.Generator, error) {
+	provider, err := runtime.prepared.AppContext().CIResolver().ResolveCIProvider()
 	if err != nil {
 		return nil, fmt.Errorf("resolve CI provider: %w", err)
 	}
+	generator, err := provider.NewGenerator(ir)
+	if err != nil {
+		return nil, fmt.Errorf("create CI generator: %w", err)
+	}
+	return generator, nil
+}
 
+func buildPipelineIR(runtime Runtime, project *projectflow.Result, mode GenerateMode, filters filter.Flags) (*pipeline.IR, error) {
 	profile, err := terraformrun.ProfileFromConfig(runtime.prepared.Config())
 	if err != nil {
 		return nil, fmt.Errorf("terraform profile: %w", err)
@@ -109,24 +135,39 @@ func newPipelineGenerator(runtime Runtime, project *projectflow.Result, mode Gen
 	if err != nil {
 		return nil, fmt.Errorf("build pipeline intent: %w", err)
 	}
+	intent = intent.WithApprovals(runtime.prepared.Config().Approvals()...)
+	contributions := runtime.prepared.PipelineContributions()
+	if mode == GenerateModeDrift {
+		// Drift pipelines only plan and collect; plugin jobs such as MR
+		// comments have nothing to act on in a scheduled run.
+		contributions, err = driftflow.PipelineContributions(runtime.prepared.Config().ServiceDir(), filters)
+		if err != nil {
+			return nil, err
+		}
+	}
 	terraformConfig, err := pipeline.NewTerraformJobConfigFromProfile(profile)
 	if err != nil {
 		return nil, fmt.Errorf("terraform job config: %w", err)
 	}
 	ir, err := pipeline.BuildProjectIR(pipeline.ProjectIRRequest{
 		Project:       project,
-		Contributions: runtime.prepared.PipelineContributions(),
+		Contributions: contributions,
 		Intent:        intent,
 		Terraform:     terraformConfig,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("build pipeline IR: %w", err)
 	}
-	generator, err := provider.NewGenerator(ir)
-	if err != nil {
-		return nil, fmt.Errorf("create CI generator: %w", err)
-	}
-	return generator, nil
+	return ir, nil
+}
+
+// irPipeline serializes the pipeline IR itself as the generated output.
+type irPipeline struct {
+	ir *pipeline.IR
+}
+
+func (p irPipeline) ToYAML() ([]byte, error) {
+	return pipeline.MarshalIRJSON(p.ir)
 }
 
 func intentForMode(mode GenerateMode) (pipeline.BuildIntent, error) {
@@ -135,6 +176,10 @@ func intentForMode(mode GenerateMode) (pipeline.BuildIntent, error) {
 		return pipeline.ApplyBuildIntent()
 	case GenerateModePlan:
 		return pipeline.PlanBuildIntent(pipeline.AllPlanResources(pipeline.ResourceKindPlanBinary))
+	case GenerateModeDrift:
+		return pipeline.DriftBuildIntent(pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON))
+	case GenerateModeDestroy:
+		return pipeline.DestroyBuildIntent()
 	default:
 		var zero pipeline.BuildIntent
 		return zero, fmt.Errorf("unsupported generate mode %q", mode)
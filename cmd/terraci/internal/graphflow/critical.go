@@ -0,0 +1,162 @@
+package graphflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
+	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/timings"
+)
+
+// CriticalPathRequest asks for the pipeline critical path weighted by the
+// timing history.
+type CriticalPathRequest struct {
+	Filters filter.Flags
+	// Kinds restricts the estimates to plan or apply jobs; empty sums every
+	// recorded job kind.
+	Kinds []string
+}
+
+// CriticalPath scans the project and computes its critical path from the
+// recorded job durations.
+func CriticalPath(ctx context.Context, runtime Runtime, req CriticalPathRequest) (*graph.CriticalPath, error) {
+	if runtime.prepared == nil {
+		return nil, errors.New("graph critical-path requires prepared command state")
+	}
+	kinds, err := parseJobKinds(req.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	project, err := projectflow.Run(ctx, runtime.project, projectflow.Request{Filters: req.Filters})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := openTimingHistory(ctx, runtime.prepared.AppContext())
+	if err != nil {
+		return nil, fmt.Errorf("open timing history: %w", err)
+	}
+	if history == nil {
+		return nil, errors.New("job timing history is disabled; set timings.enabled in the config")
+	}
+	durations, err := moduleDurations(ctx, history, project.Workflow.Graph, kinds...)
+	if err != nil {
+		return nil, err
+	}
+	return project.Workflow.Graph.CriticalPath(durations)
+}
+
+// statsDurations returns the estimated module durations for graph
+// statistics, or nil when the timing history is disabled or unavailable;
+// statistics are still shown without the critical path.
+func statsDurations(ctx context.Context, runtime Runtime, g *graph.DependencyGraph) map[string]time.Duration {
+	if runtime.prepared == nil {
+		return nil
+	}
+	history, err := openTimingHistory(ctx, runtime.prepared.AppContext())
+	if err != nil {
+		log.WithError(err).Warn("critical path omitted")
+		return nil
+	}
+	if history == nil {
+		return nil
+	}
+	durations, err := moduleDurations(ctx, history, g)
+	if err != nil {
+		log.WithError(err).Warn("critical path omitted")
+		return nil
+	}
+	return durations
+}
+
+// openTimingHistory returns the configured timing history, or nil when it is
+// disabled.
+func openTimingHistory(ctx context.Context, appCtx *plugin.AppContext) (*timings.History, error) {
+	cfg := appCtx.Config().Timings()
+	if cfg == nil || !cfg.Enabled() {
+		return nil, nil
+	}
+	provider, err := appCtx.BlobStoreResolver().ResolveBlobStoreProvider(cfg.Backend(), "set timings.backend explicitly")
+	if err != nil {
+		return nil, fmt.Errorf("resolve blob backend: %w", err)
+	}
+	store, err := provider.NewBlobStore(ctx, appCtx, plugin.BlobStoreOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create blob backend %q: %w", provider.Name(), err)
+	}
+	return timings.NewHistory(store, cfg.Samples()), nil
+}
+
+func moduleDurations(ctx context.Context, history *timings.History, g *graph.DependencyGraph, kinds ...pipeline.JobKind) (map[string]time.Duration, error) {
+	ids := slices.Sorted(maps.Keys(g.Nodes()))
+	durations, err := history.Estimates(ctx, ids, kinds...)
+	if err != nil {
+		return nil, fmt.Errorf("load job timings: %w", err)
+	}
+	return durations, nil
+}
+
+func parseJobKinds(raw []string) ([]pipeline.JobKind, error) {
+	kinds := make([]pipeline.JobKind, 0, len(raw))
+	for _, value := range raw {
+		switch kind := pipeline.JobKind(value); kind {
+		case pipeline.JobKindPlan, pipeline.JobKindApply:
+			kinds = append(kinds, kind)
+		default:
+			return nil, fmt.Errorf("unknown job kind %q (want plan or apply)", value)
+		}
+	}
+	return kinds, nil
+}
+
+// FormatCriticalPath renders a critical path as indented text.
+func FormatCriticalPath(cp *graph.CriticalPath) string {
+	var sb strings.Builder
+	if len(cp.Unmeasured) == len(cp.Timings) {
+		sb.WriteString("No job durations recorded yet; run the pipeline with timings enabled first.\n")
+		return sb.String()
+	}
+
+	timingsByID := make(map[string]graph.ModuleTiming, len(cp.Timings))
+	for _, timing := range cp.Timings {
+		timingsByID[timing.ID] = timing
+	}
+
+	fmt.Fprintf(&sb, "Estimated pipeline time: %s\n", formatDuration(cp.Total))
+	fmt.Fprintf(&sb, "\nCritical path (%d modules):\n", len(cp.Modules))
+	for _, id := range cp.Modules {
+		timing := timingsByID[id]
+		fmt.Fprintf(&sb, "  %s  %s (%s → %s)\n", id, formatDuration(timing.Duration),
+			formatDuration(timing.Start), formatDuration(timing.Finish))
+	}
+
+	if len(cp.TopSlackCost) > 0 {
+		sb.WriteString("\nHighest slack cost (pipeline time saved if the module took no time):\n")
+		for _, timing := range cp.TopSlackCost {
+			fmt.Fprintf(&sb, "  %s  %s\n", timing.ID, formatDuration(timing.SlackCost))
+		}
+	}
+
+	if len(cp.Unmeasured) > 0 {
+		fmt.Fprintf(&sb, "\nModules without recorded durations count as zero (%d):\n", len(cp.Unmeasured))
+		for _, id := range cp.Unmeasured {
+			fmt.Fprintf(&sb, "  %s\n", id)
+		}
+	}
+	return sb.String()
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
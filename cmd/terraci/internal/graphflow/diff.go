@@ -0,0 +1,291 @@
+package graphflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/workflow"
+)
+
+// DiffReportProducer is the artifact producer of the dependency changes
+// report.
+const DiffReportProducer = "graph-diff"
+
+// DiffRequest asks how the dependency graph changed since a base ref.
+type DiffRequest struct {
+	Filters filter.Flags
+	BaseRef string
+}
+
+// DiffResult contains the graph diff and its published report.
+type DiffResult struct {
+	BaseRef    string      `json:"base_ref"`
+	BaseCommit string      `json:"base_commit"`
+	Diff       *graph.Diff `json:"diff"`
+	Report     *ci.Report  `json:"-"`
+}
+
+// Diff builds the dependency graph of the working tree and of the base
+// revision exported by the change detector, compares them and publishes the
+// dependency changes report.
+func Diff(ctx context.Context, runtime Runtime, req DiffRequest) (*DiffResult, error) {
+	if runtime.prepared == nil {
+		return nil, errors.New("graph diff requires prepared command state")
+	}
+	appCtx := runtime.prepared.AppContext()
+
+	head, err := projectflow.Run(ctx, runtime.project, projectflow.Request{Filters: req.Filters})
+	if err != nil {
+		return nil, err
+	}
+
+	detector, err := appCtx.ChangeDetectorResolver().ResolveChangeDetector()
+	if err != nil {
+		return nil, fmt.Errorf("resolve change detector: %w", err)
+	}
+	exporter, ok := detector.(workflow.RevisionExporter)
+	if !ok {
+		return nil, fmt.Errorf("change detector %q cannot export base revisions", detector.Name())
+	}
+
+	baseDir, err := os.MkdirTemp("", "terraci-graph-base-")
+	if err != nil {
+		return nil, fmt.Errorf("create base revision directory: %w", err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	exported, err := exporter.ExportRevision(ctx, workflow.RevisionExportRequest{
+		WorkDir: runtime.prepared.WorkDir(),
+		BaseRef: req.BaseRef,
+		DestDir: baseDir,
+		Include: workflow.ProjectInputs(appCtx.Config().VarFiles()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	baseCfg, err := baseConfig(baseDir, runtime.prepared.Config())
+	if err != nil {
+		return nil, fmt.Errorf("base revision %s: %w", exported.Ref, err)
+	}
+	base, err := projectflow.Run(ctx, runtime.project, projectflow.Request{
+		WorkDir:      baseDir,
+		Config:       &baseCfg,
+		NoParseCache: true,
+		Filters:      req.Filters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plan base revision %s: %w", exported.Ref, err)
+	}
+
+	result := &DiffResult{
+		BaseRef:    exported.Ref,
+		BaseCommit: exported.Commit,
+		Diff:       head.Workflow.Graph.DiffFrom(base.Workflow.Graph),
+	}
+	publication, err := ci.NewArtifactPublication(ci.ArtifactPublicationOptions{
+		Producer: DiffReportProducer,
+		Results:  ci.RawResults(result),
+		BuildReport: func() (*ci.Report, error) {
+			run, runErr := plugin.NewArtifactRun(appCtx, plugin.ArtifactRunOptions{Producer: DiffReportProducer})
+			if runErr != nil {
+				return nil, fmt.Errorf("artifact run: %w", runErr)
+			}
+			result.Report, runErr = BuildDiffReport(result, run)
+			return result.Report, runErr
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := appCtx.Reports().PublishArtifacts(ctx, publication); err != nil {
+		return nil, fmt.Errorf("publish dependency changes report: %w", err)
+	}
+	return result, nil
+}
+
+// baseConfig loads the config the base revision was committed with, so rule
+// changes such as new dependencies or data_sources show up in the diff. A
+// base revision without a config file is planned with the current one.
+func baseConfig(baseDir string, current config.Config) (config.Config, error) {
+	path, ok := config.Find(baseDir)
+	if !ok {
+		return current, nil
+	}
+	return config.Load(path)
+}
+
+// BuildDiffReport renders a graph diff as the "Dependency Changes" report.
+// New cycles fail the report because they break pipeline generation.
+func BuildDiffReport(result *DiffResult, run ci.ArtifactRun) (*ci.Report, error) {
+	d := result.Diff
+	status := ci.ReportStatusPass
+	if len(d.NewCycles) > 0 {
+		status = ci.ReportStatusFail
+	}
+
+	var blocks []ci.RenderBlock
+	if d.Empty() {
+		blocks = append(blocks, ci.NewTextBlock(ci.RenderText("No dependency changes.")))
+	}
+	if rows := diffModuleRows(d); len(rows) > 0 {
+		blocks = append(blocks, ci.NewTableBlock("Modules", []ci.RenderColumn{
+			ci.NewRenderColumn("Module"),
+			ci.NewRenderColumn("Change"),
+		}, rows))
+	}
+	if rows := diffEdgeRows(d); len(rows) > 0 {
+		blocks = append(blocks, ci.NewTableBlock("Dependencies", []ci.RenderColumn{
+			ci.NewRenderColumn("Module"),
+			ci.NewRenderColumn("Depends on"),
+			ci.NewRenderColumn("Change"),
+		}, rows))
+	}
+	if len(d.NewCycles) > 0 {
+		items := make([]ci.RenderValue, 0, len(d.NewCycles))
+		for _, cycle := range d.NewCycles {
+			items = append(items, ci.RenderCode(cyclePath(cycle)))
+		}
+		blocks = append(blocks, ci.NewListBlock("New cycles", items))
+	}
+	if len(d.LevelChanges) > 0 {
+		rows := make([]ci.RenderRow, 0, len(d.LevelChanges))
+		for _, change := range d.LevelChanges {
+			rows = append(rows, ci.NewRenderRow(
+				ci.RenderModulePath(change.ID),
+				ci.RenderText(strconv.Itoa(change.Before)),
+				ci.RenderText(strconv.Itoa(change.After)),
+			))
+		}
+		blocks = append(blocks, ci.NewTableBlock("Execution levels", []ci.RenderColumn{
+			ci.NewRenderColumn("Module"),
+			ci.NewRenderColumn("Before"),
+			ci.NewRenderColumn("After"),
+		}, rows))
+	}
+
+	summaryText := diffSummary(result)
+	report, err := ci.NewRenderedReport(ci.RenderedReportOptions{
+		Producer: DiffReportProducer,
+		Title:    "Dependency Changes",
+		Status:   status,
+		Summary:  summaryText,
+		Artifact: run.Artifact(),
+		Sections: []ci.RenderedSectionOptions{{
+			Title:   "Dependency Changes",
+			Summary: summaryText,
+			Blocks:  blocks,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build dependency changes report: %w", err)
+	}
+	return report, nil
+}
+
+func diffModuleRows(d *graph.Diff) []ci.RenderRow {
+	rows := make([]ci.RenderRow, 0, len(d.AddedModules)+len(d.RemovedModules))
+	for _, id := range d.AddedModules {
+		rows = append(rows, ci.NewRenderRow(ci.RenderModulePath(id), ci.RenderLabel("added", ci.RenderToneSuccess)))
+	}
+	for _, id := range d.RemovedModules {
+		rows = append(rows, ci.NewRenderRow(ci.RenderModulePath(id), ci.RenderLabel("removed", ci.RenderToneWarning)))
+	}
+	return rows
+}
+
+func diffEdgeRows(d *graph.Diff) []ci.RenderRow {
+	rows := make([]ci.RenderRow, 0, len(d.AddedEdges)+len(d.RemovedEdges))
+	for _, edge := range d.AddedEdges {
+		rows = append(rows, ci.NewRenderRow(
+			ci.RenderModulePath(edge.From),
+			ci.RenderModulePath(edge.To),
+			ci.RenderLabel("added", ci.RenderToneSuccess),
+		))
+	}
+	for _, edge := range d.RemovedEdges {
+		rows = append(rows, ci.NewRenderRow(
+			ci.RenderModulePath(edge.From),
+			ci.RenderModulePath(edge.To),
+			ci.RenderLabel("removed", ci.RenderToneWarning),
+		))
+	}
+	return rows
+}
+
+func diffSummary(result *DiffResult) string {
+	d := result.Diff
+	return fmt.Sprintf("Since %s: modules +%d/-%d, dependencies +%d/-%d, %d new cycles, %d level changes",
+		result.BaseRef,
+		len(d.AddedModules), len(d.RemovedModules),
+		len(d.AddedEdges), len(d.RemovedEdges),
+		len(d.NewCycles), len(d.LevelChanges))
+}
+
+// FormatDiff renders a DiffResult as indented text.
+func FormatDiff(result *DiffResult) string {
+	var sb strings.Builder
+	d := result.Diff
+	fmt.Fprintf(&sb, "Dependency changes since %s (%s)\n", result.BaseRef, shortCommit(result.BaseCommit))
+	if d.Empty() {
+		sb.WriteString("\nNo dependency changes.\n")
+		return sb.String()
+	}
+
+	writeDiffList(&sb, "Added modules", d.AddedModules, "+")
+	writeDiffList(&sb, "Removed modules", d.RemovedModules, "-")
+	writeDiffList(&sb, "Added dependencies", edgeLines(d.AddedEdges), "+")
+	writeDiffList(&sb, "Removed dependencies", edgeLines(d.RemovedEdges), "-")
+
+	cycles := make([]string, 0, len(d.NewCycles))
+	for _, cycle := range d.NewCycles {
+		cycles = append(cycles, cyclePath(cycle))
+	}
+	writeDiffList(&sb, "New cycles", cycles, "!")
+
+	levels := make([]string, 0, len(d.LevelChanges))
+	for _, change := range d.LevelChanges {
+		levels = append(levels, fmt.Sprintf("%s: level %d → %d", change.ID, change.Before, change.After))
+	}
+	writeDiffList(&sb, "Execution level changes", levels, "~")
+	return sb.String()
+}
+
+func writeDiffList(sb *strings.Builder, title string, lines []string, marker string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n%s (%d):\n", title, len(lines))
+	for _, line := range lines {
+		fmt.Fprintf(sb, "  %s %s\n", marker, line)
+	}
+}
+
+func edgeLines(edges []graph.Edge) []string {
+	lines := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		lines = append(lines, edge.From+" → "+edge.To)
+	}
+	return lines
+}
+
+// cyclePath renders a cycle closed on its first module.
+func cyclePath(cycle []string) string {
+	return strings.Join(cycle, " → ") + " → " + cycle[0]
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
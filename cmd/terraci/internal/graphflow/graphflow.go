@@ -22,6 +22,9 @@ const (
 	FormatPlantUML Format = "plantuml"
 	FormatList     Format = "list"
 	FormatLevels   Format = "levels"
+	FormatHTML     Format = "html"
+	FormatMermaid  Format = "mermaid"
+	FormatJSON     Format = "json"
 )
 
 // ParseFormat parses a graph format flag.
@@ -35,6 +38,12 @@ func ParseFormat(raw string) (Format, error) {
 		return FormatList, nil
 	case FormatLevels:
 		return FormatLevels, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	case FormatMermaid:
+		return FormatMermaid, nil
+	case FormatJSON:
+		return FormatJSON, nil
 	default:
 		return "", fmt.Errorf("unknown format: %s", raw)
 	}
@@ -42,12 +51,13 @@ func ParseFormat(raw string) (Format, error) {
 
 // Runtime contains immutable dependencies needed to render a graph.
 type Runtime struct {
-	project projectflow.Runtime
+	prepared *runflow.Prepared
+	project  projectflow.Runtime
 }
 
 // NewRuntime creates a graph runtime from prepared command state.
 func NewRuntime(prepared *runflow.Prepared) Runtime {
-	return Runtime{project: projectflow.NewRuntime(prepared)}
+	return Runtime{prepared: prepared, project: projectflow.NewRuntime(prepared)}
 }
 
 // Request describes one graph command request.
@@ -98,9 +108,13 @@ func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
 		ModuleCount: len(project.Workflow.Filtered.Modules),
 	}
 	if req.ShowStats {
+		stats := depGraph.GetStats()
+		if durations := statsDurations(ctx, runtime, depGraph); durations != nil {
+			stats = depGraph.GetStatsWithDurations(durations)
+		}
 		result.Stats = &StatsResult{
 			Scope:  req.ModuleID,
-			Stats:  depGraph.GetStats(),
+			Stats:  stats,
 			Cycles: depGraph.DetectCycles(),
 		}
 		return result, nil
@@ -129,6 +143,12 @@ func Render(g *graph.DependencyGraph, libraries []*discovery.Module, format Form
 		return formatList(g, libraries)
 	case FormatLevels:
 		return formatLevels(g)
+	case FormatHTML:
+		return g.ToHTML(libraries)
+	case FormatMermaid:
+		return g.ToMermaid(), nil
+	case FormatJSON:
+		return g.ToJSON(libraries)
 	default:
 		return "", fmt.Errorf("unknown format: %s", format)
 	}
@@ -174,6 +194,7 @@ func formatList(g *graph.DependencyGraph, libraries []*discovery.Module) (string
 				} else {
 					shortDeps[i] = dep
 				}
+				shortDeps[i] += declaredMarker(g, id, dep)
 			}
 			fmt.Fprintf(&sb, "  %s → %s\n", shortName, strings.Join(shortDeps, ", "))
 		}
@@ -214,7 +235,7 @@ func formatLevels(g *graph.DependencyGraph) (string, error) {
 				depNames := make([]string, len(deps))
 				for j, dep := range deps {
 					parts := strings.Split(dep, "/")
-					depNames[j] = parts[len(parts)-1]
+					depNames[j] = parts[len(parts)-1] + declaredMarker(g, id, dep)
 				}
 				fmt.Fprintf(&sb, "  %s  (← %s)\n", id, strings.Join(depNames, ", "))
 			}
@@ -223,3 +244,12 @@ func formatLevels(g *graph.DependencyGraph) (string, error) {
 	}
 	return sb.String(), nil
 }
+
+// declaredMarker flags edges declared in config or annotations rather than
+// inferred from the code.
+func declaredMarker(g *graph.DependencyGraph, from, to string) string {
+	if g.EdgeKind(from, to) == graph.EdgeDeclared {
+		return " (declared)"
+	}
+	return ""
+}
@@ -2,19 +2,27 @@ package graphflow
 
 import (
 	"context"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/ci/citest"
 	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/registry"
+	"github.com/edelwud/terraci/pkg/workflow"
 )
 
 func TestParseFormat(t *testing.T) {
-	for _, raw := range []string{"", "dot", "plantuml", "list", "levels"} {
+	for _, raw := range []string{"", "dot", "plantuml", "mermaid", "json", "list", "levels", "html"} {
 		if _, err := ParseFormat(raw); err != nil {
 			t.Fatalf("ParseFormat(%q) error = %v", raw, err)
 		}
@@ -41,6 +49,41 @@ func TestRenderListIncludesLibraries(t *testing.T) {
 	}
 }
 
+func TestRenderHTMLIncludesLibraries(t *testing.T) {
+	module := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	library := discovery.TestLibraryModule("_modules/network", "/abs/_modules/network")
+	depGraph := graph.BuildFromDependencies([]*discovery.Module{module}, nil)
+
+	output, err := Render(depGraph, []*discovery.Module{library}, FormatHTML)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{"<!DOCTYPE html>", "platform/stage/eu-central-1/vpc", "_modules/network"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestRenderMarksDeclaredEdges(t *testing.T) {
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	depGraph := graph.NewDependencyGraph()
+	depGraph.AddNode(vpc)
+	depGraph.AddNode(app)
+	depGraph.AddDeclaredEdge(app.ID(), vpc.ID())
+
+	for _, format := range []Format{FormatList, FormatLevels} {
+		output, err := Render(depGraph, nil, format)
+		if err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		if !strings.Contains(output, "(declared)") {
+			t.Errorf("Render(%s) output does not mark the declared edge:\n%s", format, output)
+		}
+	}
+}
+
 func TestRunStats(t *testing.T) {
 	workDir := graphTestProject(t)
 	prepared := prepareGraph(t, workDir)
@@ -89,3 +132,297 @@ func prepareGraph(tb testing.TB, workDir string) *runflow.Prepared {
 	}
 	return prepared
 }
+
+func TestExplain(t *testing.T) {
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	depGraph := graph.NewDependencyGraph()
+	for _, m := range []*discovery.Module{vpc, eks, app} {
+		depGraph.AddNode(m)
+	}
+	depGraph.AddEdge(eks.ID(), vpc.ID())
+	depGraph.AddEdgeSource(eks.ID(), vpc.ID(), graph.EdgeSource{
+		Type:        "remote_state",
+		RemoteState: "vpc",
+		File:        "platform/stage/eu-central-1/eks/data.tf",
+		StartLine:   1,
+		EndLine:     8,
+		StateKey:    "platform/stage/eu-central-1/vpc/terraform.tfstate",
+		Match:       "trailing",
+	})
+	depGraph.AddEdge(app.ID(), eks.ID())
+	depGraph.AddDeclaredEdge(app.ID(), vpc.ID())
+
+	result, err := Explain(depGraph, "./platform/stage/eu-central-1/app/", vpc.ID(), 0)
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if result.Reversed || result.Truncated || len(result.Paths) != 2 {
+		t.Fatalf("Explain() = %+v, want two forward paths", result)
+	}
+	if got := result.Paths[0].Modules; len(got) != 3 || got[1] != eks.ID() {
+		t.Errorf("first path = %v, want app → eks → vpc", got)
+	}
+
+	output := FormatWhy(result)
+	for _, want := range []string{
+		"(2 paths)",
+		`remote_state "vpc" at platform/stage/eu-central-1/eks/data.tf:1-8`,
+		"state key: platform/stage/eu-central-1/vpc/terraform.tfstate",
+		"matched by: trailing",
+		"declared edge",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatWhy() missing %q:\n%s", want, output)
+		}
+	}
+
+	reversed, err := Explain(depGraph, vpc.ID(), eks.ID(), 0)
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !reversed.Reversed || len(reversed.Paths) != 1 {
+		t.Errorf("Explain(vpc, eks) = %+v, want the reverse path", reversed)
+	}
+
+	truncated, err := Explain(depGraph, app.ID(), vpc.ID(), 1)
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !truncated.Truncated || !strings.Contains(FormatWhy(truncated), "--max-paths") {
+		t.Errorf("Explain(limit 1) = %+v, want a truncated result", truncated)
+	}
+
+	if _, err := Explain(depGraph, "platform/stage/eu-central-1/missing", vpc.ID(), 0); err == nil {
+		t.Error("Explain() with an unknown module error = nil")
+	}
+}
+
+func TestExplain_WorkspaceDirectoryIsAmbiguous(t *testing.T) {
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	depGraph := graph.NewDependencyGraph()
+	for _, m := range []*discovery.Module{vpc, app.WithWorkspace("blue"), app.WithWorkspace("green")} {
+		depGraph.AddNode(m)
+	}
+
+	_, err := Explain(depGraph, app.RelativePath, vpc.ID(), 0)
+	if err == nil || !strings.Contains(err.Error(), app.ID()+"@blue") {
+		t.Errorf("Explain() error = %v, want the workspace IDs listed", err)
+	}
+	result, err := Explain(depGraph, app.ID()+"@green", vpc.ID(), 0)
+	if err != nil || len(result.Paths) != 0 {
+		t.Errorf("Explain() = %+v, %v; want no paths", result, err)
+	}
+}
+
+// testRevisionExporter exports a fixed base tree: the given files, written
+// relative to the destination directory.
+type testRevisionExporter struct {
+	files map[string]string
+}
+
+func (e *testRevisionExporter) Name() string        { return "graphflow-test" }
+func (e *testRevisionExporter) Description() string { return "graphflow test revision exporter" }
+
+func (e *testRevisionExporter) DetectChanges(context.Context, workflow.ChangeDetectionRequest) (*workflow.ChangeDetectionResult, error) {
+	return &workflow.ChangeDetectionResult{}, nil
+}
+
+func (e *testRevisionExporter) ExportRevision(_ context.Context, req workflow.RevisionExportRequest) (*workflow.RevisionExportResult, error) {
+	if err := writeFiles(req.DestDir, e.files); err != nil {
+		return nil, err
+	}
+	return &workflow.RevisionExportResult{Ref: "origin/main", Commit: strings.Repeat("0", 40)}, nil
+}
+
+func writeFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDiff_UsesBaseRevisionConfig(t *testing.T) {
+	const appToVPC = `
+dependencies:
+  - from: "platform/*/*/app"
+    to: "platform/*/*/vpc"
+`
+	defaultConfig, err := os.ReadFile(filepath.Join(graphTestProject(t), ".terraci.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	modules := map[string]string{
+		"platform/stage/eu-central-1/vpc/main.tf": "# vpc\n",
+		"platform/stage/eu-central-1/app/main.tf": "# app\n",
+	}
+
+	tests := []struct {
+		name       string
+		baseConfig string
+		wantEdges  []graph.Edge
+	}{
+		{
+			name:       "config adds an edge",
+			baseConfig: string(defaultConfig),
+			wantEdges: []graph.Edge{{
+				From: "platform/stage/eu-central-1/app",
+				To:   "platform/stage/eu-central-1/vpc",
+			}},
+		},
+		{name: "base without config uses the current one"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			base := maps.Clone(modules)
+			if tt.baseConfig != "" {
+				base[".terraci.yaml"] = tt.baseConfig
+			}
+			head := maps.Clone(modules)
+			head[".terraci.yaml"] = string(defaultConfig) + appToVPC
+			if err := writeFiles(workDir, head); err != nil {
+				t.Fatal(err)
+			}
+
+			exporter := &testRevisionExporter{files: base}
+			prepared, err := runflow.New(runflow.Options{
+				RegistryFactory: func() *registry.Registry {
+					return registry.NewFromFactories(func() plugin.Plugin { return exporter })
+				},
+			}).Prepare(context.Background(), runflow.Request{
+				CommandName: "graphflow-test",
+				WorkDir:     workDir,
+			})
+			if err != nil {
+				t.Fatalf("Prepare() error = %v", err)
+			}
+
+			result, err := Diff(context.Background(), NewRuntime(prepared), DiffRequest{})
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if !slices.Equal(result.Diff.AddedEdges, tt.wantEdges) {
+				t.Fatalf("AddedEdges = %v, want %v", result.Diff.AddedEdges, tt.wantEdges)
+			}
+		})
+	}
+}
+
+func TestBuildDiffReport(t *testing.T) {
+	result := &DiffResult{
+		BaseRef:    "origin/main",
+		BaseCommit: "0123456789abcdef0123456789abcdef01234567",
+		Diff: &graph.Diff{
+			AddedModules: []string{"platform/stage/eu-central-1/app"},
+			AddedEdges: []graph.Edge{
+				{From: "platform/stage/eu-central-1/app", To: "platform/stage/eu-central-1/vpc"},
+			},
+			RemovedEdges: []graph.Edge{
+				{From: "platform/stage/eu-central-1/eks", To: "platform/stage/eu-central-1/vpc"},
+			},
+			NewCycles: [][]string{{"platform/stage/eu-central-1/a", "platform/stage/eu-central-1/b"}},
+			LevelChanges: []graph.LevelChange{
+				{ID: "platform/stage/eu-central-1/eks", Before: 1, After: 0},
+			},
+		},
+	}
+	run, err := ci.NewArtifactRun(ci.ArtifactRunOptions{Producer: DiffReportProducer})
+	if err != nil {
+		t.Fatalf("NewArtifactRun() error = %v", err)
+	}
+
+	report, err := BuildDiffReport(result, run)
+	if err != nil {
+		t.Fatalf("BuildDiffReport() error = %v", err)
+	}
+	citest.AssertRenderedReportContract(t, report, citest.RenderedReportContract{
+		Producer: DiffReportProducer,
+		Status:   ci.ReportStatusFail,
+	})
+	if want := "Since origin/main: modules +1/-0, dependencies +1/-1, 1 new cycles, 1 level changes"; report.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", report.Summary(), want)
+	}
+
+	output := FormatDiff(result)
+	for _, want := range []string{
+		"since origin/main (0123456789ab)",
+		"+ platform/stage/eu-central-1/app → platform/stage/eu-central-1/vpc",
+		"- platform/stage/eu-central-1/eks → platform/stage/eu-central-1/vpc",
+		"! platform/stage/eu-central-1/a → platform/stage/eu-central-1/b → platform/stage/eu-central-1/a",
+		"platform/stage/eu-central-1/eks: level 1 → 0",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatDiff() missing %q:\n%s", want, output)
+		}
+	}
+
+	empty := &DiffResult{BaseRef: "HEAD~1", Diff: graph.NewDependencyGraph().DiffFrom(graph.NewDependencyGraph())}
+	report, err = BuildDiffReport(empty, run)
+	if err != nil || report.Status() != ci.ReportStatusPass {
+		t.Errorf("BuildDiffReport(empty) = %v, %v; want a passing report", report, err)
+	}
+	if !strings.Contains(FormatDiff(empty), "No dependency changes.") {
+		t.Errorf("FormatDiff(empty) = %q", FormatDiff(empty))
+	}
+}
+
+func TestCriticalPath_RequiresTimings(t *testing.T) {
+	workDir := graphTestProject(t)
+	prepared := prepareGraph(t, workDir)
+
+	_, err := CriticalPath(context.Background(), NewRuntime(prepared), CriticalPathRequest{})
+	if err == nil || !strings.Contains(err.Error(), "timings.enabled") {
+		t.Fatalf("CriticalPath() error = %v, want disabled timings error", err)
+	}
+	if _, err := CriticalPath(context.Background(), NewRuntime(prepared), CriticalPathRequest{Kinds: []string{"deploy"}}); err == nil {
+		t.Fatal("CriticalPath() accepted an unknown job kind")
+	}
+}
+
+func TestFormatCriticalPath(t *testing.T) {
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	dns := discovery.TestModule("platform", "stage", "eu-central-1", "dns")
+	g := graph.NewDependencyGraph()
+	for _, m := range []*discovery.Module{vpc, eks, dns} {
+		g.AddNode(m)
+	}
+	g.AddEdge(eks.ID(), vpc.ID())
+
+	if out := FormatCriticalPath(mustCriticalPath(t, g, nil)); !strings.Contains(out, "No job durations recorded yet") {
+		t.Errorf("FormatCriticalPath() without durations = %q", out)
+	}
+
+	out := FormatCriticalPath(mustCriticalPath(t, g, map[string]time.Duration{
+		vpc.ID(): 90 * time.Second,
+		eks.ID(): 4 * time.Minute,
+	}))
+	for _, want := range []string{
+		"Estimated pipeline time: 5m30s",
+		"Critical path (2 modules):\n  platform/stage/eu-central-1/vpc  1m30s (0s → 1m30s)\n  platform/stage/eu-central-1/eks  4m0s (1m30s → 5m30s)",
+		"Highest slack cost",
+		"count as zero (1):\n  platform/stage/eu-central-1/dns",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatCriticalPath() missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func mustCriticalPath(t *testing.T, g *graph.DependencyGraph, durations map[string]time.Duration) *graph.CriticalPath {
+	t.Helper()
+	cp, err := g.CriticalPath(durations)
+	if err != nil {
+		t.Fatalf("CriticalPath() error = %v", err)
+	}
+	return cp
+}
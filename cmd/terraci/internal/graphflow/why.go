@@ -0,0 +1,209 @@
+package graphflow
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
+	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+// DefaultWhyLimit caps the number of paths reported by Why.
+const DefaultWhyLimit = 20
+
+// WhyRequest asks why one module depends on another.
+type WhyRequest struct {
+	Filters filter.Flags
+	// From and To are module IDs or module directories relative to the
+	// project root.
+	From  string
+	To    string
+	Limit int
+}
+
+// WhyResult lists the dependency paths between two modules.
+type WhyResult struct {
+	From string
+	To   string
+	// Reversed is set when no path leads from From to To and the paths
+	// lead from To to From instead.
+	Reversed  bool
+	Paths     []WhyPath
+	Truncated bool
+}
+
+// WhyPath is one chain of dependency edges.
+type WhyPath struct {
+	Modules []string
+	Edges   []WhyEdge
+}
+
+// WhyEdge is one dependency edge with the evidence that created it.
+type WhyEdge struct {
+	From    string
+	To      string
+	Kind    graph.EdgeKind
+	Sources []graph.EdgeSource
+}
+
+// Why scans the project and explains every dependency path between two
+// modules.
+func Why(ctx context.Context, runtime Runtime, req WhyRequest) (*WhyResult, error) {
+	project, err := projectflow.Run(ctx, runtime.project, projectflow.Request{Filters: req.Filters})
+	if err != nil {
+		return nil, err
+	}
+	return Explain(project.Workflow.Graph, req.From, req.To, req.Limit)
+}
+
+// Explain collects the dependency paths from one module to another. When
+// from does not depend on to, the reverse direction is tried so the
+// arguments can be given in either order.
+func Explain(g *graph.DependencyGraph, from, to string, limit int) (*WhyResult, error) {
+	fromID, err := resolveModuleID(g, from)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := resolveModuleID(g, to)
+	if err != nil {
+		return nil, err
+	}
+	if fromID == toID {
+		return nil, fmt.Errorf("both arguments refer to module %s", fromID)
+	}
+	if limit <= 0 {
+		limit = DefaultWhyLimit
+	}
+
+	result := &WhyResult{From: fromID, To: toID}
+	paths, truncated := g.AllPaths(fromID, toID, limit)
+	if len(paths) == 0 {
+		paths, truncated = g.AllPaths(toID, fromID, limit)
+		result.Reversed = len(paths) > 0
+	}
+	result.Truncated = truncated
+
+	for _, modules := range paths {
+		whyPath := WhyPath{Modules: modules}
+		for i := 1; i < len(modules); i++ {
+			whyPath.Edges = append(whyPath.Edges, WhyEdge{
+				From:    modules[i-1],
+				To:      modules[i],
+				Kind:    g.EdgeKind(modules[i-1], modules[i]),
+				Sources: g.EdgeSources(modules[i-1], modules[i]),
+			})
+		}
+		result.Paths = append(result.Paths, whyPath)
+	}
+	return result, nil
+}
+
+// resolveModuleID accepts a module ID or a module directory. A directory
+// deployed to several workspaces is ambiguous and must be given as an ID.
+func resolveModuleID(g *graph.DependencyGraph, raw string) (string, error) {
+	id := strings.TrimPrefix(strings.TrimSuffix(path.Clean(strings.TrimSpace(raw)), "/"), "./")
+	if g.GetNode(id) != nil {
+		return id, nil
+	}
+
+	var candidates []string
+	for nodeID, node := range g.Nodes() {
+		if node.Module != nil && path.Clean(node.Module.RelativePath) == id {
+			candidates = append(candidates, nodeID)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("module not found: %s", raw)
+	case 1:
+		return candidates[0], nil
+	default:
+		sort.Strings(candidates)
+		return "", fmt.Errorf("module %s targets several workspaces, use one of: %s", raw, strings.Join(candidates, ", "))
+	}
+}
+
+// FormatWhy renders a WhyResult as indented text.
+func FormatWhy(result *WhyResult) string {
+	var sb strings.Builder
+	from, to := result.From, result.To
+	if len(result.Paths) == 0 {
+		fmt.Fprintf(&sb, "%s and %s do not depend on each other\n", from, to)
+		return sb.String()
+	}
+	if result.Reversed {
+		fmt.Fprintf(&sb, "%s does not depend on %s; showing the reverse direction\n\n", from, to)
+		from, to = to, from
+	}
+
+	count := fmt.Sprintf("%d", len(result.Paths))
+	if result.Truncated {
+		count = "first " + count
+	}
+	noun := "paths"
+	if len(result.Paths) == 1 {
+		noun = "path"
+	}
+	fmt.Fprintf(&sb, "%s depends on %s (%s %s)\n", from, to, count, noun)
+
+	for i, whyPath := range result.Paths {
+		fmt.Fprintf(&sb, "\nPath %d: %s\n", i+1, strings.Join(whyPath.Modules, " → "))
+		for _, edge := range whyPath.Edges {
+			fmt.Fprintf(&sb, "  %s → %s\n", edge.From, edge.To)
+			if len(edge.Sources) == 0 {
+				fmt.Fprintf(&sb, "    %s edge\n", edge.Kind)
+			}
+			for _, source := range edge.Sources {
+				writeEdgeSource(&sb, source)
+			}
+		}
+	}
+	if result.Truncated {
+		sb.WriteString("\nMore paths exist; raise --max-paths to list them.\n")
+	}
+	return sb.String()
+}
+
+func writeEdgeSource(sb *strings.Builder, source graph.EdgeSource) {
+	if source.RemoteState == "" {
+		fmt.Fprintf(sb, "    %s\n", source.Type)
+	} else {
+		fmt.Fprintf(sb, "    %s %q", source.Type, source.RemoteState)
+		if source.File != "" {
+			fmt.Fprintf(sb, " at %s", source.File)
+			if source.StartLine > 0 {
+				fmt.Fprintf(sb, ":%d-%d", source.StartLine, source.EndLine)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if source.StateKey != "" {
+		fmt.Fprintf(sb, "      state key: %s\n", source.StateKey)
+	}
+	if source.Workspace != "" {
+		fmt.Fprintf(sb, "      workspace: %s\n", source.Workspace)
+	}
+	if source.Match != "" {
+		fmt.Fprintf(sb, "      matched by: %s\n", matchDescription(source.Match))
+	}
+}
+
+func matchDescription(match string) string {
+	switch match {
+	case string(parser.MatchPath):
+		return "path (state key is the module path)"
+	case string(parser.MatchTrailing):
+		return "trailing (last segments of the state key are the module path)"
+	case string(parser.MatchContext):
+		return "context (state key resolved in the referencing module's context)"
+	case string(parser.MatchBackend):
+		return "backend (backend and state key match the module's own backend)"
+	default:
+		return match
+	}
+}
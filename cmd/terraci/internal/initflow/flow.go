@@ -16,8 +16,12 @@ import (
 )
 
 const (
-	providerGitLab = "gitlab"
-	providerGitHub = "github"
+	providerGitLab      = "gitlab"
+	providerGitHub      = "github"
+	providerAzureDevOps = "azuredevops"
+	providerBitbucket   = "bitbucket"
+	providerBuildkite   = "buildkite"
+	providerJenkins     = "jenkins"
 )
 
 // PluginSource is the minimum plugin source required by init flow
@@ -224,8 +228,17 @@ func (f Flow) BuildConfig(state *initwiz.StateMap) (*BuildResult, error) {
 }
 
 func generateCommand(provider string) string {
-	if provider == providerGitHub {
+	switch provider {
+	case providerGitHub:
 		return "terraci generate -o .github/workflows/terraform.yml"
+	case providerAzureDevOps:
+		return "terraci generate -o azure-pipelines.yml"
+	case providerBitbucket:
+		return "terraci generate -o bitbucket-pipelines.yml"
+	case providerBuildkite:
+		return "terraci generate | buildkite-agent pipeline upload"
+	case providerJenkins:
+		return "terraci generate -o Jenkinsfile"
 	}
 	return "terraci generate -o .gitlab-ci.yml"
 }
@@ -4,9 +4,16 @@ package projectflow
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/caarlos0/log"
 
 	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/parser"
+	"github.com/edelwud/terraci/pkg/plugin"
 	"github.com/edelwud/terraci/pkg/workflow"
 )
 
@@ -28,6 +35,16 @@ func NewRuntime(prepared *runflow.Prepared) Runtime {
 
 // Request describes one project discovery request.
 type Request struct {
+	// WorkDir, when set, plans the project in this directory instead of the
+	// prepared work directory, e.g. a base revision exported for comparison.
+	WorkDir string
+	// Config, when set, replaces the prepared config, e.g. the config of an
+	// exported base revision.
+	Config *config.Config
+	// NoParseCache skips the configured parse cache. Cache keys include the
+	// absolute module path, so a tree planned once in a temporary directory
+	// would only fill the cache with entries nothing reads.
+	NoParseCache  bool
 	Filters       filter.Flags
 	SelectTargets bool
 	ChangedOnly   bool
@@ -40,9 +57,21 @@ func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
 		return nil, errPreparedRequired
 	}
 	appCtx := runtime.prepared.AppContext()
+	workDir := req.WorkDir
+	if workDir == "" {
+		workDir = runtime.prepared.WorkDir()
+	}
+	cfg := runtime.prepared.Config()
+	if req.Config != nil {
+		cfg = *req.Config
+	}
+	var cache *parser.ModuleCache
+	if !req.NoParseCache {
+		cache = parseCache(ctx, runtime.prepared)
+	}
 	return workflow.PlanProject(ctx, workflow.ProjectRequest{
-		WorkDir: runtime.prepared.WorkDir(),
-		Config:  runtime.prepared.Config(),
+		WorkDir: workDir,
+		Config:  cfg,
 		Filters: req.Filters,
 		Targeting: workflow.TargetRequest{
 			Enabled:     req.SelectTargets,
@@ -52,5 +81,38 @@ func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
 				return appCtx.ChangeDetectorResolver().ResolveChangeDetector()
 			},
 		},
+		ParseCache: cache,
 	})
 }
+
+// parseCache returns the configured parse cache, or nil when it is disabled
+// or its blob backend is unavailable; the cache only saves time, so a
+// backend failure falls back to parsing every module. Entries past
+// parse_cache.max_age are pruned first.
+func parseCache(ctx context.Context, prepared *runflow.Prepared) *parser.ModuleCache {
+	cfg := prepared.Config().ParseCache()
+	if cfg == nil || !cfg.Enabled() {
+		return nil
+	}
+	cache, err := newParseCache(ctx, prepared.AppContext(), cfg.Backend(), cfg.MaxAge())
+	if err != nil {
+		log.WithError(err).Warn("parse cache disabled")
+		return nil
+	}
+	if err := cache.Prune(ctx); err != nil {
+		log.WithError(err).Warn("prune parse cache")
+	}
+	return cache
+}
+
+func newParseCache(ctx context.Context, appCtx *plugin.AppContext, backend string, maxAge time.Duration) (*parser.ModuleCache, error) {
+	provider, err := appCtx.BlobStoreResolver().ResolveBlobStoreProvider(backend, "set parse_cache.backend explicitly")
+	if err != nil {
+		return nil, fmt.Errorf("resolve blob backend: %w", err)
+	}
+	store, err := provider.NewBlobStore(ctx, appCtx, plugin.BlobStoreOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create blob backend %q: %w", provider.Name(), err)
+	}
+	return parser.NewModuleCache(store, appCtx.Version(), maxAge), nil
+}
@@ -1,9 +1,10 @@
 // Package schemaflow builds the .terraci.yaml JSON schema from configured
-// plugin schema contributors.
+// plugin schema contributors, and the schema of exported pipeline IR.
 package schemaflow
 
 import (
 	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/pipeline"
 )
 
 type configLoaderSource interface {
@@ -23,3 +24,9 @@ func Generate(source configLoaderSource) (string, error) {
 	}
 	return config.GenerateJSONSchema(definitions)
 }
+
+// GenerateIR returns the JSON schema for pipeline IR documents written by
+// `terraci generate --format ir-json`.
+func GenerateIR() (string, error) {
+	return pipeline.IRJSONSchema()
+}
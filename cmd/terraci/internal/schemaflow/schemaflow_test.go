@@ -47,3 +47,15 @@ func TestGenerateReturnsSourceError(t *testing.T) {
 		t.Fatalf("Generate() error = %v, want %v", err, want)
 	}
 }
+
+func TestGenerateIR(t *testing.T) {
+	t.Parallel()
+
+	schema, err := GenerateIR()
+	if err != nil {
+		t.Fatalf("GenerateIR() error = %v", err)
+	}
+	if !strings.Contains(schema, `"TerraCi Pipeline IR"`) {
+		t.Fatalf("schema = %s, want IR schema title", schema)
+	}
+}
@@ -3,11 +3,14 @@ package validateflow
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
 	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/filter"
 	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/workflow"
 )
 
 // Runtime contains immutable dependencies needed to validate a project.
@@ -23,6 +26,10 @@ func NewRuntime(prepared *runflow.Prepared) Runtime {
 // Request describes one validation request.
 type Request struct {
 	Filters filter.Flags
+	// ChangedOnly limits output contract checks to changed modules and
+	// their dependents, so removing an output still read elsewhere fails.
+	ChangedOnly bool
+	BaseRef     string
 }
 
 // Result contains validation diagnostics.
@@ -33,16 +40,34 @@ type Result struct {
 	Stats                graph.Stats
 	ExecutionLevels      [][]string
 	ExecutionLevelsError error
+	MissingOutputs       []workflow.MissingOutput
 	Passed               bool
 }
 
 // Run validates project dependency graph health.
 func Run(ctx context.Context, runtime Runtime, req Request) (*Result, error) {
-	project, err := projectflow.Run(ctx, runtime.project, projectflow.Request{Filters: req.Filters})
+	project, err := projectflow.Run(ctx, runtime.project, projectflow.Request{
+		Filters:       req.Filters,
+		SelectTargets: req.ChangedOnly,
+		ChangedOnly:   req.ChangedOnly,
+		BaseRef:       req.BaseRef,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return Evaluate(project), nil
+	result := Evaluate(project)
+
+	var consumers []*discovery.Module
+	if req.ChangedOnly {
+		consumers = append([]*discovery.Module{}, project.Targets...)
+	}
+	missing, err := workflow.CheckOutputContracts(ctx, project.Workflow, consumers)
+	if err != nil {
+		return nil, fmt.Errorf("check output contracts: %w", err)
+	}
+	result.MissingOutputs = missing
+	result.Passed = result.Passed && len(missing) == 0
+	return result, nil
 }
 
 // Evaluate derives validation diagnostics from a discovered project.
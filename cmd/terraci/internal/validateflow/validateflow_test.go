@@ -1,15 +1,33 @@
 package validateflow
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/edelwud/terraci/cmd/terraci/internal/projectflow"
+	"github.com/edelwud/terraci/cmd/terraci/internal/runflow"
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/graph"
 	"github.com/edelwud/terraci/pkg/parser"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/registry"
 	"github.com/edelwud/terraci/pkg/workflow"
 )
 
+type testChangeDetector struct {
+	changed []*discovery.Module
+}
+
+func (d *testChangeDetector) Name() string        { return "validateflow-test" }
+func (d *testChangeDetector) Description() string { return "validateflow test change detector" }
+
+func (d *testChangeDetector) DetectChanges(context.Context, workflow.ChangeDetectionRequest) (*workflow.ChangeDetectionResult, error) {
+	return &workflow.ChangeDetectionResult{Modules: d.changed}, nil
+}
+
 func TestEvaluatePassesAcyclicGraph(t *testing.T) {
 	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
 	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
@@ -56,3 +74,76 @@ func TestEvaluateFailsCycles(t *testing.T) {
 		t.Fatal("ExecutionLevelsError = nil")
 	}
 }
+
+func TestRunChangedOnlyChecksContractsOfChangedModulesAndDependents(t *testing.T) {
+	workDir := t.TempDir()
+	if err := config.Default().Save(filepath.Join(workDir, ".terraci.yaml")); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	dns := discovery.TestModule("platform", "stage", "eu-central-1", "dns")
+	writeModule(t, workDir, vpc, `
+output "vpc_id" {
+  value = "vpc-123"
+}
+`)
+	writeModule(t, workDir, eks, `
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config  = { bucket = "state", key = "platform/stage/eu-central-1/vpc/terraform.tfstate" }
+}
+
+locals {
+  subnet_ids = data.terraform_remote_state.vpc.outputs.subnet_ids
+}
+`)
+	writeModule(t, workDir, dns, "# dns\n")
+
+	tests := []struct {
+		name        string
+		changed     *discovery.Module
+		wantMissing int
+	}{
+		{name: "unrelated change", changed: dns, wantMissing: 0},
+		{name: "producer change checks dependents", changed: vpc, wantMissing: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := &testChangeDetector{changed: []*discovery.Module{tt.changed}}
+			prepared, err := runflow.New(runflow.Options{
+				RegistryFactory: func() *registry.Registry {
+					return registry.NewFromFactories(func() plugin.Plugin { return detector })
+				},
+			}).Prepare(context.Background(), runflow.Request{
+				CommandName: "validateflow-test",
+				WorkDir:     workDir,
+			})
+			if err != nil {
+				t.Fatalf("Prepare() error = %v", err)
+			}
+
+			result, err := Run(context.Background(), NewRuntime(prepared), Request{ChangedOnly: true})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if len(result.MissingOutputs) != tt.wantMissing {
+				t.Fatalf("MissingOutputs = %+v, want %d", result.MissingOutputs, tt.wantMissing)
+			}
+			if result.Passed != (tt.wantMissing == 0) {
+				t.Fatalf("Passed = %v with %d missing outputs", result.Passed, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func writeModule(t *testing.T, workDir string, module *discovery.Module, content string) {
+	t.Helper()
+	dir := filepath.Join(workDir, module.RelativePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write module: %v", err)
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"github.com/edelwud/terraci/cmd/terraci/cmd"
 
 	// Built-in plugins (blank imports trigger init() registration)
+	_ "github.com/edelwud/terraci/plugins/azuredevops"
+	_ "github.com/edelwud/terraci/plugins/bitbucket"
+	_ "github.com/edelwud/terraci/plugins/buildkite"
 	_ "github.com/edelwud/terraci/plugins/cost"
 	_ "github.com/edelwud/terraci/plugins/diskblob"
 	_ "github.com/edelwud/terraci/plugins/git"
 	_ "github.com/edelwud/terraci/plugins/github"
 	_ "github.com/edelwud/terraci/plugins/gitlab"
 	_ "github.com/edelwud/terraci/plugins/inmemcache"
+	_ "github.com/edelwud/terraci/plugins/jenkins"
 	_ "github.com/edelwud/terraci/plugins/localexec"
 	_ "github.com/edelwud/terraci/plugins/policy"
 	_ "github.com/edelwud/terraci/plugins/summary"
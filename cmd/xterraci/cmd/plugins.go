@@ -9,15 +9,19 @@ import (
 // BuiltinPlugins lists the import paths of all built-in plugins.
 // These are included by default unless excluded with --without.
 var BuiltinPlugins = map[string]string{
-	"gitlab":     "github.com/edelwud/terraci/plugins/gitlab",
-	"github":     "github.com/edelwud/terraci/plugins/github",
-	"cost":       "github.com/edelwud/terraci/plugins/cost",
-	"diskblob":   "github.com/edelwud/terraci/plugins/diskblob",
-	"policy":     "github.com/edelwud/terraci/plugins/policy",
-	"git":        "github.com/edelwud/terraci/plugins/git",
-	"inmemcache": "github.com/edelwud/terraci/plugins/inmemcache",
-	"summary":    "github.com/edelwud/terraci/plugins/summary",
-	"tfupdate":   "github.com/edelwud/terraci/plugins/tfupdate",
+	"gitlab":      "github.com/edelwud/terraci/plugins/gitlab",
+	"github":      "github.com/edelwud/terraci/plugins/github",
+	"azuredevops": "github.com/edelwud/terraci/plugins/azuredevops",
+	"bitbucket":   "github.com/edelwud/terraci/plugins/bitbucket",
+	"buildkite":   "github.com/edelwud/terraci/plugins/buildkite",
+	"jenkins":     "github.com/edelwud/terraci/plugins/jenkins",
+	"cost":        "github.com/edelwud/terraci/plugins/cost",
+	"diskblob":    "github.com/edelwud/terraci/plugins/diskblob",
+	"policy":      "github.com/edelwud/terraci/plugins/policy",
+	"git":         "github.com/edelwud/terraci/plugins/git",
+	"inmemcache":  "github.com/edelwud/terraci/plugins/inmemcache",
+	"summary":     "github.com/edelwud/terraci/plugins/summary",
+	"tfupdate":    "github.com/edelwud/terraci/plugins/tfupdate",
 }
 
 // builtinNames returns a sorted, comma-separated list of built-in plugin names.
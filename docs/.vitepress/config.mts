@@ -103,6 +103,7 @@ export default defineConfig({
               items: [
                 { text: "Git Integration", link: "/guide/git-integration" },
                 { text: "OpenTofu Support", link: "/guide/opentofu" },
+                { text: "Terragrunt Support", link: "/guide/terragrunt" },
                 { text: "Submodules", link: "/guide/submodules" },
                 { text: "Plugin System", link: "/guide/plugins" },
               ],
@@ -139,6 +140,13 @@ export default defineConfig({
                 { text: "Overview", link: "/config/" },
                 { text: "Structure", link: "/config/structure" },
                 { text: "Filters", link: "/config/filters" },
+                { text: "Approvals", link: "/config/approvals" },
+                { text: "Var Files", link: "/config/var-files" },
+                { text: "Workspaces", link: "/config/workspaces" },
+                { text: "Dependencies", link: "/config/dependencies" },
+                { text: "Data Sources", link: "/config/data-sources" },
+                { text: "Parse Cache", link: "/config/parse-cache" },
+                { text: "Job Timings", link: "/config/timings" },
                 { text: "Policy Checks", link: "/config/policy" },
                 { text: "Cost Estimation", link: "/config/cost" },
                 { text: "Dependency Updates", link: "/config/tfupdate" },
@@ -151,6 +159,10 @@ export default defineConfig({
                 { text: "GitLab CI", link: "/config/gitlab" },
                 { text: "GitLab MR", link: "/config/gitlab-mr" },
                 { text: "GitHub Actions", link: "/config/github" },
+                { text: "Azure DevOps", link: "/config/azuredevops" },
+                { text: "Bitbucket Pipelines", link: "/config/bitbucket" },
+                { text: "Buildkite", link: "/config/buildkite" },
+                { text: "Jenkins", link: "/config/jenkins" },
               ],
             },
           ],
@@ -162,6 +174,7 @@ export default defineConfig({
                 { text: "generate", link: "/cli/generate" },
                 { text: "validate", link: "/cli/validate" },
                 { text: "graph", link: "/cli/graph" },
+                { text: "drift", link: "/cli/drift" },
                 { text: "init", link: "/cli/init" },
                 { text: "summary", link: "/cli/summary" },
                 { text: "cost", link: "/cli/cost" },
@@ -236,6 +249,7 @@ export default defineConfig({
               items: [
                 { text: "Git интеграция", link: "/ru/guide/git-integration" },
                 { text: "Поддержка OpenTofu", link: "/ru/guide/opentofu" },
+                { text: "Поддержка Terragrunt", link: "/ru/guide/terragrunt" },
                 { text: "Сабмодули", link: "/ru/guide/submodules" },
                 { text: "Система плагинов", link: "/ru/guide/plugins" },
               ],
@@ -266,6 +280,13 @@ export default defineConfig({
                 { text: "Обзор", link: "/ru/config/" },
                 { text: "Структура", link: "/ru/config/structure" },
                 { text: "Фильтры", link: "/ru/config/filters" },
+                { text: "Подтверждения", link: "/ru/config/approvals" },
+                { text: "Var-файлы", link: "/ru/config/var-files" },
+                { text: "Workspaces", link: "/ru/config/workspaces" },
+                { text: "Зависимости", link: "/ru/config/dependencies" },
+                { text: "Data sources", link: "/ru/config/data-sources" },
+                { text: "Кэш парсинга", link: "/ru/config/parse-cache" },
+                { text: "Время выполнения", link: "/ru/config/timings" },
                 { text: "Проверка политик", link: "/ru/config/policy" },
                 { text: "Оценка стоимости", link: "/ru/config/cost" },
                 {
@@ -281,6 +302,10 @@ export default defineConfig({
                 { text: "GitLab CI", link: "/ru/config/gitlab" },
                 { text: "GitLab MR", link: "/ru/config/gitlab-mr" },
                 { text: "GitHub Actions", link: "/ru/config/github" },
+                { text: "Azure DevOps", link: "/ru/config/azuredevops" },
+                { text: "Bitbucket Pipelines", link: "/ru/config/bitbucket" },
+                { text: "Buildkite", link: "/ru/config/buildkite" },
+                { text: "Jenkins", link: "/ru/config/jenkins" },
               ],
             },
           ],
@@ -292,6 +317,7 @@ export default defineConfig({
                 { text: "generate", link: "/ru/cli/generate" },
                 { text: "validate", link: "/ru/cli/validate" },
                 { text: "graph", link: "/ru/cli/graph" },
+                { text: "drift", link: "/ru/cli/drift" },
                 { text: "init", link: "/ru/cli/init" },
                 { text: "summary", link: "/ru/cli/summary" },
                 { text: "cost", link: "/ru/cli/cost" },
@@ -10,3 +10,12 @@ resource "aws_vpc" "main" {
   cidr_block = "10.0.0.0/16"
   tags = { Name = "stage-vpc" }
 }
+
+resource "aws_subnet" "private" {
+  vpc_id     = aws_vpc.main.id
+  cidr_block = "10.0.1.0/24"
+}
+
+output "subnet_ids" {
+  value = [aws_subnet.private.id]
+}
@@ -28,3 +28,18 @@ resource "aws_s3_bucket" "logs" {
     Owner       = "devops"
   }
 }
+
+resource "aws_subnet" "private" {
+  vpc_id     = aws_vpc.main.id
+  cidr_block = "10.0.1.0/24"
+
+  tags = {
+    Environment = "stage"
+    Project     = "example"
+    Owner       = "devops"
+  }
+}
+
+output "subnet_id" {
+  value = aws_subnet.private.id
+}
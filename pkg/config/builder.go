@@ -52,6 +52,13 @@ type BuildOptions struct {
 	Exclude        []string
 	Include        []string
 	LibraryModules *LibraryModulesConfig
+	Approvals      []ApprovalRule
+	VarFiles       []VarFileRule
+	Workspaces     []WorkspaceRule
+	Dependencies   []DependencyRule
+	DataSources    []DataSourceRule
+	ParseCache     *ParseCacheConfig
+	Timings        *TimingsConfig
 	Extensions     ExtensionValueSet
 }
 
@@ -157,6 +164,13 @@ func Build(opts BuildOptions) (Config, error) {
 	cfg.exclude = append([]string(nil), opts.Exclude...)
 	cfg.include = append([]string(nil), opts.Include...)
 	cfg.libraryModules = cloneLibraryModulesConfig(opts.LibraryModules)
+	cfg.approvals = cloneApprovalRules(opts.Approvals)
+	cfg.varFiles = cloneVarFileRules(opts.VarFiles)
+	cfg.workspaces = cloneWorkspaceRules(opts.Workspaces)
+	cfg.dependencies = cloneDependencyRules(opts.Dependencies)
+	cfg.dataSources = cloneDataSourceRules(opts.DataSources)
+	cfg.parseCache = cloneParseCacheConfig(opts.ParseCache)
+	cfg.timings = cloneTimingsConfig(opts.Timings)
 	for i := range opts.Extensions.values {
 		setExtensionValue(&cfg, opts.Extensions.values[i])
 	}
@@ -162,7 +162,7 @@ func TestBuild_InvalidPattern(t *testing.T) {
 func TestBuild_InvalidExecution(t *testing.T) {
 	t.Parallel()
 
-	execution := ExecutionConfig{binary: "terragrunt", initEnabled: true, parallelism: 4}
+	execution := ExecutionConfig{binary: "pulumi", initEnabled: true, parallelism: 4}
 	_, err := Build(BuildOptions{Execution: &execution})
 	if err == nil {
 		t.Fatal("expected validation error for invalid execution.binary")
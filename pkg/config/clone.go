@@ -20,6 +20,78 @@ func cloneLibraryModulesConfig(c *LibraryModulesConfig) *LibraryModulesConfig {
 	return &clone
 }
 
+func cloneParseCacheConfig(c *ParseCacheConfig) *ParseCacheConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+func cloneTimingsConfig(c *TimingsConfig) *TimingsConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+func cloneApprovalRules(rules []ApprovalRule) []ApprovalRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	clone := make([]ApprovalRule, len(rules))
+	for i, rule := range rules {
+		clone[i] = ApprovalRule{match: maps.Clone(rule.match)}
+	}
+	return clone
+}
+
+func cloneVarFileRules(rules []VarFileRule) []VarFileRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	clone := make([]VarFileRule, len(rules))
+	for i, rule := range rules {
+		clone[i] = VarFileRule{match: maps.Clone(rule.match), files: append([]string(nil), rule.files...)}
+	}
+	return clone
+}
+
+func cloneWorkspaceRules(rules []WorkspaceRule) []WorkspaceRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	clone := make([]WorkspaceRule, len(rules))
+	for i, rule := range rules {
+		clone[i] = WorkspaceRule{match: maps.Clone(rule.match), names: append([]string(nil), rule.names...)}
+	}
+	return clone
+}
+
+func cloneDependencyRules(rules []DependencyRule) []DependencyRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	clone := make([]DependencyRule, len(rules))
+	for i, rule := range rules {
+		clone[i] = DependencyRule{from: rule.from.clone(), to: rule.to.clone()}
+	}
+	return clone
+}
+
+func cloneDataSourceRules(rules []DataSourceRule) []DataSourceRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	return append([]DataSourceRule(nil), rules...)
+}
+
+func (s ModuleSelector) clone() ModuleSelector {
+	s.match = maps.Clone(s.match)
+	return s
+}
+
 func (c StructureConfig) clone() StructureConfig {
 	c.segments = append(PatternSegments(nil), c.segments...)
 	return c
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"go.yaml.in/yaml/v4"
 )
@@ -177,6 +178,393 @@ execution:
 	}
 }
 
+func TestLoad_Approvals(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+approvals:
+  - match:
+      environment: prod
+      region: "eu-*"
+`
+	writeTestConfig(t, configPath, content)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	approvals := cfg.Approvals()
+	if len(approvals) != 1 {
+		t.Fatalf("Approvals() len = %d, want 1", len(approvals))
+	}
+	if got := approvals[0].Match(); got["environment"] != "prod" || got["region"] != "eu-*" {
+		t.Fatalf("Approvals()[0].Match() = %v", got)
+	}
+}
+
+func TestLoad_RejectsInvalidApprovals(t *testing.T) {
+	for name, approval := range map[string]string{
+		"unknown segment": "      stage: prod\n",
+		"bad pattern":     "      environment: \"[prod\"\n",
+		"blank pattern":   "      environment: \"\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := createTempDir(t)
+			configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+			content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+approvals:
+  - match:
+` + approval
+			writeTestConfig(t, configPath, content)
+
+			_, err := Load(configPath)
+			if err == nil {
+				t.Fatal("Load() returned nil error for invalid approvals")
+			}
+			if !strings.Contains(err.Error(), "approvals[0]") {
+				t.Fatalf("error should mention approvals[0], got: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewApprovalRuleValidatesPatterns(t *testing.T) {
+	for name, match := range map[string]map[string]string{
+		"empty":         nil,
+		"blank segment": {"": "prod"},
+		"blank pattern": {"environment": ""},
+		"bad pattern":   {"environment": "[prod"},
+	} {
+		if _, err := NewApprovalRule(ApprovalRuleOptions{Match: match}); err == nil {
+			t.Errorf("%s: NewApprovalRule() error = nil, want validation error", name)
+		}
+	}
+}
+
+func TestLoad_VarFiles(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+var_files:
+  - match:
+      environment: prod
+    files:
+      - ../../common.tfvars
+      - prod.tfvars
+`
+	writeTestConfig(t, configPath, content)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	varFiles := cfg.VarFiles()
+	if len(varFiles) != 1 {
+		t.Fatalf("VarFiles() len = %d, want 1", len(varFiles))
+	}
+	if got := varFiles[0].Match(); got["environment"] != "prod" {
+		t.Fatalf("VarFiles()[0].Match() = %v", got)
+	}
+	if got := varFiles[0].Files(); len(got) != 2 || got[0] != "../../common.tfvars" || got[1] != "prod.tfvars" {
+		t.Fatalf("VarFiles()[0].Files() = %v", got)
+	}
+}
+
+func TestLoad_RejectsInvalidVarFiles(t *testing.T) {
+	for name, rule := range map[string]string{
+		"unknown segment": "  - match: {stage: prod}\n    files: [prod.tfvars]\n",
+		"no files":        "  - match: {environment: prod}\n",
+		"empty path":      "  - match: {environment: prod}\n    files: [\"\"]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := createTempDir(t)
+			configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+			content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+var_files:
+` + rule
+			writeTestConfig(t, configPath, content)
+
+			_, err := Load(configPath)
+			if err == nil {
+				t.Fatal("Load() returned nil error for invalid var_files")
+			}
+			if !strings.Contains(err.Error(), "var_files[0]") {
+				t.Fatalf("error should mention var_files[0], got: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad_Workspaces(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+workspaces:
+  - match:
+      module: tenant-*
+    names: [acme, globex]
+`
+	writeTestConfig(t, configPath, content)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	workspaces := cfg.Workspaces()
+	if len(workspaces) != 1 {
+		t.Fatalf("Workspaces() len = %d, want 1", len(workspaces))
+	}
+	if got := workspaces[0].Match(); got["module"] != "tenant-*" {
+		t.Fatalf("Workspaces()[0].Match() = %v", got)
+	}
+	if got := workspaces[0].Names(); len(got) != 2 || got[0] != "acme" || got[1] != "globex" {
+		t.Fatalf("Workspaces()[0].Names() = %v", got)
+	}
+
+	for name, rule := range map[string]string{
+		"unknown segment": "  - match: {stage: prod}\n    names: [acme]\n",
+		"no names":        "  - match: {module: app}\n",
+		"separator":       "  - match: {module: app}\n    names: [\"a@b\"]\n",
+		"slash":           "  - match: {module: app}\n    names: [a/b]\n",
+		"shell":           "  - match: {module: app}\n    names: [\"a;b\"]\n",
+		"substitution":    "  - match: {module: app}\n    names: [\"$(id)\"]\n",
+		"dot":             "  - match: {module: app}\n    names: [\"..\"]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			writeTestConfig(t, configPath, "structure:\n  pattern: \"{service}/{environment}/{region}/{module}\"\nworkspaces:\n"+rule)
+			_, err := Load(configPath)
+			if err == nil || !strings.Contains(err.Error(), "workspaces[0]") {
+				t.Fatalf("Load() error = %v, want workspaces[0] error", err)
+			}
+		})
+	}
+}
+
+func TestLoad_Dependencies(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+dependencies:
+  - from: "*/prod/*/eks"
+    to: "*/prod/*/iam"
+  - from:
+      environment: prod
+    to:
+      module: kms
+`
+	writeTestConfig(t, configPath, content)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	rules := cfg.Dependencies()
+	if len(rules) != 2 {
+		t.Fatalf("Dependencies() len = %d, want 2", len(rules))
+	}
+	if got := rules[0].From().Glob(); got != "*/prod/*/eks" {
+		t.Fatalf("Dependencies()[0].From().Glob() = %q", got)
+	}
+	if got := rules[1].To().Match(); got["module"] != "kms" || rules[1].To().Glob() != "" {
+		t.Fatalf("Dependencies()[1].To() = %v", got)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `from: '*/prod/*/eks'`) || !strings.Contains(string(data), "module: kms") {
+		t.Fatalf("marshaled config lost dependency selectors:\n%s", data)
+	}
+}
+
+func TestLoad_RejectsInvalidDependencies(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rule string
+		want string
+	}{
+		"missing to":      {rule: "  - from: \"*/prod/*/eks\"\n", want: "dependencies[0]: to"},
+		"unknown segment": {rule: "  - from: {stage: prod}\n    to: \"*/prod/*/iam\"\n", want: "dependencies[0].from.match"},
+		"bad glob":        {rule: "  - from: \"*/prod/*/eks\"\n    to: \"a**/iam\"\n", want: "dependencies[0].to"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := createTempDir(t)
+			configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+			content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+dependencies:
+` + tc.rule
+			writeTestConfig(t, configPath, content)
+
+			_, err := Load(configPath)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Load() error = %v, want it to mention %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoad_DataSources(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+data_sources:
+  - type: tfe_outputs
+    attribute: workspace
+    module: "platform/*/*/{value}"
+  - type: aws_ssm_parameter
+    attribute: name
+    pattern: "^/(?P<id>[^/]+/[^/]+/[^/]+/[^/]+)/"
+    module: "{id}"
+`
+	writeTestConfig(t, configPath, content)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	rules := cfg.DataSources()
+	if len(rules) != 2 {
+		t.Fatalf("DataSources() len = %d, want 2", len(rules))
+	}
+	if rules[0].Type() != "tfe_outputs" || rules[0].Attribute() != "workspace" || rules[0].Pattern() != "" {
+		t.Fatalf("DataSources()[0] = %+v", rules[0])
+	}
+	if rules[1].Module() != "{id}" {
+		t.Fatalf("DataSources()[1].Module() = %q", rules[1].Module())
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), "type: aws_ssm_parameter") {
+		t.Fatalf("marshaled config lost data source rules:\n%s", data)
+	}
+}
+
+func TestLoad_RejectsInvalidDataSources(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rule string
+		want string
+	}{
+		"missing module":      {rule: "  - type: tfe_outputs\n    attribute: workspace\n", want: "data_sources[0]: module is required"},
+		"bad pattern":         {rule: "  - type: tfe_outputs\n    attribute: workspace\n    pattern: \"(\"\n    module: \"{value}\"\n", want: "data_sources[0].pattern"},
+		"unknown placeholder": {rule: "  - type: tfe_outputs\n    attribute: workspace\n    module: \"{env}/{value}\"\n", want: "data_sources[0].module: unknown placeholder {env}"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := createTempDir(t)
+			configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+			content := `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+data_sources:
+` + tc.rule
+			writeTestConfig(t, configPath, content)
+
+			_, err := Load(configPath)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Load() error = %v, want it to mention %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoad_ParseCache(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	writeTestConfig(t, configPath, `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+parse_cache:
+  enabled: true
+  backend: diskblob
+  max_age: 72h
+`)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	parseCache := cfg.ParseCache()
+	if parseCache == nil || !parseCache.Enabled() || parseCache.Backend() != "diskblob" || parseCache.MaxAge() != 72*time.Hour {
+		t.Fatalf("ParseCache() = %+v", parseCache)
+	}
+	if unset, err := NewParseCacheConfig(ParseCacheConfigOptions{Enabled: true}); err != nil || unset.MaxAge() != DefaultParseCacheMaxAge {
+		t.Fatalf("MaxAge() without max_age = %v, %v; want the default", unset.MaxAge(), err)
+	}
+	if _, err := NewParseCacheConfig(ParseCacheConfigOptions{MaxAge: -time.Hour}); err == nil {
+		t.Fatal("NewParseCacheConfig() accepted a negative max_age")
+	}
+	if Default().ParseCache() != nil {
+		t.Fatal("default config enables the parse cache")
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), "backend: diskblob") || !strings.Contains(string(data), "max_age: 72h0m0s") {
+		t.Fatalf("marshaled config lost parse cache:\n%s", data)
+	}
+}
+
+func TestLoad_Timings(t *testing.T) {
+	tmpDir := createTempDir(t)
+	configPath := filepath.Join(tmpDir, ".terraci.yaml")
+
+	writeTestConfig(t, configPath, `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+timings:
+  enabled: true
+  backend: diskblob
+`)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	timings := cfg.Timings()
+	if timings == nil || !timings.Enabled() || timings.Backend() != "diskblob" {
+		t.Fatalf("Timings() = %+v", timings)
+	}
+	if timings.Samples() != DefaultTimingSamples {
+		t.Errorf("Samples() = %d, want default %d", timings.Samples(), DefaultTimingSamples)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "samples") {
+		t.Fatalf("marshaled config invented samples:\n%s", data)
+	}
+
+	writeTestConfig(t, configPath, `structure:
+  pattern: "{service}/{environment}/{region}/{module}"
+timings:
+  samples: -1
+`)
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "timings") {
+		t.Fatalf("Load() error = %v, want timings error", err)
+	}
+}
+
 func TestLoad_RejectsInvalidServiceDir(t *testing.T) {
 	for _, serviceDir := range []string{"/tmp/terraci", "../.terraci", "artifacts/../.terraci"} {
 		t.Run(serviceDir, func(t *testing.T) {
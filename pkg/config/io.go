@@ -32,21 +32,25 @@ func Load(path string) (Config, error) {
 	return cfg, nil
 }
 
-// LoadOrDefault loads config from file or returns default if not found.
-func LoadOrDefault(dir string) (Config, error) {
-	configPaths := []string{
-		filepath.Join(dir, ".terraci.yaml"),
-		filepath.Join(dir, ".terraci.yml"),
-		filepath.Join(dir, "terraci.yaml"),
-		filepath.Join(dir, "terraci.yml"),
-	}
+// FileNames are the config file names looked up in a directory, in order.
+var FileNames = []string{".terraci.yaml", ".terraci.yml", "terraci.yaml", "terraci.yml"}
 
-	for _, path := range configPaths {
+// Find returns the path of the first config file in dir, if any.
+func Find(dir string) (string, bool) {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
 		if _, err := os.Stat(path); err == nil {
-			return Load(path)
+			return path, true
 		}
 	}
+	return "", false
+}
 
+// LoadOrDefault loads config from file or returns default if not found.
+func LoadOrDefault(dir string) (Config, error) {
+	if path, ok := Find(dir); ok {
+		return Load(path)
+	}
 	return Default(), nil
 }
 
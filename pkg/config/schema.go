@@ -17,10 +17,17 @@ type configSchema struct {
 	Exclude        []string                    `json:"exclude,omitempty" jsonschema:"description=Glob patterns for modules to exclude"`
 	Include        []string                    `json:"include,omitempty" jsonschema:"description=Glob patterns for modules to include (if empty, all modules are included after excludes)"`
 	LibraryModules *libraryModulesConfigSchema `json:"library_modules,omitempty" jsonschema:"description=Configuration for library/shared modules (non-executable modules used by other modules)"`
+	Approvals      []approvalSchema            `json:"approvals,omitempty" jsonschema:"description=Manual approval gates for apply jobs of matching modules"`
+	VarFiles       []varFileSchema             `json:"var_files,omitempty" jsonschema:"description=Extra tfvars files used to evaluate remote state keys of matching modules"`
+	Workspaces     []workspaceSchema           `json:"workspaces,omitempty" jsonschema:"description=Terraform workspaces that matching modules are deployed to"`
+	Dependencies   []dependencyRuleSchema      `json:"dependencies,omitempty" jsonschema:"description=Declared dependency edges that static analysis cannot see"`
+	DataSources    []dataSourceRuleSchema      `json:"data_sources,omitempty" jsonschema:"description=Rules mapping data sources other than terraform_remote_state to the modules producing their values"`
+	ParseCache     *parseCacheSchema           `json:"parse_cache,omitempty" jsonschema:"description=Persistent cache of parsed modules keyed by file content"`
+	Timings        *timingsSchema              `json:"timings,omitempty" jsonschema:"description=History of job durations used to estimate the pipeline critical path"`
 }
 
 type executionSchema struct {
-	Binary      string            `json:"binary,omitempty" jsonschema:"description=Terraform/OpenTofu binary to use,enum=terraform,enum=tofu,default=terraform"`
+	Binary      string            `json:"binary,omitempty" jsonschema:"description=Terraform/OpenTofu binary to use; terragrunt discovers terragrunt.hcl units,enum=terraform,enum=tofu,enum=terragrunt,default=terraform"`
 	InitEnabled bool              `json:"init_enabled,omitempty" jsonschema:"description=Automatically run terraform init before terraform operations,default=true"`
 	Parallelism int               `json:"parallelism,omitempty" jsonschema:"description=Maximum parallel jobs for local execution,minimum=1,default=4"`
 	Env         map[string]string `json:"env,omitempty" jsonschema:"description=Execution-wide environment variables"`
@@ -34,6 +41,61 @@ type libraryModulesConfigSchema struct {
 	Paths []string `json:"paths" jsonschema:"description=List of directories containing library modules (relative to root)"`
 }
 
+type parseCacheSchema struct {
+	Enabled bool   `json:"enabled,omitempty" jsonschema:"description=Cache parsed modules between runs,default=false"`
+	Backend string `json:"backend,omitempty" jsonschema:"description=Blob store backend holding the cache (e.g. diskblob); defaults to the single enabled blob store"`
+	MaxAge  string `json:"max_age,omitempty" jsonschema:"description=How long an entry is kept before it is parsed again and pruned (e.g. 72h),default=168h"`
+}
+
+type timingsSchema struct {
+	Enabled bool   `json:"enabled,omitempty" jsonschema:"description=Record job durations and use them for critical path estimates,default=false"`
+	Backend string `json:"backend,omitempty" jsonschema:"description=Blob store backend holding the history (e.g. diskblob); defaults to the single enabled blob store"`
+	Samples int    `json:"samples,omitempty" jsonschema:"description=Durations kept per module and job kind; estimates use their median,minimum=1,default=10"`
+}
+
+type approvalSchema struct {
+	Match map[string]string `json:"match" jsonschema:"description=Segment patterns (path.Match syntax) that must all match (e.g. environment: prod),required"`
+}
+
+type varFileSchema struct {
+	Match map[string]string `json:"match" jsonschema:"description=Segment patterns (path.Match syntax) that must all match (e.g. environment: prod),required"`
+	Files []string          `json:"files" jsonschema:"description=Tfvars files relative to the module directory; later files override earlier ones,required"`
+}
+
+type workspaceSchema struct {
+	Match map[string]string `json:"match" jsonschema:"description=Segment patterns (path.Match syntax) that must all match (e.g. module: tenant-*),required"`
+	Names []string          `json:"names" jsonschema:"description=Workspace names; each becomes a separate module target identified as path@workspace,required"`
+}
+
+type dependencyRuleSchema struct {
+	From moduleSelectorSchema `json:"from" jsonschema:"description=Dependent modules,required"`
+	To   moduleSelectorSchema `json:"to" jsonschema:"description=Modules depended on,required"`
+}
+
+type dataSourceRuleSchema struct {
+	Type      string `json:"type" jsonschema:"description=Data source type (e.g. aws_ssm_parameter),required"`
+	Attribute string `json:"attribute" jsonschema:"description=Attribute whose value identifies the producing module (e.g. name),required"`
+	Pattern   string `json:"pattern,omitempty" jsonschema:"description=Regular expression the value must match; named groups can be used in module"`
+	Module    string `json:"module" jsonschema:"description=Module ID glob template; {value} expands to the attribute value and {name} to a named group of pattern,required"`
+}
+
+type moduleSelectorSchema struct{}
+
+// JSONSchema describes a module selector: a module ID glob or a map of
+// segment patterns.
+func (moduleSelectorSchema) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{Type: "string", Description: "Module ID glob (e.g. */prod/*/eks); ** matches any number of segments"},
+			{
+				Type:                 "object",
+				Description:          "Segment patterns (path.Match syntax) that must all match (e.g. environment: prod)",
+				AdditionalProperties: &jsonschema.Schema{Type: "string"},
+			},
+		},
+	}
+}
+
 // ExtensionDefinition describes one typed extension config section for schema
 // generation.
 type ExtensionDefinition struct {
@@ -1,12 +1,22 @@
 package config
 
-import "maps"
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"time"
+)
 
 const (
 	DefaultServiceDir        = ".terraci"
 	ExecutionBinaryTerraform = "terraform"
 	ExecutionBinaryTofu      = "tofu"
-	DefaultParallelism       = 4
+	// ExecutionBinaryTerragrunt switches discovery to terragrunt.hcl units
+	// and runs jobs through the terragrunt CLI.
+	ExecutionBinaryTerragrunt = "terragrunt"
+	DefaultParallelism        = 4
 )
 
 // Config is the immutable TerraCi configuration read model.
@@ -19,6 +29,13 @@ type Config struct {
 	exclude        []string
 	include        []string
 	libraryModules *LibraryModulesConfig
+	approvals      []ApprovalRule
+	varFiles       []VarFileRule
+	workspaces     []WorkspaceRule
+	dependencies   []DependencyRule
+	dataSources    []DataSourceRule
+	parseCache     *ParseCacheConfig
+	timings        *TimingsConfig
 	extensions     extensionNodeMap
 }
 
@@ -30,11 +47,68 @@ type ExecutionConfig struct {
 	env         map[string]string
 }
 
+// ParseCacheConfig enables the persistent cache of parsed modules.
+type ParseCacheConfig struct {
+	enabled bool
+	backend string
+	maxAge  time.Duration
+}
+
+// TimingsConfig enables the history of job durations used to estimate the
+// pipeline critical path.
+type TimingsConfig struct {
+	enabled bool
+	backend string
+	samples int
+}
+
 // LibraryModulesConfig defines configuration for library/shared modules
 type LibraryModulesConfig struct {
 	paths []string
 }
 
+// ApprovalRule gates apply jobs of modules whose segments match.
+type ApprovalRule struct {
+	match map[string]string
+}
+
+// VarFileRule adds tfvars files to the evaluation of modules whose segments
+// match.
+type VarFileRule struct {
+	match map[string]string
+	files []string
+}
+
+// WorkspaceRule deploys modules whose segments match to several Terraform
+// workspaces.
+type WorkspaceRule struct {
+	match map[string]string
+	names []string
+}
+
+// DependencyRule declares that modules selected by From depend on modules
+// selected by To, for edges static analysis cannot see.
+type DependencyRule struct {
+	from ModuleSelector
+	to   ModuleSelector
+}
+
+// ModuleSelector picks modules either by a module ID glob or by segment
+// patterns.
+type ModuleSelector struct {
+	glob  string
+	match map[string]string
+}
+
+// DataSourceRule maps a data source attribute to the module producing its
+// value, so data sources other than terraform_remote_state order jobs too.
+type DataSourceRule struct {
+	dataType  string
+	attribute string
+	pattern   string
+	module    string
+}
+
 // StructureConfig defines the directory structure
 type StructureConfig struct {
 	pattern  string
@@ -56,7 +130,7 @@ func NewExecutionConfig(opts ExecutionConfigOptions) (ExecutionConfig, error) {
 		binary = ExecutionBinaryTerraform
 	}
 	switch binary {
-	case ExecutionBinaryTerraform, ExecutionBinaryTofu:
+	case ExecutionBinaryTerraform, ExecutionBinaryTofu, ExecutionBinaryTerragrunt:
 	default:
 		return ExecutionConfig{}, unsupportedExecutionBinaryError(binary)
 	}
@@ -144,6 +218,250 @@ func (c LibraryModulesConfig) Paths() []string {
 	return append([]string(nil), c.paths...)
 }
 
+// DefaultParseCacheMaxAge is how long parse cache entries are kept when
+// parse_cache.max_age is not set.
+const DefaultParseCacheMaxAge = 7 * 24 * time.Hour
+
+// ParseCacheConfigOptions describes parse cache settings.
+type ParseCacheConfigOptions struct {
+	Enabled bool
+	Backend string
+	// MaxAge bounds how long an entry is kept; zero uses the default.
+	MaxAge time.Duration
+}
+
+// NewParseCacheConfig creates immutable parse cache settings.
+func NewParseCacheConfig(opts ParseCacheConfigOptions) (ParseCacheConfig, error) {
+	if opts.MaxAge < 0 {
+		return ParseCacheConfig{}, errors.New("max_age must not be negative")
+	}
+	return ParseCacheConfig{enabled: opts.Enabled, backend: opts.Backend, maxAge: opts.MaxAge}, nil
+}
+
+// Enabled reports whether parsed modules are cached between runs.
+func (c ParseCacheConfig) Enabled() bool {
+	return c.enabled
+}
+
+// Backend returns the blob store backend name, or empty to use the single
+// enabled blob store.
+func (c ParseCacheConfig) Backend() string {
+	return c.backend
+}
+
+// MaxAge returns how long an entry is kept before it is parsed again and
+// pruned.
+func (c ParseCacheConfig) MaxAge() time.Duration {
+	if c.maxAge == 0 {
+		return DefaultParseCacheMaxAge
+	}
+	return c.maxAge
+}
+
+// DefaultTimingSamples is the number of durations kept per module and job
+// kind when timings.samples is not set.
+const DefaultTimingSamples = 10
+
+// TimingsConfigOptions describes job timing history settings.
+type TimingsConfigOptions struct {
+	Enabled bool
+	Backend string
+	Samples int
+}
+
+// NewTimingsConfig creates immutable job timing history settings.
+func NewTimingsConfig(opts TimingsConfigOptions) (TimingsConfig, error) {
+	if opts.Samples < 0 {
+		return TimingsConfig{}, errors.New("samples must not be negative")
+	}
+	return TimingsConfig{enabled: opts.Enabled, backend: opts.Backend, samples: opts.Samples}, nil
+}
+
+// Enabled reports whether job durations are recorded and used for estimates.
+func (c TimingsConfig) Enabled() bool {
+	return c.enabled
+}
+
+// Backend returns the blob store backend name, or empty to use the single
+// enabled blob store.
+func (c TimingsConfig) Backend() string {
+	return c.backend
+}
+
+// Samples returns the number of durations kept per module and job kind.
+func (c TimingsConfig) Samples() int {
+	if c.samples == 0 {
+		return DefaultTimingSamples
+	}
+	return c.samples
+}
+
+// ApprovalRuleOptions describes one manual approval rule.
+type ApprovalRuleOptions struct {
+	Match map[string]string
+}
+
+// NewApprovalRule creates an immutable approval rule. Segment names are
+// checked against the structure pattern by Config.Validate.
+func NewApprovalRule(opts ApprovalRuleOptions) (ApprovalRule, error) {
+	if len(opts.Match) == 0 {
+		return ApprovalRule{}, errors.New("match must contain at least one segment")
+	}
+	for _, segment := range slices.Sorted(maps.Keys(opts.Match)) {
+		pattern := opts.Match[segment]
+		if segment == "" {
+			return ApprovalRule{}, errors.New("match: segment name is required")
+		}
+		if pattern == "" {
+			return ApprovalRule{}, fmt.Errorf("match.%s: pattern is required", segment)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return ApprovalRule{}, fmt.Errorf("match.%s: invalid pattern %q: %w", segment, pattern, err)
+		}
+	}
+	return ApprovalRule{match: maps.Clone(opts.Match)}, nil
+}
+
+// Match returns defensive segment patterns; values use path.Match syntax.
+func (r ApprovalRule) Match() map[string]string {
+	return maps.Clone(r.match)
+}
+
+// VarFileRuleOptions describes one var-file rule.
+type VarFileRuleOptions struct {
+	Match map[string]string
+	Files []string
+}
+
+// NewVarFileRule creates an immutable var-file rule.
+func NewVarFileRule(opts VarFileRuleOptions) (VarFileRule, error) {
+	if len(opts.Match) == 0 {
+		return VarFileRule{}, errors.New("match must contain at least one segment")
+	}
+	if len(opts.Files) == 0 {
+		return VarFileRule{}, errors.New("files must contain at least one path")
+	}
+	return VarFileRule{match: maps.Clone(opts.Match), files: append([]string(nil), opts.Files...)}, nil
+}
+
+// Match returns defensive segment patterns; values use path.Match syntax.
+func (r VarFileRule) Match() map[string]string {
+	return maps.Clone(r.match)
+}
+
+// Files returns defensive var-file paths, relative to the module directory.
+func (r VarFileRule) Files() []string {
+	return append([]string(nil), r.files...)
+}
+
+// WorkspaceRuleOptions describes one workspace rule.
+type WorkspaceRuleOptions struct {
+	Match map[string]string
+	Names []string
+}
+
+// NewWorkspaceRule creates an immutable workspace rule.
+func NewWorkspaceRule(opts WorkspaceRuleOptions) (WorkspaceRule, error) {
+	if len(opts.Match) == 0 {
+		return WorkspaceRule{}, errors.New("match must contain at least one segment")
+	}
+	if len(opts.Names) == 0 {
+		return WorkspaceRule{}, errors.New("names must contain at least one workspace")
+	}
+	return WorkspaceRule{match: maps.Clone(opts.Match), names: append([]string(nil), opts.Names...)}, nil
+}
+
+// Match returns defensive segment patterns; values use path.Match syntax.
+func (r WorkspaceRule) Match() map[string]string {
+	return maps.Clone(r.match)
+}
+
+// Names returns defensive workspace names.
+func (r WorkspaceRule) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// ModuleSelectorOptions describes one module selector; exactly one of Glob
+// and Match must be set.
+type ModuleSelectorOptions struct {
+	Glob  string
+	Match map[string]string
+}
+
+// NewModuleSelector creates an immutable module selector.
+func NewModuleSelector(opts ModuleSelectorOptions) (ModuleSelector, error) {
+	switch {
+	case opts.Glob != "" && len(opts.Match) > 0:
+		return ModuleSelector{}, errors.New("use either a module glob or segment patterns, not both")
+	case opts.Glob == "" && len(opts.Match) == 0:
+		return ModuleSelector{}, errors.New("module glob or segment patterns are required")
+	}
+	return ModuleSelector{glob: opts.Glob, match: maps.Clone(opts.Match)}, nil
+}
+
+// Glob returns the module ID glob, or "" for segment selectors.
+func (s ModuleSelector) Glob() string { return s.glob }
+
+// Match returns defensive segment patterns; values use path.Match syntax.
+func (s ModuleSelector) Match() map[string]string { return maps.Clone(s.match) }
+
+// DependencyRuleOptions describes one declared dependency rule.
+type DependencyRuleOptions struct {
+	From ModuleSelector
+	To   ModuleSelector
+}
+
+// NewDependencyRule creates an immutable dependency rule.
+func NewDependencyRule(opts DependencyRuleOptions) (DependencyRule, error) {
+	if opts.From.glob == "" && len(opts.From.match) == 0 {
+		return DependencyRule{}, errors.New("from is required")
+	}
+	if opts.To.glob == "" && len(opts.To.match) == 0 {
+		return DependencyRule{}, errors.New("to is required")
+	}
+	return DependencyRule{from: opts.From.clone(), to: opts.To.clone()}, nil
+}
+
+// From returns the selector of dependent modules.
+func (r DependencyRule) From() ModuleSelector { return r.from.clone() }
+
+// To returns the selector of modules depended on.
+func (r DependencyRule) To() ModuleSelector { return r.to.clone() }
+
+// DataSourceRuleOptions describes one data source rule.
+type DataSourceRuleOptions struct {
+	Type      string
+	Attribute string
+	Pattern   string
+	Module    string
+}
+
+// NewDataSourceRule creates an immutable data source rule.
+func NewDataSourceRule(opts DataSourceRuleOptions) (DataSourceRule, error) {
+	switch {
+	case opts.Type == "":
+		return DataSourceRule{}, errors.New("type is required")
+	case opts.Attribute == "":
+		return DataSourceRule{}, errors.New("attribute is required")
+	case opts.Module == "":
+		return DataSourceRule{}, errors.New("module is required")
+	}
+	return DataSourceRule{dataType: opts.Type, attribute: opts.Attribute, pattern: opts.Pattern, module: opts.Module}, nil
+}
+
+// Type returns the data source type, e.g. aws_ssm_parameter.
+func (r DataSourceRule) Type() string { return r.dataType }
+
+// Attribute returns the attribute whose value identifies the producer.
+func (r DataSourceRule) Attribute() string { return r.attribute }
+
+// Pattern returns the regular expression values must match, or "".
+func (r DataSourceRule) Pattern() string { return r.pattern }
+
+// Module returns the module ID glob template; {value} and named groups of
+// Pattern are expanded.
+func (r DataSourceRule) Module() string { return r.module }
+
 // ServiceDir returns the project-level service directory for cache and artifacts.
 func (c Config) ServiceDir() string {
 	return c.serviceDir
@@ -174,6 +492,41 @@ func (c Config) Include() []string {
 	return append([]string(nil), c.include...)
 }
 
+// Approvals returns defensive manual approval rules.
+func (c Config) Approvals() []ApprovalRule {
+	return cloneApprovalRules(c.approvals)
+}
+
+// VarFiles returns defensive var-file rules.
+func (c Config) VarFiles() []VarFileRule {
+	return cloneVarFileRules(c.varFiles)
+}
+
+// Workspaces returns defensive workspace rules.
+func (c Config) Workspaces() []WorkspaceRule {
+	return cloneWorkspaceRules(c.workspaces)
+}
+
+// Dependencies returns defensive declared dependency rules.
+func (c Config) Dependencies() []DependencyRule {
+	return cloneDependencyRules(c.dependencies)
+}
+
+// DataSources returns defensive data source rules.
+func (c Config) DataSources() []DataSourceRule {
+	return cloneDataSourceRules(c.dataSources)
+}
+
+// ParseCache returns defensive parse cache settings, if configured.
+func (c Config) ParseCache() *ParseCacheConfig {
+	return cloneParseCacheConfig(c.parseCache)
+}
+
+// Timings returns defensive job timing history settings, if configured.
+func (c Config) Timings() *TimingsConfig {
+	return cloneTimingsConfig(c.timings)
+}
+
 // LibraryModules returns defensive library module settings, if configured.
 func (c Config) LibraryModules() *LibraryModulesConfig {
 	return cloneLibraryModulesConfig(c.libraryModules)
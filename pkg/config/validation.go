@@ -3,7 +3,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
 
+	"github.com/edelwud/terraci/pkg/pathmatch"
 	"github.com/edelwud/terraci/pkg/workspacepath"
 )
 
@@ -22,7 +27,7 @@ func (c Config) Validate() error {
 	}
 
 	switch c.execution.Binary() {
-	case "", ExecutionBinaryTerraform, ExecutionBinaryTofu:
+	case "", ExecutionBinaryTerraform, ExecutionBinaryTofu, ExecutionBinaryTerragrunt:
 	default:
 		return unsupportedExecutionBinaryError(c.execution.Binary())
 	}
@@ -31,6 +36,110 @@ func (c Config) Validate() error {
 		return invalidParallelismError()
 	}
 
+	for i, rule := range c.approvals {
+		if err := c.validateSegmentMatch(fmt.Sprintf("approvals[%d]", i), rule.match); err != nil {
+			return err
+		}
+	}
+	for i, rule := range c.varFiles {
+		field := fmt.Sprintf("var_files[%d]", i)
+		if err := c.validateSegmentMatch(field, rule.match); err != nil {
+			return err
+		}
+		for j, file := range rule.files {
+			if file == "" {
+				return fmt.Errorf("%s.files[%d]: path is required", field, j)
+			}
+		}
+	}
+
+	for i, rule := range c.workspaces {
+		field := fmt.Sprintf("workspaces[%d]", i)
+		if err := c.validateSegmentMatch(field, rule.match); err != nil {
+			return err
+		}
+		for j, name := range rule.names {
+			if err := validateWorkspaceName(name); err != nil {
+				return fmt.Errorf("%s.names[%d]: %w", field, j, err)
+			}
+		}
+	}
+
+	for i, rule := range c.dependencies {
+		if err := c.validateModuleSelector(fmt.Sprintf("dependencies[%d].from", i), rule.from); err != nil {
+			return err
+		}
+		if err := c.validateModuleSelector(fmt.Sprintf("dependencies[%d].to", i), rule.to); err != nil {
+			return err
+		}
+	}
+
+	for i, rule := range c.dataSources {
+		if err := validateDataSourceRule(fmt.Sprintf("data_sources[%d]", i), rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dataSourcePlaceholder matches a {name} placeholder of a data source
+// module template.
+var dataSourcePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+func validateDataSourceRule(field string, rule DataSourceRule) error {
+	groups := []string{"value"}
+	if rule.pattern != "" {
+		pattern, err := regexp.Compile(rule.pattern)
+		if err != nil {
+			return fmt.Errorf("%s.pattern: %w", field, err)
+		}
+		groups = append(groups, pattern.SubexpNames()...)
+	}
+	for _, match := range dataSourcePlaceholder.FindAllStringSubmatch(rule.module, -1) {
+		if !slices.Contains(groups, match[1]) {
+			return fmt.Errorf("%s.module: unknown placeholder {%s}; use {value} or a named group of pattern", field, match[1])
+		}
+	}
+	return nil
+}
+
+func (c Config) validateModuleSelector(field string, selector ModuleSelector) error {
+	if selector.glob != "" {
+		if err := pathmatch.ValidateGlob(selector.glob); err != nil {
+			return fmt.Errorf("%s: invalid glob %q: %w", field, selector.glob, err)
+		}
+		return nil
+	}
+	return c.validateSegmentMatch(field, selector.match)
+}
+
+// validateSegmentMatch checks that match only names pattern segments and
+// holds valid path.Match patterns.
+func (c Config) validateSegmentMatch(field string, match map[string]string) error {
+	segments := c.structure.Segments()
+	for _, segment := range slices.Sorted(maps.Keys(match)) {
+		if !segments.Contains(segment) {
+			return fmt.Errorf("%s.match: unknown segment %q (pattern %q)", field, segment, c.structure.Pattern())
+		}
+		if _, err := path.Match(match[segment], ""); err != nil {
+			return fmt.Errorf("%s.match.%s: invalid pattern %q: %w", field, segment, match[segment], err)
+		}
+	}
+	return nil
+}
+
+// validateWorkspaceName rejects names that cannot be part of a module ID
+// ("path@workspace").
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func validateWorkspaceName(name string) error {
+	if name == "" {
+		return errors.New("workspace name is required")
+	}
+	if name == "." || name == ".." || !workspaceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid workspace name %q: use letters, digits, \"_\", \".\" and \"-\"", name)
+	}
 	return nil
 }
 
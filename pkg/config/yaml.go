@@ -1,5 +1,10 @@
 package config
 
+import (
+	"fmt"
+	"time"
+)
+
 type configYAML struct {
 	ServiceDir     string              `yaml:"service_dir,omitempty"`
 	Execution      executionYAML       `yaml:"execution,omitempty"`
@@ -7,6 +12,13 @@ type configYAML struct {
 	Exclude        []string            `yaml:"exclude,omitempty"`
 	Include        []string            `yaml:"include,omitempty"`
 	LibraryModules *libraryModulesYAML `yaml:"library_modules,omitempty"`
+	Approvals      []approvalYAML      `yaml:"approvals,omitempty"`
+	VarFiles       []varFileYAML       `yaml:"var_files,omitempty"`
+	Workspaces     []workspaceYAML     `yaml:"workspaces,omitempty"`
+	Dependencies   []dependencyYAML    `yaml:"dependencies,omitempty"`
+	DataSources    []dataSourceYAML    `yaml:"data_sources,omitempty"`
+	ParseCache     *parseCacheYAML     `yaml:"parse_cache,omitempty"`
+	Timings        *timingsYAML        `yaml:"timings,omitempty"`
 	Extensions     extensionNodeMap    `yaml:"extensions,omitempty"`
 }
 
@@ -25,6 +37,69 @@ type libraryModulesYAML struct {
 	Paths []string `yaml:"paths"`
 }
 
+type parseCacheYAML struct {
+	Enabled bool   `yaml:"enabled,omitempty"`
+	Backend string `yaml:"backend,omitempty"`
+	MaxAge  string `yaml:"max_age,omitempty"`
+}
+
+type timingsYAML struct {
+	Enabled bool   `yaml:"enabled,omitempty"`
+	Backend string `yaml:"backend,omitempty"`
+	Samples int    `yaml:"samples,omitempty"`
+}
+
+type approvalYAML struct {
+	Match map[string]string `yaml:"match"`
+}
+
+type varFileYAML struct {
+	Match map[string]string `yaml:"match"`
+	Files []string          `yaml:"files"`
+}
+
+type workspaceYAML struct {
+	Match map[string]string `yaml:"match"`
+	Names []string          `yaml:"names"`
+}
+
+type dependencyYAML struct {
+	From moduleSelectorYAML `yaml:"from"`
+	To   moduleSelectorYAML `yaml:"to"`
+}
+
+type dataSourceYAML struct {
+	Type      string `yaml:"type"`
+	Attribute string `yaml:"attribute"`
+	Pattern   string `yaml:"pattern,omitempty"`
+	Module    string `yaml:"module"`
+}
+
+// moduleSelectorYAML is either a module ID glob ("*/prod/*/eks") or a map of
+// segment patterns ({environment: prod, module: eks}).
+type moduleSelectorYAML struct {
+	Glob  string
+	Match map[string]string
+}
+
+// UnmarshalYAML supports both the glob string and the segment map form.
+func (s *moduleSelectorYAML) UnmarshalYAML(unmarshal func(any) error) error {
+	var glob string
+	if err := unmarshal(&glob); err == nil {
+		s.Glob = glob
+		return nil
+	}
+	return unmarshal(&s.Match)
+}
+
+// MarshalYAML emits the form the selector was written in.
+func (s moduleSelectorYAML) MarshalYAML() (any, error) {
+	if s.Glob != "" {
+		return s.Glob, nil
+	}
+	return s.Match, nil
+}
+
 // MarshalYAML preserves the public .terraci.yaml shape while keeping runtime
 // config fields private to pkg/config.
 func (c Config) MarshalYAML() (any, error) {
@@ -69,6 +144,75 @@ func (c Config) toYAML() configYAML {
 			}
 			return &libraryModulesYAML{Paths: c.libraryModules.Paths()}
 		}(),
+		Approvals: func() []approvalYAML {
+			if len(c.approvals) == 0 {
+				return nil
+			}
+			rules := make([]approvalYAML, len(c.approvals))
+			for i, rule := range c.approvals {
+				rules[i] = approvalYAML{Match: rule.Match()}
+			}
+			return rules
+		}(),
+		VarFiles: func() []varFileYAML {
+			if len(c.varFiles) == 0 {
+				return nil
+			}
+			rules := make([]varFileYAML, len(c.varFiles))
+			for i, rule := range c.varFiles {
+				rules[i] = varFileYAML{Match: rule.Match(), Files: rule.Files()}
+			}
+			return rules
+		}(),
+		Workspaces: func() []workspaceYAML {
+			if len(c.workspaces) == 0 {
+				return nil
+			}
+			rules := make([]workspaceYAML, len(c.workspaces))
+			for i, rule := range c.workspaces {
+				rules[i] = workspaceYAML{Match: rule.Match(), Names: rule.Names()}
+			}
+			return rules
+		}(),
+		Dependencies: func() []dependencyYAML {
+			if len(c.dependencies) == 0 {
+				return nil
+			}
+			rules := make([]dependencyYAML, len(c.dependencies))
+			for i, rule := range c.dependencies {
+				rules[i] = dependencyYAML{
+					From: moduleSelectorYAML{Glob: rule.from.glob, Match: rule.from.Match()},
+					To:   moduleSelectorYAML{Glob: rule.to.glob, Match: rule.to.Match()},
+				}
+			}
+			return rules
+		}(),
+		DataSources: func() []dataSourceYAML {
+			if len(c.dataSources) == 0 {
+				return nil
+			}
+			rules := make([]dataSourceYAML, len(c.dataSources))
+			for i, rule := range c.dataSources {
+				rules[i] = dataSourceYAML{Type: rule.dataType, Attribute: rule.attribute, Pattern: rule.pattern, Module: rule.module}
+			}
+			return rules
+		}(),
+		ParseCache: func() *parseCacheYAML {
+			if c.parseCache == nil {
+				return nil
+			}
+			wire := &parseCacheYAML{Enabled: c.parseCache.Enabled(), Backend: c.parseCache.Backend()}
+			if c.parseCache.maxAge != 0 {
+				wire.MaxAge = c.parseCache.maxAge.String()
+			}
+			return wire
+		}(),
+		Timings: func() *timingsYAML {
+			if c.timings == nil {
+				return nil
+			}
+			return &timingsYAML{Enabled: c.timings.enabled, Backend: c.timings.backend, Samples: c.timings.samples}
+		}(),
 		Extensions: cloneYAMLNodeMap(c.extensions),
 	}
 }
@@ -105,6 +249,82 @@ func configFromYAML(wire configYAML) (Config, error) {
 		libraryModules = &cfg
 	}
 
+	var approvals []ApprovalRule
+	for i, rule := range wire.Approvals {
+		approval, err := NewApprovalRule(ApprovalRuleOptions{Match: rule.Match})
+		if err != nil {
+			return Config{}, fmt.Errorf("approvals[%d]: %w", i, err)
+		}
+		approvals = append(approvals, approval)
+	}
+
+	var varFiles []VarFileRule
+	for i, rule := range wire.VarFiles {
+		varFile, err := NewVarFileRule(VarFileRuleOptions{Match: rule.Match, Files: rule.Files})
+		if err != nil {
+			return Config{}, fmt.Errorf("var_files[%d]: %w", i, err)
+		}
+		varFiles = append(varFiles, varFile)
+	}
+
+	var workspaces []WorkspaceRule
+	for i, rule := range wire.Workspaces {
+		workspace, err := NewWorkspaceRule(WorkspaceRuleOptions{Match: rule.Match, Names: rule.Names})
+		if err != nil {
+			return Config{}, fmt.Errorf("workspaces[%d]: %w", i, err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	var dependencies []DependencyRule
+	for i, rule := range wire.Dependencies {
+		dependency, err := dependencyRuleFromYAML(rule)
+		if err != nil {
+			return Config{}, fmt.Errorf("dependencies[%d]: %w", i, err)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	var dataSources []DataSourceRule
+	for i, rule := range wire.DataSources {
+		dataSource, err := NewDataSourceRule(DataSourceRuleOptions{
+			Type:      rule.Type,
+			Attribute: rule.Attribute,
+			Pattern:   rule.Pattern,
+			Module:    rule.Module,
+		})
+		if err != nil {
+			return Config{}, fmt.Errorf("data_sources[%d]: %w", i, err)
+		}
+		dataSources = append(dataSources, dataSource)
+	}
+
+	var parseCache *ParseCacheConfig
+	if wire.ParseCache != nil {
+		var maxAge time.Duration
+		if wire.ParseCache.MaxAge != "" {
+			parsed, err := time.ParseDuration(wire.ParseCache.MaxAge)
+			if err != nil {
+				return Config{}, fmt.Errorf("parse_cache: max_age: %w", err)
+			}
+			maxAge = parsed
+		}
+		cfg, err := NewParseCacheConfig(ParseCacheConfigOptions{Enabled: wire.ParseCache.Enabled, Backend: wire.ParseCache.Backend, MaxAge: maxAge})
+		if err != nil {
+			return Config{}, fmt.Errorf("parse_cache: %w", err)
+		}
+		parseCache = &cfg
+	}
+
+	var timings *TimingsConfig
+	if wire.Timings != nil {
+		cfg, err := NewTimingsConfig(TimingsConfigOptions{Enabled: wire.Timings.Enabled, Backend: wire.Timings.Backend, Samples: wire.Timings.Samples})
+		if err != nil {
+			return Config{}, fmt.Errorf("timings: %w", err)
+		}
+		timings = &cfg
+	}
+
 	cfg := Config{
 		serviceDir:     wire.ServiceDir,
 		execution:      execution,
@@ -112,6 +332,13 @@ func configFromYAML(wire configYAML) (Config, error) {
 		exclude:        append([]string(nil), wire.Exclude...),
 		include:        append([]string(nil), wire.Include...),
 		libraryModules: libraryModules,
+		approvals:      approvals,
+		varFiles:       varFiles,
+		workspaces:     workspaces,
+		dependencies:   dependencies,
+		dataSources:    dataSources,
+		parseCache:     parseCache,
+		timings:        timings,
 		extensions:     cloneYAMLNodeMap(wire.Extensions),
 	}
 	if err := cfg.Validate(); err != nil {
@@ -119,3 +346,15 @@ func configFromYAML(wire configYAML) (Config, error) {
 	}
 	return cfg, nil
 }
+
+func dependencyRuleFromYAML(wire dependencyYAML) (DependencyRule, error) {
+	from, err := NewModuleSelector(ModuleSelectorOptions{Glob: wire.From.Glob, Match: wire.From.Match})
+	if err != nil {
+		return DependencyRule{}, fmt.Errorf("from: %w", err)
+	}
+	to, err := NewModuleSelector(ModuleSelectorOptions{Glob: wire.To.Glob, Match: wire.To.Match})
+	if err != nil {
+		return DependencyRule{}, fmt.Errorf("to: %w", err)
+	}
+	return NewDependencyRule(DependencyRuleOptions{From: from, To: to})
+}
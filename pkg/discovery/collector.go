@@ -14,6 +14,7 @@ type moduleCollector struct {
 	absRoot      string
 	segments     []string
 	libraryPaths []string // already cleaned project-relative roots (forward-slash)
+	terragrunt   bool
 	modules      []*Module
 	byID         map[string]*Module
 }
@@ -31,7 +32,7 @@ func (c *moduleCollector) visit(path string, info os.FileInfo, walkErr error) er
 		return filepath.SkipDir
 	}
 
-	if !isTerraformDir(info, path) {
+	if !c.isModuleDir(info, path) {
 		return nil
 	}
 
@@ -126,11 +127,18 @@ func shouldSkipDir(info os.FileInfo) bool {
 	return info.IsDir() && strings.HasPrefix(info.Name(), ".")
 }
 
+func (c *moduleCollector) isModuleDir(info os.FileInfo, path string) bool {
+	if c.terragrunt {
+		return isTerragruntDir(info, path)
+	}
+	return isTerraformDir(info, path)
+}
+
 func isTerraformDir(info os.FileInfo, path string) bool {
 	return info.IsDir() && containsTerraformFiles(path)
 }
 
-// containsTerraformFiles checks if a directory contains .tf files. Reads the
+// containsTerraformFiles checks if a directory contains .tf or .tf.json files. Reads the
 // directory entries directly instead of going through filepath.Glob: the
 // scanner is invoked once per directory in the walk, so on a 10K-directory
 // repo the difference is ~10K syscalls (Glob does Lstat-of-parent +
@@ -144,9 +152,19 @@ func containsTerraformFiles(dir string) bool {
 		if entry.IsDir() {
 			continue
 		}
-		if strings.HasSuffix(entry.Name(), ".tf") {
+		if name := entry.Name(); strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
 			return true
 		}
 	}
 	return false
 }
+
+// isTerragruntDir reports whether a directory is a Terragrunt unit. Cache
+// directories (.terragrunt-cache) are already skipped as dot-dirs.
+func isTerragruntDir(info os.FileInfo, path string) bool {
+	if !info.IsDir() {
+		return false
+	}
+	unit, err := os.Stat(filepath.Join(path, TerragruntUnitFile))
+	return err == nil && !unit.IsDir()
+}
@@ -6,7 +6,7 @@ package discovery
 type ModuleIndex struct {
 	modules []*Module
 	byID    map[string]*Module
-	byPath  map[string]*Module
+	byPath  map[string][]*Module
 }
 
 // NewModuleIndex creates an index from a list of modules.
@@ -14,13 +14,15 @@ func NewModuleIndex(modules []*Module) *ModuleIndex {
 	idx := &ModuleIndex{
 		modules: modules,
 		byID:    make(map[string]*Module, len(modules)),
-		byPath:  make(map[string]*Module, len(modules)),
+		byPath:  make(map[string][]*Module, len(modules)),
 	}
 
 	for _, m := range modules {
 		idx.byID[m.ID()] = m
-		idx.byPath[m.Path] = m
-		idx.byPath[m.RelativePath] = m
+		idx.byPath[m.Path] = append(idx.byPath[m.Path], m)
+		if m.RelativePath != m.Path {
+			idx.byPath[m.RelativePath] = append(idx.byPath[m.RelativePath], m)
+		}
 	}
 
 	return idx
@@ -32,5 +34,15 @@ func (idx *ModuleIndex) All() []*Module { return idx.modules }
 // ByID returns a module by its ID.
 func (idx *ModuleIndex) ByID(id string) *Module { return idx.byID[id] }
 
-// ByPath returns a module by its path.
-func (idx *ModuleIndex) ByPath(path string) *Module { return idx.byPath[path] }
+// ByPath returns a module by its path. When the directory is deployed to
+// several workspaces the first registered module is returned; use
+// AllByPath for every workspace.
+func (idx *ModuleIndex) ByPath(path string) *Module {
+	if modules := idx.byPath[path]; len(modules) > 0 {
+		return modules[0]
+	}
+	return nil
+}
+
+// AllByPath returns every module of a directory, one per workspace.
+func (idx *ModuleIndex) AllByPath(path string) []*Module { return idx.byPath[path] }
@@ -40,3 +40,21 @@ func TestModuleIndex_ByPath(t *testing.T) {
 		t.Error("ByPath(vpc) = nil")
 	}
 }
+
+func TestModuleIndex_Workspaces(t *testing.T) {
+	t.Parallel()
+
+	vpc := TestModule("platform", "stage", "eu-central-1", "vpc")
+	tenant := vpc.WithWorkspace("tenant-a")
+	idx := NewModuleIndex([]*Module{vpc, tenant})
+
+	if m := idx.ByID("platform/stage/eu-central-1/vpc@tenant-a"); m != tenant {
+		t.Errorf("ByID(vpc@tenant-a) = %v, want workspace module", m)
+	}
+	if m := idx.ByPath("platform/stage/eu-central-1/vpc"); m != vpc {
+		t.Errorf("ByPath(vpc) = %v, want first registered module", m)
+	}
+	if got := idx.AllByPath("platform/stage/eu-central-1/vpc"); len(got) != 2 {
+		t.Errorf("AllByPath(vpc) = %v, want both workspaces", got)
+	}
+}
@@ -24,6 +24,11 @@ type Module struct {
 	// executable target selection but tracked separately for reporting and
 	// change-detection.
 	IsLibrary bool
+
+	// Workspace is the Terraform workspace the module targets. Empty means
+	// the default workspace; a directory deployed to several workspaces is
+	// discovered once per workspace, each with its own ID.
+	Workspace string
 }
 
 // NewModule creates a Module from ordered segment names and values.
@@ -56,12 +61,27 @@ func (m *Module) Components() map[string]string {
 	return maps.Clone(m.components)
 }
 
-// ID returns a unique slash-separated identifier for the module.
-func (m *Module) ID() string { return normalizeRelativePath(m.RelativePath) }
+// ID returns a unique slash-separated identifier for the module. Modules
+// targeting a non-default workspace are identified as "path@workspace".
+func (m *Module) ID() string {
+	if m.Workspace == "" {
+		return normalizeRelativePath(m.RelativePath)
+	}
+	return normalizeRelativePath(m.RelativePath) + WorkspaceSeparator + m.Workspace
+}
 
 // String returns the module ID.
 func (m *Module) String() string { return m.ID() }
 
+// WithWorkspace returns a copy of the module targeting workspace. The copy
+// shares Parent and Children with m.
+func (m *Module) WithWorkspace(workspace string) *Module {
+	clone := *m
+	clone.components = maps.Clone(m.components)
+	clone.Workspace = workspace
+	return &clone
+}
+
 // LeafValue returns the value of the last pattern segment.
 func (m *Module) LeafValue() string {
 	if len(m.segments) == 0 {
@@ -6,6 +6,9 @@ import (
 	"strings"
 )
 
+// TerragruntUnitFile is the configuration file that marks a Terragrunt unit.
+const TerragruntUnitFile = "terragrunt.hcl"
+
 // Scanner discovers Terraform modules in a directory tree.
 type Scanner struct {
 	RootDir  string
@@ -16,6 +19,10 @@ type Scanner struct {
 	// sanity-cleaned (trim, drop empty, drop absolute, dedup, slash-normalize)
 	// in NewScanner.
 	LibraryPaths []string
+
+	// Terragrunt switches discovery to Terragrunt units: a directory is a
+	// module when it holds a TerragruntUnitFile rather than .tf files.
+	Terragrunt bool
 }
 
 // NewScanner creates a Scanner from structure config values and optional
@@ -41,6 +48,7 @@ func (s *Scanner) Scan(ctx context.Context) ([]*Module, error) {
 		absRoot:      absRoot,
 		segments:     s.Segments,
 		libraryPaths: s.LibraryPaths,
+		terragrunt:   s.Terragrunt,
 		byID:         make(map[string]*Module),
 	}
 
@@ -87,6 +87,71 @@ func TestScanner_Scan_DeepSubmodules(t *testing.T) {
 	t.Error("deep submodule not found")
 }
 
+func TestScanner_TFJSONModules(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createModuleTree(t, tmpDir, []string{"platform/stage/eu-central-1/vpc"})
+	dir := filepath.Join(tmpDir, "platform", "stage", "eu-central-1", "eks")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.tf.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := NewScanner(tmpDir, defaultSegments).Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("modules = %d, want 2 (native and JSON)", len(found))
+	}
+}
+
+func TestScanner_Terragrunt(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Plain Terraform modules are not units in Terragrunt mode.
+	createModuleTree(t, tmpDir, []string{"modules/vpc", "platform/prod/eu-central-1/legacy"})
+	for _, p := range []string{
+		"platform/prod/eu-central-1/vpc",
+		"platform/prod/eu-central-1/eks",
+		"platform/prod/eu-central-1/vpc/.terragrunt-cache/abc",
+	} {
+		dir := filepath.Join(tmpDir, p)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, TerragruntUnitFile), []byte("# unit"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The root config sits above the structure depth and is not a unit.
+	if err := os.WriteFile(filepath.Join(tmpDir, TerragruntUnitFile), []byte("# root"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := NewScanner(tmpDir, defaultSegments)
+	scanner.Terragrunt = true
+	found, err := scanner.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	want := map[string]bool{
+		"platform/prod/eu-central-1/vpc": true,
+		"platform/prod/eu-central-1/eks": true,
+	}
+	if len(found) != len(want) {
+		t.Errorf("units = %d, want %d", len(found), len(want))
+	}
+	for _, m := range found {
+		if !want[m.ID()] {
+			t.Errorf("unexpected unit %q", m.ID())
+		}
+	}
+}
+
 func TestScanner_LibraryFlag(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,93 @@
+package discovery
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pathmatch"
+)
+
+const (
+	// WorkspacesFile is the per-module file listing the Terraform workspaces
+	// the module is deployed to, one name per line.
+	WorkspacesFile = "workspaces"
+
+	// DefaultWorkspace is the name of Terraform's default workspace. Modules
+	// targeting it keep an empty Module.Workspace.
+	DefaultWorkspace = "default"
+
+	// WorkspaceSeparator joins a module path and workspace in module IDs.
+	WorkspaceSeparator = "@"
+)
+
+// SplitID splits a module ID into the module's relative path and its
+// workspace, which is empty for the default workspace.
+func SplitID(id string) (relativePath, workspace string) {
+	if i := strings.LastIndex(id, WorkspaceSeparator); i >= 0 {
+		return id[:i], id[i+len(WorkspaceSeparator):]
+	}
+	return id, ""
+}
+
+// ReadWorkspaces reads the workspaces file of the module directory dir.
+// Blank lines and lines starting with # are ignored. A missing file yields
+// no workspaces and no error.
+func ReadWorkspaces(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, WorkspacesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		if err := ValidateWorkspaceName(name); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", WorkspacesFile, line, err)
+		}
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ValidateWorkspaceName rejects names that cannot be part of a module ID,
+// a plan file name or an unquoted shell word: only letters, digits, "_",
+// "." and "-" are allowed.
+func ValidateWorkspaceName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("workspace name is empty")
+	case name == "." || name == ".." || !workspaceNamePattern.MatchString(name):
+		return fmt.Errorf("invalid workspace name %q: use letters, digits, \"_\", \".\" and \"-\"", name)
+	}
+	return nil
+}
+
+// MatchGlob reports whether pattern matches the module ID. Patterns naming
+// a directory also match every workspace deployed from it, so
+// "platform/*/*/vpc" selects "platform/stage/eu-central-1/vpc@tenant-a".
+func (m *Module) MatchGlob(pattern string) (bool, error) {
+	ok, err := pathmatch.MatchGlob(pattern, m.ID())
+	if ok || err != nil || m.Workspace == "" {
+		return ok, err
+	}
+	return pathmatch.MatchGlob(pattern, m.RelativePath)
+}
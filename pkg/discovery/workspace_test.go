@@ -0,0 +1,78 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestModule_WithWorkspace(t *testing.T) {
+	t.Parallel()
+
+	vpc := TestModule("platform", "stage", "eu-central-1", "vpc")
+	tenant := vpc.WithWorkspace("tenant-a")
+
+	if got := tenant.ID(); got != "platform/stage/eu-central-1/vpc@tenant-a" {
+		t.Errorf("ID() = %q", got)
+	}
+	if tenant.RelativePath != vpc.RelativePath || tenant.Name() != "vpc" {
+		t.Errorf("workspace module = %+v, want same directory", tenant)
+	}
+	tenant.SetComponent("module", "other")
+	if vpc.Get("module") != "vpc" {
+		t.Error("WithWorkspace() shares components with the original")
+	}
+	if vpc.Workspace != "" || vpc.ID() != "platform/stage/eu-central-1/vpc" {
+		t.Errorf("original module changed: %q", vpc.ID())
+	}
+	if path, workspace := SplitID(tenant.ID()); path != vpc.RelativePath || workspace != "tenant-a" {
+		t.Errorf("SplitID() = %q, %q", path, workspace)
+	}
+	if path, workspace := SplitID(vpc.ID()); path != vpc.RelativePath || workspace != "" {
+		t.Errorf("SplitID(default) = %q, %q", path, workspace)
+	}
+}
+
+func TestReadWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if names, err := ReadWorkspaces(dir); err != nil || names != nil {
+		t.Fatalf("ReadWorkspaces(missing) = %v, %v", names, err)
+	}
+
+	content := "# tenants\ntenant-a\n\n  tenant-b  \ndefault\n"
+	if err := os.WriteFile(filepath.Join(dir, WorkspacesFile), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	names, err := ReadWorkspaces(dir)
+	if err != nil {
+		t.Fatalf("ReadWorkspaces() error = %v", err)
+	}
+	if want := []string{"tenant-a", "tenant-b", "default"}; !slices.Equal(names, want) {
+		t.Errorf("ReadWorkspaces() = %v, want %v", names, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, WorkspacesFile), []byte("a@b\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadWorkspaces(dir); err == nil {
+		t.Error("ReadWorkspaces() accepted a name with @")
+	}
+}
+
+func TestValidateWorkspaceName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"tenant-a", "tenant_b", "eu.prod", "A1"} {
+		if err := ValidateWorkspaceName(name); err != nil {
+			t.Errorf("ValidateWorkspaceName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "a@b", "a/b", `a\b`, "tenant a", "a;b", "$(id)", "`id`", "a'b", `a"b`, "a*", "a\nb", "ünï"} {
+		if err := ValidateWorkspaceName(name); err == nil {
+			t.Errorf("ValidateWorkspaceName(%q) accepted an invalid name", name)
+		}
+	}
+}
@@ -32,3 +32,16 @@ type NoModulesError struct {
 func (e *NoModulesError) Error() string {
 	return "no modules found in " + e.Dir
 }
+
+// UnresolvedVariableError indicates that a remote state path depends on a
+// Terraform variable with no value in defaults, tfvars or var-files. The
+// workflow matches it to report the variable instead of a missing edge.
+type UnresolvedVariableError struct {
+	Module      string
+	RemoteState string
+	Variable    string
+}
+
+func (e *UnresolvedVariableError) Error() string {
+	return fmt.Sprintf("variable %q has no value (from %s.%s)", e.Variable, e.Module, e.RemoteState)
+}
@@ -29,6 +29,16 @@ func TestNoModulesError(t *testing.T) {
 	}
 }
 
+func TestUnresolvedVariableError(t *testing.T) {
+	t.Parallel()
+
+	e := &UnresolvedVariableError{Module: "platform/prod/eu/app", RemoteState: "vpc", Variable: "environment"}
+	want := `variable "environment" has no value (from platform/prod/eu/app.vpc)`
+	if e.Error() != want {
+		t.Errorf("Error() = %q, want %q", e.Error(), want)
+	}
+}
+
 func TestErrorsAs(t *testing.T) {
 	t.Parallel()
 
@@ -32,10 +32,19 @@ type globFilter struct {
 }
 
 func (f globFilter) matchID(moduleID string) bool {
-	id := filepath.ToSlash(moduleID)
+	return f.matchAny(moduleID)
+}
+
+// matchAny applies the filter to a module known by several IDs: excluded
+// if any ID matches an exclude, included if any ID matches an include.
+func (f globFilter) matchAny(moduleIDs ...string) bool {
+	ids := make([]string, len(moduleIDs))
+	for i, id := range moduleIDs {
+		ids[i] = filepath.ToSlash(id)
+	}
 
 	for _, pattern := range f.excludes {
-		if matchGlob(filepath.ToSlash(pattern), id) {
+		if matchGlobAny(filepath.ToSlash(pattern), ids) {
 			return false
 		}
 	}
@@ -45,7 +54,7 @@ func (f globFilter) matchID(moduleID string) bool {
 	}
 
 	for _, pattern := range f.includes {
-		if matchGlob(filepath.ToSlash(pattern), id) {
+		if matchGlobAny(filepath.ToSlash(pattern), ids) {
 			return true
 		}
 	}
@@ -53,8 +62,13 @@ func (f globFilter) matchID(moduleID string) bool {
 	return false
 }
 
+// match checks the module ID and, for workspace modules, the directory, so
+// directory patterns cover every workspace of a module.
 func (f globFilter) match(module *discovery.Module) bool {
-	return f.matchID(module.ID())
+	if module.Workspace == "" {
+		return f.matchAny(module.ID())
+	}
+	return f.matchAny(module.ID(), module.RelativePath)
 }
 
 // segmentFilter filters modules by a named segment value.
@@ -176,3 +190,7 @@ func matchGlob(pattern, path string) bool {
 	matched, err := pathmatch.MatchGlob(pattern, path)
 	return err == nil && matched
 }
+
+func matchGlobAny(pattern string, paths []string) bool {
+	return slices.ContainsFunc(paths, func(path string) bool { return matchGlob(pattern, path) })
+}
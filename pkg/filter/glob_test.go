@@ -215,6 +215,39 @@ func TestGlobFilter_MatchModule(t *testing.T) {
 	}
 }
 
+func TestApply_WorkspaceModules(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	modules := []*discovery.Module{vpc, vpc.WithWorkspace("acme"), vpc.WithWorkspace("globex")}
+
+	tests := []struct {
+		name    string
+		exclude []string
+		include []string
+		want    int
+	}{
+		{"directory include covers workspaces", nil, []string{"platform/*/*/vpc"}, 3},
+		{"workspace include", nil, []string{"platform/*/*/vpc@acme"}, 1},
+		{"workspace exclude", []string{"**/vpc@globex"}, nil, 2},
+		{"directory exclude covers workspaces", []string{"platform/stage/eu-central-1/vpc"}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Apply(modules, Options{Excludes: tt.exclude, Includes: tt.include})
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("Apply() returned %d modules, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
 func TestApply_InvalidGlobReturnsError(t *testing.T) {
 	t.Parallel()
 
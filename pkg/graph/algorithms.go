@@ -127,6 +127,57 @@ func (g *DependencyGraph) DetectCycles() [][]string {
 	return cycles
 }
 
+// AllPaths returns every simple dependency path from one module to another,
+// each starting with from and ending with to. Paths are enumerated in
+// lexicographic order; when limit is positive at most limit paths are returned
+// and truncated reports whether more exist.
+func (g *DependencyGraph) AllPaths(from, to string, limit int) (paths [][]string, truncated bool) {
+	if g.nodes[from] == nil || g.nodes[to] == nil || from == to {
+		return nil, false
+	}
+
+	// Only modules that can reach the target are worth descending into.
+	reaches := map[string]bool{to: true}
+	for _, id := range g.GetAllDependents(to) {
+		reaches[id] = true
+	}
+	if !reaches[from] {
+		return nil, false
+	}
+
+	onPath := map[string]bool{from: true}
+	path := []string{from}
+	var walk func(id string) bool
+	walk = func(id string) bool {
+		next := append([]string(nil), g.edges[id]...)
+		sort.Strings(next)
+		for _, dep := range next {
+			if !reaches[dep] || onPath[dep] {
+				continue
+			}
+			if dep == to {
+				if limit > 0 && len(paths) == limit {
+					truncated = true
+					return false
+				}
+				paths = append(paths, append(append([]string(nil), path...), to))
+				continue
+			}
+			onPath[dep] = true
+			path = append(path, dep)
+			ok := walk(dep)
+			path = path[:len(path)-1]
+			delete(onPath, dep)
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	walk(from)
+	return paths, truncated
+}
+
 func findIndex(slice []string, value string) int {
 	for i, v := range slice {
 		if v == value {
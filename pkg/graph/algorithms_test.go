@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/edelwud/terraci/pkg/discovery"
@@ -99,3 +100,50 @@ func TestDetectCycles_NoCycles(t *testing.T) {
 		t.Errorf("expected no cycles, got %v", cycles)
 	}
 }
+
+func TestAllPaths(t *testing.T) {
+	t.Parallel()
+
+	g := buildTestGraph()
+	const (
+		app = "platform/stage/eu-central-1/app"
+		eks = "platform/stage/eu-central-1/eks"
+		rds = "platform/stage/eu-central-1/rds"
+		vpc = "platform/stage/eu-central-1/vpc"
+	)
+
+	paths, truncated := g.AllPaths(app, vpc, 0)
+	want := [][]string{{app, eks, vpc}, {app, rds, vpc}}
+	if truncated || !slices.EqualFunc(paths, want, slices.Equal[[]string]) {
+		t.Errorf("AllPaths(app, vpc) = %v, %v; want %v", paths, truncated, want)
+	}
+
+	paths, truncated = g.AllPaths(app, vpc, 1)
+	if !truncated || len(paths) != 1 || !slices.Equal(paths[0], want[0]) {
+		t.Errorf("AllPaths(app, vpc, 1) = %v, %v; want first path, truncated", paths, truncated)
+	}
+
+	if paths, _ := g.AllPaths(vpc, app, 0); len(paths) != 0 {
+		t.Errorf("AllPaths(vpc, app) = %v, want none against edge direction", paths)
+	}
+	if paths, _ := g.AllPaths(eks, rds, 0); len(paths) != 0 {
+		t.Errorf("AllPaths(eks, rds) = %v, want none", paths)
+	}
+}
+
+func TestAllPaths_Cycle(t *testing.T) {
+	t.Parallel()
+
+	g := NewDependencyGraph()
+	for _, name := range []string{"a", "b", "c"} {
+		g.AddNode(discovery.TestModule("svc", "env", "reg", name))
+	}
+	g.AddEdge("svc/env/reg/a", "svc/env/reg/b")
+	g.AddEdge("svc/env/reg/b", "svc/env/reg/a")
+	g.AddEdge("svc/env/reg/b", "svc/env/reg/c")
+
+	paths, _ := g.AllPaths("svc/env/reg/a", "svc/env/reg/c", 0)
+	if len(paths) != 1 || !slices.Equal(paths[0], []string{"svc/env/reg/a", "svc/env/reg/b", "svc/env/reg/c"}) {
+		t.Errorf("AllPaths() = %v, want the single simple path", paths)
+	}
+}
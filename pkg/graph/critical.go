@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// ModuleTiming places one module on the weighted schedule in which every
+// module starts as soon as all of its dependencies finish.
+type ModuleTiming struct {
+	ID       string        `json:"id"`
+	Duration time.Duration `json:"duration"`
+	Start    time.Duration `json:"start"`
+	Finish   time.Duration `json:"finish"`
+	// Slack is how long the module can be delayed without delaying the
+	// pipeline; zero for modules on a critical path.
+	Slack time.Duration `json:"slack"`
+	// SlackCost is the pipeline time saved if the module took no time at
+	// all; non-zero only for modules without slack.
+	SlackCost time.Duration `json:"slack_cost"`
+}
+
+// CriticalPath is the weighted longest dependency chain of the graph.
+type CriticalPath struct {
+	// Modules lists the chain bounding the pipeline, dependencies first.
+	Modules []string `json:"modules"`
+	// Total is the estimated pipeline wall-clock time.
+	Total time.Duration `json:"total"`
+	// Timings holds every module in execution level order.
+	Timings []ModuleTiming `json:"timings"`
+	// TopSlackCost lists the modules whose speedup shortens the pipeline
+	// most.
+	TopSlackCost []ModuleTiming `json:"top_slack_cost"`
+	// Unmeasured lists modules without a duration; they count as zero.
+	Unmeasured []string `json:"unmeasured"`
+}
+
+// CriticalPath computes the weighted longest path over ExecutionLevels,
+// using durations as module weights. Modules missing from durations weigh
+// nothing and are reported as unmeasured. It fails when the graph has a
+// cycle.
+func (g *DependencyGraph) CriticalPath(durations map[string]time.Duration) (*CriticalPath, error) {
+	levels, err := g.ExecutionLevels()
+	if err != nil {
+		return nil, err
+	}
+	order := slices.Concat(levels...)
+
+	weights := make(map[string]time.Duration, len(order))
+	cp := &CriticalPath{Modules: []string{}, Unmeasured: []string{}, TopSlackCost: []ModuleTiming{}}
+	for _, id := range order {
+		d, ok := durations[id]
+		if !ok {
+			cp.Unmeasured = append(cp.Unmeasured, id)
+		}
+		weights[id] = d
+	}
+	sort.Strings(cp.Unmeasured)
+
+	finish, via, total, last := g.longestPaths(order, weights)
+	cp.Total = total
+	for id := last; id != ""; id = via[id] {
+		cp.Modules = append(cp.Modules, id)
+	}
+	slices.Reverse(cp.Modules)
+
+	// tail is the longest chain of dependents after a module finishes.
+	tail := make(map[string]time.Duration, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		for _, dependent := range g.reverseEdges[id] {
+			tail[id] = max(tail[id], weights[dependent]+tail[dependent])
+		}
+	}
+
+	cp.Timings = make([]ModuleTiming, 0, len(order))
+	for _, id := range order {
+		timing := ModuleTiming{
+			ID:       id,
+			Duration: weights[id],
+			Start:    finish[id] - weights[id],
+			Finish:   finish[id],
+			Slack:    total - finish[id] - tail[id],
+		}
+		if timing.Slack == 0 && timing.Duration > 0 {
+			weights[id] = 0
+			_, _, without, _ := g.longestPaths(order, weights)
+			weights[id] = timing.Duration
+			timing.SlackCost = total - without
+		}
+		cp.Timings = append(cp.Timings, timing)
+		if timing.SlackCost > 0 {
+			cp.TopSlackCost = append(cp.TopSlackCost, timing)
+		}
+	}
+	sort.SliceStable(cp.TopSlackCost, func(i, j int) bool {
+		return cp.TopSlackCost[i].SlackCost > cp.TopSlackCost[j].SlackCost
+	})
+	if len(cp.TopSlackCost) > 5 {
+		cp.TopSlackCost = cp.TopSlackCost[:5]
+	}
+	return cp, nil
+}
+
+// longestPaths returns the finish time of every module in topological
+// order, the dependency each one waited for last, the longest finish time
+// and the module reaching it. Ties go to the smallest module ID.
+func (g *DependencyGraph) longestPaths(order []string, weights map[string]time.Duration) (finish map[string]time.Duration, via map[string]string, total time.Duration, last string) {
+	finish = make(map[string]time.Duration, len(order))
+	via = make(map[string]string, len(order))
+	for _, id := range order {
+		var start time.Duration
+		deps := append([]string(nil), g.edges[id]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if finish[dep] > start || via[id] == "" && finish[dep] == start {
+				start = finish[dep]
+				via[id] = dep
+			}
+		}
+		finish[id] = start + weights[id]
+		if last == "" || finish[id] > total || finish[id] == total && id < last {
+			total, last = finish[id], id
+		}
+	}
+	return finish, via, total, last
+}
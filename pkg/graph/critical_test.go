@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+func TestCriticalPath(t *testing.T) {
+	t.Parallel()
+
+	g := buildTestGraph()
+	const (
+		app = "platform/stage/eu-central-1/app"
+		eks = "platform/stage/eu-central-1/eks"
+		rds = "platform/stage/eu-central-1/rds"
+		vpc = "platform/stage/eu-central-1/vpc"
+	)
+
+	cp, err := g.CriticalPath(map[string]time.Duration{
+		vpc: 2 * time.Minute,
+		eks: 10 * time.Minute,
+		rds: 5 * time.Minute,
+		app: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("CriticalPath() error = %v", err)
+	}
+	if want := []string{vpc, eks, app}; !slices.Equal(cp.Modules, want) {
+		t.Errorf("Modules = %v, want %v", cp.Modules, want)
+	}
+	if cp.Total != 13*time.Minute {
+		t.Errorf("Total = %v, want 13m", cp.Total)
+	}
+	if len(cp.Unmeasured) != 0 {
+		t.Errorf("Unmeasured = %v, want none", cp.Unmeasured)
+	}
+
+	timings := make(map[string]ModuleTiming, len(cp.Timings))
+	for _, timing := range cp.Timings {
+		timings[timing.ID] = timing
+	}
+	if got := timings[rds]; got.Start != 2*time.Minute || got.Slack != 5*time.Minute || got.SlackCost != 0 {
+		t.Errorf("rds timing = %+v, want start 2m, slack 5m, no slack cost", got)
+	}
+
+	var top []string
+	for _, timing := range cp.TopSlackCost {
+		top = append(top, timing.ID)
+	}
+	if want := []string{eks, vpc, app}; !slices.Equal(top, want) {
+		t.Errorf("TopSlackCost = %v, want %v", top, want)
+	}
+	if got := cp.TopSlackCost[0].SlackCost; got != 5*time.Minute {
+		t.Errorf("eks slack cost = %v, want 5m (rds becomes critical)", got)
+	}
+}
+
+func TestCriticalPath_Unmeasured(t *testing.T) {
+	t.Parallel()
+
+	g := buildTestGraph()
+	cp, err := g.CriticalPath(map[string]time.Duration{
+		"platform/stage/eu-central-1/vpc": time.Minute,
+		"platform/stage/eu-central-1/eks": time.Minute,
+		"platform/stage/eu-central-1/app": time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("CriticalPath() error = %v", err)
+	}
+	if want := []string{"platform/stage/eu-central-1/rds"}; !slices.Equal(cp.Unmeasured, want) {
+		t.Errorf("Unmeasured = %v, want %v", cp.Unmeasured, want)
+	}
+	if cp.Total != 3*time.Minute {
+		t.Errorf("Total = %v, want 3m", cp.Total)
+	}
+}
+
+func TestCriticalPath_Cycle(t *testing.T) {
+	t.Parallel()
+
+	g := NewDependencyGraph()
+	g.AddNode(discovery.TestModule("svc", "env", "reg", "a"))
+	g.AddNode(discovery.TestModule("svc", "env", "reg", "b"))
+	g.AddEdge("svc/env/reg/a", "svc/env/reg/b")
+	g.AddEdge("svc/env/reg/b", "svc/env/reg/a")
+
+	if _, err := g.CriticalPath(nil); err == nil {
+		t.Fatal("CriticalPath() expected a cycle error")
+	}
+	if stats := g.GetStatsWithDurations(nil); stats.CriticalPath != nil {
+		t.Errorf("GetStatsWithDurations() critical path = %+v, want nil on cycle", stats.CriticalPath)
+	}
+}
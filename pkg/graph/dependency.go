@@ -19,10 +19,74 @@ type DependencyGraph struct {
 	edges        map[string][]string // from → [to] (depends on)
 	reverseEdges map[string][]string // to → [from] (depended by)
 	libraryUsage map[string][]string // library path → [module IDs]
+	declared     map[edge]struct{}   // edges only declared, never inferred
+	sources      map[edge][]EdgeSource
 	// diagnostics collects non-fatal warnings produced while building the graph
 	diagnostics []diagnostic.Diagnostic
 }
 
+type edge struct{ from, to string }
+
+// EdgeKind tells how a dependency edge was found.
+type EdgeKind string
+
+const (
+	// EdgeInferred marks an edge found by static analysis (remote state,
+	// Terragrunt dependency blocks).
+	EdgeInferred EdgeKind = "inferred"
+	// EdgeDeclared marks an edge that only exists because .terraci.yaml or a
+	// terraci:depends_on annotation declares it.
+	EdgeDeclared EdgeKind = "declared"
+)
+
+// EdgeSource names what created a dependency edge.
+type EdgeSource struct {
+	// Type is the dependency type, e.g. "remote_state", "data_source" or
+	// "declared".
+	Type string `json:"type"`
+	// RemoteState is the name of the terraform_remote_state data source for
+	// remote_state edges.
+	RemoteState string `json:"remote_state,omitempty"`
+	// File, StartLine and EndLine locate the remote state block. File is
+	// relative to the project root.
+	File      string `json:"file,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	// StateKey is the resolved state key the remote state reads.
+	StateKey  string `json:"state_key,omitempty"`
+	Workspace string `json:"workspace,omitempty"`
+	// Match is the strategy that matched the state key to the target:
+	// "path", "trailing", "context" or "backend".
+	Match string `json:"match,omitempty"`
+}
+
+// newEdgeSource converts the provenance the parser recorded for dep.
+func newEdgeSource(dep *parser.Dependency) EdgeSource {
+	source := EdgeSource{Type: dep.Type, RemoteState: dep.RemoteStateName}
+	if ev := dep.Evidence; ev != nil {
+		source.File = projectFile(dep.From, ev.Range.Filename)
+		source.StartLine = ev.Range.Start.Line
+		source.EndLine = ev.Range.End.Line
+		source.StateKey = ev.StatePath
+		source.Workspace = ev.Workspace
+		source.Match = string(ev.Match)
+	}
+	return source
+}
+
+// projectFile returns filename relative to the project root when it lies in
+// the module directory of from.
+func projectFile(from *discovery.Module, filename string) string {
+	if from == nil || filename == "" {
+		return filename
+	}
+	rel, err := filepath.Rel(from.Path, filename)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filename
+	}
+	return filepath.ToSlash(filepath.Join(from.RelativePath, rel))
+}
+
 // Node represents a module in the dependency graph.
 type Node struct {
 	Module    *discovery.Module
@@ -37,6 +101,8 @@ func NewDependencyGraph() *DependencyGraph {
 		edges:        make(map[string][]string),
 		reverseEdges: make(map[string][]string),
 		libraryUsage: make(map[string][]string),
+		declared:     make(map[edge]struct{}),
+		sources:      make(map[edge][]EdgeSource),
 	}
 }
 
@@ -49,9 +115,20 @@ func BuildFromDependencies(modules []*discovery.Module, deps map[string]*parser.
 	}
 
 	for moduleID, moduleDeps := range deps {
+		declaredOnly := declaredOnlyTargets(moduleDeps.Dependencies)
 		for _, depID := range moduleDeps.DependsOn {
+			if declaredOnly[depID] {
+				g.AddDeclaredEdge(moduleID, depID)
+				continue
+			}
 			g.AddEdge(moduleID, depID)
 		}
+		for _, dep := range moduleDeps.Dependencies {
+			if dep == nil || dep.To == nil {
+				continue
+			}
+			g.AddEdgeSource(moduleID, dep.To.ID(), newEdgeSource(dep))
+		}
 		for _, libDep := range moduleDeps.LibraryDependencies {
 			g.AddLibraryUsage(libDep.LibraryPath, moduleID)
 		}
@@ -60,6 +137,21 @@ func BuildFromDependencies(modules []*discovery.Module, deps map[string]*parser.
 	return g
 }
 
+// declaredOnlyTargets returns the targets reached only through declared
+// dependencies.
+func declaredOnlyTargets(dependencies []*parser.Dependency) map[string]bool {
+	targets := make(map[string]bool)
+	for _, dep := range dependencies {
+		if dep == nil || dep.To == nil {
+			continue
+		}
+		id := dep.To.ID()
+		declared, seen := targets[id]
+		targets[id] = dep.Type == parser.DependencyTypeDeclared && (!seen || declared)
+	}
+	return targets
+}
+
 // --- Node and edge operations ---
 
 // AddNode adds a module to the graph.
@@ -81,6 +173,7 @@ func (g *DependencyGraph) AddEdge(from, to string) {
 		return
 	}
 	if slices.Contains(g.edges[from], to) {
+		delete(g.declared, edge{from, to})
 		return
 	}
 
@@ -90,6 +183,41 @@ func (g *DependencyGraph) AddEdge(from, to string) {
 	g.nodes[to].OutDegree++
 }
 
+// AddDeclaredEdge adds an edge that static analysis did not find. An edge
+// that already exists keeps its kind.
+func (g *DependencyGraph) AddDeclaredEdge(from, to string) {
+	if slices.Contains(g.edges[from], to) {
+		return
+	}
+	g.AddEdge(from, to)
+	if slices.Contains(g.edges[from], to) {
+		g.declared[edge{from, to}] = struct{}{}
+	}
+}
+
+// EdgeKind reports how the edge from → to was found.
+func (g *DependencyGraph) EdgeKind(from, to string) EdgeKind {
+	if _, ok := g.declared[edge{from, to}]; ok {
+		return EdgeDeclared
+	}
+	return EdgeInferred
+}
+
+// AddEdgeSource records what created the edge from → to. Sources of edges
+// not in the graph are ignored.
+func (g *DependencyGraph) AddEdgeSource(from, to string, source EdgeSource) {
+	key := edge{from, to}
+	if !slices.Contains(g.edges[from], to) || slices.Contains(g.sources[key], source) {
+		return
+	}
+	g.sources[key] = append(g.sources[key], source)
+}
+
+// EdgeSources returns what created the edge from → to, in the order found.
+func (g *DependencyGraph) EdgeSources(from, to string) []EdgeSource {
+	return g.sources[edge{from, to}]
+}
+
 // Nodes returns all nodes in the graph.
 func (g *DependencyGraph) Nodes() map[string]*Node { return g.nodes }
 
@@ -214,9 +342,17 @@ func (g *DependencyGraph) Subgraph(moduleIDs []string) *DependencyGraph {
 
 	for from := range moduleSet {
 		for _, to := range g.edges[from] {
-			if moduleSet[to] {
+			if !moduleSet[to] {
+				continue
+			}
+			if g.EdgeKind(from, to) == EdgeDeclared {
+				sub.AddDeclaredEdge(from, to)
+			} else {
 				sub.AddEdge(from, to)
 			}
+			for _, source := range g.EdgeSources(from, to) {
+				sub.AddEdgeSource(from, to, source)
+			}
 		}
 	}
 
@@ -1,8 +1,11 @@
 package graph
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
+
 	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/parser"
 )
@@ -66,6 +69,92 @@ func TestAddEdge(t *testing.T) {
 	}
 }
 
+func TestBuildFromDependencies_DeclaredEdges(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("svc", "env", "reg", "vpc")
+	dns := discovery.TestModule("svc", "env", "reg", "dns")
+	app := discovery.TestModule("svc", "env", "reg", "app")
+	deps := map[string]*parser.ModuleDependencies{
+		app.ID(): {
+			DependsOn: []string{vpc.ID(), dns.ID()},
+			Dependencies: []*parser.Dependency{
+				{From: app, To: vpc, Type: parser.DependencyTypeDeclared},
+				{From: app, To: vpc, Type: "remote_state"},
+				{From: app, To: dns, Type: parser.DependencyTypeDeclared},
+			},
+		},
+	}
+	g := BuildFromDependencies([]*discovery.Module{vpc, dns, app}, deps)
+
+	if kind := g.EdgeKind(app.ID(), vpc.ID()); kind != EdgeInferred {
+		t.Errorf("app -> vpc kind = %q, want %q: an inferred dependency wins", kind, EdgeInferred)
+	}
+	if kind := g.EdgeKind(app.ID(), dns.ID()); kind != EdgeDeclared {
+		t.Errorf("app -> dns kind = %q, want %q", kind, EdgeDeclared)
+	}
+	if kind := g.Subgraph([]string{app.ID(), dns.ID()}).EdgeKind(app.ID(), dns.ID()); kind != EdgeDeclared {
+		t.Errorf("subgraph app -> dns kind = %q, want %q", kind, EdgeDeclared)
+	}
+
+	g.AddEdge(app.ID(), dns.ID())
+	if kind := g.EdgeKind(app.ID(), dns.ID()); kind != EdgeInferred {
+		t.Errorf("after AddEdge app -> dns kind = %q, want %q", kind, EdgeInferred)
+	}
+}
+
+func TestBuildFromDependencies_EdgeSources(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("svc", "env", "reg", "vpc")
+	app := discovery.TestModule("svc", "env", "reg", "app")
+	app.Path = "/repo/svc/env/reg/app"
+	evidence := &parser.DependencyEvidence{
+		Range: hcl.Range{
+			Filename: "/repo/svc/env/reg/app/data.tf",
+			Start:    hcl.Pos{Line: 3},
+			End:      hcl.Pos{Line: 9},
+		},
+		StatePath: "svc/env/reg/vpc/terraform.tfstate",
+		Match:     parser.MatchContext,
+	}
+	deps := map[string]*parser.ModuleDependencies{
+		app.ID(): {
+			DependsOn: []string{vpc.ID()},
+			Dependencies: []*parser.Dependency{
+				{From: app, To: vpc, Type: parser.DependencyTypeRemoteState, RemoteStateName: "vpc", Evidence: evidence},
+				{From: app, To: vpc, Type: parser.DependencyTypeRemoteState, RemoteStateName: "vpc", Evidence: evidence},
+				{From: app, To: vpc, Type: parser.DependencyTypeDeclared},
+			},
+		},
+	}
+	g := BuildFromDependencies([]*discovery.Module{vpc, app}, deps)
+
+	want := []EdgeSource{
+		{
+			Type:        parser.DependencyTypeRemoteState,
+			RemoteState: "vpc",
+			File:        "svc/env/reg/app/data.tf",
+			StartLine:   3,
+			EndLine:     9,
+			StateKey:    "svc/env/reg/vpc/terraform.tfstate",
+			Match:       "context",
+		},
+		{Type: parser.DependencyTypeDeclared},
+	}
+	if got := g.EdgeSources(app.ID(), vpc.ID()); !slices.Equal(got, want) {
+		t.Errorf("EdgeSources() = %+v, want %+v", got, want)
+	}
+	if got := g.Subgraph([]string{app.ID(), vpc.ID()}).EdgeSources(app.ID(), vpc.ID()); !slices.Equal(got, want) {
+		t.Errorf("subgraph EdgeSources() = %+v, want %+v", got, want)
+	}
+
+	g.AddEdgeSource(vpc.ID(), app.ID(), EdgeSource{Type: "remote_state"})
+	if got := g.EdgeSources(vpc.ID(), app.ID()); got != nil {
+		t.Errorf("source of a missing edge was recorded: %+v", got)
+	}
+}
+
 func TestAddEdge_NonexistentNodes(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Edge is one dependency edge: From depends on To.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// LevelChange records a module that runs at a different execution level.
+type LevelChange struct {
+	ID     string `json:"id"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// Diff describes how a dependency graph changed relative to a base graph.
+type Diff struct {
+	AddedModules   []string      `json:"added_modules"`
+	RemovedModules []string      `json:"removed_modules"`
+	AddedEdges     []Edge        `json:"added_edges"`
+	RemovedEdges   []Edge        `json:"removed_edges"`
+	NewCycles      [][]string    `json:"new_cycles"`
+	LevelChanges   []LevelChange `json:"level_changes"`
+}
+
+// Empty reports whether the graphs have the same modules, edges and levels.
+func (d *Diff) Empty() bool {
+	return len(d.AddedModules) == 0 && len(d.RemovedModules) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 &&
+		len(d.NewCycles) == 0 && len(d.LevelChanges) == 0
+}
+
+// DiffFrom compares g against base. A cycle is new when it uses a module or
+// an edge the base graph does not have. Execution levels are compared only
+// for modules present in both graphs; modules in a cycle are placed after
+// all others, as in the HTML explorer.
+func (g *DependencyGraph) DiffFrom(base *DependencyGraph) *Diff {
+	d := &Diff{
+		AddedModules:   []string{},
+		RemovedModules: []string{},
+		AddedEdges:     []Edge{},
+		RemovedEdges:   []Edge{},
+		NewCycles:      [][]string{},
+		LevelChanges:   []LevelChange{},
+	}
+
+	for _, id := range sortedMapKeys(g.nodes) {
+		if base.nodes[id] == nil {
+			d.AddedModules = append(d.AddedModules, id)
+		}
+	}
+	for _, id := range sortedMapKeys(base.nodes) {
+		if g.nodes[id] == nil {
+			d.RemovedModules = append(d.RemovedModules, id)
+		}
+	}
+
+	d.AddedEdges = g.edgesMissingFrom(base)
+	d.RemovedEdges = base.edgesMissingFrom(g)
+
+	seen := make(map[string]bool)
+	for _, cycle := range g.DetectCycles() {
+		cycle = rotateCycle(cycle)
+		key := strings.Join(cycle, "\x00")
+		if seen[key] || base.hasCycle(cycle) {
+			continue
+		}
+		seen[key] = true
+		d.NewCycles = append(d.NewCycles, cycle)
+	}
+	sort.Slice(d.NewCycles, func(i, j int) bool {
+		return slices.Compare(d.NewCycles[i], d.NewCycles[j]) < 0
+	})
+
+	baseLevels, _ := base.layoutColumns()
+	headLevels, _ := g.layoutColumns()
+	for _, id := range sortedMapKeys(g.nodes) {
+		if before, ok := baseLevels[id]; ok && before != headLevels[id] {
+			d.LevelChanges = append(d.LevelChanges, LevelChange{ID: id, Before: before, After: headLevels[id]})
+		}
+	}
+	return d
+}
+
+// edgesMissingFrom returns the edges of g that other does not have.
+func (g *DependencyGraph) edgesMissingFrom(other *DependencyGraph) []Edge {
+	var out []Edge
+	for _, from := range sortedMapKeys(g.edges) {
+		tos := append([]string(nil), g.edges[from]...)
+		sort.Strings(tos)
+		for _, to := range tos {
+			if !slices.Contains(other.edges[from], to) {
+				out = append(out, Edge{From: from, To: to})
+			}
+		}
+	}
+	if out == nil {
+		return []Edge{}
+	}
+	return out
+}
+
+// hasCycle reports whether every module and edge of cycle exists in g.
+func (g *DependencyGraph) hasCycle(cycle []string) bool {
+	for i, id := range cycle {
+		next := cycle[(i+1)%len(cycle)]
+		if g.nodes[id] == nil || !slices.Contains(g.edges[id], next) {
+			return false
+		}
+	}
+	return true
+}
+
+// rotateCycle starts a cycle at its smallest module ID so the same cycle
+// found from different starting points compares equal.
+func rotateCycle(cycle []string) []string {
+	if len(cycle) == 0 {
+		return cycle
+	}
+	start := 0
+	for i, id := range cycle {
+		if id < cycle[start] {
+			start = i
+		}
+	}
+	return append(append([]string(nil), cycle[start:]...), cycle[:start]...)
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+func TestDiffFrom(t *testing.T) {
+	t.Parallel()
+
+	base := buildTestGraph()
+	head := buildTestGraph()
+	const (
+		app = "platform/stage/eu-central-1/app"
+		eks = "platform/stage/eu-central-1/eks"
+		rds = "platform/stage/eu-central-1/rds"
+		vpc = "platform/stage/eu-central-1/vpc"
+		dns = "platform/stage/eu-central-1/dns"
+	)
+	head.AddNode(discovery.TestModule("platform", "stage", "eu-central-1", "dns"))
+	head.AddEdge(rds, dns)
+	head.AddEdge(dns, eks)
+
+	d := head.DiffFrom(base)
+	if !slices.Equal(d.AddedModules, []string{dns}) || len(d.RemovedModules) != 0 {
+		t.Errorf("modules added %v, removed %v", d.AddedModules, d.RemovedModules)
+	}
+	if want := []Edge{{From: dns, To: eks}, {From: rds, To: dns}}; !slices.Equal(d.AddedEdges, want) {
+		t.Errorf("AddedEdges = %v, want %v", d.AddedEdges, want)
+	}
+	if len(d.RemovedEdges) != 0 || len(d.NewCycles) != 0 {
+		t.Errorf("RemovedEdges = %v, NewCycles = %v", d.RemovedEdges, d.NewCycles)
+	}
+	want := []LevelChange{{ID: app, Before: 2, After: 4}, {ID: rds, Before: 1, After: 3}}
+	if !slices.Equal(d.LevelChanges, want) {
+		t.Errorf("LevelChanges = %v, want %v", d.LevelChanges, want)
+	}
+
+	reverse := base.DiffFrom(head)
+	if !slices.Equal(reverse.RemovedModules, []string{dns}) || len(reverse.RemovedEdges) != 2 {
+		t.Errorf("reverse diff = %+v", reverse)
+	}
+	if !base.DiffFrom(buildTestGraph()).Empty() {
+		t.Error("diff of identical graphs is not empty")
+	}
+}
+
+func TestDiffFrom_NewCycles(t *testing.T) {
+	t.Parallel()
+
+	base := buildTestGraph()
+	head := buildTestGraph()
+	head.AddEdge("platform/stage/eu-central-1/vpc", "platform/stage/eu-central-1/app")
+
+	d := head.DiffFrom(base)
+	if len(d.NewCycles) == 0 {
+		t.Fatalf("NewCycles = %v, want the cycle through vpc and app", d.NewCycles)
+	}
+	for _, cycle := range d.NewCycles {
+		if cycle[0] != "platform/stage/eu-central-1/app" {
+			t.Errorf("cycle %v does not start at its smallest module", cycle)
+		}
+	}
+
+	if again := head.DiffFrom(head); len(again.NewCycles) != 0 {
+		t.Errorf("cycle present in the base reported as new: %v", again.NewCycles)
+	}
+}
@@ -0,0 +1,212 @@
+package graph
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+//go:embed explorer.html
+var explorerSource string
+
+var explorerTemplate = template.Must(template.New("explorer").Parse(explorerSource))
+
+// Explorer layout, in pixels. Columns are execution levels; each context
+// group gets its own horizontal band.
+const (
+	explorerMargin       = 24
+	explorerColumnWidth  = 240
+	explorerRowHeight    = 40
+	explorerBandHeader   = 28
+	explorerBandGap      = 16
+	explorerNodeWidth    = 200
+	explorerNodeHeight   = 28
+	explorerLibraryGroup = "library_modules"
+)
+
+// explorerData is the graph handed to the explorer page as JSON.
+type explorerData struct {
+	Segments   []string        `json:"segments"`
+	Groups     []explorerGroup `json:"groups"`
+	Nodes      []explorerNode  `json:"nodes"`
+	Edges      []explorerEdge  `json:"edges"`
+	Width      int             `json:"width"`
+	Height     int             `json:"height"`
+	NodeWidth  int             `json:"nodeWidth"`
+	NodeHeight int             `json:"nodeHeight"`
+}
+
+type explorerGroup struct {
+	Name    string `json:"name"`
+	Y       int    `json:"y"`
+	Height  int    `json:"height"`
+	Library bool   `json:"library,omitempty"`
+}
+
+type explorerNode struct {
+	ID         string            `json:"id"`
+	Label      string            `json:"label"`
+	Group      string            `json:"group"`
+	Components map[string]string `json:"components,omitempty"`
+	Library    bool              `json:"library,omitempty"`
+	X          int               `json:"x"`
+	Y          int               `json:"y"`
+}
+
+// explorerEdge is a direct edge between node indices. The page derives
+// transitive dependencies and dependents from these edges when a module is
+// clicked, so the embedded data stays linear in the graph size.
+type explorerEdge struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// ToHTML exports the graph as a self-contained HTML page for exploring it
+// in a browser without network access. Modules are laid out by execution
+// level in one band per context group; the page supports search, coloring
+// by segment and highlighting the transitive dependencies and dependents of
+// a clicked module. Library modules are rendered in a separate band with
+// dashed edges to their consumers, like ToDOTWithLibraries.
+func (g *DependencyGraph) ToHTML(libraries []*discovery.Module) (string, error) {
+	data := g.explorerLayout(libraries)
+
+	var sb strings.Builder
+	if err := explorerTemplate.Execute(&sb, struct{ Data explorerData }{data}); err != nil {
+		return "", fmt.Errorf("render html graph: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func (g *DependencyGraph) explorerLayout(libraries []*discovery.Module) explorerData {
+	columns, columnCount := g.layoutColumns()
+	data := explorerData{NodeWidth: explorerNodeWidth, NodeHeight: explorerNodeHeight}
+	index := make(map[string]int, len(g.nodes))
+	y := explorerMargin
+
+	groups := g.groupNodesByContext()
+	for _, groupKey := range sortedMapKeys(groups) {
+		ids := groups[groupKey]
+		sort.Strings(ids)
+
+		rows := make([]int, columnCount)
+		for _, id := range ids {
+			column := columns[id]
+			node := explorerNode{
+				ID:    id,
+				Label: shortLabel(id),
+				Group: groupKey,
+				X:     explorerMargin + column*explorerColumnWidth,
+				Y:     y + explorerBandHeader + rows[column]*explorerRowHeight,
+			}
+			if module := g.nodes[id].Module; module != nil {
+				node.Components = module.Components()
+				for _, segment := range module.Segments() {
+					if !slices.Contains(data.Segments, segment) {
+						data.Segments = append(data.Segments, segment)
+					}
+				}
+			}
+			rows[column]++
+			index[id] = len(data.Nodes)
+			data.Nodes = append(data.Nodes, node)
+		}
+		y = data.addBand(groupKey, y, slices.Max(rows), false)
+	}
+
+	libsByID := make(map[string]*discovery.Module, len(libraries))
+	libIndex := make(map[*discovery.Module]int, len(libraries))
+	libs := make([]*discovery.Module, 0, len(libraries))
+	for _, m := range libraries {
+		if m != nil {
+			libsByID[m.Path] = m
+			libs = append(libs, m)
+		}
+	}
+	if len(libs) > 0 {
+		sort.Slice(libs, func(i, j int) bool { return libs[i].RelativePath < libs[j].RelativePath })
+		for i, m := range libs {
+			libIndex[m] = len(data.Nodes)
+			data.Nodes = append(data.Nodes, explorerNode{
+				ID:      m.RelativePath,
+				Label:   m.RelativePath,
+				Group:   explorerLibraryGroup,
+				Library: true,
+				X:       explorerMargin + (i%columnCount)*explorerColumnWidth,
+				Y:       y + explorerBandHeader + (i/columnCount)*explorerRowHeight,
+			})
+		}
+		y = data.addBand(explorerLibraryGroup, y, (len(libs)+columnCount-1)/columnCount, true)
+	}
+
+	for _, from := range sortedMapKeys(g.edges) {
+		tos := append([]string(nil), g.edges[from]...)
+		sort.Strings(tos)
+		for _, to := range tos {
+			data.Edges = append(data.Edges, explorerEdge{From: index[from], To: index[to], Kind: string(g.EdgeKind(from, to))})
+		}
+	}
+
+	g.eachLibraryEdge(libsByID, func(owner *discovery.Module, consumer string) {
+		if to, ok := index[consumer]; ok {
+			data.Edges = append(data.Edges, explorerEdge{From: libIndex[owner], To: to, Kind: "library"})
+		}
+	})
+
+	data.Width = 2*explorerMargin + (columnCount-1)*explorerColumnWidth + explorerNodeWidth
+	data.Height = y - explorerBandGap + explorerMargin
+	return data
+}
+
+// addBand records a group band starting at y with the given number of rows
+// and returns where the next band starts.
+func (d *explorerData) addBand(name string, y, rows int, library bool) int {
+	height := explorerBandHeader + rows*explorerRowHeight
+	d.Groups = append(d.Groups, explorerGroup{Name: name, Y: y, Height: height, Library: library})
+	return y + height + explorerBandGap
+}
+
+// layoutColumns assigns every node the execution level it would run at.
+// Unlike ExecutionLevels it tolerates cycles: nodes that cannot be ordered
+// are placed in a trailing column so the graph can still be inspected.
+func (g *DependencyGraph) layoutColumns() (columns map[string]int, count int) {
+	columns = make(map[string]int, len(g.nodes))
+	pending := make(map[string]int, len(g.nodes))
+	var queue []string
+	for _, id := range sortedMapKeys(g.nodes) {
+		pending[id] = len(g.edges[id])
+		if pending[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		count = max(count, columns[id]+1)
+		for _, dependent := range g.reverseEdges[id] {
+			columns[dependent] = max(columns[dependent], columns[id]+1)
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	cyclic := false
+	for id, remaining := range pending {
+		if remaining > 0 {
+			columns[id] = count
+			cyclic = true
+		}
+	}
+	if cyclic {
+		count++
+	}
+	return columns, max(count, 1)
+}
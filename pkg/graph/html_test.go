@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+func TestToHTML(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	kafka := discovery.TestLibraryModule("_modules/kafka", "/abs/_modules/kafka")
+	g := BuildFromDependencies([]*discovery.Module{vpc, eks}, map[string]*parser.ModuleDependencies{
+		eks.ID(): {DependsOn: []string{vpc.ID()}},
+	})
+	g.AddLibraryUsage("/abs/_modules/kafka", eks.ID())
+
+	page, err := g.ToHTML([]*discovery.Module{kafka})
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`"id":"platform/stage/eu-central-1/vpc"`,
+		`"id":"_modules/kafka"`,
+		`"kind":"library"`,
+		`"segments":["service","environment","region","module"]`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML output missing %q", want)
+		}
+	}
+	for _, external := range []string{"src=", "href=", "@import"} {
+		if strings.Contains(page, external) {
+			t.Errorf("HTML output references external resources via %q", external)
+		}
+	}
+}
+
+func TestExplorerLayout(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	dns := discovery.TestModule("shared", "prod", "eu-central-1", "dns")
+	kafka := discovery.TestLibraryModule("_modules/kafka", "/abs/_modules/kafka")
+	g := BuildFromDependencies([]*discovery.Module{vpc, eks, app, dns}, map[string]*parser.ModuleDependencies{
+		eks.ID(): {DependsOn: []string{vpc.ID()}},
+		app.ID(): {DependsOn: []string{eks.ID()}},
+	})
+	g.AddLibraryUsage("/abs/_modules/kafka/acl", eks.ID())
+
+	data := g.explorerLayout([]*discovery.Module{kafka})
+
+	nodes := make(map[string]explorerNode, len(data.Nodes))
+	index := make(map[string]int, len(data.Nodes))
+	for i, node := range data.Nodes {
+		nodes[node.ID] = node
+		index[node.ID] = i
+	}
+	if got := []int{nodes[vpc.ID()].X, nodes[eks.ID()].X, nodes[app.ID()].X}; !slices.IsSorted(got) || got[0] == got[2] {
+		t.Errorf("columns = %v, want increasing by execution level", got)
+	}
+	if nodes[dns.ID()].Group != "shared/prod" || nodes[dns.ID()].Y <= nodes[app.ID()].Y {
+		t.Errorf("dns = %+v, want its own band below platform/stage", nodes[dns.ID()])
+	}
+
+	// Only direct edges are embedded; the page computes closures on click.
+	wantEdges := []explorerEdge{
+		{From: index[app.ID()], To: index[eks.ID()], Kind: string(EdgeInferred)},
+		{From: index[eks.ID()], To: index[vpc.ID()], Kind: string(EdgeInferred)},
+		{From: index["_modules/kafka"], To: index[eks.ID()], Kind: "library"},
+	}
+	if !slices.Equal(data.Edges, wantEdges) {
+		t.Errorf("edges = %+v, want %+v", data.Edges, wantEdges)
+	}
+
+	lib := nodes["_modules/kafka"]
+	if !lib.Library || lib.Group != explorerLibraryGroup {
+		t.Fatalf("library node = %+v", lib)
+	}
+	if len(data.Groups) != 3 || !data.Groups[2].Library {
+		t.Errorf("groups = %+v", data.Groups)
+	}
+}
+
+func TestExplorerLayout_Cycle(t *testing.T) {
+	t.Parallel()
+
+	g := NewDependencyGraph()
+	for _, name := range []string{"a", "b", "c"} {
+		g.AddNode(discovery.TestModule("platform", "stage", "eu-central-1", name))
+	}
+	g.AddEdge("platform/stage/eu-central-1/a", "platform/stage/eu-central-1/b")
+	g.AddEdge("platform/stage/eu-central-1/b", "platform/stage/eu-central-1/a")
+	g.AddEdge("platform/stage/eu-central-1/b", "platform/stage/eu-central-1/c")
+
+	columns, count := g.layoutColumns()
+	if count != 2 || columns["platform/stage/eu-central-1/c"] != 0 ||
+		columns["platform/stage/eu-central-1/a"] != 1 || columns["platform/stage/eu-central-1/b"] != 1 {
+		t.Errorf("layoutColumns() = %v, %d; want cycle in a trailing column", columns, count)
+	}
+	if _, err := g.ToHTML(nil); err != nil {
+		t.Errorf("ToHTML() error = %v", err)
+	}
+}
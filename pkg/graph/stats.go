@@ -1,32 +1,38 @@
 package graph
 
-import "sort"
+import (
+	"sort"
+	"time"
+)
 
 // Stats contains statistics about the dependency graph.
 type Stats struct {
-	TotalModules int
-	TotalEdges   int
-	RootModules  int // Modules with no dependencies
-	LeafModules  int // Modules with no dependents
-	MaxDepth     int
-	AverageDepth float64
-	HasCycles    bool
-	CycleCount   int
+	TotalModules int     `json:"total_modules"`
+	TotalEdges   int     `json:"total_edges"`
+	RootModules  int     `json:"root_modules"` // Modules with no dependencies
+	LeafModules  int     `json:"leaf_modules"` // Modules with no dependents
+	MaxDepth     int     `json:"max_depth"`
+	AverageDepth float64 `json:"average_depth"`
+	HasCycles    bool    `json:"has_cycles"`
+	CycleCount   int     `json:"cycle_count"`
 
 	// Per-level module counts
-	LevelCounts []int
+	LevelCounts []int `json:"level_counts"`
 
 	// Top modules by fan-in (most depended upon)
-	TopDependedOn []ModuleStat
+	TopDependedOn []ModuleStat `json:"top_depended_on"`
 
 	// Top modules by fan-out (most dependencies)
-	TopDependencies []ModuleStat
+	TopDependencies []ModuleStat `json:"top_dependencies"`
+
+	// Weighted critical path, set by GetStatsWithDurations
+	CriticalPath *CriticalPath `json:"critical_path,omitempty"`
 }
 
 // ModuleStat holds a module ID and a count.
 type ModuleStat struct {
-	ID    string
-	Count int
+	ID    string `json:"id"`
+	Count int    `json:"count"`
 }
 
 // GetStats returns statistics about the dependency graph.
@@ -71,6 +77,17 @@ func (g *DependencyGraph) GetStats() Stats {
 	return stats
 }
 
+// GetStatsWithDurations returns GetStats extended with the critical path
+// weighted by durations. The critical path is omitted when the graph has a
+// cycle.
+func (g *DependencyGraph) GetStatsWithDurations(durations map[string]time.Duration) Stats {
+	stats := g.GetStats()
+	if cp, err := g.CriticalPath(durations); err == nil {
+		stats.CriticalPath = cp
+	}
+	return stats
+}
+
 // topByFanIn returns the top N modules that are most depended upon.
 func (g *DependencyGraph) topByFanIn(n int) []ModuleStat {
 	var stats []ModuleStat
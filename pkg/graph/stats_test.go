@@ -1,6 +1,9 @@
 package graph
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestGetStats(t *testing.T) {
 	t.Parallel()
@@ -41,3 +44,20 @@ func TestGetStats_Empty(t *testing.T) {
 		t.Errorf("TotalEdges = %d, want 0", stats.TotalEdges)
 	}
 }
+
+func TestGetStatsWithDurations(t *testing.T) {
+	t.Parallel()
+
+	g := buildTestGraph()
+	stats := g.GetStatsWithDurations(map[string]time.Duration{"platform/stage/eu-central-1/eks": time.Minute})
+
+	if stats.TotalModules != 4 {
+		t.Errorf("TotalModules = %d, want 4", stats.TotalModules)
+	}
+	if stats.CriticalPath == nil || stats.CriticalPath.Total != time.Minute {
+		t.Fatalf("CriticalPath = %+v, want total 1m", stats.CriticalPath)
+	}
+	if len(stats.CriticalPath.Unmeasured) != 3 {
+		t.Errorf("Unmeasured = %v, want 3 modules", stats.CriticalPath.Unmeasured)
+	}
+}
@@ -1,7 +1,9 @@
 package graph
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -39,12 +41,7 @@ func (g *DependencyGraph) ToDOTWithLibraries(libraries []*discovery.Module) stri
 		sb.WriteString("    color=\"#999999\";\n")
 
 		for _, id := range ids {
-			parts := strings.Split(id, "/")
-			label := id
-			if len(parts) >= 2 {
-				label = strings.Join(parts[len(parts)-2:], "/")
-			}
-			fmt.Fprintf(&sb, "    %q [label=%q];\n", id, label)
+			fmt.Fprintf(&sb, "    %q [label=%q];\n", id, shortLabel(id))
 		}
 		sb.WriteString("  }\n\n")
 		clusterIdx++
@@ -54,6 +51,10 @@ func (g *DependencyGraph) ToDOTWithLibraries(libraries []*discovery.Module) stri
 
 	for from, tos := range g.edges {
 		for _, to := range tos {
+			if g.EdgeKind(from, to) == EdgeDeclared {
+				fmt.Fprintf(&sb, "  %q -> %q [style=dotted, color=\"#3366cc\"];\n", from, to)
+				continue
+			}
 			fmt.Fprintf(&sb, "  %q -> %q;\n", from, to)
 		}
 	}
@@ -95,10 +96,18 @@ func writeLibraryCluster(sb *strings.Builder, libraries []*discovery.Module, clu
 }
 
 // writeLibraryEdges emits dashed edges from library nodes to executable
-// consumers using the graph's tracked libraryUsage. Only libraries present in
-// libsByID are rendered to avoid leaking absolute paths from libraryUsage that
-// the caller did not surface as nodes.
+// consumers using the graph's tracked libraryUsage.
 func writeLibraryEdges(sb *strings.Builder, g *DependencyGraph, libsByID map[string]*discovery.Module) {
+	g.eachLibraryEdge(libsByID, func(owner *discovery.Module, consumer string) {
+		fmt.Fprintf(sb, "  %q -> %q [style=dashed, color=\"#aa6633\"];\n", owner.RelativePath, consumer)
+	})
+}
+
+// eachLibraryEdge calls fn for every library → consumer pair in
+// libraryUsage, in sorted order. Only libraries present in libsByID are
+// visited to avoid leaking absolute paths from libraryUsage that the caller
+// did not surface as nodes.
+func (g *DependencyGraph) eachLibraryEdge(libsByID map[string]*discovery.Module, fn func(owner *discovery.Module, consumer string)) {
 	if len(libsByID) == 0 {
 		return
 	}
@@ -126,7 +135,7 @@ func writeLibraryEdges(sb *strings.Builder, g *DependencyGraph, libsByID map[str
 		consumersSorted := append([]string(nil), consumers...)
 		sort.Strings(consumersSorted)
 		for _, consumer := range consumersSorted {
-			fmt.Fprintf(sb, "  %q -> %q [style=dashed, color=\"#aa6633\"];\n", owner.RelativePath, consumer)
+			fn(owner, consumer)
 		}
 	}
 }
@@ -144,6 +153,15 @@ func enclosingLibrary(libsByID map[string]*discovery.Module, libPath string) *di
 	return best
 }
 
+// shortLabel returns the last two path segments of a module ID.
+func shortLabel(id string) string {
+	parts := strings.Split(id, "/")
+	if len(parts) < 2 {
+		return id
+	}
+	return strings.Join(parts[len(parts)-2:], "/")
+}
+
 // minPartsForRegion is the minimum path segments needed to extract a region sub-group.
 const minPartsForRegion = 3
 
@@ -214,7 +232,11 @@ func (g *DependencyGraph) ToPlantUML() string {
 	for from, tos := range g.edges {
 		fromAlias := plantUMLAlias(from)
 		for _, to := range tos {
-			fmt.Fprintf(&sb, "%s --> %s\n", fromAlias, plantUMLAlias(to))
+			arrow := "-->"
+			if g.EdgeKind(from, to) == EdgeDeclared {
+				arrow = "..>"
+			}
+			fmt.Fprintf(&sb, "%s %s %s\n", fromAlias, arrow, plantUMLAlias(to))
 		}
 	}
 
@@ -222,6 +244,145 @@ func (g *DependencyGraph) ToPlantUML() string {
 	return sb.String()
 }
 
+// ToMermaid exports the graph as a Mermaid flowchart, grouped by context
+// like ToDOT, for embedding in Markdown.
+func (g *DependencyGraph) ToMermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	// Mermaid node IDs are restricted to word characters, so nodes are
+	// numbered in sorted order and labelled with their module path.
+	aliases := make(map[string]string, len(g.nodes))
+	for i, id := range sortedMapKeys(g.nodes) {
+		aliases[id] = fmt.Sprintf("m%d", i)
+	}
+
+	groups := g.groupNodesByContext()
+	for i, groupKey := range sortedMapKeys(groups) {
+		ids := groups[groupKey]
+		sort.Strings(ids)
+
+		fmt.Fprintf(&sb, "  subgraph c%d[\"%s\"]\n", i, mermaidText(groupKey))
+		for _, id := range ids {
+			fmt.Fprintf(&sb, "    %s[\"%s\"]\n", aliases[id], mermaidText(shortLabel(id)))
+		}
+		sb.WriteString("  end\n")
+	}
+
+	for _, from := range sortedMapKeys(g.edges) {
+		tos := append([]string(nil), g.edges[from]...)
+		sort.Strings(tos)
+		for _, to := range tos {
+			arrow := "-->"
+			if g.EdgeKind(from, to) == EdgeDeclared {
+				arrow = "-.->"
+			}
+			fmt.Fprintf(&sb, "  %s %s %s\n", aliases[from], arrow, aliases[to])
+		}
+	}
+	return sb.String()
+}
+
+// mermaidText escapes double quotes, which end a quoted Mermaid label.
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// jsonGraph is the JSON export of a dependency graph.
+type jsonGraph struct {
+	Modules   []jsonModule  `json:"modules"`
+	Edges     []jsonEdge    `json:"edges"`
+	Libraries []jsonLibrary `json:"libraries,omitempty"`
+	Stats     Stats         `json:"stats"`
+}
+
+type jsonModule struct {
+	ID           string            `json:"id"`
+	Path         string            `json:"path"`
+	Workspace    string            `json:"workspace,omitempty"`
+	Components   map[string]string `json:"components"`
+	Dependencies []string          `json:"dependencies"`
+	Dependents   []string          `json:"dependents"`
+}
+
+type jsonEdge struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Kind    EdgeKind     `json:"kind"`
+	Sources []EdgeSource `json:"sources,omitempty"`
+}
+
+type jsonLibrary struct {
+	Path   string   `json:"path"`
+	UsedBy []string `json:"used_by"`
+}
+
+// ToJSON exports the graph as indented JSON for other tools: every module
+// with its components and direct dependencies and dependents, every edge
+// with its kind and the blocks that created it, the given library modules
+// with their consumers, and GetStats.
+func (g *DependencyGraph) ToJSON(libraries []*discovery.Module) (string, error) {
+	out := jsonGraph{
+		Modules: make([]jsonModule, 0, len(g.nodes)),
+		Edges:   []jsonEdge{},
+		Stats:   g.GetStats(),
+	}
+
+	for _, id := range sortedMapKeys(g.nodes) {
+		module := jsonModule{
+			ID:           id,
+			Path:         id,
+			Components:   map[string]string{},
+			Dependencies: sortedCopy(g.edges[id]),
+			Dependents:   sortedCopy(g.reverseEdges[id]),
+		}
+		if m := g.nodes[id].Module; m != nil {
+			module.Path = m.RelativePath
+			module.Workspace = m.Workspace
+			module.Components = m.Components()
+		}
+		out.Modules = append(out.Modules, module)
+
+		for _, to := range module.Dependencies {
+			out.Edges = append(out.Edges, jsonEdge{
+				From:    id,
+				To:      to,
+				Kind:    g.EdgeKind(id, to),
+				Sources: g.EdgeSources(id, to),
+			})
+		}
+	}
+
+	libsByID := make(map[string]*discovery.Module, len(libraries))
+	usedBy := make(map[string][]string, len(libraries))
+	for _, m := range libraries {
+		if m != nil {
+			libsByID[m.Path] = m
+			usedBy[m.RelativePath] = []string{}
+		}
+	}
+	g.eachLibraryEdge(libsByID, func(owner *discovery.Module, consumer string) {
+		usedBy[owner.RelativePath] = append(usedBy[owner.RelativePath], consumer)
+	})
+	for _, path := range sortedMapKeys(usedBy) {
+		// Nested library paths can name the same consumer twice.
+		consumers := slices.Compact(sortedCopy(usedBy[path]))
+		out.Libraries = append(out.Libraries, jsonLibrary{Path: path, UsedBy: consumers})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode graph json: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+func sortedCopy(values []string) []string {
+	out := append([]string{}, values...)
+	sort.Strings(out)
+	return out
+}
+
 func (g *DependencyGraph) groupNodesByContext() map[string][]string {
 	groups := make(map[string][]string)
 	for id := range g.nodes {
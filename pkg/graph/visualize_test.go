@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"encoding/json"
+	"slices"
 	"strings"
 	"testing"
 
@@ -50,6 +52,85 @@ func TestToPlantUML(t *testing.T) {
 	}
 }
 
+func TestToMermaid(t *testing.T) {
+	t.Parallel()
+
+	g := NewDependencyGraph()
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	for _, m := range []*discovery.Module{vpc, eks, app} {
+		g.AddNode(m)
+	}
+	g.AddEdge(eks.ID(), vpc.ID())
+	g.AddDeclaredEdge(app.ID(), eks.ID())
+
+	// Nodes are numbered in sorted order: app, eks, vpc.
+	want := `flowchart LR
+  subgraph c0["platform/stage"]
+    m0["eu-central-1/app"]
+    m1["eu-central-1/eks"]
+    m2["eu-central-1/vpc"]
+  end
+  m0 -.-> m1
+  m1 --> m2
+`
+	if got := g.ToMermaid(); got != want {
+		t.Errorf("ToMermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	kafka := discovery.TestLibraryModule("_modules/kafka", "/abs/_modules/kafka")
+	unused := discovery.TestLibraryModule("_modules/unused", "/abs/_modules/unused")
+	g := BuildFromDependencies([]*discovery.Module{vpc, eks}, map[string]*parser.ModuleDependencies{
+		eks.ID(): {
+			DependsOn: []string{vpc.ID()},
+			Dependencies: []*parser.Dependency{
+				{From: eks, To: vpc, Type: parser.DependencyTypeRemoteState, RemoteStateName: "network"},
+			},
+		},
+	})
+	g.AddLibraryUsage("/abs/_modules/kafka", eks.ID())
+	g.AddLibraryUsage("/abs/_modules/kafka/acl", eks.ID())
+
+	out, err := g.ToJSON([]*discovery.Module{kafka, unused})
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	var got jsonGraph
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out)
+	}
+
+	if len(got.Modules) != 2 || got.Modules[0].ID != eks.ID() || got.Modules[0].Components["module"] != "eks" {
+		t.Errorf("modules = %+v", got.Modules)
+	}
+	if !slices.Equal(got.Modules[1].Dependents, []string{eks.ID()}) {
+		t.Errorf("vpc dependents = %v", got.Modules[1].Dependents)
+	}
+	wantEdge := jsonEdge{From: eks.ID(), To: vpc.ID(), Kind: EdgeInferred, Sources: []EdgeSource{{Type: "remote_state", RemoteState: "network"}}}
+	if len(got.Edges) != 1 || got.Edges[0].From != wantEdge.From || got.Edges[0].To != wantEdge.To ||
+		got.Edges[0].Kind != wantEdge.Kind || !slices.Equal(got.Edges[0].Sources, wantEdge.Sources) {
+		t.Errorf("edges = %+v, want [%+v]", got.Edges, wantEdge)
+	}
+	wantLibs := []jsonLibrary{{Path: "_modules/kafka", UsedBy: []string{eks.ID()}}, {Path: "_modules/unused", UsedBy: []string{}}}
+	if len(got.Libraries) != 2 || !slices.Equal(got.Libraries[0].UsedBy, wantLibs[0].UsedBy) ||
+		got.Libraries[1].Path != wantLibs[1].Path || len(got.Libraries[1].UsedBy) != 0 {
+		t.Errorf("libraries = %+v, want %+v", got.Libraries, wantLibs)
+	}
+	if got.Stats.TotalModules != 2 || got.Stats.TotalEdges != 1 {
+		t.Errorf("stats = %+v", got.Stats)
+	}
+	if !strings.Contains(out, `"total_modules": 2`) {
+		t.Errorf("stats are not snake_case:\n%s", out)
+	}
+}
+
 func TestToDOTWithLibraries(t *testing.T) {
 	t.Parallel()
 
@@ -124,3 +205,21 @@ func TestPlantUMLAlias(t *testing.T) {
 		}
 	}
 }
+
+func TestDeclaredEdgeStyles(t *testing.T) {
+	t.Parallel()
+
+	g := NewDependencyGraph()
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	g.AddNode(vpc)
+	g.AddNode(eks)
+	g.AddDeclaredEdge(eks.ID(), vpc.ID())
+
+	if dot := g.ToDOT(); !strings.Contains(dot, `style=dotted`) {
+		t.Errorf("DOT output does not mark the declared edge:\n%s", dot)
+	}
+	if uml := g.ToPlantUML(); !strings.Contains(uml, "..>") || strings.Contains(uml, "-->") {
+		t.Errorf("PlantUML output does not mark the declared edge:\n%s", uml)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
 	"github.com/edelwud/terraci/pkg/parser/internal/testutil"
 )
 
@@ -34,3 +35,52 @@ func BenchmarkParseModule(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkParseModuleCache compares a cold parse cache, where every module
+// is parsed and stored, with a warm one, where it is loaded from the cache.
+func BenchmarkParseModuleCache(b *testing.B) {
+	for _, tc := range []struct {
+		name      string
+		fileCount int
+	}{
+		{name: "files=5", fileCount: 5},
+		{name: "files=20", fileCount: 20},
+		{name: "files=50", fileCount: 50},
+	} {
+		dir := b.TempDir()
+		testutil.BuildParserBenchmarkModule(b, dir, tc.fileCount)
+
+		b.Run(tc.name+"/cold", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				parser := NewParser(nil)
+				parser.Cache = NewModuleCache(blobtest.NewMemoryStore(""), "bench", 0)
+				parsed, err := parser.ParseModule(context.Background(), dir)
+				if err != nil {
+					b.Fatalf("ParseModule() error = %v", err)
+				}
+				benchParsedModule = parsed
+			}
+		})
+
+		b.Run(tc.name+"/warm", func(b *testing.B) {
+			parser := NewParser(nil)
+			parser.Cache = NewModuleCache(blobtest.NewMemoryStore(""), "bench", 0)
+			if _, err := parser.ParseModule(context.Background(), dir); err != nil {
+				b.Fatalf("ParseModule() error = %v", err)
+			}
+
+			b.ReportAllocs()
+			for b.Loop() {
+				parsed, err := parser.ParseModule(context.Background(), dir)
+				if err != nil {
+					b.Fatalf("ParseModule() error = %v", err)
+				}
+				benchParsedModule = parsed
+			}
+			if stats := parser.Cache.Stats(); stats.Misses != 1 {
+				b.Fatalf("Stats() = %+v, want only the priming miss", stats)
+			}
+		})
+	}
+}
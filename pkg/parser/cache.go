@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache"
+	moduleparse "github.com/edelwud/terraci/pkg/parser/internal/moduleparse"
+	"github.com/edelwud/terraci/pkg/parser/internal/parsecache"
+)
+
+// ModuleCacheNamespace is the blob store namespace of the parse cache.
+const ModuleCacheNamespace = "parser/modules"
+
+// ModuleCache keeps parsed modules in a blob store between runs. Entries are
+// keyed by a hash of the module files, var-files, data source rules and the
+// TerraCi version, so a change to any of them is a miss and stale entries
+// are never read. Entries older than the cache's max age are misses too, and
+// Prune deletes them, so superseded entries do not accumulate.
+//
+// Modules loaded from the cache carry no Files and only the locals
+// top-level blocks: enough for dependency extraction, not for callers that
+// inspect the HCL directly.
+type ModuleCache struct {
+	store   *blobcache.Cache
+	version string
+	maxAge  time.Duration
+
+	hits    atomic.Int64
+	misses  atomic.Int64
+	skipped atomic.Int64
+	errors  atomic.Int64
+}
+
+// CacheStats counts ModuleCache lookups.
+type CacheStats struct {
+	// Hits are modules loaded from the cache.
+	Hits int64
+	// Misses are modules parsed because no entry matched.
+	Misses int64
+	// Skipped are misses that could not be stored, e.g. modules written in
+	// HCL JSON syntax.
+	Skipped int64
+	// Errors are failed blob store reads and writes; they degrade to
+	// parsing the module.
+	Errors int64
+}
+
+// NewModuleCache creates a parse cache over store. version is the TerraCi
+// version and is part of every key. Entries written more than maxAge ago are
+// parsed again; zero keeps them forever.
+func NewModuleCache(store blobcache.Store, version string, maxAge time.Duration) *ModuleCache {
+	return &ModuleCache{
+		store:   blobcache.New(store, ModuleCacheNamespace, maxAge),
+		version: version,
+		maxAge:  maxAge,
+	}
+}
+
+// Prune deletes entries older than the cache's max age.
+func (c *ModuleCache) Prune(ctx context.Context) error {
+	return c.store.CleanExpired(ctx)
+}
+
+// Stats returns the lookup counters so far.
+func (c *ModuleCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Skipped: c.skipped.Load(),
+		Errors:  c.errors.Load(),
+	}
+}
+
+func (c *ModuleCache) expired(meta blobcache.Meta) bool {
+	return c.maxAge > 0 && time.Since(meta.UpdatedAt) > c.maxAge
+}
+
+// parse returns the cached module for modulePath, or parses it and stores
+// the result.
+func (c *ModuleCache) parse(ctx context.Context, modulePath string, segments []string, opts moduleparse.Options) (*ParsedModule, error) {
+	key, err := parsecache.Key(c.version, modulePath, segments, opts)
+	if err != nil {
+		c.errors.Add(1)
+		return moduleparse.Run(ctx, modulePath, segments, opts)
+	}
+
+	data, meta, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.errors.Add(1)
+	}
+	if ok && !c.expired(meta) {
+		if parsed, err := parsecache.Decode(data, modulePath, opts.DataSources); err == nil {
+			c.hits.Add(1)
+			return parsed, nil
+		}
+	}
+
+	c.misses.Add(1)
+	parsed, err := moduleparse.Run(ctx, modulePath, segments, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = parsecache.Encode(parsed, opts.DataSources)
+	if err != nil {
+		c.skipped.Add(1)
+		return parsed, nil
+	}
+	if _, err := c.store.Put(ctx, key, data, blobcache.PutOptions{ContentType: "application/json"}); err != nil {
+		c.errors.Add(1)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache"
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+func TestModuleCache_ExtractDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	eksPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "eks")
+	vpcPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "vpc")
+	writeTestFile(t, eksPath, "data.tf", `
+locals {
+  env = "stage"
+}
+
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = { bucket = "b", key = "platform/${local.env}/eu-central-1/vpc/terraform.tfstate" }
+}
+`)
+	writeTestFile(t, vpcPath, "main.tf", "# VPC")
+
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	eks.Path = eksPath
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	vpc.Path = vpcPath
+	index := discovery.NewModuleIndex([]*discovery.Module{eks, vpc})
+
+	cache := NewModuleCache(blobtest.NewMemoryStore(""), "test", 0)
+	extract := func() *ModuleDependencies {
+		t.Helper()
+		parser := NewParser(nil)
+		parser.Cache = cache
+		deps, err := NewDependencyExtractor(parser, index).ExtractDependencies(context.Background(), eks)
+		if err != nil {
+			t.Fatalf("extract: %v", err)
+		}
+		return deps
+	}
+
+	for run := range 2 {
+		deps := extract()
+		if len(deps.DependsOn) != 1 || deps.DependsOn[0] != vpc.ID() {
+			t.Fatalf("run %d: DependsOn = %v, want [%s]", run, deps.DependsOn, vpc.ID())
+		}
+	}
+	// Both modules are parsed: eks for its remote states, vpc to match them.
+	if stats := cache.Stats(); stats != (CacheStats{Hits: 2, Misses: 2}) {
+		t.Fatalf("Stats() = %+v, want 2 hits and 2 misses", stats)
+	}
+
+	writeTestFile(t, eksPath, "data.tf", "# no dependencies")
+	if deps := extract(); len(deps.DependsOn) != 0 {
+		t.Fatalf("stale entry used after edit: DependsOn = %v", deps.DependsOn)
+	}
+	if stats := cache.Stats(); stats != (CacheStats{Hits: 2, Misses: 3}) {
+		t.Fatalf("Stats() = %+v, want the edited module to miss", stats)
+	}
+}
+
+func TestModuleCache_MaxAge(t *testing.T) {
+	tmpDir := t.TempDir()
+	vpcPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "vpc")
+	writeTestFile(t, vpcPath, "main.tf", "# VPC")
+
+	store := blobtest.NewMemoryStore("")
+	parse := func(cache *ModuleCache) {
+		t.Helper()
+		parser := NewParser(nil)
+		parser.Cache = cache
+		if _, err := parser.ParseModule(context.Background(), vpcPath); err != nil {
+			t.Fatalf("ParseModule() error = %v", err)
+		}
+	}
+
+	fresh := NewModuleCache(store, "test", time.Hour)
+	parse(fresh)
+	parse(fresh)
+	if stats := fresh.Stats(); stats != (CacheStats{Hits: 1, Misses: 1}) {
+		t.Fatalf("Stats() = %+v, want an entry younger than max age to hit", stats)
+	}
+
+	expired := NewModuleCache(store, "test", time.Nanosecond)
+	parse(expired)
+	if stats := expired.Stats(); stats != (CacheStats{Misses: 1}) {
+		t.Fatalf("Stats() = %+v, want an entry past max age to miss", stats)
+	}
+	if err := expired.Prune(context.Background()); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	entries, err := blobcache.New(store, ModuleCacheNamespace, 0).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("entries after Prune() = %d, want 0", len(entries))
+	}
+}
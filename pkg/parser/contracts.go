@@ -16,6 +16,22 @@ type ModuleParser interface {
 // Parser handles parsing of Terraform HCL files.
 type Parser struct {
 	segments []string
+
+	// VarFiles maps module directories to extra tfvars files, loaded after
+	// terraform.tfvars and *.auto.tfvars like terraform -var-file.
+	VarFiles map[string][]string
+
+	// DataSources turn data sources other than terraform_remote_state into
+	// dependencies on the modules producing their values.
+	DataSources []DataSourceRule
+
+	// Cache, when set, loads unchanged modules from a persistent parse
+	// cache instead of parsing them.
+	Cache *ModuleCache
+
+	// EarlyEvaluation evaluates variables and locals in module source and
+	// version arguments, as OpenTofu 1.8+ does.
+	EarlyEvaluation bool
 }
 
 // NewParser creates a new HCL parser with the given pattern segments.
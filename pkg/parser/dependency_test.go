@@ -2,10 +2,13 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/edelwud/terraci/pkg/discovery"
+	terrierrors "github.com/edelwud/terraci/pkg/errors"
 )
 
 func TestExtractDependencies_SingleRemoteState(t *testing.T) {
@@ -43,6 +46,17 @@ data "terraform_remote_state" "vpc" {
 	if deps.Dependencies[0].Type != "remote_state" {
 		t.Errorf("dep type = %q, want remote_state", deps.Dependencies[0].Type)
 	}
+
+	evidence := deps.Dependencies[0].Evidence
+	if evidence == nil {
+		t.Fatal("remote_state dependency has no evidence")
+	}
+	if evidence.Range.Filename != filepath.Join(eksPath, "data.tf") || evidence.Range.Start.Line != 2 || evidence.Range.End.Line != 5 {
+		t.Errorf("evidence range = %v, want data.tf:2-5", evidence.Range)
+	}
+	if evidence.StatePath != "platform/stage/eu-central-1/vpc/terraform.tfstate" || evidence.Match != MatchPath {
+		t.Errorf("evidence = %+v, want the state key matched by path", evidence)
+	}
 }
 
 func TestExtractDependencies_Multiple(t *testing.T) {
@@ -115,6 +129,50 @@ data "terraform_remote_state" "deps" {
 	}
 }
 
+func TestExtractDependencies_MixedJSONAndNative(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	vpcPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "vpc")
+	writeTestFile(t, vpcPath, "main.tf.json", `{"terraform": {"backend": {"s3": {"bucket": "b", "key": "platform/stage/eu-central-1/vpc/terraform.tfstate"}}}}`)
+
+	// The same dependency declared in native syntax and in JSON syntax.
+	nativePath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "eks")
+	writeTestFile(t, nativePath, "data.tf", `
+locals { env = "stage" }
+
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = { bucket = "b", key = "platform/${local.env}/eu-central-1/vpc/terraform.tfstate" }
+}
+`)
+	jsonPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "app")
+	writeTestFile(t, jsonPath, "data.tf.json", `{
+  "locals": {"env": "stage"},
+  "data": {"terraform_remote_state": {"vpc": {
+    "backend": "s3",
+    "config": {"bucket": "b", "key": "platform/${local.env}/eu-central-1/vpc/terraform.tfstate"}
+  }}}
+}`)
+
+	modules := make([]*discovery.Module, 0, 3)
+	for name, path := range map[string]string{"vpc": vpcPath, "eks": nativePath, "app": jsonPath} {
+		m := discovery.TestModule("platform", "stage", "eu-central-1", name)
+		m.Path = path
+		modules = append(modules, m)
+	}
+
+	deps, errs := NewDependencyExtractor(NewParser(nil), discovery.NewModuleIndex(modules)).ExtractAllDependencies(context.Background())
+	if len(errs) != 0 {
+		t.Fatalf("errors: %v", errs)
+	}
+	for _, id := range []string{"platform/stage/eu-central-1/eks", "platform/stage/eu-central-1/app"} {
+		got := deps[id].DependsOn
+		if len(got) != 1 || got[0] != "platform/stage/eu-central-1/vpc" {
+			t.Errorf("%s DependsOn = %v, want [platform/stage/eu-central-1/vpc]", id, got)
+		}
+	}
+}
+
 func TestExtractDependencies_Library(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -142,6 +200,44 @@ func TestExtractDependencies_Library(t *testing.T) {
 	}
 }
 
+func TestExtractDependencies_LibraryEarlyEvaluation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "kafka")
+	libPath := createTestModuleDir(t, tmpDir, "_modules", "kafka")
+
+	writeTestFile(t, modPath, "main.tf", `
+variable "modules_root" {
+  default = "../../../../_modules"
+}
+
+module "kafka" {
+  source = "${var.modules_root}/kafka"
+}
+`)
+	writeTestFile(t, libPath, "main.tf", "# Kafka lib")
+
+	m := discovery.TestModule("platform", "stage", "eu-central-1", "kafka")
+	m.Path = modPath
+	index := discovery.NewModuleIndex([]*discovery.Module{m})
+
+	for _, early := range []bool{false, true} {
+		p := NewParser(nil)
+		p.EarlyEvaluation = early
+		deps, err := NewDependencyExtractor(p, index).ExtractDependencies(context.Background(), m)
+		if err != nil {
+			t.Fatalf("extract: %v", err)
+		}
+		want := 0
+		if early {
+			want = 1
+		}
+		if len(deps.LibraryDependencies) != want {
+			t.Errorf("early evaluation %t: lib deps = %d, want %d", early, len(deps.LibraryDependencies), want)
+		}
+	}
+}
+
 func TestExtractAllDependencies(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -186,6 +282,64 @@ data "terraform_remote_state" "rds" {
 	}
 }
 
+func TestExtractDependencies_TfvarsAndUnresolvedVariables(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	vpcPath := createTestModuleDir(t, tmpDir, "platform", "prod", "eu-central-1", "vpc")
+	eksPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "eks")
+	appPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "app")
+	writeTestFile(t, vpcPath, "main.tf", "# VPC")
+
+	remoteState := `
+variable "environment" {
+  default = "stage"
+}
+variable "region" {}
+
+locals {
+  state_key = "platform/${var.environment}/${var.region}/vpc/terraform.tfstate"
+}
+
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = { bucket = "b", key = local.state_key }
+}
+`
+	writeTestFile(t, eksPath, "main.tf", remoteState)
+	writeTestFile(t, eksPath, "terraform.tfvars", `environment = "dev"`)
+	writeTestFile(t, eksPath, "region.auto.tfvars.json", `{"region": "eu-central-1"}`)
+	writeTestFile(t, eksPath, "prod.tfvars", `environment = "prod"`)
+	writeTestFile(t, appPath, "main.tf", remoteState)
+
+	vpc := discovery.TestModule("platform", "prod", "eu-central-1", "vpc")
+	vpc.Path = vpcPath
+	eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+	eks.Path = eksPath
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	app.Path = appPath
+
+	moduleParser := NewParser(nil)
+	moduleParser.VarFiles = map[string][]string{eksPath: {"prod.tfvars"}}
+	extractor := NewDependencyExtractor(moduleParser, discovery.NewModuleIndex([]*discovery.Module{vpc, eks, app}))
+
+	deps, err := extractor.ExtractDependencies(context.Background(), eks)
+	if err != nil {
+		t.Fatalf("extract eks: %v", err)
+	}
+	if len(deps.Dependencies) != 1 || deps.Dependencies[0].To != vpc {
+		t.Fatalf("eks deps = %+v, errors = %v, want prod vpc", deps.Dependencies, deps.Errors)
+	}
+
+	deps, err = extractor.ExtractDependencies(context.Background(), app)
+	if err != nil {
+		t.Fatalf("extract app: %v", err)
+	}
+	var unresolved *terrierrors.UnresolvedVariableError
+	if len(deps.Errors) != 1 || !errors.As(deps.Errors[0], &unresolved) || unresolved.Variable != "region" {
+		t.Fatalf("app errors = %v, want unresolved variable region", deps.Errors)
+	}
+}
+
 func TestExtractDependencies_BackendDisambiguation(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -251,6 +405,101 @@ data "terraform_remote_state" "vpc" {
 	}
 }
 
+func TestExtractDependencies_BackendIdentities(t *testing.T) {
+	tests := []struct {
+		name     string
+		backendA string
+		backendB string
+		ref      string
+	}{
+		{
+			name: "azurerm",
+			backendA: `backend "azurerm" {
+  storage_account_name = "acct-a"
+  container_name = "tfstate"
+  key = "prod/vpc.tfstate"
+}`,
+			backendB: `backend "azurerm" {
+  storage_account_name = "acct-b"
+  container_name = "tfstate"
+  key = "prod/vpc.tfstate"
+}`,
+			ref: `backend = "azurerm"` + "\n" + `config = { storage_account_name = "acct-b", container_name = "tfstate", key = "prod/vpc.tfstate" }`,
+		},
+		{
+			name: "gcs",
+			backendA: `backend "gcs" {
+  bucket = "state-a"
+  prefix = "prod/vpc"
+}`,
+			backendB: `backend "gcs" {
+  bucket = "state-b"
+  prefix = "prod/vpc"
+}`,
+			ref: `backend = "gcs"` + "\n" + `config = { bucket = "state-b", prefix = "prod/vpc" }`,
+		},
+		{
+			name: "consul",
+			backendA: `backend "consul" {
+  address = "consul-a:8500"
+  path = "prod/vpc"
+}`,
+			backendB: `backend "consul" {
+  address = "consul-b:8500"
+  path = "prod/vpc"
+}`,
+			ref: `backend = "consul"` + "\n" + `config = { address = "consul-b:8500", path = "prod/vpc" }`,
+		},
+		{
+			name: "pg",
+			backendA: `backend "pg" {
+  conn_str = "postgres://a/state"
+  schema_name = "vpc"
+}`,
+			backendB: `backend "pg" {
+  conn_str = "postgres://b/state"
+  schema_name = "vpc"
+}`,
+			ref: `backend = "pg"` + "\n" + `config = { conn_str = "postgres://b/state", schema_name = "vpc" }`,
+		},
+		{
+			name:     "http",
+			backendA: `backend "http" { address = "https://state.example.com/a/vpc" }`,
+			backendB: `backend "http" { address = "https://state.example.com/b/vpc" }`,
+			ref:      `backend = "http"` + "\n" + `config = { address = "https://state.example.com/b/vpc" }`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			vpcAPath := createTestModuleDir(t, tmpDir, "team-a", "prod", "eu-central-1", "vpc")
+			vpcBPath := createTestModuleDir(t, tmpDir, "team-b", "prod", "eu-central-1", "vpc")
+			appPath := createTestModuleDir(t, tmpDir, "team-a", "prod", "eu-central-1", "app")
+			writeTestFile(t, vpcAPath, "backend.tf", "terraform {\n"+tt.backendA+"\n}\n")
+			writeTestFile(t, vpcBPath, "backend.tf", "terraform {\n"+tt.backendB+"\n}\n")
+			writeTestFile(t, appPath, "data.tf", "data \"terraform_remote_state\" \"vpc\" {\n"+tt.ref+"\n}\n")
+
+			vpcA := discovery.TestModule("team-a", "prod", "eu-central-1", "vpc")
+			vpcA.Path = vpcAPath
+			vpcB := discovery.TestModule("team-b", "prod", "eu-central-1", "vpc")
+			vpcB.Path = vpcBPath
+			app := discovery.TestModule("team-a", "prod", "eu-central-1", "app")
+			app.Path = appPath
+
+			extractor := NewDependencyExtractor(NewParser(nil), discovery.NewModuleIndex([]*discovery.Module{vpcA, vpcB, app}))
+			allDeps, _ := extractor.ExtractAllDependencies(context.Background())
+
+			appDeps := allDeps[app.ID()]
+			if len(appDeps.Dependencies) != 1 {
+				t.Fatalf("deps = %d, want 1 (errors: %v)", len(appDeps.Dependencies), appDeps.Errors)
+			}
+			if target := appDeps.Dependencies[0].To; target.ID() != vpcB.ID() {
+				t.Errorf("target = %q, want %s", target.ID(), vpcB.ID())
+			}
+		})
+	}
+}
+
 func TestExtractDependencies_BackendFallback(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -283,3 +532,159 @@ data "terraform_remote_state" "vpc" {
 		t.Errorf("target = %q, want vpc", appDeps.Dependencies[0].To.ID())
 	}
 }
+
+func TestExtractDependencies_DependsOnAnnotation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	names := []string{"app", "iam", "kms"}
+	modules := make([]*discovery.Module, 0, len(names))
+	for _, name := range names {
+		path := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", name)
+		m := discovery.TestModule("platform", "stage", "eu-central-1", name)
+		m.Path = path
+		modules = append(modules, m)
+		writeTestFile(t, path, "main.tf", "# Module")
+	}
+	writeTestFile(t, modules[0].Path, "main.tf", `
+# terraci:depends_on=platform/stage/*/iam, platform/stage/*/kms
+# terraci:depends_on=platform/stage/*/app
+// terraci:depends_on=platform/prod/*/dns
+resource "null_resource" "app" {}
+`)
+
+	extractor := NewDependencyExtractor(NewParser(nil), discovery.NewModuleIndex(modules))
+	deps, err := extractor.ExtractDependencies(context.Background(), modules[0])
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+
+	if len(deps.DependsOn) != 2 || deps.DependsOn[0] != modules[1].ID() || deps.DependsOn[1] != modules[2].ID() {
+		t.Errorf("DependsOn = %v, want [iam kms]", deps.DependsOn)
+	}
+	for _, dep := range deps.Dependencies {
+		if dep.Type != DependencyTypeDeclared {
+			t.Errorf("dependency on %s has type %q, want %q", dep.To.ID(), dep.Type, DependencyTypeDeclared)
+		}
+	}
+	if len(deps.Errors) != 1 || deps.Errors[0].Error() != `no module matches terraci:depends_on "platform/prod/*/dns" (from platform/stage/eu-central-1/app)` {
+		t.Errorf("errors = %v", deps.Errors)
+	}
+}
+
+func TestExtractDependencies_DataSourceRules(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	names := []string{"app", "vpc"}
+	modules := make([]*discovery.Module, 0, len(names))
+	for _, name := range names {
+		path := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", name)
+		m := discovery.TestModule("platform", "stage", "eu-central-1", name)
+		m.Path = path
+		modules = append(modules, m)
+		writeTestFile(t, path, "main.tf", "# Module")
+	}
+	writeTestFile(t, modules[0].Path, "main.tf", `
+data "aws_ssm_parameter" "vpc_id" {
+  name = "/${local.service}/${local.environment}/${local.region}/vpc/vpc_id"
+}
+
+data "aws_ssm_parameter" "cache" {
+  name = "/platform/stage/eu-central-1/cache/endpoint"
+}
+
+data "aws_ssm_parameter" "token" {
+  name = "/platform/${terraform.workspace}/eu-central-1/vpc/token"
+}
+`)
+
+	moduleParser := NewParser(nil)
+	moduleParser.DataSources = []DataSourceRule{{
+		Type:      "aws_ssm_parameter",
+		Attribute: "name",
+		Pattern:   regexp.MustCompile(`^/(?P<id>[^/]+/[^/]+/[^/]+/[^/]+)/`),
+		Module:    "{id}",
+	}}
+	extractor := NewDependencyExtractor(moduleParser, discovery.NewModuleIndex(modules))
+	deps, err := extractor.ExtractDependencies(context.Background(), modules[0])
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+
+	if len(deps.Dependencies) != 1 || deps.Dependencies[0].To != modules[1] || deps.Dependencies[0].Type != DependencyTypeDataSource {
+		t.Fatalf("dependencies = %+v, want one data_source dependency on vpc; errors %v", deps.Dependencies, deps.Errors)
+	}
+	wantErrs := []string{
+		`no module matches "platform/stage/eu-central-1/cache" for name "/platform/stage/eu-central-1/cache/endpoint" (from platform/stage/eu-central-1/app.data.aws_ssm_parameter.cache)`,
+		`unresolved name of platform/stage/eu-central-1/app.data.aws_ssm_parameter.token`,
+	}
+	if len(deps.Errors) != len(wantErrs) {
+		t.Fatalf("errors = %v, want %d", deps.Errors, len(wantErrs))
+	}
+	for i, want := range wantErrs {
+		if deps.Errors[i].Error() != want {
+			t.Errorf("errors[%d] = %q, want %q", i, deps.Errors[i], want)
+		}
+	}
+}
+
+func TestExtractDependencies_Workspaces(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	appPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "app")
+	vpcPath := createTestModuleDir(t, tmpDir, "platform", "stage", "eu-central-1", "vpc")
+	writeTestFile(t, appPath, "data.tf", `
+data "terraform_remote_state" "shared" {
+  backend = "s3"
+  config = { bucket = "b", key = "platform/stage/eu-central-1/vpc/terraform.tfstate" }
+}
+data "terraform_remote_state" "acme" {
+  backend   = "s3"
+  workspace = "acme"
+  config = { bucket = "b", key = "platform/stage/eu-central-1/vpc/terraform.tfstate" }
+}
+data "terraform_remote_state" "globex" {
+  backend = "s3"
+  config = { bucket = "b", key = "tenants/globex/platform/stage/eu-central-1/vpc/terraform.tfstate", workspace_key_prefix = "tenants" }
+}
+data "terraform_remote_state" "initech" {
+  backend   = "s3"
+  workspace = "initech"
+  config = { bucket = "b", key = "platform/stage/eu-central-1/vpc/terraform.tfstate" }
+}
+`)
+	writeTestFile(t, vpcPath, "main.tf", "# VPC")
+
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	app.Path = appPath
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	vpc.Path = vpcPath
+	acme := vpc.WithWorkspace("acme")
+	globex := vpc.WithWorkspace("globex")
+
+	extractor := NewDependencyExtractor(NewParser(nil), discovery.NewModuleIndex([]*discovery.Module{app, vpc, acme, globex}))
+	deps, err := extractor.ExtractDependencies(context.Background(), app)
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+
+	got := make(map[string]string, len(deps.Dependencies))
+	for _, dep := range deps.Dependencies {
+		got[dep.RemoteStateName] = dep.To.ID()
+	}
+	want := map[string]string{
+		"shared": "platform/stage/eu-central-1/vpc",
+		"acme":   "platform/stage/eu-central-1/vpc@acme",
+		"globex": "platform/stage/eu-central-1/vpc@globex",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("dependencies = %v, want %v", got, want)
+	}
+	for name, id := range want {
+		if got[name] != id {
+			t.Errorf("%s -> %q, want %q", name, got[name], id)
+		}
+	}
+	if len(deps.Errors) != 1 {
+		t.Fatalf("errors = %v, want one for the undeployed workspace", deps.Errors)
+	}
+}
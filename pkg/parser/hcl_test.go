@@ -340,6 +340,58 @@ module "rel_mod" {
 	}
 }
 
+func TestParseModule_JSONSyntax(t *testing.T) {
+	dir := setupTempModule(t, map[string]string{
+		"main.tf.json": `{
+  "//": "generated by cdktf",
+  "locals": {"environment": "stage", "prefix": "platform/${local.environment}"},
+  "terraform": {
+    "backend": {"s3": {"bucket": "my-state-bucket", "key": "platform/stage/eu-central-1/eks/terraform.tfstate"}},
+    "required_providers": [{"aws": {"source": "hashicorp/aws", "version": "~> 5.0"}}]
+  },
+  "data": {
+    "terraform_remote_state": {
+      "vpc": {
+        "backend": "s3",
+        "config": {"bucket": "my-state-bucket", "key": "${local.prefix}/eu-central-1/vpc/terraform.tfstate"}
+      }
+    }
+  },
+  "module": {"kafka": {"source": "../../../_modules/kafka"}}
+}`,
+		"outputs.tf": `output "name" { value = local.environment }`,
+	})
+
+	result, err := NewParser(nil).ParseModule(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Diagnostics.HasErrors() {
+		t.Fatalf("diagnostics: %v", result.Diagnostics)
+	}
+
+	assertLocalEquals(t, result, "environment", "stage")
+	assertLocalEquals(t, result, "prefix", "platform/stage")
+	if len(result.Files) != 2 {
+		t.Errorf("files = %d, want 2 (native and JSON)", len(result.Files))
+	}
+	if result.Backend == nil || result.Backend.Type != "s3" || result.Backend.Config["bucket"] != "my-state-bucket" {
+		t.Errorf("backend = %+v", result.Backend)
+	}
+	if len(result.RequiredProviders) != 1 || result.RequiredProviders[0].Source != "hashicorp/aws" || result.RequiredProviders[0].VersionConstraint != "~> 5.0" {
+		t.Errorf("required providers = %+v", result.RequiredProviders)
+	}
+	if len(result.ModuleCalls) != 1 || !result.ModuleCalls[0].IsLocal {
+		t.Errorf("module calls = %+v", result.ModuleCalls)
+	}
+	if len(result.RemoteStates) != 1 || result.RemoteStates[0].Backend != "s3" {
+		t.Fatalf("remote states = %+v", result.RemoteStates)
+	}
+	if _, ok := result.RemoteStates[0].Config["key"]; !ok {
+		t.Error("remote state config missing key expression")
+	}
+}
+
 func TestParseModule_InvalidHCL(t *testing.T) {
 	dir := setupTempModule(t, map[string]string{
 		"invalid.tf": `locals { broken = "unclosed string\n}`,
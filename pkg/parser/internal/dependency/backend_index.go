@@ -49,6 +49,6 @@ func (i *backendModuleIndex) Build(ctx context.Context, index *discovery.ModuleI
 	_ = group.Wait() //nolint:errcheck
 }
 
-func (i *backendModuleIndex) Match(backendType, bucket, statePath string) *discovery.Module {
-	return parserdeps.MatchByBackend(i.items, backendType, bucket, statePath)
+func (i *backendModuleIndex) Match(backendType, location, statePath string) *discovery.Module {
+	return parserdeps.MatchByBackend(i.items, backendType, location, statePath)
 }
@@ -6,7 +6,9 @@ import (
 
 	"github.com/edelwud/terraci/pkg/discovery"
 	parserdeps "github.com/edelwud/terraci/pkg/parser/internal/deps"
+	"github.com/edelwud/terraci/pkg/parser/internal/evalctx"
 	"github.com/edelwud/terraci/pkg/parser/model"
+	"github.com/edelwud/terraci/pkg/pathmatch"
 )
 
 const maxConcurrentExtractions = 20
@@ -17,14 +19,26 @@ type Engine struct {
 	cache        *parsedModuleCache
 	backendIndex *backendModuleIndex
 	backendOnce  sync.Once
+	evalBuilder  evalctx.Builder
+}
+
+// segmentsProvider is implemented by parsers that know the structure
+// segments, so data source attributes can use path-derived locals.
+type segmentsProvider interface {
+	Segments() []string
 }
 
 func NewEngine(parser ModuleParser, index *discovery.ModuleIndex) *Engine {
+	var segments []string
+	if provider, ok := parser.(segmentsProvider); ok {
+		segments = provider.Segments()
+	}
 	return &Engine{
 		parser:       parser,
 		index:        index,
 		cache:        newParsedModuleCache(parser),
 		backendIndex: newBackendModuleIndex(),
+		evalBuilder:  evalctx.NewBuilder(segments),
 	}
 }
 
@@ -64,34 +78,73 @@ func (e *Engine) prepareBackendIndex(ctx context.Context) {
 	})
 }
 
-func (e *Engine) MatchPathToModule(statePath string, from *discovery.Module) *discovery.Module {
+func (e *Engine) MatchPathToModule(statePath string, from *discovery.Module) (*discovery.Module, model.MatchMethod) {
 	return parserdeps.MatchPathToModule(e.index, statePath, from)
 }
 
+// MatchWorkspace returns the module deploying module's directory to
+// workspace; an empty workspace is the default one.
+func (e *Engine) MatchWorkspace(module *discovery.Module, workspace string) *discovery.Module {
+	return parserdeps.MatchWorkspace(e.index, module, workspace)
+}
+
 // MatchBackend triggers lazy backend-index construction on first use.
 // Single-module callers whose remote_state pointers all disambiguate by
 // path alone never invoke this — and therefore never pay the O(N) parse cost.
 //
 // ctx propagates cancellation into the parallel module-parse pass that the
 // index uses on first build; subsequent calls reuse the cached index.
-func (e *Engine) MatchBackend(ctx context.Context, backendType, bucket, statePath string) *discovery.Module {
+func (e *Engine) MatchBackend(ctx context.Context, backendType, location, statePath string) *discovery.Module {
 	if e.backendIndex == nil {
 		return nil
 	}
 
 	e.prepareBackendIndex(ctx)
 
-	return e.backendIndex.Match(backendType, bucket, statePath)
+	return e.backendIndex.Match(backendType, location, statePath)
+}
+
+// BackendLocation returns the type and location key of a module's own
+// backend. It parses only that module, so path matches can be checked
+// without building the backend index.
+func (e *Engine) BackendLocation(ctx context.Context, module *discovery.Module) (backendType, location string, ok bool) {
+	parsed, err := e.cache.Get(ctx, module)
+	if err != nil || parsed.Backend == nil {
+		return "", "", false
+	}
+	location, ok = parserdeps.IdentityFor(parsed.Backend.Type).LocationKey(parsed.Backend.Config)
+	return parsed.Backend.Type, location, ok
+}
+
+// MatchPattern returns the modules whose ID matches a glob pattern.
+func (e *Engine) MatchPattern(pattern string) ([]*discovery.Module, error) {
+	if err := pathmatch.ValidateGlob(pattern); err != nil {
+		return nil, err
+	}
+	var matched []*discovery.Module
+	for _, module := range e.index.All() {
+		if ok, _ := module.MatchGlob(pattern); ok {
+			matched = append(matched, module)
+		}
+	}
+	return matched, nil
 }
 
 func ContainsDynamicPattern(path string) bool {
 	return parserdeps.ContainsDynamicPattern(path)
 }
 
+// BackendIndexKey returns the backend index key of a module's own backend, or
+// "" when the config lacks the location attributes its backend type needs.
 func BackendIndexKey(bc *model.BackendConfig, modulePath string) string {
 	if bc == nil {
 		return ""
 	}
 
-	return parserdeps.BackendIndexKey(bc.Type, bc.Config["bucket"], bc.Config["key"], modulePath)
+	identity := parserdeps.IdentityFor(bc.Type)
+	location, ok := identity.LocationKey(bc.Config)
+	if !ok {
+		return ""
+	}
+	return parserdeps.BackendIndexKey(bc.Type, location, bc.Config[identity.Path], modulePath)
 }
@@ -104,7 +104,7 @@ func TestRemoteStateTargetResolverResolve(t *testing.T) {
 	legacy := discovery.TestModule("network", "shared", "global", "legacy")
 	legacy.RelativePath = "legacy/custom"
 
-	engine := NewEngine(nil, discovery.NewModuleIndex([]*discovery.Module{app, vpc, legacy}))
+	engine := NewEngine(&fakeDependencyParser{}, discovery.NewModuleIndex([]*discovery.Module{app, vpc, legacy}))
 	engine.backendIndex.items[BackendIndexKey(&model.BackendConfig{
 		Type: "s3",
 		Config: map[string]string{
@@ -121,12 +121,12 @@ func TestRemoteStateTargetResolverResolve(t *testing.T) {
 		map[string]cty.Value{},
 	)
 
-	pathMatched := resolver.Resolve(&model.RemoteStateRef{}, "platform/stage/eu-central-1/vpc/terraform.tfstate")
-	if pathMatched == nil || pathMatched.ID() != vpc.ID() {
-		t.Fatalf("path match = %v, want %s", pathMatched, vpc.ID())
+	pathMatched, method := resolver.Resolve(&model.RemoteStateRef{}, "platform/stage/eu-central-1/vpc/terraform.tfstate")
+	if pathMatched == nil || pathMatched.ID() != vpc.ID() || method != model.MatchPath {
+		t.Fatalf("path match = %v (%s), want %s by path", pathMatched, method, vpc.ID())
 	}
 
-	backendMatched := resolver.Resolve(&model.RemoteStateRef{
+	backendMatched, _ := resolver.Resolve(&model.RemoteStateRef{
 		Name:    "legacy",
 		Backend: "s3",
 		Config: map[string]hcl.Expression{
@@ -138,6 +138,44 @@ func TestRemoteStateTargetResolverResolve(t *testing.T) {
 	}
 }
 
+func TestRemoteStateTargetResolverPrefersBackendOverConflictingPath(t *testing.T) {
+	app := discovery.TestModule("platform", "stage", "eu-central-1", "app")
+	app.Path = "app-path"
+	local := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	local.Path = "local-path"
+	shared := discovery.TestModule("network", "shared", "global", "vpc")
+	shared.Path = "shared-path"
+
+	azurerm := func(account string) *model.BackendConfig {
+		return &model.BackendConfig{Type: "azurerm", Config: map[string]string{
+			"storage_account_name": account,
+			"container_name":       "tfstate",
+			"key":                  "vpc.tfstate",
+		}}
+	}
+	parser := &fakeDependencyParser{modules: map[string]*model.ParsedModule{
+		app.Path:    {Path: app.Path},
+		local.Path:  {Path: local.Path, Backend: azurerm("local")},
+		shared.Path: {Path: shared.Path, Backend: azurerm("shared")},
+	}}
+	engine := NewEngine(parser, discovery.NewModuleIndex([]*discovery.Module{app, local, shared}))
+	resolver := newRemoteStateTargetResolver(context.Background(), engine, app, map[string]cty.Value{}, map[string]cty.Value{})
+
+	// "vpc.tfstate" path-matches the sibling vpc, whose backend lives in
+	// another storage account than the one the remote state reads.
+	got, method := resolver.Resolve(&model.RemoteStateRef{
+		Name:    "vpc",
+		Backend: "azurerm",
+		Config: map[string]hcl.Expression{
+			"storage_account_name": mustParseExpression(t, `"shared"`),
+			"container_name":       mustParseExpression(t, `"tfstate"`),
+		},
+	}, "vpc.tfstate")
+	if got == nil || got.ID() != shared.ID() || method != model.MatchBackend {
+		t.Fatalf("Resolve() = %v (%s), want %s by backend", got, method, shared.ID())
+	}
+}
+
 type fakeDependencyParser struct {
 	modules map[string]*model.ParsedModule
 	paths   []string
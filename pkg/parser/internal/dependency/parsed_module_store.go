@@ -22,11 +22,13 @@ func newParsedModuleCache(parser ModuleParser) *parsedModuleCache {
 	}
 }
 
+// Get parses module once per directory; modules deploying the same
+// directory to several workspaces share the result.
 func (c *parsedModuleCache) Get(ctx context.Context, module *discovery.Module) (*model.ParsedModule, error) {
-	moduleID := module.ID()
+	key := module.Path
 
 	c.mu.RLock()
-	parsed, ok := c.items[moduleID]
+	parsed, ok := c.items[key]
 	c.mu.RUnlock()
 	if ok {
 		return parsed, nil
@@ -40,10 +42,10 @@ func (c *parsedModuleCache) Get(ctx context.Context, module *discovery.Module) (
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if cached, ok := c.items[moduleID]; ok {
+	if cached, ok := c.items[key]; ok {
 		return cached, nil
 	}
 
-	c.items[moduleID] = parsed
+	c.items[key] = parsed
 	return parsed, nil
 }
@@ -9,6 +9,9 @@ import (
 
 	"github.com/edelwud/terraci/internal/terraform/eval"
 	"github.com/edelwud/terraci/pkg/discovery"
+	terrierrors "github.com/edelwud/terraci/pkg/errors"
+	parserdeps "github.com/edelwud/terraci/pkg/parser/internal/deps"
+	"github.com/edelwud/terraci/pkg/parser/internal/evalctx"
 	"github.com/edelwud/terraci/pkg/parser/internal/exprfast"
 	"github.com/edelwud/terraci/pkg/parser/model"
 )
@@ -16,7 +19,7 @@ import (
 // DependencyTypeRemoteState marks a dependency derived from a
 // terraform_remote_state data source. Reused by tests so the literal stays
 // in one place.
-const DependencyTypeRemoteState = "remote_state"
+const DependencyTypeRemoteState = model.DependencyTypeRemoteState
 
 type dependencySession struct {
 	ctx       context.Context
@@ -36,15 +39,25 @@ type workspaceResolver interface {
 	ResolveWorkspacePath(ref *model.RemoteStateRef, modulePath string, locals, variables map[string]cty.Value) ([]string, error)
 }
 
+// stateLocationResolver is implemented by parsers that also resolve the
+// workspace each remote state reads.
+type stateLocationResolver interface {
+	ResolveStateLocations(ref *model.RemoteStateRef, modulePath string, locals, variables map[string]cty.Value) ([]model.StateLocation, error)
+}
+
 type targetMatcher interface {
-	MatchPathToModule(statePath string, from *discovery.Module) *discovery.Module
-	MatchBackend(ctx context.Context, backendType, bucket, statePath string) *discovery.Module
+	MatchPathToModule(statePath string, from *discovery.Module) (*discovery.Module, model.MatchMethod)
+	MatchWorkspace(module *discovery.Module, workspace string) *discovery.Module
+	MatchBackend(ctx context.Context, backendType, location, statePath string) *discovery.Module
+	BackendLocation(ctx context.Context, module *discovery.Module) (backendType, location string, ok bool)
+	MatchPattern(pattern string) ([]*discovery.Module, error)
 }
 
 type sessionDependencies struct {
 	resolver      workspaceResolver
 	parsedModules parsedModuleStore
 	targets       targetMatcher
+	evalBuilder   evalctx.Builder
 }
 
 func newSessionDependencies(engine *Engine) sessionDependencies {
@@ -52,6 +65,7 @@ func newSessionDependencies(engine *Engine) sessionDependencies {
 		resolver:      engine.parser,
 		parsedModules: engine.cache,
 		targets:       engine,
+		evalBuilder:   engine.evalBuilder,
 	}
 }
 
@@ -75,6 +89,8 @@ func (s *dependencySession) Run() (*ModuleDependencies, error) {
 	s.variables = parsed.Variables
 
 	s.collectRemoteStateDependencies()
+	s.collectDeclaredDependencies()
+	s.collectDataSourceDependencies()
 	s.collectLibraryDependencies()
 
 	return s.builder.Build(), nil
@@ -88,6 +104,75 @@ func (s *dependencySession) collectRemoteStateDependencies() {
 	}
 }
 
+// collectDeclaredDependencies adds an edge to every module matching a
+// terraci:depends_on annotation.
+func (s *dependencySession) collectDeclaredDependencies() {
+	for _, pattern := range s.parsed.DeclaredDependencies {
+		targets, err := s.deps.targets.MatchPattern(pattern)
+		if err != nil {
+			s.builder.AddErrors(fmt.Errorf("terraci:depends_on in %s: %w", s.module.ID(), err))
+			continue
+		}
+		if len(targets) == 0 {
+			s.builder.AddErrors(fmt.Errorf("no module matches terraci:depends_on %q (from %s)", pattern, s.module.ID()))
+			continue
+		}
+		for _, target := range targets {
+			if target == s.module {
+				continue
+			}
+			s.builder.AddDependencies(&Dependency{
+				From: s.module,
+				To:   target,
+				Type: model.DependencyTypeDeclared,
+			})
+		}
+	}
+}
+
+// collectDataSourceDependencies adds an edge to every module matching the
+// pattern a data source rule derives from the data source's attribute.
+// Values the rule's pattern does not match belong to no module and are
+// skipped.
+func (s *dependencySession) collectDataSourceDependencies() {
+	if len(s.parsed.DataSources) == 0 {
+		return
+	}
+
+	evalCtx := s.deps.evalBuilder.Build(s.module.RelativePath, s.locals, s.variables)
+	for _, ref := range s.parsed.DataSources {
+		source := fmt.Sprintf("%s.data.%s.%s", s.module.ID(), ref.Rule.Type, ref.Name)
+		value, ok := evalStringExpr(ref.Expr, evalCtx)
+		if !ok {
+			s.builder.AddErrors(fmt.Errorf("unresolved %s of %s", ref.Rule.Attribute, source))
+			continue
+		}
+		pattern, ok := ref.Rule.ModulePattern(value)
+		if !ok {
+			continue
+		}
+		targets, err := s.deps.targets.MatchPattern(pattern)
+		if err != nil {
+			s.builder.AddErrors(fmt.Errorf("module pattern %q for %s: %w", pattern, source, err))
+			continue
+		}
+		if len(targets) == 0 {
+			s.builder.AddErrors(fmt.Errorf("no module matches %q for %s %q (from %s)", pattern, ref.Rule.Attribute, value, source))
+			continue
+		}
+		for _, target := range targets {
+			if target == s.module {
+				continue
+			}
+			s.builder.AddDependencies(&Dependency{
+				From: s.module,
+				To:   target,
+				Type: model.DependencyTypeDataSource,
+			})
+		}
+	}
+}
+
 func (s *dependencySession) collectLibraryDependencies() {
 	for _, moduleCall := range s.parsed.ModuleCalls {
 		if !moduleCall.IsLocal || moduleCall.ResolvedPath == "" {
@@ -105,35 +190,103 @@ func (s *dependencySession) resolveRemoteStateDependency(remoteState *model.Remo
 	resolution := newRemoteStateResolution()
 	targetResolver := newRemoteStateTargetResolver(s.ctx, s.deps.targets, s.module, s.locals, s.variables)
 
-	paths, err := s.deps.resolver.ResolveWorkspacePath(remoteState, s.module.RelativePath, s.locals, s.variables)
+	locations, err := s.resolveStateLocations(remoteState)
 	if err != nil {
 		resolution.AddError(fmt.Errorf("resolve workspace path for %s.%s: %w", s.module.ID(), remoteState.Name, err))
 		return resolution
 	}
 
-	for _, path := range paths {
+	// A path that fails to resolve because a variable has no value is
+	// reported as that variable rather than as the unresolved path.
+	var unresolved []error
+	checkedVariables := false
+	fail := func(err error) {
+		if !checkedVariables {
+			checkedVariables = true
+			unresolved = s.unresolvedVariableErrors(remoteState)
+			for _, variableErr := range unresolved {
+				resolution.AddError(variableErr)
+			}
+		}
+		if len(unresolved) == 0 {
+			resolution.AddError(err)
+		}
+	}
+
+	for _, location := range locations {
+		path := location.Path
 		if ContainsDynamicPattern(path) {
-			resolution.AddError(fmt.Errorf("unresolved dynamic path %q for %s.%s", path, s.module.ID(), remoteState.Name))
+			fail(fmt.Errorf("unresolved dynamic path %q for %s.%s", path, s.module.ID(), remoteState.Name))
 			continue
 		}
 
-		target := targetResolver.Resolve(remoteState, path)
+		target, method := targetResolver.Resolve(remoteState, path)
 		if target == nil {
-			resolution.AddError(fmt.Errorf("no module for path %q (from %s.%s)", path, s.module.ID(), remoteState.Name))
+			fail(fmt.Errorf("no module for path %q (from %s.%s)", path, s.module.ID(), remoteState.Name))
+			continue
+		}
+		workspaceTarget := s.deps.targets.MatchWorkspace(target, location.Workspace)
+		if workspaceTarget == nil {
+			fail(fmt.Errorf("module %s has no workspace %q (from %s.%s)",
+				target.RelativePath, workspaceLabel(location.Workspace), s.module.ID(), remoteState.Name))
 			continue
 		}
+		target = workspaceTarget
 
 		resolution.AddDependency(&Dependency{
 			From:            s.module,
 			To:              target,
 			Type:            DependencyTypeRemoteState,
 			RemoteStateName: remoteState.Name,
+			Evidence: &model.DependencyEvidence{
+				Range:     remoteState.Range,
+				StatePath: path,
+				Workspace: location.Workspace,
+				Match:     method,
+			},
 		})
 	}
 
 	return resolution
 }
 
+// resolveStateLocations resolves the states a remote state reads. Parsers
+// that cannot resolve workspaces read every state in the default workspace.
+func (s *dependencySession) resolveStateLocations(remoteState *model.RemoteStateRef) ([]model.StateLocation, error) {
+	if resolver, ok := s.deps.resolver.(stateLocationResolver); ok {
+		return resolver.ResolveStateLocations(remoteState, s.module.RelativePath, s.locals, s.variables)
+	}
+	paths, err := s.deps.resolver.ResolveWorkspacePath(remoteState, s.module.RelativePath, s.locals, s.variables)
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]model.StateLocation, 0, len(paths))
+	for _, path := range paths {
+		locations = append(locations, model.StateLocation{Path: path})
+	}
+	return locations, nil
+}
+
+func workspaceLabel(workspace string) string {
+	if workspace == "" {
+		return discovery.DefaultWorkspace
+	}
+	return workspace
+}
+
+func (s *dependencySession) unresolvedVariableErrors(remoteState *model.RemoteStateRef) []error {
+	names := s.unresolvedVariables(remoteState)
+	errs := make([]error, 0, len(names))
+	for _, name := range names {
+		errs = append(errs, &terrierrors.UnresolvedVariableError{
+			Module:      s.module.ID(),
+			RemoteState: remoteState.Name,
+			Variable:    name,
+		})
+	}
+	return errs
+}
+
 type remoteStateTargetResolver struct {
 	ctx       context.Context
 	targets   targetMatcher
@@ -157,32 +310,67 @@ func newRemoteStateTargetResolver(
 	}
 }
 
-func (r *remoteStateTargetResolver) Resolve(remoteState *model.RemoteStateRef, statePath string) *discovery.Module {
-	target := r.targets.MatchPathToModule(statePath, r.module)
-	if target != nil {
-		return target
+// Resolve matches a state path to a module and reports the strategy that
+// matched it. A path match is kept unless the matched module's own backend
+// lives in a different store than the one the remote state reads from;
+// colliding keys across buckets, storage accounts or databases then resolve
+// through the backend index.
+func (r *remoteStateTargetResolver) Resolve(remoteState *model.RemoteStateRef, statePath string) (*discovery.Module, model.MatchMethod) {
+	target, method := r.targets.MatchPathToModule(statePath, r.module)
+	if target != nil && !r.backendConflicts(remoteState, target) {
+		return target, method
 	}
 
-	return r.matchByBackend(remoteState, statePath)
+	if matched := r.matchByBackend(remoteState, statePath); matched != nil {
+		return matched, model.MatchBackend
+	}
+	return target, method
 }
 
 func (r *remoteStateTargetResolver) matchByBackend(remoteState *model.RemoteStateRef, statePath string) *discovery.Module {
-	if remoteState.Backend == "" {
+	location, ok := r.location(remoteState)
+	if !ok {
 		return nil
 	}
 
-	bucketExpr, ok := remoteState.Config["bucket"]
+	return r.targets.MatchBackend(r.ctx, remoteState.Backend, location, statePath)
+}
+
+// backendConflicts reports whether target's own backend is of the remote
+// state's type but in a different location.
+func (r *remoteStateTargetResolver) backendConflicts(remoteState *model.RemoteStateRef, target *discovery.Module) bool {
+	location, ok := r.location(remoteState)
 	if !ok {
-		return nil
+		return false
 	}
+	backendType, targetLocation, ok := r.targets.BackendLocation(r.ctx, target)
+	return ok && backendType == remoteState.Backend && targetLocation != location
+}
 
-	evalCtx := eval.NewContext(r.locals, r.variables, r.module.Path)
-	bucket, ok := evalStringExpr(bucketExpr, evalCtx)
-	if !ok {
-		return nil
+// location evaluates the backend location attributes of a remote state
+// config. ok is false when the backend is unset or a required attribute is
+// missing or dynamic.
+func (r *remoteStateTargetResolver) location(remoteState *model.RemoteStateRef) (string, bool) {
+	if remoteState.Backend == "" {
+		return "", false
 	}
 
-	return r.targets.MatchBackend(r.ctx, remoteState.Backend, bucket, statePath)
+	identity := parserdeps.IdentityFor(remoteState.Backend)
+	values := make(map[string]string, len(identity.Location))
+	var evalCtx *hcl.EvalContext
+	for _, attr := range identity.Location {
+		expr, ok := remoteState.Config[attr]
+		if !ok {
+			continue
+		}
+		if evalCtx == nil {
+			evalCtx = eval.NewContext(r.locals, r.variables, r.module.Path)
+		}
+		if value, ok := evalStringExpr(expr, evalCtx); ok {
+			values[attr] = value
+		}
+	}
+	return identity.LocationKey(values)
 }
 
 func evalStringExpr(expr hcl.Expression, ctx *hcl.EvalContext) (string, bool) {
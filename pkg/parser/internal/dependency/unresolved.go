@@ -0,0 +1,71 @@
+package dependency
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/edelwud/terraci/pkg/parser/model"
+)
+
+// unresolvedVariables returns the variables a remote state config reads,
+// directly or through locals, that have no value in defaults, tfvars or
+// var-files.
+func (s *dependencySession) unresolvedVariables(remoteState *model.RemoteStateRef) []string {
+	localAttrs := s.localAttributes()
+	missing := make(map[string]struct{})
+	visited := make(map[string]bool)
+
+	var walk func(expr hcl.Expression)
+	walk = func(expr hcl.Expression) {
+		for _, traversal := range expr.Variables() {
+			name, ok := traversalAttrName(traversal)
+			if !ok {
+				continue
+			}
+			switch traversal.RootName() {
+			case "var":
+				if _, ok := s.variables[name]; !ok {
+					missing[name] = struct{}{}
+				}
+			case "local":
+				if _, ok := s.locals[name]; ok || visited[name] {
+					continue
+				}
+				visited[name] = true
+				if attr, ok := localAttrs[name]; ok {
+					walk(attr.Expr)
+				}
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(remoteState.Config)) {
+		walk(remoteState.Config[name])
+	}
+	if remoteState.ForEach != nil {
+		walk(remoteState.ForEach)
+	}
+	return slices.Sorted(maps.Keys(missing))
+}
+
+func (s *dependencySession) localAttributes() hcl.Attributes {
+	attrs := make(hcl.Attributes)
+	for _, block := range s.parsed.TopLevelBlocks()["locals"] {
+		blockAttrs, _ := block.Body.JustAttributes() //nolint:errcheck // diagnostics were reported at parse time
+		maps.Copy(attrs, blockAttrs)
+	}
+	return attrs
+}
+
+func traversalAttrName(traversal hcl.Traversal) (string, bool) {
+	if len(traversal) < 2 {
+		return "", false
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
@@ -0,0 +1,50 @@
+package deps
+
+import "strings"
+
+// BackendIdentity describes where a backend type keeps a state: Location
+// attributes identify the store (bucket, storage account and container,
+// database) and Path names the attribute holding the state path inside it.
+type BackendIdentity struct {
+	Location []string
+	Path     string
+	// LocationOptional marks backends whose location usually comes from the
+	// environment (CONSUL_HTTP_ADDR, PG_CONN_STR). An absent location then
+	// matches an absent location instead of disabling backend matching.
+	LocationOptional bool
+}
+
+var backendIdentities = map[string]BackendIdentity{
+	"s3":      {Location: []string{"bucket"}, Path: "key"},
+	"gcs":     {Location: []string{"bucket"}, Path: "prefix"},
+	"azurerm": {Location: []string{"storage_account_name", "container_name"}, Path: "key"},
+	"consul":  {Location: []string{"address"}, Path: "path", LocationOptional: true},
+	"http":    {Path: "address"},
+	"pg":      {Location: []string{"conn_str"}, Path: "schema_name", LocationOptional: true},
+}
+
+// defaultBackendIdentity covers bucket-style backends without a dedicated
+// entry (oss, cos, ...).
+var defaultBackendIdentity = BackendIdentity{Location: []string{"bucket"}, Path: "key"}
+
+// IdentityFor returns the state identity of a backend type.
+func IdentityFor(backendType string) BackendIdentity {
+	if identity, ok := backendIdentities[backendType]; ok {
+		return identity
+	}
+	return defaultBackendIdentity
+}
+
+// LocationKey joins the location attribute values into one key. ok is false
+// when a location attribute is missing and the backend requires it.
+func (b BackendIdentity) LocationKey(values map[string]string) (string, bool) {
+	parts := make([]string, 0, len(b.Location))
+	for _, attr := range b.Location {
+		value := values[attr]
+		if value == "" && !b.LocationOptional {
+			return "", false
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "/"), true
+}
@@ -5,29 +5,35 @@ import (
 	"strings"
 
 	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser/model"
 )
 
-func MatchPathToModule(index *discovery.ModuleIndex, statePath string, from *discovery.Module) *discovery.Module {
+// MatchPathToModule returns the module that owns the state at statePath
+// and the strategy that matched it.
+func MatchPathToModule(index *discovery.ModuleIndex, statePath string, from *discovery.Module) (*discovery.Module, model.MatchMethod) {
 	normalized := NormalizeStatePath(statePath)
 	parts := strings.Split(normalized, "/")
 
-	strategies := []func() *discovery.Module{
-		func() *discovery.Module { return index.ByID(normalized) },
-		func() *discovery.Module {
-			return index.ByID(strings.ReplaceAll(normalized, "/", string(filepath.Separator)))
-		},
-		func() *discovery.Module { return tryTrailingMatch(index, parts, 5) },
-		func() *discovery.Module { return tryTrailingMatch(index, parts, 4) },
-		func() *discovery.Module { return tryContextMatch(index, parts, from) },
+	strategies := []struct {
+		method model.MatchMethod
+		match  func() *discovery.Module
+	}{
+		{model.MatchPath, func() *discovery.Module { return moduleAt(index, normalized) }},
+		{model.MatchPath, func() *discovery.Module {
+			return moduleAt(index, strings.ReplaceAll(normalized, "/", string(filepath.Separator)))
+		}},
+		{model.MatchTrailing, func() *discovery.Module { return tryTrailingMatch(index, parts, 5) }},
+		{model.MatchTrailing, func() *discovery.Module { return tryTrailingMatch(index, parts, 4) }},
+		{model.MatchContext, func() *discovery.Module { return tryContextMatch(index, parts, from) }},
 	}
 
 	for _, strategy := range strategies {
-		if module := strategy(); module != nil {
-			return module
+		if module := strategy.match(); module != nil {
+			return module, strategy.method
 		}
 	}
 
-	return nil
+	return nil, ""
 }
 
 func NormalizeStatePath(path string) string {
@@ -44,54 +50,78 @@ func ContainsDynamicPattern(path string) bool {
 		strings.Contains(path, "\"}")
 }
 
-func BackendIndexKey(backendType, bucket, stateKey, modulePath string) string {
-	if bucket == "" {
-		return ""
-	}
+// BackendIndexKey builds the backend index key of a state. location is the
+// BackendIdentity.LocationKey of the backend; an empty stateKey falls back to
+// the module path.
+func BackendIndexKey(backendType, location, stateKey, modulePath string) string {
 	if stateKey != "" {
 		stateKey = NormalizeStatePath(stateKey)
 	} else {
 		stateKey = modulePath
 	}
-	return backendType + ":" + bucket + ":" + stateKey
+	return backendType + ":" + location + ":" + stateKey
 }
 
+// MatchByBackend looks up the module that owns the state at statePath in the
+// backend store identified by backendType and location.
 func MatchByBackend(
 	backendIndex map[string]*discovery.Module,
-	backendType, bucket, statePath string,
+	backendType, location, statePath string,
 ) *discovery.Module {
-	if len(backendIndex) == 0 || backendType == "" || bucket == "" {
+	if len(backendIndex) == 0 || backendType == "" || statePath == "" {
 		return nil
 	}
-
-	key := backendType + ":" + bucket + ":" + NormalizeStatePath(statePath)
-	return backendIndex[key]
+	return backendIndex[BackendIndexKey(backendType, location, statePath, "")]
 }
 
 func tryTrailingMatch(index *discovery.ModuleIndex, parts []string, n int) *discovery.Module {
 	if len(parts) < n {
 		return nil
 	}
-	return index.ByID(strings.Join(parts[len(parts)-n:], "/"))
+	return moduleAt(index, strings.Join(parts[len(parts)-n:], "/"))
 }
 
 func tryContextMatch(index *discovery.ModuleIndex, parts []string, from *discovery.Module) *discovery.Module {
 	prefix := from.ContextPrefix()
 
 	if len(parts) == 1 {
-		if module := index.ByID(prefix + "/" + parts[0]); module != nil {
+		if module := moduleAt(index, prefix+"/"+parts[0]); module != nil {
 			return module
 		}
 		if from.IsSubmodule() {
-			if module := index.ByID(prefix + "/" + from.LeafValue() + "/" + parts[0]); module != nil {
+			if module := moduleAt(index, prefix+"/"+from.LeafValue()+"/"+parts[0]); module != nil {
 				return module
 			}
 		}
 	}
 
 	if len(parts) == 2 {
-		return index.ByID(prefix + "/" + parts[0] + "/" + parts[1])
+		return moduleAt(index, prefix+"/"+parts[0]+"/"+parts[1])
 	}
 
 	return nil
 }
+
+// moduleAt returns the module with id, or the first module of the directory
+// id when it is only deployed to named workspaces.
+func moduleAt(index *discovery.ModuleIndex, id string) *discovery.Module {
+	if module := index.ByID(id); module != nil {
+		return module
+	}
+	return index.ByPath(id)
+}
+
+// MatchWorkspace returns the module of module's directory that targets
+// workspace ("" for the default workspace), or nil when the directory is
+// not deployed to it.
+func MatchWorkspace(index *discovery.ModuleIndex, module *discovery.Module, workspace string) *discovery.Module {
+	if module.Workspace == workspace {
+		return module
+	}
+	for _, candidate := range index.AllByPath(module.RelativePath) {
+		if candidate.Workspace == workspace {
+			return candidate
+		}
+	}
+	return nil
+}
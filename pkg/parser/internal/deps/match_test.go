@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser/model"
 )
 
 func TestMatchPathToModule(t *testing.T) {
@@ -25,22 +26,26 @@ func TestMatchPathToModule(t *testing.T) {
 		statePath string
 		from      *discovery.Module
 		wantID    string
+		method    model.MatchMethod
 	}{
-		{"full path with tfstate", "platform/stage/eu-central-1/vpc/terraform.tfstate", modules[1], "platform/stage/eu-central-1/vpc"},
-		{"short context match", "vpc", modules[1], "platform/stage/eu-central-1/vpc"},
-		{"submodule path", "platform/stage/eu-central-1/ec2/rabbitmq/terraform.tfstate", modules[0], "platform/stage/eu-central-1/ec2/rabbitmq"},
-		{"env prefix", "env:/stage/platform/stage/eu-central-1/vpc/terraform.tfstate", modules[1], "platform/stage/eu-central-1/vpc"},
+		{"full path with tfstate", "platform/stage/eu-central-1/vpc/terraform.tfstate", modules[1], "platform/stage/eu-central-1/vpc", model.MatchPath},
+		{"short context match", "vpc", modules[1], "platform/stage/eu-central-1/vpc", model.MatchContext},
+		{"submodule path", "platform/stage/eu-central-1/ec2/rabbitmq/terraform.tfstate", modules[0], "platform/stage/eu-central-1/ec2/rabbitmq", model.MatchPath},
+		{"env prefix", "env:/stage/platform/stage/eu-central-1/vpc/terraform.tfstate", modules[1], "platform/stage/eu-central-1/vpc", model.MatchTrailing},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := MatchPathToModule(index, tt.statePath, tt.from)
+			got, method := MatchPathToModule(index, tt.statePath, tt.from)
 			if got == nil || got.ID() != tt.wantID {
 				if got == nil {
 					t.Fatalf("got nil, want %s", tt.wantID)
 				}
 				t.Fatalf("got %s, want %s", got.ID(), tt.wantID)
 			}
+			if method != tt.method {
+				t.Errorf("method = %q, want %q", method, tt.method)
+			}
 		})
 	}
 }
@@ -78,3 +83,29 @@ func TestBackendIndexKeyAndMatchByBackend(t *testing.T) {
 		t.Fatalf("got %s, want %s", got.ID(), target.ID())
 	}
 }
+
+func TestBackendIdentityLocationKey(t *testing.T) {
+	tests := []struct {
+		backend string
+		values  map[string]string
+		want    string
+		wantOK  bool
+	}{
+		{"s3", map[string]string{"bucket": "state"}, "state", true},
+		{"s3", map[string]string{}, "", false},
+		{"azurerm", map[string]string{"storage_account_name": "acct", "container_name": "tfstate"}, "acct/tfstate", true},
+		{"azurerm", map[string]string{"storage_account_name": "acct"}, "", false},
+		{"consul", map[string]string{}, "", true},
+		{"pg", map[string]string{"conn_str": "postgres://db"}, "postgres://db", true},
+		{"http", map[string]string{}, "", true},
+		{"oss", map[string]string{"bucket": "state"}, "state", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			got, ok := IdentityFor(tt.backend).LocationKey(tt.values)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("LocationKey() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
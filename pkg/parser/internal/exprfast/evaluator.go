@@ -1,9 +1,6 @@
 package exprfast
 
-import (
-	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
-)
+import "github.com/hashicorp/hcl/v2"
 
 type Evaluator struct {
 	ctx *hcl.EvalContext
@@ -34,19 +31,26 @@ func (e Evaluator) ContentAttr(content *hcl.BodyContent, name string) (string, b
 	return e.Attr(content.Attributes, name)
 }
 
-func (e Evaluator) ObjectStringAttrs(objExpr *hclsyntax.ObjectConsExpr) map[string]string {
+// ObjectStringAttrs evaluates the string-valued items of an object
+// constructor. Native object expressions and HCL JSON objects are both
+// supported; other expressions yield an empty map.
+func (e Evaluator) ObjectStringAttrs(objExpr hcl.Expression) map[string]string {
 	values := make(map[string]string)
 	if objExpr == nil {
 		return values
 	}
 
-	for _, item := range objExpr.Items {
-		key, ok := New(nil).String(item.KeyExpr)
+	items, diags := hcl.ExprMap(objExpr)
+	if diags.HasErrors() {
+		return values
+	}
+	for _, item := range items {
+		key, ok := New(nil).String(item.Key)
 		if !ok {
 			continue
 		}
 
-		value, ok := e.String(item.ValueExpr)
+		value, ok := e.String(item.Value)
 		if !ok {
 			continue
 		}
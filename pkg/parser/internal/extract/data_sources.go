@@ -0,0 +1,33 @@
+package extract
+
+import "github.com/hashicorp/hcl/v2"
+
+// extractDataSources records the data sources matched by the configured
+// rules together with the attribute expression naming their producer.
+func extractDataSources(ctx *Context) {
+	if len(ctx.DataSources) == 0 {
+		return
+	}
+
+	for _, block := range ctx.Source.DataBlocks() {
+		if len(block.Labels) < 2 {
+			continue
+		}
+		for _, rule := range ctx.DataSources {
+			if rule.Type != block.Labels[0] {
+				continue
+			}
+			content, _, _ := block.Body.PartialContent(&hcl.BodySchema{
+				Attributes: []hcl.AttributeSchema{{Name: rule.Attribute}},
+			})
+			if content == nil || content.Attributes[rule.Attribute] == nil {
+				continue
+			}
+			ctx.Sink.AppendDataSource(DataSourceRef{
+				Name: block.Labels[1],
+				Rule: rule,
+				Expr: content.Attributes[rule.Attribute].Expr,
+			})
+		}
+	}
+}
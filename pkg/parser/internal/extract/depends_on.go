@@ -0,0 +1,29 @@
+package extract
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// dependsOnAnnotation matches a whole-line comment such as
+// "# terraci:depends_on=platform/prod/*/iam, platform/prod/*/kms".
+var dependsOnAnnotation = regexp.MustCompile(`(?m)^[ \t]*(?:#|//)[ \t]*terraci:depends_on[ \t]*=[ \t]*(.*?)[ \t]*\r?$`)
+
+func extractDependsOnAnnotations(ctx *Context) {
+	files := ctx.Source.SharedFiles()
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		file := files[name]
+		if file == nil || strings.HasSuffix(name, ".json") {
+			continue
+		}
+		for _, match := range dependsOnAnnotation.FindAllSubmatch(file.Bytes, -1) {
+			for pattern := range strings.SplitSeq(string(match[1]), ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					ctx.Sink.AppendDeclaredDependency(pattern)
+				}
+			}
+		}
+	}
+}
@@ -2,7 +2,6 @@ package extract
 
 import (
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/edelwud/terraci/pkg/parser/internal/exprfast"
@@ -16,7 +15,7 @@ func evalStringAttr(attrs map[string]*hcl.Attribute, name string, ctx *hcl.EvalC
 	return exprfast.New(ctx).Attr(attrs, name)
 }
 
-func evalObjectStringAttrs(objExpr *hclsyntax.ObjectConsExpr, ctx *hcl.EvalContext) map[string]string {
+func evalObjectStringAttrs(objExpr hcl.Expression, ctx *hcl.EvalContext) map[string]string {
 	return exprfast.New(ctx).ObjectStringAttrs(objExpr)
 }
 
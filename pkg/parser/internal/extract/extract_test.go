@@ -2,6 +2,9 @@ package extract
 
 import (
 	"context"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
@@ -21,6 +24,8 @@ type testSink struct {
 	lockedProviders   []LockedProvider
 	remoteStates      []RemoteStateRef
 	moduleCalls       []ModuleCall
+	declared          []string
+	dataSources       []DataSourceRef
 	diagnostics       hcl.Diagnostics
 }
 
@@ -53,6 +58,12 @@ func (s *testSink) AppendRemoteState(ref RemoteStateRef) {
 	s.remoteStates = append(s.remoteStates, ref)
 }
 func (s *testSink) AppendModuleCall(call ModuleCall) { s.moduleCalls = append(s.moduleCalls, call) }
+func (s *testSink) AppendDeclaredDependency(pattern string) {
+	s.declared = append(s.declared, pattern)
+}
+func (s *testSink) AppendDataSource(ref DataSourceRef) {
+	s.dataSources = append(s.dataSources, ref)
+}
 
 func TestRunDefault_ExtractsModuleFacts(t *testing.T) {
 	dir := t.TempDir()
@@ -152,3 +163,210 @@ module "vpc" {
 		t.Fatal("expected local module call")
 	}
 }
+
+func TestRunDefault_TfvarsPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "variables.tf", `
+variable "a" { default = "default" }
+variable "b" { default = "default" }
+variable "c" { default = "default" }
+variable "d" { default = "default" }
+variable "e" { default = "default" }
+
+locals {
+  state_key = "${var.e}/terraform.tfstate"
+}
+`)
+	testutil.WriteFile(t, dir, "terraform.tfvars", "a = \"tfvars\"\nb = \"tfvars\"\nc = \"tfvars\"\nd = \"tfvars\"\ne = \"tfvars\"\n")
+	testutil.WriteFile(t, dir, "terraform.tfvars.json", `{"b": "tfvars.json", "c": "tfvars.json", "d": "tfvars.json", "e": "tfvars.json"}`)
+	testutil.WriteFile(t, dir, "a.auto.tfvars", "c = \"a.auto\"\nd = \"a.auto\"\ne = \"a.auto\"\n")
+	testutil.WriteFile(t, dir, "b.auto.tfvars.json", `{"d": "b.auto.json", "e": "b.auto.json"}`)
+	testutil.WriteFile(t, dir, "prod.vars", "e = \"var-file\"\n")
+
+	index, err := source.NewLoader().Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	sink := newTestSink(dir)
+	RunDefault(&Context{
+		Source:      index,
+		EvalBuilder: evalctx.NewBuilder([]string{"service", "environment", "region", "module"}),
+		Sink:        sink,
+		VarFiles:    []string{"prod.vars"},
+	})
+
+	want := map[string]string{"a": "tfvars", "b": "tfvars.json", "c": "a.auto", "d": "b.auto.json", "e": "var-file"}
+	for name, value := range want {
+		if got := sink.variables[name].AsString(); got != value {
+			t.Errorf("variable %s = %q, want %q", name, got, value)
+		}
+	}
+	if got := sink.locals["state_key"].AsString(); got != "var-file/terraform.tfstate" {
+		t.Errorf("local state_key = %q, want it built from the var-file value", got)
+	}
+}
+
+func TestRunDefault_DependsOnAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "main.tf", `
+# terraci:depends_on=platform/prod/*/iam, platform/prod/*/kms
+resource "aws_ssm_parameter" "endpoint" {
+  name = "/app/endpoint" # terraci:depends_on=ignored/inline
+}
+
+  // terraci:depends_on = platform/prod/*/dns
+`)
+	testutil.WriteFile(t, dir, "extra.tf.json", `{"locals": {"note": "# terraci:depends_on=ignored/json"}}`)
+
+	index, err := source.NewLoader().Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	sink := newTestSink(dir)
+	RunDefault(&Context{
+		Source:      index,
+		EvalBuilder: evalctx.NewBuilder([]string{"service", "environment", "region", "module"}),
+		Sink:        sink,
+	})
+
+	want := []string{"platform/prod/*/iam", "platform/prod/*/kms", "platform/prod/*/dns"}
+	if !slices.Equal(sink.declared, want) {
+		t.Fatalf("declared dependencies = %v, want %v", sink.declared, want)
+	}
+}
+
+func TestRunDefault_DataSources(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "main.tf", `
+data "aws_ssm_parameter" "vpc_id" {
+  name = "/platform/${var.environment}/eu-central-1/vpc/vpc_id"
+}
+
+data "aws_ssm_parameter" "unnamed" {
+  with_decryption = true
+}
+
+data "aws_ami" "ubuntu" {
+  name_regex = "ubuntu"
+}
+
+data "tfe_outputs" "network" {
+  workspace = "network"
+}
+`)
+
+	index, err := source.NewLoader().Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	sink := newTestSink(dir)
+	RunDefault(&Context{
+		Source:      index,
+		EvalBuilder: evalctx.NewBuilder([]string{"service", "environment", "region", "module"}),
+		Sink:        sink,
+		DataSources: []DataSourceRule{
+			{
+				Type:      "aws_ssm_parameter",
+				Attribute: "name",
+				Pattern:   regexp.MustCompile(`^/(?P<id>[^/]+/[^/]+/[^/]+/[^/]+)/`),
+				Module:    "{id}",
+			},
+			{Type: "tfe_outputs", Attribute: "workspace", Module: "platform/*/*/{value}"},
+		},
+	})
+
+	got := make([]string, 0, len(sink.dataSources))
+	for _, ref := range sink.dataSources {
+		if ref.Expr == nil {
+			t.Errorf("data source %s has no attribute expression", ref.Name)
+		}
+		got = append(got, ref.Rule.Type+"."+ref.Name)
+	}
+	want := []string{"aws_ssm_parameter.vpc_id", "tfe_outputs.network"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("data sources = %v, want %v", got, want)
+	}
+}
+
+func TestRunDefault_EarlyEvaluation(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFile(t, dir, "main.tf", `
+variable "env" {
+  default = "prod"
+}
+
+variable "passphrase" {}
+
+locals {
+  modules = "../../modules"
+}
+
+terraform {
+  backend "s3" {
+    bucket = "state"
+    key    = "${var.env}/vpc/terraform.tfstate"
+  }
+
+  encryption {
+    key_provider "pbkdf2" "main" {
+      passphrase = var.passphrase
+    }
+    method "aes_gcm" "main" {
+      keys = key_provider.pbkdf2.main
+    }
+    state {
+      method = method.aes_gcm.main
+    }
+  }
+}
+
+module "net" {
+  source  = "${local.modules}/net"
+}
+
+module "registry" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> ${var.env == "prod" ? "5.0" : "4.0"}"
+}
+`)
+
+	index, err := source.NewLoader().Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	run := func(early bool) *testSink {
+		t.Helper()
+		sink := newTestSink(dir)
+		RunDefault(&Context{
+			Source:          index,
+			EvalBuilder:     evalctx.NewBuilder([]string{"service", "environment", "region", "module"}),
+			Sink:            sink,
+			EarlyEvaluation: early,
+		})
+		return sink
+	}
+
+	literal := run(false)
+	if literal.moduleCalls[0].Source != "" || literal.moduleCalls[1].Version != "" {
+		t.Errorf("module calls without early evaluation = %+v, want only literals", literal.moduleCalls)
+	}
+
+	sink := run(true)
+	if len(sink.diagnostics) > 0 {
+		t.Errorf("diagnostics = %v, want none for encryption blocks", sink.diagnostics)
+	}
+	if sink.backend == nil || sink.backend.Config["key"] != "prod/vpc/terraform.tfstate" {
+		t.Errorf("backend = %+v", sink.backend)
+	}
+	net := sink.moduleCalls[0]
+	if !net.IsLocal || net.ResolvedPath != filepath.Clean(filepath.Join(dir, "../../modules/net")) {
+		t.Errorf("net module call = %+v, want resolved local source", net)
+	}
+	if got := sink.moduleCalls[1].Version; got != "~> 5.0" {
+		t.Errorf("registry version = %q, want ~> 5.0", got)
+	}
+}
@@ -4,25 +4,38 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
+
 	"github.com/edelwud/terraci/pkg/parser/internal/source"
 )
 
 func extractModuleCalls(ctx *Context) {
-	for _, module := range ctx.Source.ModuleBlockViews() {
+	views := ctx.Source.ModuleBlockViews()
+	if len(views) == 0 {
+		return
+	}
+
+	// Terraform only accepts literal sources; OpenTofu's early evaluation
+	// also resolves variables and locals.
+	var evalCtx *hcl.EvalContext
+	if ctx.EarlyEvaluation {
+		evalCtx = ctx.buildEvalContext()
+	}
+	for _, module := range views {
 		call := ModuleCall{Name: module.Name()}
-		parseModuleBlock(ctx, module, &call)
+		parseModuleBlock(ctx, module, evalCtx, &call)
 		ctx.Sink.AppendModuleCall(call)
 	}
 }
 
-func parseModuleBlock(ctx *Context, view source.ModuleBlockView, call *ModuleCall) {
+func parseModuleBlock(ctx *Context, view source.ModuleBlockView, evalCtx *hcl.EvalContext, call *ModuleCall) {
 	content, diags := view.Content()
 	ctx.Sink.AddDiags(diags)
 	if content == nil {
 		return
 	}
 
-	if src, ok := evalContentStringAttr(content, "source"); ok {
+	if src, ok := evalStringAttr(content.Attributes, "source", evalCtx); ok {
 		call.Source = src
 		if strings.HasPrefix(src, "./") || strings.HasPrefix(src, "../") {
 			call.IsLocal = true
@@ -30,7 +43,7 @@ func parseModuleBlock(ctx *Context, view source.ModuleBlockView, call *ModuleCal
 		}
 	}
 
-	if ver, ok := evalContentStringAttr(content, "version"); ok {
+	if ver, ok := evalStringAttr(content.Attributes, "version", evalCtx); ok {
 		call.Version = ver
 	}
 }
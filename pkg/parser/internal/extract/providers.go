@@ -1,7 +1,7 @@
 package extract
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -22,9 +22,7 @@ func extractRequiredProviders(ctx *Context) {
 					continue
 				}
 
-				if objExpr, isObj := attr.Expr.(*hclsyntax.ObjectConsExpr); isObj {
-					fillRequiredProviderFromObject(&rp, objExpr)
-				}
+				fillRequiredProviderFromObject(&rp, attr.Expr)
 
 				ctx.Sink.AppendRequiredProvider(rp)
 			}
@@ -32,7 +30,7 @@ func extractRequiredProviders(ctx *Context) {
 	}
 }
 
-func fillRequiredProviderFromObject(rp *RequiredProvider, objExpr *hclsyntax.ObjectConsExpr) {
+func fillRequiredProviderFromObject(rp *RequiredProvider, objExpr hcl.Expression) {
 	values := evalObjectStringAttrs(objExpr, nil)
 	if source, ok := values["source"]; ok {
 		rp.Source = source
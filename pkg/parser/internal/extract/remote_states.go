@@ -12,6 +12,7 @@ func extractRemoteStates(ctx *Context) {
 			Name:    remoteState.Name(),
 			Config:  make(map[string]hcl.Expression),
 			RawBody: remoteState.RawBody(),
+			Range:   remoteState.Range(),
 		}
 		parseRemoteStateBlock(ctx, remoteState, &ref)
 		ctx.Sink.AppendRemoteState(ref)
@@ -31,6 +32,9 @@ func parseRemoteStateBlock(ctx *Context, view source.RemoteStateBlockView, ref *
 	if attr, ok := content.Attributes["for_each"]; ok {
 		ref.ForEach = attr.Expr
 	}
+	if attr, ok := content.Attributes["workspace"]; ok {
+		ref.Workspace = attr.Expr
+	}
 
 	if _, ok := content.Attributes["config"]; ok {
 		view.AppendInlineConfigExpressions(content, ref.Config)
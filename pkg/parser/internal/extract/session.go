@@ -16,12 +16,14 @@ func (s *session) Run() {
 
 func (s *session) pipeline() []extractorStep {
 	return []extractorStep{
-		extractLocals,
 		extractTfvars,
+		extractLocals,
 		extractBackendConfig,
 		extractRequiredProviders,
 		extractLockFile,
 		extractRemoteStates,
 		extractModuleCalls,
+		extractDependsOnAnnotations,
+		extractDataSources,
 	}
 }
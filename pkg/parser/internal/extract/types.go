@@ -21,6 +21,8 @@ type Sink interface {
 	AppendLockedProvider(LockedProvider)
 	AppendRemoteState(RemoteStateRef)
 	AppendModuleCall(ModuleCall)
+	AppendDeclaredDependency(pattern string)
+	AppendDataSource(DataSourceRef)
 }
 
 type Source interface {
@@ -29,14 +31,25 @@ type Source interface {
 	TerraformBlockViews() []source.TerraformBlockView
 	RemoteStateBlockViews() []source.RemoteStateBlockView
 	ModuleBlockViews() []source.ModuleBlockView
+	DataBlocks() []*hcl.Block
 	LockFile() (*hcl.File, hcl.Diagnostics)
 	ParseHCLFile(path string) (*hcl.File, hcl.Diagnostics, error)
+	SharedFiles() map[string]*hcl.File
 }
 
 type Context struct {
 	Source      Source
 	EvalBuilder evalctx.Builder
 	Sink        Sink
+	// VarFiles are extra tfvars files applied after the auto-loaded ones;
+	// relative paths are resolved against the module directory.
+	VarFiles []string
+	// DataSources are the rules turning data sources into dependencies.
+	DataSources []DataSourceRule
+	// EarlyEvaluation evaluates module source and version arguments with
+	// variables and locals, like OpenTofu 1.8+; otherwise only literals are
+	// read, as Terraform requires.
+	EarlyEvaluation bool
 }
 
 type BackendConfig = model.BackendConfig
@@ -48,3 +61,7 @@ type LockedProvider = model.LockedProvider
 type RemoteStateRef = model.RemoteStateRef
 
 type ModuleCall = model.ModuleCall
+
+type DataSourceRule = model.DataSourceRule
+
+type DataSourceRef = model.DataSourceRef
@@ -2,19 +2,42 @@ package extract
 
 import (
 	"path/filepath"
+	"slices"
 )
 
+// extractTfvars fills variables in Terraform's precedence order, later
+// sources overriding earlier ones: defaults, terraform.tfvars,
+// terraform.tfvars.json, *.auto.tfvars and *.auto.tfvars.json in lexical
+// order, then the configured var-files in the order given.
 func extractTfvars(ctx *Context) {
 	extractVariableDefaults(ctx)
 
-	if tfvars := filepath.Join(ctx.Sink.Path(), "terraform.tfvars"); fileExists(tfvars) {
-		loadTfvarsFile(ctx, tfvars)
+	for _, path := range tfvarsFiles(ctx.Sink.Path(), ctx.VarFiles) {
+		loadTfvarsFile(ctx, path)
 	}
+}
 
-	autoFiles, _ := filepath.Glob(filepath.Join(ctx.Sink.Path(), "*.auto.tfvars")) //nolint:errcheck
-	for _, path := range autoFiles {
-		loadTfvarsFile(ctx, path)
+func tfvarsFiles(dir string, varFiles []string) []string {
+	var files []string
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		if path := filepath.Join(dir, name); fileExists(path) {
+			files = append(files, path)
+		}
+	}
+
+	autoFiles, _ := filepath.Glob(filepath.Join(dir, "*.auto.tfvars"))     //nolint:errcheck
+	autoJSON, _ := filepath.Glob(filepath.Join(dir, "*.auto.tfvars.json")) //nolint:errcheck
+	autoFiles = append(autoFiles, autoJSON...)
+	slices.Sort(autoFiles)
+	files = append(files, autoFiles...)
+
+	for _, path := range varFiles {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		files = append(files, path)
 	}
+	return files
 }
 
 func extractVariableDefaults(ctx *Context) {
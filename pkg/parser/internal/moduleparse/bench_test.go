@@ -25,7 +25,7 @@ func BenchmarkRun(b *testing.B) {
 		b.Run(tc.name, func(b *testing.B) {
 			b.ReportAllocs()
 			for b.Loop() {
-				parsed, err := Run(context.Background(), dir, []string{"service", "environment", "region", "module"})
+				parsed, err := Run(context.Background(), dir, []string{"service", "environment", "region", "module"}, Options{})
 				if err != nil {
 					b.Fatalf("Run() error = %v", err)
 				}
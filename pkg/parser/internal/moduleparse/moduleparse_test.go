@@ -23,7 +23,7 @@ data "terraform_remote_state" "vpc" {
 `)
 	writeModuleFile(t, dir, "module.tf", `module "vpc" { source = "../_modules/vpc" }`)
 
-	parsed, err := Run(context.Background(), dir, []string{"service", "environment", "region", "module"})
+	parsed, err := Run(context.Background(), dir, []string{"service", "environment", "region", "module"}, Options{})
 	if err != nil {
 		t.Fatalf("Run() error = %v", err)
 	}
@@ -97,6 +97,10 @@ func (s diagnosticSource) ModuleBlockViews() []source.ModuleBlockView {
 	return nil
 }
 
+func (s diagnosticSource) DataBlocks() []*hcl.Block {
+	return nil
+}
+
 func (s diagnosticSource) LockFile() (*hcl.File, hcl.Diagnostics) {
 	return nil, nil
 }
@@ -6,6 +6,23 @@ import (
 	"github.com/edelwud/terraci/pkg/parser/model"
 )
 
-func Run(ctx context.Context, modulePath string, segments []string) (*model.ParsedModule, error) {
-	return newRunner(modulePath, segments).Run(ctx)
+// Options are the per-module inputs of Run beyond the module path.
+type Options struct {
+	// VarFiles are loaded after the auto-loaded tfvars files, like
+	// terraform -var-file.
+	VarFiles []string
+	// DataSources are the rules turning data sources into dependencies.
+	DataSources []model.DataSourceRule
+	// EarlyEvaluation evaluates module sources against variables and
+	// locals, like OpenTofu's early evaluation.
+	EarlyEvaluation bool
+}
+
+// Run parses the module at modulePath.
+func Run(ctx context.Context, modulePath string, segments []string, opts Options) (*model.ParsedModule, error) {
+	r := newRunner(modulePath, segments)
+	r.extractCtx.VarFiles = opts.VarFiles
+	r.extractCtx.DataSources = opts.DataSources
+	r.extractCtx.EarlyEvaluation = opts.EarlyEvaluation
+	return r.Run(ctx)
 }
@@ -14,7 +14,6 @@ import (
 
 type loadedSource interface {
 	extract.Source
-	SharedFiles() map[string]*hcl.File
 	SharedDiagnostics() hcl.Diagnostics
 	SharedTopLevelBlockIndex() map[string][]*hcl.Block
 }
@@ -105,6 +104,14 @@ func (s *parsedModuleSink) AppendModuleCall(call extract.ModuleCall) {
 	s.parsed.ModuleCalls = append(s.parsed.ModuleCalls, &call)
 }
 
+func (s *parsedModuleSink) AppendDeclaredDependency(pattern string) {
+	s.parsed.DeclaredDependencies = append(s.parsed.DeclaredDependencies, pattern)
+}
+
+func (s *parsedModuleSink) AppendDataSource(ref extract.DataSourceRef) {
+	s.parsed.DataSources = append(s.parsed.DataSources, &ref)
+}
+
 func (r *runner) Run(ctx context.Context) (*model.ParsedModule, error) {
 	if err := r.load(ctx); err != nil {
 		return nil, err
@@ -0,0 +1,118 @@
+// Package outputs finds the remote state outputs a module reads and the
+// outputs it declares, for checking contracts between modules.
+package outputs
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/edelwud/terraci/pkg/parser/model"
+)
+
+// Reference is a read of data.terraform_remote_state.<RemoteState>.outputs.<Output>.
+type Reference = model.OutputReference
+
+// References returns the remote state output reads in native HCL files,
+// ordered by file and position. JSON files are not inspected.
+func References(files map[string]*hcl.File) []Reference {
+	var refs []Reference
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		// Attributes are visited in map order; sort each file by position.
+		var fileRefs []Reference
+		_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if ref, ok := reference(node); ok {
+				fileRefs = append(fileRefs, ref)
+			}
+			return nil
+		})
+		slices.SortFunc(fileRefs, func(a, b Reference) int {
+			return a.Range.Start.Byte - b.Range.Start.Byte
+		})
+		refs = append(refs, fileRefs...)
+	}
+	return refs
+}
+
+// Declared returns the names of the output blocks among blocks.
+func Declared(blocks []*hcl.Block) map[string]bool {
+	names := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		if len(block.Labels) > 0 {
+			names[block.Labels[0]] = true
+		}
+	}
+	return names
+}
+
+// reference matches data.terraform_remote_state.x.outputs.y, with an
+// optional literal instance key after x, and the for_each form
+// data.terraform_remote_state.x[each.key].outputs.y, which HCL parses as a
+// relative traversal of an index expression.
+func reference(node hclsyntax.Node) (Reference, bool) {
+	switch expr := node.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		name, rest, ok := remoteStateRoot(expr.Traversal)
+		if !ok {
+			return Reference{}, false
+		}
+		if len(rest) > 0 {
+			if _, isIndex := rest[0].(hcl.TraverseIndex); isIndex {
+				rest = rest[1:]
+			}
+		}
+		return outputReference(name, rest, expr.SrcRange)
+	case *hclsyntax.RelativeTraversalExpr:
+		index, ok := expr.Source.(*hclsyntax.IndexExpr)
+		if !ok {
+			return Reference{}, false
+		}
+		collection, ok := index.Collection.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return Reference{}, false
+		}
+		name, rest, ok := remoteStateRoot(collection.Traversal)
+		if !ok || len(rest) > 0 {
+			return Reference{}, false
+		}
+		return outputReference(name, expr.Traversal, expr.SrcRange)
+	}
+	return Reference{}, false
+}
+
+// remoteStateRoot splits data.terraform_remote_state.<name> off a traversal.
+func remoteStateRoot(traversal hcl.Traversal) (string, hcl.Traversal, bool) {
+	if len(traversal) < 3 || traversal.RootName() != "data" || attrName(traversal[1]) != "terraform_remote_state" {
+		return "", nil, false
+	}
+	name := attrName(traversal[2])
+	return name, traversal[3:], name != ""
+}
+
+func outputReference(remoteState string, rest hcl.Traversal, rng hcl.Range) (Reference, bool) {
+	if len(rest) < 2 || attrName(rest[0]) != "outputs" {
+		return Reference{}, false
+	}
+	output := attrName(rest[1])
+	if index, ok := rest[1].(hcl.TraverseIndex); ok && index.Key.Type() == cty.String && index.Key.IsKnown() && !index.Key.IsNull() {
+		output = index.Key.AsString()
+	}
+	if output == "" {
+		return Reference{}, false
+	}
+	return Reference{RemoteState: remoteState, Output: output, Range: rng}, true
+}
+
+func attrName(step hcl.Traverser) string {
+	if attr, ok := step.(hcl.TraverseAttr); ok {
+		return attr.Name
+	}
+	return ""
+}
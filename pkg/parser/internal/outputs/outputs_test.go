@@ -0,0 +1,65 @@
+package outputs
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func TestReferences(t *testing.T) {
+	parser := hclparse.NewParser()
+	file, diags := parser.ParseHCL([]byte(`
+locals {
+  vpc_id  = data.terraform_remote_state.vpc.outputs.vpc_id
+  subnets = data.terraform_remote_state.vpc.outputs["subnet_ids"]
+  all     = data.terraform_remote_state.vpc.outputs
+  ami     = data.aws_ami.ubuntu.id
+}
+
+resource "aws_instance" "app" {
+  for_each  = data.terraform_remote_state.eks
+  subnet_id = data.terraform_remote_state.eks[each.key].outputs.node_subnet
+  tags = {
+    cluster = "${data.terraform_remote_state.eks["prod"].outputs.cluster_name}"
+  }
+}
+`), "main.tf")
+	if diags.HasErrors() {
+		t.Fatalf("parse: %v", diags)
+	}
+	jsonFile, diags := parser.ParseJSON([]byte(`{"locals": {"x": "${data.terraform_remote_state.vpc.outputs.json_only}"}}`), "extra.tf.json")
+	if diags.HasErrors() {
+		t.Fatalf("parse json: %v", diags)
+	}
+
+	refs := References(map[string]*hcl.File{"main.tf": file, "extra.tf.json": jsonFile})
+	got := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		got = append(got, fmt.Sprintf("%s.%s@%d", ref.RemoteState, ref.Output, ref.Range.Start.Line))
+	}
+	want := []string{"vpc.vpc_id@3", "vpc.subnet_ids@4", "eks.node_subnet@11", "eks.cluster_name@13"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("References() = %v, want %v", got, want)
+	}
+}
+
+func TestDeclared(t *testing.T) {
+	file, diags := hclparse.NewParser().ParseHCL([]byte(`
+output "vpc_id" { value = "x" }
+output "subnet_ids" { value = [] }
+`), "outputs.tf")
+	if diags.HasErrors() {
+		t.Fatalf("parse: %v", diags)
+	}
+	content, _, _ := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "output", LabelNames: []string{"name"}}},
+	})
+
+	declared := Declared(content.Blocks)
+	if !declared["vpc_id"] || !declared["subnet_ids"] || declared["cluster_name"] {
+		t.Fatalf("Declared() = %v", declared)
+	}
+}
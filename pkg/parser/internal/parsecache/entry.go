@@ -0,0 +1,291 @@
+package parsecache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/edelwud/terraci/pkg/parser/internal/outputs"
+	"github.com/edelwud/terraci/pkg/parser/model"
+)
+
+// entry is the cached form of a model.ParsedModule. Expressions kept for
+// dependency extraction are stored as their source text and re-parsed on
+// load, which is far cheaper than parsing every file of the module.
+type entry struct {
+	Locals               map[string]ctyjson.SimpleJSONValue `json:"locals,omitempty"`
+	Variables            map[string]ctyjson.SimpleJSONValue `json:"variables,omitempty"`
+	Backend              *model.BackendConfig               `json:"backend,omitempty"`
+	RequiredProviders    []*model.RequiredProvider          `json:"required_providers,omitempty"`
+	LockedProviders      []*model.LockedProvider            `json:"locked_providers,omitempty"`
+	RemoteStates         []remoteState                      `json:"remote_states,omitempty"`
+	ModuleCalls          []*model.ModuleCall                `json:"module_calls,omitempty"`
+	DeclaredDependencies []string                           `json:"declared_dependencies,omitempty"`
+	DataSources          []dataSource                       `json:"data_sources,omitempty"`
+	LocalAttributes      map[string]expression              `json:"local_attributes,omitempty"`
+	Outputs              []string                           `json:"outputs,omitempty"`
+	OutputReferences     []model.OutputReference            `json:"output_references,omitempty"`
+	Diagnostics          []diagnostic                       `json:"diagnostics,omitempty"`
+}
+
+type remoteState struct {
+	Name         string                `json:"name"`
+	Backend      string                `json:"backend,omitempty"`
+	Config       map[string]expression `json:"config,omitempty"`
+	ForEach      *expression           `json:"for_each,omitempty"`
+	Workspace    *expression           `json:"workspace,omitempty"`
+	WorkspaceDir string                `json:"workspace_dir,omitempty"`
+	Range        hcl.Range             `json:"range"`
+}
+
+type dataSource struct {
+	Name string     `json:"name"`
+	Rule int        `json:"rule"`
+	Expr expression `json:"expr"`
+}
+
+type expression struct {
+	Source string    `json:"source"`
+	Range  hcl.Range `json:"range"`
+}
+
+type diagnostic struct {
+	Severity hcl.DiagnosticSeverity `json:"severity"`
+	Summary  string                 `json:"summary"`
+	Detail   string                 `json:"detail,omitempty"`
+	Subject  *hcl.Range             `json:"subject,omitempty"`
+}
+
+// Encode serializes parsed for the cache. It fails for modules that cannot
+// be restored faithfully, such as modules with HCL JSON expressions or
+// values that are not wholly known; those are parsed on every run.
+func Encode(parsed *model.ParsedModule, rules []model.DataSourceRule) ([]byte, error) {
+	enc := encoder{files: parsed.Files}
+
+	out := entry{
+		Locals:               enc.values(parsed.Locals),
+		Variables:            enc.values(parsed.Variables),
+		Backend:              parsed.Backend,
+		RequiredProviders:    parsed.RequiredProviders,
+		LockedProviders:      parsed.LockedProviders,
+		ModuleCalls:          parsed.ModuleCalls,
+		DeclaredDependencies: parsed.DeclaredDependencies,
+		LocalAttributes:      enc.localAttributes(parsed.TopLevelBlocks()["locals"]),
+		Outputs:              slices.Sorted(maps.Keys(outputs.Declared(parsed.TopLevelBlocks()["output"]))),
+		OutputReferences:     outputs.References(parsed.Files),
+	}
+	for _, ref := range parsed.RemoteStates {
+		state := remoteState{
+			Name:         ref.Name,
+			Backend:      ref.Backend,
+			Config:       make(map[string]expression, len(ref.Config)),
+			WorkspaceDir: ref.WorkspaceDir,
+			Range:        ref.Range,
+		}
+		for _, name := range slices.Sorted(maps.Keys(ref.Config)) {
+			state.Config[name] = enc.expression(ref.Config[name])
+		}
+		if ref.ForEach != nil {
+			forEach := enc.expression(ref.ForEach)
+			state.ForEach = &forEach
+		}
+		if ref.Workspace != nil {
+			workspace := enc.expression(ref.Workspace)
+			state.Workspace = &workspace
+		}
+		out.RemoteStates = append(out.RemoteStates, state)
+	}
+	for _, ref := range parsed.DataSources {
+		rule := ruleIndex(rules, ref.Rule)
+		if rule < 0 {
+			enc.fail(fmt.Errorf("data source %s matches no configured rule", ref.Name))
+		}
+		out.DataSources = append(out.DataSources, dataSource{Name: ref.Name, Rule: rule, Expr: enc.expression(ref.Expr)})
+	}
+	for _, diag := range parsed.Diagnostics {
+		out.Diagnostics = append(out.Diagnostics, diagnostic{
+			Severity: diag.Severity,
+			Summary:  diag.Summary,
+			Detail:   diag.Detail,
+			Subject:  diag.Subject,
+		})
+	}
+
+	if enc.err != nil {
+		return nil, enc.err
+	}
+	return json.Marshal(out)
+}
+
+// Decode restores a parsed module encoded by Encode. The result carries no
+// Files and only the locals and output top-level blocks, which is all
+// dependency extraction and output contract checks read; output reads are
+// restored into OutputReferences.
+func Decode(data []byte, modulePath string, rules []model.DataSourceRule) (*model.ParsedModule, error) {
+	var in entry
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("decode cache entry: %w", err)
+	}
+
+	var dec decoder
+	parsed := model.NewParsedModule(modulePath)
+	for name, value := range in.Locals {
+		parsed.Locals[name] = value.Value
+	}
+	for name, value := range in.Variables {
+		parsed.Variables[name] = value.Value
+	}
+	parsed.Backend = in.Backend
+	parsed.RequiredProviders = append(parsed.RequiredProviders, in.RequiredProviders...)
+	parsed.LockedProviders = append(parsed.LockedProviders, in.LockedProviders...)
+	parsed.ModuleCalls = append(parsed.ModuleCalls, in.ModuleCalls...)
+	parsed.DeclaredDependencies = in.DeclaredDependencies
+	parsed.OutputReferences = in.OutputReferences
+
+	for _, state := range in.RemoteStates {
+		ref := &model.RemoteStateRef{
+			Name:         state.Name,
+			Backend:      state.Backend,
+			Config:       make(map[string]hcl.Expression, len(state.Config)),
+			WorkspaceDir: state.WorkspaceDir,
+			Range:        state.Range,
+		}
+		for name, expr := range state.Config {
+			ref.Config[name] = dec.expression(expr)
+		}
+		if state.ForEach != nil {
+			ref.ForEach = dec.expression(*state.ForEach)
+		}
+		if state.Workspace != nil {
+			ref.Workspace = dec.expression(*state.Workspace)
+		}
+		parsed.RemoteStates = append(parsed.RemoteStates, ref)
+	}
+	for _, ref := range in.DataSources {
+		if ref.Rule < 0 || ref.Rule >= len(rules) {
+			return nil, fmt.Errorf("data source %s refers to unknown rule %d", ref.Name, ref.Rule)
+		}
+		parsed.DataSources = append(parsed.DataSources, &model.DataSourceRef{
+			Name: ref.Name,
+			Rule: rules[ref.Rule],
+			Expr: dec.expression(ref.Expr),
+		})
+	}
+	blocks := make(map[string][]*hcl.Block)
+	if len(in.LocalAttributes) > 0 {
+		body := &hclsyntax.Body{Attributes: make(hclsyntax.Attributes, len(in.LocalAttributes))}
+		for name, expr := range in.LocalAttributes {
+			body.Attributes[name] = &hclsyntax.Attribute{
+				Name:      name,
+				Expr:      dec.expression(expr),
+				SrcRange:  expr.Range,
+				NameRange: expr.Range,
+			}
+		}
+		blocks["locals"] = []*hcl.Block{{Type: "locals", Body: body}}
+	}
+	for _, name := range in.Outputs {
+		blocks["output"] = append(blocks["output"], &hcl.Block{Type: "output", Labels: []string{name}, Body: &hclsyntax.Body{}})
+	}
+	parsed.AdoptTopLevelBlocks(blocks)
+	for _, diag := range in.Diagnostics {
+		parsed.AddDiags(hcl.Diagnostics{{
+			Severity: diag.Severity,
+			Summary:  diag.Summary,
+			Detail:   diag.Detail,
+			Subject:  diag.Subject,
+		}})
+	}
+
+	if dec.err != nil {
+		return nil, dec.err
+	}
+	return parsed, nil
+}
+
+type encoder struct {
+	files map[string]*hcl.File
+	err   error
+}
+
+func (e *encoder) fail(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+func (e *encoder) values(values map[string]cty.Value) map[string]ctyjson.SimpleJSONValue {
+	out := make(map[string]ctyjson.SimpleJSONValue, len(values))
+	for name, value := range values {
+		if value.ContainsMarked() || !value.IsWhollyKnown() {
+			e.fail(fmt.Errorf("value %s cannot be cached", name))
+			continue
+		}
+		out[name] = ctyjson.SimpleJSONValue{Value: value}
+	}
+	return out
+}
+
+func (e *encoder) localAttributes(blocks []*hcl.Block) map[string]expression {
+	out := make(map[string]expression)
+	for _, block := range blocks {
+		attrs, _ := block.Body.JustAttributes() //nolint:errcheck // diagnostics were reported at parse time
+		for name, attr := range attrs {
+			out[name] = e.expression(attr.Expr)
+		}
+	}
+	return out
+}
+
+// expression captures the source text of a native syntax expression and
+// checks that it parses back to the same range.
+func (e *encoder) expression(expr hcl.Expression) expression {
+	if _, ok := expr.(hclsyntax.Expression); !ok {
+		e.fail(errors.New("only native syntax expressions can be cached"))
+		return expression{}
+	}
+	rng := expr.Range()
+	file, ok := e.files[rng.Filename]
+	if !ok || rng.End.Byte > len(file.Bytes) || rng.Start.Byte > rng.End.Byte {
+		e.fail(fmt.Errorf("no source for expression at %s", rng))
+		return expression{}
+	}
+	out := expression{Source: string(file.Bytes[rng.Start.Byte:rng.End.Byte]), Range: rng}
+	if reparsed, diags := parseExpression(out); diags.HasErrors() || reparsed.Range() != rng {
+		e.fail(fmt.Errorf("expression at %s does not round-trip", rng))
+	}
+	return out
+}
+
+type decoder struct {
+	err error
+}
+
+func (d *decoder) expression(expr expression) hclsyntax.Expression {
+	parsed, diags := parseExpression(expr)
+	if diags.HasErrors() && d.err == nil {
+		d.err = fmt.Errorf("parse cached expression at %s: %w", expr.Range, diags)
+	}
+	return parsed
+}
+
+func parseExpression(expr expression) (hclsyntax.Expression, hcl.Diagnostics) {
+	return hclsyntax.ParseExpression([]byte(expr.Source), expr.Range.Filename, expr.Range.Start)
+}
+
+func ruleIndex(rules []model.DataSourceRule, rule model.DataSourceRule) int {
+	for i, candidate := range rules {
+		if candidate.Type == rule.Type && candidate.Attribute == rule.Attribute &&
+			candidate.Module == rule.Module && candidate.Pattern == rule.Pattern {
+			return i
+		}
+	}
+	return -1
+}
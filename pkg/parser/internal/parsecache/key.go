@@ -0,0 +1,118 @@
+// Package parsecache encodes parsed modules for the persistent parse cache
+// and derives their content-addressed keys.
+package parsecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	moduleparse "github.com/edelwud/terraci/pkg/parser/internal/moduleparse"
+)
+
+// formatVersion is part of every key; bump it whenever the entry layout or
+// the extraction semantics change so older entries are never read.
+const formatVersion = "3"
+
+// Key returns the cache key of the module at modulePath. It hashes salt
+// (the TerraCi version), the module path and segments, the Terraform,
+// tfvars and lock files of the module, the extra var-files, the evaluation
+// mode and the data source rules, so any change to an input yields a new
+// key.
+func Key(salt, modulePath string, segments []string, opts moduleparse.Options) (string, error) {
+	h := sha256.New()
+	writeField(h, formatVersion)
+	writeField(h, salt)
+	writeField(h, modulePath)
+	writeField(h, strings.Join(segments, "/"))
+
+	files, err := moduleFiles(modulePath)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range files {
+		if err := writeFile(h, path); err != nil {
+			return "", err
+		}
+	}
+
+	for _, path := range opts.VarFiles {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(modulePath, path)
+		}
+		if err := writeFile(h, path); err != nil {
+			return "", err
+		}
+	}
+
+	writeField(h, strconv.FormatBool(opts.EarlyEvaluation))
+
+	for _, rule := range opts.DataSources {
+		pattern := ""
+		if rule.Pattern != nil {
+			pattern = rule.Pattern.String()
+		}
+		writeField(h, rule.Type)
+		writeField(h, rule.Attribute)
+		writeField(h, pattern)
+		writeField(h, rule.Module)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// moduleFiles returns the sorted files of a module directory that the
+// parser reads.
+func moduleFiles(modulePath string) ([]string, error) {
+	entries, err := os.ReadDir(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("read module dir: %w", err)
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isModuleFile(entry.Name()) {
+			continue
+		}
+		files = append(files, filepath.Join(modulePath, entry.Name()))
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func isModuleFile(name string) bool {
+	for _, suffix := range []string{".tf", ".tf.json", ".tfvars", ".tfvars.json"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return name == ".terraform.lock.hcl"
+}
+
+// writeFile hashes the path and content of a file; a missing file hashes
+// as absent, so creating it later changes the key.
+func writeFile(h hash.Hash, path string) error {
+	writeField(h, path)
+	content, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		writeField(h, "\x00absent")
+	case err != nil:
+		return fmt.Errorf("read %s: %w", path, err)
+	default:
+		writeField(h, string(content))
+	}
+	return nil
+}
+
+// writeField writes a length-prefixed value so adjacent fields cannot
+// collide.
+func writeField(h hash.Hash, value string) {
+	fmt.Fprintf(h, "%d:%s;", len(value), value)
+}
@@ -0,0 +1,193 @@
+package parsecache
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/edelwud/terraci/internal/terraform/eval"
+	moduleparse "github.com/edelwud/terraci/pkg/parser/internal/moduleparse"
+	"github.com/edelwud/terraci/pkg/parser/internal/testutil"
+	"github.com/edelwud/terraci/pkg/parser/model"
+)
+
+var testSegments = []string{"service", "environment", "region", "module"}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	dir := testutil.SetupTempModule(t, map[string]string{
+		"main.tf": `
+terraform {
+  backend "s3" {
+    bucket = "state"
+    key    = "app/terraform.tfstate"
+  }
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+variable "env" {
+  default = "stage"
+}
+
+locals {
+  prefix = "platform/${var.env}"
+  tags   = { team = "core", count = 2 }
+}
+
+data "terraform_remote_state" "vpc" {
+  for_each  = toset(["a", "b"])
+  backend   = "s3"
+  workspace = each.key
+  config = {
+    bucket = "state"
+    key    = "${local.prefix}/${each.key}/vpc/terraform.tfstate"
+  }
+}
+
+data "aws_ssm_parameter" "db" {
+  name = "/platform/${var.env}/db"
+}
+
+module "lib" {
+  source = "../lib"
+}
+
+output "vpc_id" {
+  value = data.terraform_remote_state.vpc["a"].outputs.vpc_id
+}
+`,
+		"terraform.tfvars": `env = "prod"`,
+	})
+	rules := []model.DataSourceRule{{
+		Type:      "aws_ssm_parameter",
+		Attribute: "name",
+		Pattern:   regexp.MustCompile(`^/(?P<svc>[^/]+)/`),
+		Module:    "{svc}/*/*/db",
+	}}
+
+	parsed, err := moduleparse.Run(context.Background(), dir, testSegments, moduleparse.Options{DataSources: rules})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	data, err := Encode(parsed, rules)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	got, err := Decode(data, dir, rules)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if !got.Locals["tags"].RawEquals(parsed.Locals["tags"]) || !got.Variables["env"].RawEquals(cty.StringVal("prod")) {
+		t.Errorf("values = %#v / %#v", got.Locals, got.Variables)
+	}
+	if got.Backend == nil || got.Backend.Config["key"] != "app/terraform.tfstate" {
+		t.Errorf("backend = %+v", got.Backend)
+	}
+	if len(got.RequiredProviders) != 1 || got.RequiredProviders[0].Source != "hashicorp/aws" {
+		t.Errorf("required providers = %+v", got.RequiredProviders)
+	}
+	if len(got.ModuleCalls) != 1 || got.ModuleCalls[0].ResolvedPath != parsed.ModuleCalls[0].ResolvedPath {
+		t.Errorf("module calls = %+v", got.ModuleCalls)
+	}
+	if len(got.DataSources) != 1 || got.DataSources[0].Rule.Pattern != rules[0].Pattern {
+		t.Fatalf("data sources = %+v", got.DataSources)
+	}
+
+	if len(got.RemoteStates) != 1 || got.RemoteStates[0].ForEach == nil || got.RemoteStates[0].Workspace == nil {
+		t.Fatalf("remote states = %+v", got.RemoteStates)
+	}
+	if got.RemoteStates[0].Range != parsed.RemoteStates[0].Range {
+		t.Errorf("remote state range = %v, want %v", got.RemoteStates[0].Range, parsed.RemoteStates[0].Range)
+	}
+	key := got.RemoteStates[0].Config["key"]
+	if key.Range() != parsed.RemoteStates[0].Config["key"].Range() {
+		t.Errorf("key range = %v, want %v", key.Range(), parsed.RemoteStates[0].Config["key"].Range())
+	}
+	evalCtx := eval.NewContext(got.Locals, got.Variables, dir)
+	evalCtx.Variables["local"] = eval.SafeObjectVal(got.Locals)
+	evalCtx.Variables["each"] = cty.ObjectVal(map[string]cty.Value{"key": cty.StringVal("a")})
+	value, diags := key.Value(evalCtx)
+	if diags.HasErrors() || value.AsString() != "platform/prod/a/vpc/terraform.tfstate" {
+		t.Errorf("key = %#v (%v)", value, diags)
+	}
+
+	locals := got.TopLevelBlocks()["locals"]
+	if len(locals) != 1 {
+		t.Fatalf("locals blocks = %d, want 1", len(locals))
+	}
+	attrs, _ := locals[0].Body.JustAttributes()
+	if _, ok := attrs["prefix"]; !ok || len(attrs) != 2 {
+		t.Errorf("locals attributes = %v", attrs)
+	}
+
+	if outputs := got.TopLevelBlocks()["output"]; len(outputs) != 1 || outputs[0].Labels[0] != "vpc_id" {
+		t.Errorf("output blocks = %v, want vpc_id", outputs)
+	}
+	if refs := got.OutputReferences; len(refs) != 1 || refs[0].RemoteState != "vpc" || refs[0].Output != "vpc_id" || refs[0].Range.Start.Line != 43 {
+		t.Errorf("output references = %+v, want vpc.vpc_id at line 43", refs)
+	}
+}
+
+func TestEncode_RejectsJSONSyntax(t *testing.T) {
+	dir := testutil.SetupTempModule(t, map[string]string{
+		"main.tf.json": `{"data": {"terraform_remote_state": {"vpc": {"backend": "s3", "config": {"key": "vpc.tfstate"}}}}}`,
+	})
+	parsed, err := moduleparse.Run(context.Background(), dir, testSegments, moduleparse.Options{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, err := Encode(parsed, nil); err == nil || !strings.Contains(err.Error(), "native syntax") {
+		t.Fatalf("Encode() error = %v, want native syntax error", err)
+	}
+}
+
+func TestKey_ChangesWithInputs(t *testing.T) {
+	dir := testutil.SetupTempModule(t, map[string]string{"main.tf": `locals { a = 1 }`})
+	key := func(salt string, opts moduleparse.Options) string {
+		t.Helper()
+		k, err := Key(salt, dir, testSegments, opts)
+		if err != nil {
+			t.Fatalf("Key() error = %v", err)
+		}
+		return k
+	}
+
+	base := key("v1", moduleparse.Options{})
+	if again := key("v1", moduleparse.Options{}); again != base {
+		t.Fatal("Key() is not stable")
+	}
+	if key("v2", moduleparse.Options{}) == base {
+		t.Error("version does not change the key")
+	}
+	if key("v1", moduleparse.Options{VarFiles: []string{"prod.tfvars"}}) == base {
+		t.Error("var-file does not change the key")
+	}
+	if key("v1", moduleparse.Options{EarlyEvaluation: true}) == base {
+		t.Error("early evaluation does not change the key")
+	}
+	if key("v1", moduleparse.Options{DataSources: []model.DataSourceRule{{Type: "x", Attribute: "y", Module: "z"}}}) == base {
+		t.Error("data source rule does not change the key")
+	}
+
+	testutil.WriteFile(t, dir, "README.md", "ignored")
+	if key("v1", moduleparse.Options{}) != base {
+		t.Error("unrelated file changes the key")
+	}
+	for _, name := range []string{"main.tf", "terraform.tfvars", ".terraform.lock.hcl"} {
+		testutil.WriteFile(t, dir, name, "# "+name)
+		next := key("v1", moduleparse.Options{})
+		if next == base {
+			t.Errorf("writing %s does not change the key", filepath.Base(name))
+		}
+		base = next
+	}
+}
@@ -6,6 +6,14 @@ import (
 	"github.com/edelwud/terraci/pkg/parser/internal/exprfast"
 )
 
-func extractPathTemplate(expr hcl.Expression, ctx *hcl.EvalContext) ([]string, error) {
-	return exprfast.ExtractTemplate(expr, ctx)
+func extractPathTemplate(expr hcl.Expression, workspace string, ctx *hcl.EvalContext) ([]Location, error) {
+	paths, err := exprfast.ExtractTemplate(expr, ctx)
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]Location, 0, len(paths))
+	for _, path := range paths {
+		locations = append(locations, Location{Path: path, Workspace: workspace})
+	}
+	return locations, nil
 }
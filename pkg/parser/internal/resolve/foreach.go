@@ -7,14 +7,18 @@ import (
 	log "github.com/caarlos0/log"
 )
 
-func (r Resolver) resolveForEach(forEachExpr, pathExpr hcl.Expression, evalCtx *hcl.EvalContext) ([]string, error) {
+func (r Resolver) resolveForEach(forEachExpr, pathExpr, workspaceExpr hcl.Expression, evalCtx *hcl.EvalContext) ([]Location, error) {
 	forEachVal, diags := forEachExpr.Value(evalCtx)
 	if diags.HasErrors() {
 		log.WithField("reason", "for_each evaluation failed").Debug("falling back to template extraction")
-		return extractPathTemplate(pathExpr, evalCtx)
+		workspace, err := workspaceName(workspaceExpr, evalCtx)
+		if err != nil {
+			return nil, err
+		}
+		return extractPathTemplate(pathExpr, workspace, evalCtx)
 	}
 
-	var paths []string
+	var locations []Location
 	for it := forEachVal.ElementIterator(); it.Next(); {
 		k, v := it.Element()
 
@@ -32,11 +36,16 @@ func (r Resolver) resolveForEach(forEachExpr, pathExpr hcl.Expression, evalCtx *
 		}
 
 		pathVal, diags := pathExpr.Value(iterCtx)
-		if !diags.HasErrors() && pathVal.Type() == cty.String {
-			log.WithField("path", pathVal.AsString()).Debug("resolved for_each path")
-			paths = append(paths, pathVal.AsString())
+		if diags.HasErrors() || pathVal.Type() != cty.String {
+			continue
+		}
+		workspace, err := workspaceName(workspaceExpr, iterCtx)
+		if err != nil {
+			return nil, err
 		}
+		log.WithField("path", pathVal.AsString()).Debug("resolved for_each path")
+		locations = append(locations, Location{Path: pathVal.AsString(), Workspace: workspace})
 	}
 
-	return paths, nil
+	return locations, nil
 }
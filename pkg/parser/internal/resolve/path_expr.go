@@ -1,6 +1,10 @@
 package resolve
 
-import "github.com/hashicorp/hcl/v2"
+import (
+	"github.com/hashicorp/hcl/v2"
+
+	parserdeps "github.com/edelwud/terraci/pkg/parser/internal/deps"
+)
 
 // Backend config attributes that hold the workspace path expression we
 // resolve for terraform_remote_state references. Centralized so foreach
@@ -11,7 +15,13 @@ const (
 	configKeyValue  = "value"
 )
 
+// findPathExpression returns the state path attribute of the ref's backend
+// type (key, prefix, path, address, schema_name), falling back to key and
+// prefix for backends without a known identity.
 func findPathExpression(ref *Ref) hcl.Expression {
+	if expr, ok := ref.Config[parserdeps.IdentityFor(ref.Backend).Path]; ok {
+		return expr
+	}
 	if expr, ok := ref.Config[configKeyKey]; ok {
 		return expr
 	}
@@ -4,10 +4,29 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// Resolve returns the state paths a remote state reads.
 func (r Resolver) Resolve(
 	ref *Ref,
 	modulePath string,
 	locals, variables map[string]cty.Value,
 ) ([]string, error) {
+	locations, err := r.ResolveLocations(ref, modulePath, locals, variables)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(locations))
+	for _, location := range locations {
+		paths = append(paths, location.Path)
+	}
+	return paths, nil
+}
+
+// ResolveLocations returns the states a remote state reads together with
+// their Terraform workspaces.
+func (r Resolver) ResolveLocations(
+	ref *Ref,
+	modulePath string,
+	locals, variables map[string]cty.Value,
+) ([]Location, error) {
 	return newResolveSession(r, ref, modulePath, locals, variables).Run()
 }
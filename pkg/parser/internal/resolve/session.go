@@ -32,7 +32,7 @@ func newResolveSession(
 	}
 }
 
-func (s *resolveSession) Run() ([]string, error) {
+func (s *resolveSession) Run() ([]Location, error) {
 	if s.ref == nil {
 		return nil, errors.New("remote state ref is nil")
 	}
@@ -42,14 +42,24 @@ func (s *resolveSession) Run() ([]string, error) {
 	evalCtx := s.resolver.evalBuilder.Build(s.modulePath, s.locals, s.variables)
 	pathExpr := s.pathExpression()
 	if pathExpr == nil {
-		return nil, errors.New("no key or prefix found in remote state config")
+		return nil, errors.New("no state path attribute found in remote state config")
 	}
 
+	var locations []Location
+	var err error
 	if s.ref.ForEach != nil {
-		return s.resolver.resolveForEach(s.ref.ForEach, pathExpr, evalCtx)
+		locations, err = s.resolver.resolveForEach(s.ref.ForEach, pathExpr, s.ref.Workspace, evalCtx)
+	} else {
+		locations, err = s.resolver.resolveSimple(pathExpr, s.ref.Workspace, evalCtx)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return s.resolver.resolveSimple(pathExpr, evalCtx)
+	for i := range locations {
+		locations[i] = s.splitWorkspaceKeyPrefix(locations[i], evalCtx)
+	}
+	return locations, nil
 }
 
 func (s *resolveSession) pathExpression() hcl.Expression {
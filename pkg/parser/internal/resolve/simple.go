@@ -8,12 +8,17 @@ import (
 	"github.com/edelwud/terraci/pkg/parser/internal/exprfast"
 )
 
-func (r Resolver) resolveSimple(pathExpr hcl.Expression, evalCtx *hcl.EvalContext) ([]string, error) {
+func (r Resolver) resolveSimple(pathExpr, workspaceExpr hcl.Expression, evalCtx *hcl.EvalContext) ([]Location, error) {
+	workspace, err := workspaceName(workspaceExpr, evalCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	if path, ok := exprfast.New(evalCtx).String(pathExpr); ok {
 		log.WithField("path", path).Debug("resolved simple path")
-		return []string{path}, nil
+		return []Location{{Path: path, Workspace: workspace}}, nil
 	}
 
 	log.WithField("reason", "evaluation failed").Debug("falling back to template extraction")
-	return extractPathTemplate(pathExpr, evalCtx)
+	return extractPathTemplate(pathExpr, workspace, evalCtx)
 }
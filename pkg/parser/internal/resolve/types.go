@@ -14,3 +14,5 @@ func NewResolver(evalBuilder evalctx.Builder) Resolver {
 }
 
 type Ref = model.RemoteStateRef
+
+type Location = model.StateLocation
@@ -0,0 +1,66 @@
+package resolve
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/edelwud/terraci/pkg/parser/internal/exprfast"
+)
+
+const (
+	// defaultWorkspace is Terraform's default workspace name.
+	defaultWorkspace = "default"
+	// defaultWorkspaceKeyPrefix is the s3 backend's workspace_key_prefix
+	// default: non-default workspace states live at <prefix>/<name>/<key>.
+	defaultWorkspaceKeyPrefix = "env:"
+	configWorkspaceKeyPrefix  = "workspace_key_prefix"
+)
+
+// workspaceName evaluates the workspace argument of a remote state. An unset
+// argument or "default" reads the default workspace and yields "".
+func workspaceName(expr hcl.Expression, ctx *hcl.EvalContext) (string, error) {
+	if expr == nil {
+		return "", nil
+	}
+	name, ok := exprfast.New(ctx).String(expr)
+	if !ok {
+		return "", errors.New("unresolved workspace argument")
+	}
+	if name == defaultWorkspace {
+		return "", nil
+	}
+	return name, nil
+}
+
+// splitWorkspaceKeyPrefix recognizes s3 keys that address a workspace state
+// directly ("env:/tenant-a/app/terraform.tfstate") and splits them into
+// the workspace and the key the producing module configures.
+func (s *resolveSession) splitWorkspaceKeyPrefix(location Location, evalCtx *hcl.EvalContext) Location {
+	if location.Workspace != "" || s.ref.Backend != "s3" {
+		return location
+	}
+
+	prefix := defaultWorkspaceKeyPrefix
+	if expr, ok := s.ref.Config[configWorkspaceKeyPrefix]; ok {
+		value, ok := exprfast.New(evalCtx).String(expr)
+		if !ok {
+			return location
+		}
+		prefix = value
+	}
+
+	rest, ok := strings.CutPrefix(location.Path, prefix+"/")
+	if !ok {
+		return location
+	}
+	workspace, key, ok := strings.Cut(rest, "/")
+	if !ok || workspace == "" || key == "" {
+		return location
+	}
+	if workspace == defaultWorkspace {
+		workspace = ""
+	}
+	return Location{Path: key, Workspace: workspace}
+}
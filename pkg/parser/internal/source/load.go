@@ -3,9 +3,11 @@ package source
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
 )
 
 type Loader struct{}
@@ -22,11 +24,22 @@ func readFile(path string) ([]byte, error) {
 	return os.ReadFile(path)
 }
 
+// isTerraformFile reports whether a file name is a Terraform configuration
+// file in native (.tf) or JSON (.tf.json) syntax.
+func isTerraformFile(name string) bool {
+	return strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")
+}
+
+// parseHCLFile parses native HCL, or HCL JSON syntax for .json files.
 func parseHCLFile(path string) (*hcl.File, hcl.Diagnostics, error) {
 	content, err := readFile(path)
 	if err != nil {
 		return nil, nil, err
 	}
+	if strings.HasSuffix(path, ".json") {
+		file, diags := hcljson.Parse(content, path)
+		return file, diags, nil
+	}
 	file, diags := hclsyntax.ParseConfig(content, path, hcl.Pos{Line: 1, Column: 1})
 	return file, diags, nil
 }
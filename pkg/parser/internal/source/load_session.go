@@ -6,7 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"golang.org/x/sync/errgroup"
@@ -61,7 +60,7 @@ func (s *loadSession) discoverTFFiles() ([]string, error) {
 
 	tfFiles := make([]string, 0, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tf") {
+		if entry.IsDir() || !isTerraformFile(entry.Name()) {
 			continue
 		}
 		tfFiles = append(tfFiles, filepath.Join(s.modulePath, entry.Name()))
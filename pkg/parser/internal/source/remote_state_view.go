@@ -18,6 +18,15 @@ func (v RemoteStateBlockView) Name() string {
 	return v.block.Labels[1]
 }
 
+// Range returns the source range of the whole block, or of its header for
+// blocks written in HCL JSON syntax.
+func (v RemoteStateBlockView) Range() hcl.Range {
+	if body, ok := v.block.Body.(*hclsyntax.Body); ok {
+		return hcl.RangeBetween(v.block.DefRange, body.SrcRange)
+	}
+	return v.block.DefRange
+}
+
 func (v RemoteStateBlockView) RawBody() hcl.Body {
 	return v.block.Body
 }
@@ -36,18 +45,20 @@ func (v RemoteStateBlockView) AppendInlineConfigExpressions(
 		return
 	}
 
-	objExpr, isObj := attr.Expr.(*hclsyntax.ObjectConsExpr)
-	if !isObj {
+	// hcl.ExprMap accepts both native object constructors and HCL JSON
+	// objects; any other expression is not an inline config.
+	items, diags := hcl.ExprMap(attr.Expr)
+	if diags.HasErrors() {
 		return
 	}
 
 	evaluator := exprfast.New(nil)
-	for _, item := range objExpr.Items {
-		key, ok := evaluator.String(item.KeyExpr)
+	for _, item := range items {
+		key, ok := evaluator.String(item.Key)
 		if !ok {
 			continue
 		}
-		dst[key] = item.ValueExpr
+		dst[key] = item.Value
 	}
 }
 
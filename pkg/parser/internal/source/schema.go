@@ -9,6 +9,7 @@ var topLevelBlockSchema = &hcl.BodySchema{
 		{Type: "terraform"},
 		{Type: "data", LabelNames: []string{"type", "name"}},
 		{Type: "module", LabelNames: []string{"name"}},
+		{Type: "output", LabelNames: []string{"name"}},
 	},
 }
 
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// DependencyTypeDataSource marks a dependency derived from a data source
+// matched by a DataSourceRule.
+const DependencyTypeDataSource = "data_source"
+
+// DataSourceRule maps a data source attribute to the module that produces
+// the value, for contracts other than terraform_remote_state (tfe_outputs
+// workspaces, SSM parameter prefixes, ...).
+type DataSourceRule struct {
+	// Type is the data source type, e.g. "aws_ssm_parameter".
+	Type string
+	// Attribute names the attribute whose value identifies the producer.
+	Attribute string
+	// Pattern, when set, must match the value; its named groups are
+	// available to Module. Values it does not match are ignored.
+	Pattern *regexp.Regexp
+	// Module is a module ID glob template; {value} expands to the attribute
+	// value and {name} to the named group of Pattern.
+	Module string
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// ModulePattern expands the rule's module template for value. ok is false
+// when Pattern does not match.
+func (r DataSourceRule) ModulePattern(value string) (string, bool) {
+	groups := map[string]string{"value": value}
+	if r.Pattern != nil {
+		match := r.Pattern.FindStringSubmatch(value)
+		if match == nil {
+			return "", false
+		}
+		for i, name := range r.Pattern.SubexpNames() {
+			if name != "" {
+				groups[name] = match[i]
+			}
+		}
+	}
+	return templatePlaceholder.ReplaceAllStringFunc(r.Module, func(placeholder string) string {
+		return groups[strings.Trim(placeholder, "{}")]
+	}), true
+}
+
+// DataSourceRef is a data source block matched by a DataSourceRule. Expr
+// is evaluated during dependency extraction, where path-derived locals of
+// the module are known.
+type DataSourceRef struct {
+	Name string
+	Rule DataSourceRule
+	Expr hcl.Expression
+}
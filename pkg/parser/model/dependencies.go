@@ -1,12 +1,56 @@
 package model
 
-import "github.com/edelwud/terraci/pkg/discovery"
+import (
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+// DependencyTypeRemoteState marks a dependency derived from a
+// terraform_remote_state data source.
+const DependencyTypeRemoteState = "remote_state"
+
+// DependencyTypeDeclared marks a dependency declared in .terraci.yaml or a
+// terraci:depends_on annotation instead of inferred from remote state.
+const DependencyTypeDeclared = "declared"
 
 type Dependency struct {
 	From            *discovery.Module
 	To              *discovery.Module
 	Type            string
 	RemoteStateName string
+	// Evidence explains how a remote_state dependency was resolved; nil
+	// for other dependency types.
+	Evidence *DependencyEvidence
+}
+
+// MatchMethod names the strategy that matched a state path to a module.
+type MatchMethod string
+
+const (
+	// MatchPath matched the state path to a module path exactly.
+	MatchPath MatchMethod = "path"
+	// MatchTrailing matched the last path segments of the state path to a
+	// module path.
+	MatchTrailing MatchMethod = "trailing"
+	// MatchContext matched a short state path relative to the context of
+	// the reading module.
+	MatchContext MatchMethod = "context"
+	// MatchBackend matched the state to the module whose own backend writes
+	// it.
+	MatchBackend MatchMethod = "backend"
+)
+
+// DependencyEvidence records where a remote_state dependency comes from.
+type DependencyEvidence struct {
+	// Range is the source range of the terraform_remote_state block.
+	Range hcl.Range
+	// StatePath is the resolved state key the block reads.
+	StatePath string
+	// Workspace is the workspace the block reads; empty is the default.
+	Workspace string
+	// Match is the strategy that picked the target module.
+	Match MatchMethod
 }
 
 type LibraryDependency struct {
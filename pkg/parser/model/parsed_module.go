@@ -6,17 +6,32 @@ import (
 )
 
 type ParsedModule struct {
-	Path              string
-	Locals            map[string]cty.Value
-	Variables         map[string]cty.Value
-	Backend           *BackendConfig
-	RequiredProviders []*RequiredProvider
-	LockedProviders   []*LockedProvider
-	RemoteStates      []*RemoteStateRef
-	ModuleCalls       []*ModuleCall
-	Files             map[string]*hcl.File
-	Diagnostics       hcl.Diagnostics
-	topLevelBlocks    map[string][]*hcl.Block
+	Path                 string
+	Locals               map[string]cty.Value
+	Variables            map[string]cty.Value
+	Backend              *BackendConfig
+	RequiredProviders    []*RequiredProvider
+	LockedProviders      []*LockedProvider
+	RemoteStates         []*RemoteStateRef
+	ModuleCalls          []*ModuleCall
+	DeclaredDependencies []string
+	DataSources          []*DataSourceRef
+	Files                map[string]*hcl.File
+	Diagnostics          hcl.Diagnostics
+	topLevelBlocks       map[string][]*hcl.Block
+
+	// OutputReferences holds the remote state output reads of a module
+	// restored from the parse cache, which keeps no Files. Parsed modules
+	// leave it nil; their reads are found in Files.
+	OutputReferences []OutputReference
+}
+
+// OutputReference is a read of
+// data.terraform_remote_state.<RemoteState>.outputs.<Output>.
+type OutputReference struct {
+	RemoteState string
+	Output      string
+	Range       hcl.Range
 }
 
 type RequiredProvider struct {
@@ -45,12 +60,24 @@ type BackendConfig struct {
 }
 
 type RemoteStateRef struct {
-	Name         string
-	Backend      string
-	Config       map[string]hcl.Expression
-	ForEach      hcl.Expression
+	Name    string
+	Backend string
+	Config  map[string]hcl.Expression
+	ForEach hcl.Expression
+	// Workspace is the workspace argument; nil reads the default workspace.
+	Workspace    hcl.Expression
 	WorkspaceDir string
 	RawBody      hcl.Body
+	// Range is the source range of the data block.
+	Range hcl.Range
+}
+
+// StateLocation is one state a remote state reads: the state path in its
+// backend and the Terraform workspace. An empty Workspace is the default
+// workspace.
+type StateLocation struct {
+	Path      string
+	Workspace string
 }
 
 func NewParsedModule(modulePath string) *ParsedModule {
@@ -62,6 +89,7 @@ func NewParsedModule(modulePath string) *ParsedModule {
 		LockedProviders:   make([]*LockedProvider, 0),
 		RemoteStates:      make([]*RemoteStateRef, 0),
 		ModuleCalls:       make([]*ModuleCall, 0),
+		DataSources:       make([]*DataSourceRef, 0),
 		Files:             make(map[string]*hcl.File),
 		Diagnostics:       make(hcl.Diagnostics, 0),
 		topLevelBlocks:    make(map[string][]*hcl.Block),
@@ -11,6 +11,31 @@ type (
 	LockedProvider     = parsermodel.LockedProvider
 	ModuleCall         = parsermodel.ModuleCall
 	RemoteStateRef     = parsermodel.RemoteStateRef
+	StateLocation      = parsermodel.StateLocation
+	Dependency         = parsermodel.Dependency
+	DependencyEvidence = parsermodel.DependencyEvidence
+	MatchMethod        = parsermodel.MatchMethod
 	LibraryDependency  = parsermodel.LibraryDependency
 	ModuleDependencies = parsermodel.ModuleDependencies
+	DataSourceRule     = parsermodel.DataSourceRule
+)
+
+// DependencyTypeRemoteState marks a dependency derived from a
+// terraform_remote_state data source.
+const DependencyTypeRemoteState = parsermodel.DependencyTypeRemoteState
+
+// DependencyTypeDeclared marks a dependency declared in .terraci.yaml or a
+// terraci:depends_on annotation instead of inferred from remote state.
+const DependencyTypeDeclared = parsermodel.DependencyTypeDeclared
+
+// DependencyTypeDataSource marks a dependency derived from a data source
+// matched by a DataSourceRule.
+const DependencyTypeDataSource = parsermodel.DependencyTypeDataSource
+
+// Match methods of a remote_state dependency; see parsermodel.MatchMethod.
+const (
+	MatchPath     = parsermodel.MatchPath
+	MatchTrailing = parsermodel.MatchTrailing
+	MatchContext  = parsermodel.MatchContext
+	MatchBackend  = parsermodel.MatchBackend
 )
@@ -0,0 +1,24 @@
+package parser
+
+import "github.com/edelwud/terraci/pkg/parser/internal/outputs"
+
+// OutputReference is a read of
+// data.terraform_remote_state.<RemoteState>.outputs.<Output>.
+type OutputReference = outputs.Reference
+
+// OutputReferences returns the remote state outputs a parsed module reads,
+// ordered by file and position. Only native HCL files are inspected.
+func OutputReferences(parsed *ParsedModule) []OutputReference {
+	if parsed == nil {
+		return nil
+	}
+	if parsed.OutputReferences != nil {
+		return append([]OutputReference(nil), parsed.OutputReferences...)
+	}
+	return outputs.References(parsed.Files)
+}
+
+// DeclaredOutputs returns the names of a parsed module's output blocks.
+func DeclaredOutputs(parsed *ParsedModule) map[string]bool {
+	return outputs.Declared(parsed.TopLevelBlocks()["output"])
+}
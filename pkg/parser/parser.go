@@ -13,7 +13,18 @@ func (p *Parser) ParseModule(ctx context.Context, modulePath string) (*ParsedMod
 		return nil, err
 	}
 
-	parsed, err := moduleparse.Run(ctx, modulePath, p.segments)
+	opts := moduleparse.Options{
+		VarFiles:        p.VarFiles[modulePath],
+		DataSources:     p.DataSources,
+		EarlyEvaluation: p.EarlyEvaluation,
+	}
+	var parsed *ParsedModule
+	var err error
+	if p.Cache != nil {
+		parsed, err = p.Cache.parse(ctx, modulePath, p.segments, opts)
+	} else {
+		parsed, err = moduleparse.Run(ctx, modulePath, p.segments, opts)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("load module: %w", err)
 	}
@@ -12,3 +12,11 @@ import (
 func (p *Parser) ResolveWorkspacePath(ref *RemoteStateRef, modulePath string, locals, variables map[string]cty.Value) ([]string, error) {
 	return resolve.NewResolver(evalctx.NewBuilder(p.segments)).Resolve(ref, modulePath, locals, variables)
 }
+
+// ResolveStateLocations resolves the states a remote state reads like
+// ResolveWorkspacePath, together with the Terraform workspace of each: the
+// workspace argument, or the workspace named in an s3 key under
+// workspace_key_prefix.
+func (p *Parser) ResolveStateLocations(ref *RemoteStateRef, modulePath string, locals, variables map[string]cty.Value) ([]StateLocation, error) {
+	return resolve.NewResolver(evalctx.NewBuilder(p.segments)).ResolveLocations(ref, modulePath, locals, variables)
+}
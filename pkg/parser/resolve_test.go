@@ -2,6 +2,7 @@ package parser
 
 import (
 	"context"
+	"slices"
 	"testing"
 )
 
@@ -367,3 +368,45 @@ func assertPaths(t *testing.T, paths []string, want ...string) {
 		}
 	}
 }
+
+func TestResolveStateLocations_Workspaces(t *testing.T) {
+	dir := setupTempModule(t, map[string]string{
+		"data.tf": `
+data "terraform_remote_state" "tenants" {
+  for_each  = toset(["acme", "default"])
+  backend   = "s3"
+  workspace = each.key
+  config = { bucket = "b", key = "platform/stage/eu-central-1/vpc/terraform.tfstate" }
+}
+
+data "terraform_remote_state" "prefixed" {
+  backend = "s3"
+  config = { bucket = "b", key = "env:/globex/platform/stage/eu-central-1/vpc/terraform.tfstate" }
+}
+`,
+	})
+
+	p := NewParser(nil)
+	result, err := p.ParseModule(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	const key = "platform/stage/eu-central-1/vpc/terraform.tfstate"
+	for _, tc := range []struct {
+		index int
+		want  []StateLocation
+	}{
+		{0, []StateLocation{{Path: key, Workspace: "acme"}, {Path: key}}},
+		{1, []StateLocation{{Path: key, Workspace: "globex"}}},
+	} {
+		ref := result.RemoteStates[tc.index]
+		got, err := p.ResolveStateLocations(ref, "platform/stage/eu-central-1/eks", result.Locals, result.Variables)
+		if err != nil {
+			t.Fatalf("%s: resolve: %v", ref.Name, err)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: locations = %v, want %v", ref.Name, got, tc.want)
+		}
+	}
+}
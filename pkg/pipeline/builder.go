@@ -5,6 +5,7 @@ import (
 
 	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/workflow"
 )
 
 type projectIRBuildInput struct {
@@ -23,7 +24,7 @@ func buildProjectIR(opts projectIRBuildInput) (*IR, error) {
 	}
 	allContributedJobs := collectContributedJobs(opts.Contributions)
 	plan, err := prepareModuleGraph(
-		opts.DepGraph, opts.TargetModules, opts.AllModules, opts.ModuleIndex,
+		opts.DepGraph, opts.TargetModules, opts.AllModules, opts.ModuleIndex, opts.Intent.Destroy(),
 	)
 	if err != nil {
 		return nil, err
@@ -148,30 +149,40 @@ func buildModuleJobs(plan *jobPlan, intent BuildIntent, terraform TerraformJobCo
 		var planJob *Job
 
 		if moduleNeedsPlanJob(modulePath, intent, requests) {
-			job := buildPlanJob(plan, mod, env, !intent.ApplyEnabled(), terraform, planOutputs[modulePath])
+			job := buildPlanJob(plan, mod, env, planDependencies(plan, mod, intent), terraform, planOutputs[modulePath])
+			job.operation.terraform.destroy = intent.Destroy()
 			planJob = &job
 			jobs = append(jobs, job)
 		}
 
 		if intent.ApplyEnabled() {
-			jobs = append(jobs, buildApplyJob(plan, mod, env, planJob, terraform))
+			job := buildApplyJob(plan, mod, env, planJob, terraform)
+			job.operation.terraform.destroy = intent.Destroy()
+			job.approval = intent.Destroy() || workflow.RequiresApproval(intent.approvals, mod)
+			jobs = append(jobs, job)
 		}
 	}
 
 	return jobs
 }
 
-func buildPlanJob(plan *jobPlan, mod *discovery.Module, env map[string]string, planOnly bool, terraform TerraformJobConfig, outputs PlanOutputs) Job {
-	planName := jobName(JobKindPlan, mod)
-	modulePath := mod.ID()
-	planOperation, produces, artifact := terraform.NewPlanOperation(planName, modulePath, outputs)
-
-	var deps []JobDependency
-	if planOnly {
-		deps = controlDependencies(resolveDependencyNames(mod, JobKindPlan, plan.subgraph, plan.moduleIndex))
-	} else {
-		deps = controlDependencies(resolveDependencyNames(mod, JobKindApply, plan.subgraph, plan.moduleIndex))
+// planDependencies returns the control edges of a module's plan job: the
+// applies of its dependencies, the plans of its dependencies in plan-only
+// pipelines, and none in drift pipelines.
+func planDependencies(plan *jobPlan, mod *discovery.Module, intent BuildIntent) []JobDependency {
+	switch {
+	case intent.Drift():
+		return nil
+	case intent.ApplyEnabled():
+		return controlDependencies(resolveDependencyNames(mod, JobKindApply, plan))
+	default:
+		return controlDependencies(resolveDependencyNames(mod, JobKindPlan, plan))
 	}
+}
+
+func buildPlanJob(plan *jobPlan, mod *discovery.Module, env map[string]string, deps []JobDependency, terraform TerraformJobConfig, outputs PlanOutputs) Job {
+	planName := jobName(JobKindPlan, mod)
+	planOperation, produces, artifact := terraform.NewPlanOperation(planName, mod, outputs)
 
 	return Job{
 		name:           planName,
@@ -186,9 +197,8 @@ func buildPlanJob(plan *jobPlan, mod *discovery.Module, env map[string]string, p
 }
 
 func buildApplyJob(plan *jobPlan, mod *discovery.Module, env map[string]string, planJob *Job, terraform TerraformJobConfig) Job {
-	modulePath := mod.ID()
-	applyOperation := terraform.NewApplyOperation(modulePath, planJob != nil)
-	applyDeps := controlDependencies(resolveDependencyNames(mod, JobKindApply, plan.subgraph, plan.moduleIndex))
+	applyOperation := terraform.NewApplyOperation(mod, planJob != nil)
+	applyDeps := controlDependencies(resolveDependencyNames(mod, JobKindApply, plan))
 	var consumes []ResourceSpec
 	var inputArtifacts []InputArtifact
 
@@ -197,7 +207,7 @@ func buildApplyJob(plan *jobPlan, mod *discovery.Module, env map[string]string,
 			Job: planJob.name,
 		}}, applyDeps...)
 		consumes = append(consumes,
-			PlanResource(ResourceKindPlanBinary, modulePath, applyOperation.terraform.planFile),
+			PlanResource(ResourceKindPlanBinary, mod.ID(), applyOperation.terraform.planFile),
 		)
 		inputArtifacts = append(inputArtifacts, InputArtifact{
 			Artifact:    planJob.outputArtifact,
@@ -266,6 +276,11 @@ func resolvePipelineResources(ir *IR, plan *jobPlan, required []ResourceRequest,
 		if err != nil {
 			return err
 		}
+		if job.alwaysRun {
+			for j := range artifacts {
+				artifacts[j].Optional = true
+			}
+		}
 		job.consumes = consumes
 		job.inputArtifacts = artifacts
 		for _, dep := range deps {
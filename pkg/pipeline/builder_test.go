@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/discovery"
 )
 
@@ -77,6 +78,117 @@ func TestBuild_PlanIntentSuppressesApply(t *testing.T) {
 	}
 }
 
+func TestBuild_ApprovalRulesGateMatchingApplyJobs(t *testing.T) {
+	t.Parallel()
+
+	prod := discovery.TestModule("svc", "prod", "eu", "vpc")
+	stage := discovery.TestModule("svc", "stage", "eu", "vpc")
+	rule, err := config.NewApprovalRule(config.ApprovalRuleOptions{Match: map[string]string{"environment": "prod*"}})
+	if err != nil {
+		t.Fatalf("NewApprovalRule: %v", err)
+	}
+	intent := mustIntent(t, true).WithApprovals(rule)
+
+	ir, err := buildProjectIR(testProjectIRBuildInput([]*discovery.Module{prod, stage}, nil, intent))
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if !findJob(ir.jobs, jobName(JobKindApply, prod)).RequiresApproval() {
+		t.Fatal("prod apply job should require approval")
+	}
+	if findJob(ir.jobs, jobName(JobKindApply, stage)).RequiresApproval() {
+		t.Fatal("stage apply job should not require approval")
+	}
+	if findJob(ir.jobs, jobName(JobKindPlan, prod)).RequiresApproval() {
+		t.Fatal("plan jobs are never gated")
+	}
+}
+
+func TestBuild_DestroyIntentReversesDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("svc", "prod", "eu", "vpc")
+	app := discovery.TestModule("svc", "prod", "eu", "app")
+	modules := []*discovery.Module{vpc, app}
+	intent, err := DestroyBuildIntent()
+	if err != nil {
+		t.Fatalf("DestroyBuildIntent: %v", err)
+	}
+
+	ir, err := buildProjectIR(testProjectIRBuildInput(modules, [][2]int{{1, 0}}, intent))
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	vpcPlan := findJob(ir.jobs, jobName(JobKindPlan, vpc))
+	vpcApply := findJob(ir.jobs, jobName(JobKindApply, vpc))
+	appApply := findJob(ir.jobs, jobName(JobKindApply, app))
+	if vpcPlan == nil || vpcApply == nil || appApply == nil {
+		t.Fatalf("jobs = %v, want plan/apply for both modules", ir.JobNamesByKind(JobKindApply))
+	}
+	if !hasDependency(vpcPlan.dependencies, appApply.name) || !hasDependency(vpcApply.dependencies, appApply.name) {
+		t.Fatalf("vpc plan/apply dependencies = %v / %v, want dependent %s first", vpcPlan.dependencies, vpcApply.dependencies, appApply.name)
+	}
+	if hasDependency(appApply.dependencies, vpcApply.name) {
+		t.Fatalf("app apply dependencies = %v, must not wait for vpc", appApply.dependencies)
+	}
+	for _, job := range []*Job{vpcPlan, vpcApply} {
+		if !job.operation.Terraform().Destroy() {
+			t.Fatalf("%s operation is not a destroy", job.name)
+		}
+	}
+	if !vpcApply.RequiresApproval() || !appApply.RequiresApproval() || vpcPlan.RequiresApproval() {
+		t.Fatal("every destroy apply, and only applies, must require approval")
+	}
+	if ir.jobs[0].module.ID() != app.ID() {
+		t.Fatalf("first job module = %s, want dependent %s", ir.jobs[0].module.ID(), app.ID())
+	}
+}
+
+func TestBuild_DriftIntentPlansModulesIndependently(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("svc", "prod", "eu", "vpc")
+	app := discovery.TestModule("svc", "prod", "eu", "app")
+	intent, err := DriftBuildIntent(AllPlanResources(ResourceKindPlanJSON))
+	if err != nil {
+		t.Fatalf("DriftBuildIntent: %v", err)
+	}
+	opts := testProjectIRBuildInput([]*discovery.Module{vpc, app}, [][2]int{{1, 0}}, intent)
+	opts.Contributions = mustContributionSet(t, mustContribution(t, mustContributedJob(t, ContributedJobOptions{
+		Name:      "terraci-drift",
+		Commands:  []string{"terraci drift"},
+		Consumes:  []ResourceRequest{AllPlanResources(ResourceKindPlanJSON)},
+		AlwaysRun: true,
+	})))
+
+	ir, err := buildProjectIR(opts)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if names := ir.JobNamesByKind(JobKindApply); len(names) != 0 {
+		t.Fatalf("apply jobs = %v, want none", names)
+	}
+	appPlan := findJob(ir.jobs, jobName(JobKindPlan, app))
+	if len(appPlan.dependencies) != 0 {
+		t.Fatalf("app plan dependencies = %v, want none in drift mode", appPlan.dependencies)
+	}
+
+	collector := findJob(ir.jobs, "terraci-drift")
+	if !collector.AlwaysRun() {
+		t.Fatal("collector should always run")
+	}
+	for _, mod := range []*discovery.Module{vpc, app} {
+		planName := jobName(JobKindPlan, mod)
+		if !hasDependency(collector.dependencies, planName) {
+			t.Fatalf("collector dependencies = %v, want %s", collector.dependencies, planName)
+		}
+		if !hasInputArtifact(collector.inputArtifacts, PlanArtifactName(planName), planName, true) {
+			t.Fatalf("collector input artifacts = %#v, want optional %s artifact", collector.inputArtifacts, planName)
+		}
+	}
+}
+
 func TestBuild_RequiredPlanJSONMakesOnlyMatchingModuleDetailed(t *testing.T) {
 	t.Parallel()
 
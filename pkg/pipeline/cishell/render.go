@@ -8,8 +8,11 @@ package cishell
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/terraformrun"
 )
 
 // RenderOperation converts a typed operation into shell command lines.
@@ -31,28 +34,34 @@ func renderTerraformPlan(op *pipeline.TerraformOperation) []string {
 		return nil
 	}
 
-	planFile := filepath.Base(op.PlanFile())
+	planFile := planFileArg(op)
 	script := []string{"cd " + op.ModulePath()}
 	if op.InitEnabled() {
 		script = append(script, op.Binary()+" init")
 	}
+	script = appendWorkspaceSelect(script, op)
 
+	plan := op.Binary() + " plan"
+	if op.Destroy() {
+		plan += " -destroy"
+	}
 	if op.DetailedPlan() {
+		exitFile := exitCodeFile(op)
 		if op.PlanTextFile() != "" {
 			planText := filepath.Base(op.PlanTextFile())
-			script = append(script, fmt.Sprintf("(%s plan -out=%s -detailed-exitcode 2>&1 || echo $? > .tf_exit) | tee %s", op.Binary(), planFile, planText))
+			script = append(script, fmt.Sprintf("(%s -out=%s -detailed-exitcode 2>&1 || echo $? > %s) | tee %s", plan, planFile, exitFile, planText))
 		} else {
-			script = append(script, fmt.Sprintf("(%s plan -out=%s -detailed-exitcode || echo $? > .tf_exit)", op.Binary(), planFile))
+			script = append(script, fmt.Sprintf("(%s -out=%s -detailed-exitcode || echo $? > %s)", plan, planFile, exitFile))
 		}
 		if op.PlanJSONFile() != "" {
 			planJSON := filepath.Base(op.PlanJSONFile())
 			script = append(script, fmt.Sprintf("%s show -json %s > %s", op.Binary(), planFile, planJSON))
 		}
-		script = append(script, `TF_EXIT=$(cat .tf_exit 2>/dev/null || echo 0); rm -f .tf_exit; if [ "$TF_EXIT" -eq 2 ]; then exit 0; else exit "$TF_EXIT"; fi`)
+		script = append(script, fmt.Sprintf(`TF_EXIT=$(cat %[1]s 2>/dev/null || echo 0); rm -f %[1]s; if [ "$TF_EXIT" -eq 2 ]; then exit 0; else exit "$TF_EXIT"; fi`, exitFile))
 		return script
 	}
 
-	return append(script, op.Binary()+" plan -out="+planFile)
+	return append(script, plan+" -out="+planFile)
 }
 
 func renderTerraformApply(op *pipeline.TerraformOperation) []string {
@@ -64,12 +73,64 @@ func renderTerraformApply(op *pipeline.TerraformOperation) []string {
 	if op.InitEnabled() {
 		script = append(script, op.Binary()+" init")
 	}
+	script = appendWorkspaceSelect(script, op)
 
-	if op.UsePlanFile() {
-		script = append(script, op.Binary()+" apply "+filepath.Base(op.PlanFile()))
-	} else {
+	switch {
+	case op.UsePlanFile():
+		// A saved destroy plan is applied like any other plan file.
+		script = append(script, op.Binary()+" apply "+planFileArg(op))
+	case op.Destroy():
+		script = append(script, op.Binary()+" apply -destroy")
+	default:
 		script = append(script, op.Binary()+" apply")
 	}
 
 	return script
 }
+
+// planFileArg returns the plan file as passed to the binary. Terragrunt runs
+// Terraform inside .terragrunt-cache, where a relative path would resolve, so
+// its plan file is anchored at the unit directory.
+func planFileArg(op *pipeline.TerraformOperation) string {
+	planFile := filepath.Base(op.PlanFile())
+	if op.Binary() == terraformrun.BinaryTerragrunt.String() {
+		return "$PWD/" + planFile
+	}
+	return planFile
+}
+
+// appendWorkspaceSelect switches to the operation's workspace once the
+// backend is initialized; default-workspace operations are left untouched.
+// The select creates a missing workspace; TF_WORKSPACE then pins the rest of
+// the script to it, because jobs of other workspaces running in the same
+// checkout rewrite the directory's selected workspace concurrently.
+func appendWorkspaceSelect(script []string, op *pipeline.TerraformOperation) []string {
+	if op.Workspace() == "" {
+		return script
+	}
+	workspace := Quote(op.Workspace())
+	return append(script,
+		op.Binary()+" workspace select -or-create "+workspace,
+		"export TF_WORKSPACE="+workspace,
+	)
+}
+
+// exitCodeFile names the file a detailed plan stashes its exit code in,
+// distinct per workspace so parallel plans of one directory keep their own.
+func exitCodeFile(op *pipeline.TerraformOperation) string {
+	if op.Workspace() == "" {
+		return ".tf_exit"
+	}
+	return Quote(".tf_exit." + op.Workspace())
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./@:=+,-]+$`)
+
+// Quote returns value as a single shell word. Values made only of characters
+// the shell never interprets are returned unchanged.
+func Quote(value string) string {
+	if shellSafe.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -1,15 +1,19 @@
 package cishell
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
 )
 
 func TestTerraformJobConfig_PlanScript(t *testing.T) {
 	t.Parallel()
 
-	modulePath := "svc/prod/us-east-1/vpc"
+	module := discovery.TestModule("svc", "prod", "us-east-1", "vpc")
+	modulePath := module.RelativePath
 
 	tests := []struct {
 		name              string
@@ -56,7 +60,7 @@ func TestTerraformJobConfig_PlanScript(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			op, _, artifact := tt.config.NewPlanOperation("plan-svc-prod-us-east-1-vpc", modulePath, tt.outputs)
+			op, _, artifact := tt.config.NewPlanOperation("plan-svc-prod-us-east-1-vpc", module, tt.outputs)
 			script := RenderOperation(op)
 
 			// First command is always cd
@@ -120,7 +124,8 @@ func TestTerraformJobConfig_PlanScript(t *testing.T) {
 func TestTerraformJobConfig_ApplyScript(t *testing.T) {
 	t.Parallel()
 
-	modulePath := "svc/prod/us-east-1/vpc"
+	module := discovery.TestModule("svc", "prod", "us-east-1", "vpc")
+	modulePath := module.RelativePath
 
 	tests := []struct {
 		name         string
@@ -162,7 +167,7 @@ func TestTerraformJobConfig_ApplyScript(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			script := RenderOperation(tt.config.NewApplyOperation(modulePath, tt.usePlanFile))
+			script := RenderOperation(tt.config.NewApplyOperation(module, tt.usePlanFile))
 
 			// First command is always cd
 			if script[0] != "cd "+modulePath {
@@ -172,7 +177,7 @@ func TestTerraformJobConfig_ApplyScript(t *testing.T) {
 			hasInit := false
 			lastCmd := script[len(script)-1]
 			for _, cmd := range script {
-				if cmd == tt.config.NewApplyOperation(modulePath, tt.usePlanFile).Terraform().Binary()+" init" {
+				if cmd == tt.config.NewApplyOperation(module, tt.usePlanFile).Terraform().Binary()+" init" {
 					hasInit = true
 				}
 			}
@@ -187,6 +192,93 @@ func TestTerraformJobConfig_ApplyScript(t *testing.T) {
 	}
 }
 
+func TestRenderOperation_DestroyPlanAndApply(t *testing.T) {
+	t.Parallel()
+
+	ir := pipelinetest.MustDestroySingleModuleIR(t, discovery.TestModule("svc", "prod", "us-east-1", "vpc"))
+	plan := RenderOperation(pipelinetest.MustJobByKind(t, ir, pipeline.JobKindPlan).Operation())
+	apply := RenderOperation(pipelinetest.MustJobByKind(t, ir, pipeline.JobKindApply).Operation())
+
+	if got := plan[len(plan)-1]; got != "terraform plan -destroy -out=plan.tfplan" {
+		t.Errorf("plan command = %q, want destroy plan", got)
+	}
+	if got := apply[len(apply)-1]; got != "terraform apply plan.tfplan" {
+		t.Errorf("apply command = %q, want saved destroy plan", got)
+	}
+}
+
+func TestRenderOperation_Workspace(t *testing.T) {
+	t.Parallel()
+
+	module := discovery.TestModule("svc", "prod", "us-east-1", "vpc").WithWorkspace("acme")
+	config := mustTerraformConfig(t, true, "tofu")
+
+	plan, _, artifact := config.NewPlanOperation("plan-svc-prod-us-east-1-vpc--acme", module, pipeline.PlanOutputs{})
+	want := []string{
+		"cd svc/prod/us-east-1/vpc",
+		"tofu init",
+		"tofu workspace select -or-create acme",
+		"export TF_WORKSPACE=acme",
+		"tofu plan -out=plan.acme.tfplan",
+	}
+	if got := RenderOperation(plan); !slices.Equal(got, want) {
+		t.Errorf("plan script = %q, want %q", got, want)
+	}
+	if artifact.Paths[0] != "svc/prod/us-east-1/vpc/plan.acme.tfplan" {
+		t.Errorf("artifact = %q, want workspace plan file", artifact.Paths[0])
+	}
+
+	apply := RenderOperation(config.NewApplyOperation(module, true))
+	if apply[2] != "tofu workspace select -or-create acme" || apply[3] != "export TF_WORKSPACE=acme" || apply[4] != "tofu apply plan.acme.tfplan" {
+		t.Errorf("apply script = %q", apply)
+	}
+
+	detailed, _, _ := config.NewPlanOperation("plan-svc-prod-us-east-1-vpc--acme", module, pipeline.PlanOutputs{Text: true})
+	script := RenderOperation(detailed)
+	if !slices.Contains(script, "(tofu plan -out=plan.acme.tfplan -detailed-exitcode 2>&1 || echo $? > .tf_exit.acme) | tee plan.acme.txt") {
+		t.Errorf("plan script = %q, want a per-workspace exit code file", script)
+	}
+}
+
+func TestRenderOperation_TerragruntAnchorsPlanFile(t *testing.T) {
+	t.Parallel()
+
+	module := discovery.TestModule("svc", "prod", "us-east-1", "vpc")
+	config := mustTerraformConfig(t, false, "terragrunt")
+
+	plan, _, _ := config.NewPlanOperation("plan-svc-prod-us-east-1-vpc", module, pipeline.PlanOutputs{Text: true, JSON: true})
+	script := RenderOperation(plan)
+	if !slices.Contains(script, "(terragrunt plan -out=$PWD/plan.tfplan -detailed-exitcode 2>&1 || echo $? > .tf_exit) | tee plan.txt") {
+		t.Errorf("plan script = %q, want plan file anchored at the unit", script)
+	}
+	if !slices.Contains(script, "terragrunt show -json $PWD/plan.tfplan > plan.json") {
+		t.Errorf("plan script = %q, want show of the anchored plan file", script)
+	}
+
+	apply := RenderOperation(config.NewApplyOperation(module, true))
+	if got := apply[len(apply)-1]; got != "terragrunt apply $PWD/plan.tfplan" {
+		t.Errorf("apply command = %q, want anchored plan file", got)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"tenant-a":                 "tenant-a",
+		"platform/prod/vpc@tenant": "platform/prod/vpc@tenant",
+		"my app":                   "'my app'",
+		"$(id)":                    "'$(id)'",
+		"it's":                     `'it'\''s'`,
+		"":                         "''",
+	}
+	for value, want := range tests {
+		if got := Quote(value); got != want {
+			t.Errorf("Quote(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
 func mustTerraformConfig(tb testing.TB, initEnabled bool, binary string) pipeline.TerraformJobConfig {
 	tb.Helper()
 	config, err := pipeline.NewTerraformJobConfig(pipeline.TerraformJobConfigOptions{
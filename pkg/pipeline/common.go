@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/edelwud/terraci/pkg/discovery"
@@ -17,14 +18,18 @@ type jobPlan struct {
 	moduleOrder   []string
 	subgraph      *graph.DependencyGraph
 	moduleIndex   *discovery.ModuleIndex
+	reverse       bool
 }
 
 // prepareModuleGraph prepares the module graph from target modules. It is an
 // internal detail of BuildProjectIR and is not part of the public package API.
+// With reverse set, module order and job dependencies follow the inverted
+// graph so dependents run before the modules they depend on.
 func prepareModuleGraph(
 	depGraph *graph.DependencyGraph,
 	targetModules, allModules []*discovery.Module,
 	moduleIndex *discovery.ModuleIndex,
+	reverse bool,
 ) (*jobPlan, error) {
 	if targetModules == nil {
 		targetModules = allModules
@@ -41,12 +46,16 @@ func prepareModuleGraph(
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate module order: %w", err)
 	}
+	if reverse {
+		slices.Reverse(moduleOrder)
+	}
 
 	return &jobPlan{
 		targetModules: targetModules,
 		moduleOrder:   moduleOrder,
 		subgraph:      subgraph,
 		moduleIndex:   moduleIndex,
+		reverse:       reverse,
 	}, nil
 }
 
@@ -54,27 +63,31 @@ func prepareModuleGraph(
 // Only Plan/Apply kinds are supported here; contributed jobs carry their
 // own name (assigned by the contributor).
 func jobName(kind JobKind, module *discovery.Module) string {
+	name := jobNameReplacer.Replace(module.ID())
 	prefix := kind.NamePrefix()
 	if prefix == "" {
-		return strings.ReplaceAll(module.ID(), "/", "-")
+		return name
 	}
-	name := strings.ReplaceAll(module.ID(), "/", "-")
 	return fmt.Sprintf("%s-%s", prefix, name)
 }
 
-// resolveDependencyNames returns job names for a module's dependencies in the
-// supplied subgraph. The subgraph is expected to already be scoped to the
-// target module set; only modules present in moduleIndex are emitted.
-func resolveDependencyNames(
-	module *discovery.Module,
-	kind JobKind,
-	subgraph *graph.DependencyGraph,
-	moduleIndex *discovery.ModuleIndex,
-) []string {
-	deps := subgraph.GetDependencies(module.ID())
+// jobNameReplacer maps module IDs onto characters every CI provider accepts
+// in job names; the workspace separator becomes a double dash so
+// "vpc@prod" cannot collide with a sibling directory named "vpc-prod".
+var jobNameReplacer = strings.NewReplacer("/", "-", discovery.WorkspaceSeparator, "--")
+
+// resolveDependencyNames returns job names for the modules that must finish
+// before module: its dependencies, or its dependents when the plan is
+// reversed. The subgraph is already scoped to the target module set; only
+// modules present in the module index are emitted.
+func resolveDependencyNames(module *discovery.Module, kind JobKind, plan *jobPlan) []string {
+	deps := plan.subgraph.GetDependencies(module.ID())
+	if plan.reverse {
+		deps = plan.subgraph.GetDependents(module.ID())
+	}
 	names := make([]string, 0, len(deps))
 	for _, depID := range deps {
-		depModule := moduleIndex.ByID(depID)
+		depModule := plan.moduleIndex.ByID(depID)
 		if depModule == nil {
 			continue
 		}
@@ -71,7 +71,7 @@ func TestPrepareModuleGraph(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			plan, err := prepareModuleGraph(depGraph, tt.targets, allModules, idx)
+			plan, err := prepareModuleGraph(depGraph, tt.targets, allModules, idx, false)
 			if err != nil {
 				t.Fatalf("prepareModuleGraph() error = %v", err)
 			}
@@ -187,7 +187,7 @@ func TestResolveDependencyNamesInternal(t *testing.T) {
 				ids[i] = m.ID()
 			}
 			subgraph := depGraph.Subgraph(ids)
-			got := resolveDependencyNames(tt.module, tt.jobKind, subgraph, idx)
+			got := resolveDependencyNames(tt.module, tt.jobKind, &jobPlan{subgraph: subgraph, moduleIndex: idx})
 			if tt.wantNoNames {
 				if len(got) != 0 {
 					t.Errorf("expected no names, got %v", got)
@@ -219,7 +219,7 @@ func TestResolveDependencyNamesInternal_DepNotInIndex(t *testing.T) {
 	idx := discovery.NewModuleIndex([]*discovery.Module{modB})
 
 	subgraph := depGraph.Subgraph([]string{modA.ID(), modB.ID()})
-	got := resolveDependencyNames(modB, JobKindPlan, subgraph, idx)
+	got := resolveDependencyNames(modB, JobKindPlan, &jobPlan{subgraph: subgraph, moduleIndex: idx})
 	// modA is in target set but not in index, so it should be skipped
 	if len(got) != 0 {
 		t.Errorf("expected no names when dep not in index, got %v", got)
@@ -353,3 +353,22 @@ func TestIRDryRun(t *testing.T) {
 		})
 	}
 }
+
+type yamlPipeline struct{}
+
+func (yamlPipeline) ToYAML() ([]byte, error) { return nil, nil }
+
+type groovyPipeline struct{ yamlPipeline }
+
+func (groovyPipeline) CommentPrefix() string { return "//" }
+
+func TestCommentPrefix(t *testing.T) {
+	t.Parallel()
+
+	if got := CommentPrefix(yamlPipeline{}); got != "#" {
+		t.Fatalf("CommentPrefix(yaml) = %q, want #", got)
+	}
+	if got := CommentPrefix(groovyPipeline{}); got != "//" {
+		t.Fatalf("CommentPrefix(groovy) = %q, want //", got)
+	}
+}
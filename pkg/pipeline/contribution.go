@@ -15,6 +15,8 @@ type ContributedJobOptions struct {
 	Consumes     []ResourceRequest
 	Produces     []ResourceSpec
 	AllowFailure bool
+	// AlwaysRun runs the job even when one of its dependencies failed.
+	AlwaysRun bool
 }
 
 // PluginCommandJobOptions is the canonical producer-facing builder input for
@@ -26,6 +28,8 @@ type PluginCommandJobOptions struct {
 	Consumes     []ResourceRequest
 	Produces     []ResourceSpec
 	AllowFailure bool
+	// AlwaysRun runs the job even when one of its dependencies failed.
+	AlwaysRun bool
 }
 
 // NewContribution builds a contribution value object from validated jobs.
@@ -88,6 +92,7 @@ func NewContributedJob(opts ContributedJobOptions) (ContributedJob, error) {
 		consumes:     append([]ResourceRequest(nil), opts.Consumes...),
 		produces:     append([]ResourceSpec(nil), opts.Produces...),
 		allowFailure: opts.AllowFailure,
+		alwaysRun:    opts.AlwaysRun,
 	}
 	if err := validateContributedJob(job); err != nil {
 		return ContributedJob{}, err
@@ -170,6 +175,9 @@ func (j ContributedJob) Produces() []ResourceSpec {
 // AllowFailure reports whether the pipeline job is allowed to fail.
 func (j ContributedJob) AllowFailure() bool { return j.allowFailure }
 
+// AlwaysRun reports whether the job runs even when a dependency failed.
+func (j ContributedJob) AlwaysRun() bool { return j.alwaysRun }
+
 func (j ContributedJob) clone() ContributedJob {
 	j.commands = append([]string(nil), j.commands...)
 	j.dependencies = append([]JobDependency(nil), j.dependencies...)
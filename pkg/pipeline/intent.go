@@ -3,6 +3,8 @@ package pipeline
 import (
 	"errors"
 	"fmt"
+
+	"github.com/edelwud/terraci/pkg/config"
 )
 
 // BuildIntent describes the caller's high-level pipeline intent. BuildProjectIR
@@ -10,11 +12,16 @@ import (
 type BuildIntent struct {
 	constructed  bool
 	applyEnabled bool
+	destroy      bool
+	drift        bool
 	resources    []ResourceRequest
+	approvals    []config.ApprovalRule
 }
 
 type buildIntentOptions struct {
 	ApplyEnabled     bool
+	Destroy          bool
+	Drift            bool
 	ResourceRequests []ResourceRequest
 }
 
@@ -27,6 +34,8 @@ func newBuildIntent(opts buildIntentOptions) (BuildIntent, error) {
 	return BuildIntent{
 		constructed:  true,
 		applyEnabled: opts.ApplyEnabled,
+		destroy:      opts.Destroy,
+		drift:        opts.Drift,
 		resources:    append([]ResourceRequest(nil), opts.ResourceRequests...),
 	}, nil
 }
@@ -47,9 +56,30 @@ func PlanBuildIntent(resources ...ResourceRequest) (BuildIntent, error) {
 	})
 }
 
+// DriftBuildIntent returns an intent that creates only resource-driven plan
+// jobs for drift detection. Nothing is applied, so plan jobs do not wait for
+// each other and one failing plan does not hold back the others.
+func DriftBuildIntent(resources ...ResourceRequest) (BuildIntent, error) {
+	return newBuildIntent(buildIntentOptions{
+		Drift:            true,
+		ResourceRequests: resources,
+	})
+}
+
+// DestroyBuildIntent returns an intent that creates destroy plan and apply
+// jobs in reverse dependency order: consumers are destroyed before the
+// modules they depend on, and every apply requires manual approval.
+func DestroyBuildIntent(resources ...ResourceRequest) (BuildIntent, error) {
+	return newBuildIntent(buildIntentOptions{
+		ApplyEnabled:     true,
+		Destroy:          true,
+		ResourceRequests: resources,
+	})
+}
+
 func (i BuildIntent) validate() error {
 	if !i.constructed {
-		return errors.New("build intent must be created with pipeline.ApplyBuildIntent or pipeline.PlanBuildIntent")
+		return errors.New("build intent must be created with pipeline.ApplyBuildIntent, pipeline.PlanBuildIntent, pipeline.DriftBuildIntent, or pipeline.DestroyBuildIntent")
 	}
 	return nil
 }
@@ -57,7 +87,27 @@ func (i BuildIntent) validate() error {
 // ApplyEnabled reports whether apply jobs should be emitted.
 func (i BuildIntent) ApplyEnabled() bool { return i.applyEnabled }
 
+// Destroy reports whether jobs tear down modules instead of applying them.
+func (i BuildIntent) Destroy() bool { return i.destroy }
+
+// Drift reports whether plan jobs detect drift and run independently of
+// each other.
+func (i BuildIntent) Drift() bool { return i.drift }
+
 // ResourceRequests returns resource requests that influence IR construction.
 func (i BuildIntent) ResourceRequests() []ResourceRequest {
 	return append([]ResourceRequest(nil), i.resources...)
 }
+
+// WithApprovals returns a copy of the intent whose apply jobs require manual
+// approval for modules matched by any of rules.
+func (i BuildIntent) WithApprovals(rules ...config.ApprovalRule) BuildIntent {
+	i.resources = append([]ResourceRequest(nil), i.resources...)
+	i.approvals = append(append([]config.ApprovalRule(nil), i.approvals...), rules...)
+	return i
+}
+
+// ApprovalRules returns the rules that gate apply jobs.
+func (i BuildIntent) ApprovalRules() []config.ApprovalRule {
+	return append([]config.ApprovalRule(nil), i.approvals...)
+}
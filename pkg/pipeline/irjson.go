@@ -0,0 +1,314 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/terraformrun"
+)
+
+// IRJSONVersion is the schema version written to exported IR documents.
+// It is bumped on any incompatible change to the document shape.
+const IRJSONVersion = 1
+
+// IRDocument is the versioned JSON form of a pipeline IR.
+type IRDocument struct {
+	Version int     `json:"version" jsonschema:"required,description=IR document schema version,enum=1"`
+	Jobs    []IRJob `json:"jobs" jsonschema:"required,description=Jobs in deterministic execution-plan order"`
+}
+
+// IRJob is the JSON form of a pipeline job.
+type IRJob struct {
+	Name             string            `json:"name" jsonschema:"required,description=Unique job name"`
+	Kind             JobKind           `json:"kind" jsonschema:"required,enum=plan,enum=apply,enum=command"`
+	Module           *IRModule         `json:"module,omitempty" jsonschema:"description=Module of plan and apply jobs; absent for command jobs"`
+	Env              map[string]string `json:"env,omitempty" jsonschema:"description=Job environment variables"`
+	Dependencies     []string          `json:"dependencies,omitempty" jsonschema:"description=Names of jobs that must finish before this job"`
+	InputArtifacts   []IRInputArtifact `json:"input_artifacts,omitempty" jsonschema:"description=Artifacts restored before the job runs"`
+	OutputArtifact   *IRArtifact       `json:"output_artifact,omitempty" jsonschema:"description=Artifact published by the job"`
+	Consumes         []IRResource      `json:"consumes,omitempty" jsonschema:"description=Resources read by the job"`
+	Produces         []IRResource      `json:"produces,omitempty" jsonschema:"description=Resources written by the job"`
+	AllowFailure     bool              `json:"allow_failure,omitempty"`
+	AlwaysRun        bool              `json:"always_run,omitempty" jsonschema:"description=Job runs even when a dependency failed"`
+	RequiresApproval bool              `json:"requires_approval,omitempty" jsonschema:"description=Job waits for a manual approval"`
+	Operation        IROperation       `json:"operation" jsonschema:"required"`
+}
+
+// IRModule is the JSON form of a job's module.
+type IRModule struct {
+	Path       string            `json:"path" jsonschema:"required,description=Workspace-relative module path"`
+	Workspace  string            `json:"workspace,omitempty" jsonschema:"description=Terraform workspace of the module; absent for the default workspace"`
+	Segments   []string          `json:"segments,omitempty" jsonschema:"description=Ordered structure segment names"`
+	Components map[string]string `json:"components,omitempty" jsonschema:"description=Segment values keyed by segment name"`
+}
+
+// IRArtifact is the JSON form of a CI artifact.
+type IRArtifact struct {
+	Name  string   `json:"name" jsonschema:"required"`
+	Paths []string `json:"paths" jsonschema:"required,description=Workspace-relative artifact paths"`
+}
+
+// IRInputArtifact is the JSON form of an artifact restored from another job.
+type IRInputArtifact struct {
+	Artifact    IRArtifact `json:"artifact" jsonschema:"required"`
+	ProducerJob string     `json:"producer_job" jsonschema:"required"`
+	Optional    bool       `json:"optional,omitempty"`
+}
+
+// IRResource is the JSON form of a ResourceSpec.
+type IRResource struct {
+	Kind       ResourceKind `json:"kind" jsonschema:"required,enum=plan_binary,enum=plan_text,enum=plan_json,enum=plugin_result,enum=plugin_report"`
+	ModulePath string       `json:"module_path,omitempty" jsonschema:"description=Module of plan resources"`
+	Producer   string       `json:"producer,omitempty" jsonschema:"description=Producer of plugin resources"`
+	Path       string       `json:"path" jsonschema:"required,description=Workspace-relative resource path"`
+}
+
+// IROperation is the JSON form of a job's executable payload.
+type IROperation struct {
+	Type      OperationType         `json:"type" jsonschema:"required,enum=terraform_plan,enum=terraform_apply,enum=commands"`
+	Terraform *IRTerraformOperation `json:"terraform,omitempty" jsonschema:"description=Terraform payload of plan and apply operations"`
+	Commands  []string              `json:"commands,omitempty" jsonschema:"description=Shell commands of command operations"`
+}
+
+// IRTerraformOperation is the JSON form of a terraform/tofu operation.
+type IRTerraformOperation struct {
+	Binary       string `json:"binary" jsonschema:"required,enum=terraform,enum=tofu,enum=terragrunt"`
+	ModulePath   string `json:"module_path" jsonschema:"required"`
+	Workspace    string `json:"workspace,omitempty" jsonschema:"description=Terraform workspace selected after init; absent for the default workspace"`
+	Init         bool   `json:"init,omitempty" jsonschema:"description=Run init before the operation"`
+	PlanFile     string `json:"plan_file,omitempty"`
+	PlanTextFile string `json:"plan_text_file,omitempty"`
+	PlanJSONFile string `json:"plan_json_file,omitempty"`
+	DetailedPlan bool   `json:"detailed_plan,omitempty"`
+	UsePlanFile  bool   `json:"use_plan_file,omitempty"`
+	Destroy      bool   `json:"destroy,omitempty"`
+}
+
+// Document returns the versioned JSON form of the IR.
+func (ir *IR) Document() IRDocument {
+	doc := IRDocument{Version: IRJSONVersion, Jobs: make([]IRJob, 0)}
+	if ir == nil {
+		return doc
+	}
+	for i := range ir.jobs {
+		doc.Jobs = append(doc.Jobs, ir.jobs[i].document())
+	}
+	return doc
+}
+
+// MarshalIRJSON serializes the IR as an indented, versioned JSON document.
+func MarshalIRJSON(ir *IR) ([]byte, error) {
+	data, err := json.MarshalIndent(ir.Document(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal pipeline IR: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// UnmarshalIRJSON loads an IR exported by MarshalIRJSON. The document version
+// must match IRJSONVersion and the loaded IR must pass Validate. Module paths
+// stay workspace-relative; executors resolve them against their work dir.
+func UnmarshalIRJSON(data []byte) (*IR, error) {
+	var doc IRDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode pipeline IR: %w", err)
+	}
+	if doc.Version != IRJSONVersion {
+		return nil, fmt.Errorf("unsupported pipeline IR version %d (want %d)", doc.Version, IRJSONVersion)
+	}
+
+	ir := &IR{jobs: make([]Job, 0, len(doc.Jobs))}
+	for i := range doc.Jobs {
+		job, err := jobFromDocument(doc.Jobs[i])
+		if err != nil {
+			return nil, err
+		}
+		ir.jobs = append(ir.jobs, job)
+	}
+	if err := ir.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid pipeline IR: %w", err)
+	}
+	return ir, nil
+}
+
+// IRJSONSchema returns the JSON Schema of IRDocument.
+func IRJSONSchema() (string, error) {
+	r := &jsonschema.Reflector{
+		DoNotReference:             true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	schema := r.Reflect(&IRDocument{})
+	schema.ID = "https://github.com/edelwud/terraci/raw/main/terraci-ir.schema.json"
+	schema.Title = "TerraCi Pipeline IR"
+	schema.Description = fmt.Sprintf("Provider-agnostic pipeline job DAG exported by terraci generate --format ir-json (version %d)", IRJSONVersion)
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal IR JSON schema: %w", err)
+	}
+	return string(data), nil
+}
+
+func (j Job) document() IRJob {
+	doc := IRJob{
+		Name:             j.name,
+		Kind:             j.kind,
+		Env:              j.Env(),
+		Dependencies:     DependencyNames(j.dependencies),
+		Consumes:         resourceDocuments(j.consumes),
+		Produces:         resourceDocuments(j.produces),
+		AllowFailure:     j.allowFailure,
+		AlwaysRun:        j.alwaysRun,
+		RequiresApproval: j.approval,
+		Operation: IROperation{
+			Type:     j.operation.typ,
+			Commands: j.operation.Commands(),
+		},
+	}
+	if j.module != nil {
+		doc.Module = &IRModule{
+			Path:       j.module.RelativePath,
+			Workspace:  j.module.Workspace,
+			Segments:   append([]string(nil), j.module.Segments()...),
+			Components: j.module.Components(),
+		}
+	}
+	for _, input := range j.inputArtifacts {
+		doc.InputArtifacts = append(doc.InputArtifacts, IRInputArtifact{
+			Artifact:    artifactDocument(input.Artifact),
+			ProducerJob: input.ProducerJob,
+			Optional:    input.Optional,
+		})
+	}
+	if j.outputArtifact.Configured() {
+		artifact := artifactDocument(j.outputArtifact)
+		doc.OutputArtifact = &artifact
+	}
+	if tf := j.operation.terraform; tf != nil {
+		doc.Operation.Terraform = &IRTerraformOperation{
+			Binary:       tf.binary.String(),
+			ModulePath:   tf.modulePath,
+			Workspace:    tf.workspace,
+			Init:         tf.initEnabled,
+			PlanFile:     tf.planFile,
+			PlanTextFile: tf.planTextFile,
+			PlanJSONFile: tf.planJSONFile,
+			DetailedPlan: tf.detailedPlan,
+			UsePlanFile:  tf.usePlanFile,
+			Destroy:      tf.destroy,
+		}
+	}
+	return doc
+}
+
+func jobFromDocument(doc IRJob) (Job, error) {
+	job := Job{
+		name:         doc.Name,
+		kind:         doc.Kind,
+		allowFailure: doc.AllowFailure,
+		alwaysRun:    doc.AlwaysRun,
+		approval:     doc.RequiresApproval,
+	}
+	if len(doc.Env) > 0 {
+		job.env = doc.Env
+	}
+	if doc.Module != nil {
+		values := make([]string, len(doc.Module.Segments))
+		for i, segment := range doc.Module.Segments {
+			values[i] = doc.Module.Components[segment]
+		}
+		job.module = discovery.NewModule(doc.Module.Segments, values, doc.Module.Path, doc.Module.Path)
+		job.module.Workspace = doc.Module.Workspace
+	}
+	for _, name := range doc.Dependencies {
+		job.dependencies = append(job.dependencies, JobDependency{Job: name})
+	}
+	for _, input := range doc.InputArtifacts {
+		job.inputArtifacts = append(job.inputArtifacts, InputArtifact{
+			Artifact:    artifactFromDocument(input.Artifact),
+			ProducerJob: input.ProducerJob,
+			Optional:    input.Optional,
+		})
+	}
+	if doc.OutputArtifact != nil {
+		job.outputArtifact = artifactFromDocument(*doc.OutputArtifact)
+	}
+	job.consumes = resourcesFromDocuments(doc.Consumes)
+	job.produces = resourcesFromDocuments(doc.Produces)
+
+	job.operation = Operation{
+		typ:      doc.Operation.Type,
+		commands: append([]string(nil), doc.Operation.Commands...),
+	}
+	if tf := doc.Operation.Terraform; tf != nil {
+		binary, err := terraformrun.ParseBinary(tf.Binary)
+		if err != nil {
+			return Job{}, fmt.Errorf("pipeline job %q: %w", doc.Name, err)
+		}
+		if tf.Workspace != "" {
+			if err := discovery.ValidateWorkspaceName(tf.Workspace); err != nil {
+				return Job{}, fmt.Errorf("pipeline job %q: %w", doc.Name, err)
+			}
+		}
+		job.operation.terraform = &TerraformOperation{
+			binary:       binary,
+			kind:         doc.Operation.Type,
+			modulePath:   tf.ModulePath,
+			workspace:    tf.Workspace,
+			initEnabled:  tf.Init,
+			planFile:     tf.PlanFile,
+			planTextFile: tf.PlanTextFile,
+			planJSONFile: tf.PlanJSONFile,
+			detailedPlan: tf.DetailedPlan,
+			usePlanFile:  tf.UsePlanFile,
+			destroy:      tf.Destroy,
+		}
+	}
+	return job, nil
+}
+
+func artifactDocument(artifact Artifact) IRArtifact {
+	return IRArtifact{Name: artifact.Name, Paths: append([]string(nil), artifact.Paths...)}
+}
+
+func artifactFromDocument(doc IRArtifact) Artifact {
+	return Artifact{Name: doc.Name, Paths: append([]string(nil), doc.Paths...)}
+}
+
+func resourceDocuments(resources []ResourceSpec) []IRResource {
+	if len(resources) == 0 {
+		return nil
+	}
+	docs := make([]IRResource, 0, len(resources))
+	for _, resource := range resources {
+		docs = append(docs, IRResource{
+			Kind:       resource.Ref.Kind,
+			ModulePath: resource.Ref.ModulePath,
+			Producer:   resource.Ref.Producer,
+			Path:       resource.Path,
+		})
+	}
+	return docs
+}
+
+func resourcesFromDocuments(docs []IRResource) []ResourceSpec {
+	if len(docs) == 0 {
+		return nil
+	}
+	resources := make([]ResourceSpec, 0, len(docs))
+	for _, doc := range docs {
+		resources = append(resources, ResourceSpec{
+			Ref: ResourceRef{
+				Kind:       doc.Kind,
+				ModulePath: doc.ModulePath,
+				Producer:   doc.Producer,
+			},
+			Path: doc.Path,
+		})
+	}
+	return resources
+}
@@ -0,0 +1,145 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+func TestIRJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("svc", "prod", "eu", "vpc")
+	eks := discovery.TestModule("svc", "prod", "eu", "eks").WithWorkspace("tenant-a")
+	rule, err := config.NewApprovalRule(config.ApprovalRuleOptions{Match: map[string]string{"module": "eks"}})
+	if err != nil {
+		t.Fatalf("NewApprovalRule: %v", err)
+	}
+	opts := testProjectIRBuildInput([]*discovery.Module{vpc, eks}, [][2]int{{1, 0}}, mustIntent(t, true).WithApprovals(rule))
+	opts.Contributions = mustContributionSet(t, mustContribution(t, mustContributedJob(t, ContributedJobOptions{
+		Name:      "cost-estimation",
+		Commands:  []string{"terraci cost"},
+		Consumes:  []ResourceRequest{AllPlanResources(ResourceKindPlanJSON)},
+		Produces:  PluginResultAndReportResources(".terraci", "cost"),
+		AlwaysRun: true,
+	})))
+	ir, err := buildProjectIR(opts)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	data, err := MarshalIRJSON(ir)
+	if err != nil {
+		t.Fatalf("MarshalIRJSON() error = %v", err)
+	}
+	loaded, err := UnmarshalIRJSON(data)
+	if err != nil {
+		t.Fatalf("UnmarshalIRJSON() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Document(), ir.Document()) {
+		t.Fatalf("round trip changed IR:\n got %+v\nwant %+v", loaded.Document(), ir.Document())
+	}
+
+	if cost, ok := loaded.FindJob("cost-estimation"); !ok || !cost.AlwaysRun() {
+		t.Fatal("loaded cost-estimation job should always run")
+	}
+
+	apply, ok := loaded.JobForModule(JobKindApply, eks)
+	if !ok {
+		t.Fatal("loaded IR has no eks apply job")
+	}
+	if !apply.RequiresApproval() {
+		t.Fatal("loaded eks apply should require approval")
+	}
+	if got := apply.Module().Get("environment"); got != "prod" {
+		t.Fatalf("module environment = %q, want prod", got)
+	}
+	if got := apply.Module().ID(); got != "svc/prod/eu/eks@tenant-a" {
+		t.Fatalf("module ID = %q, want workspace preserved", got)
+	}
+	if tf := apply.Operation().Terraform(); tf == nil || !tf.UsePlanFile() || tf.ModulePath() != "svc/prod/eu/eks" || tf.Workspace() != "tenant-a" {
+		t.Fatalf("apply terraform operation = %+v", tf)
+	}
+}
+
+func TestIRJSON_DocumentShape(t *testing.T) {
+	t.Parallel()
+
+	mod := discovery.TestModule("svc", "prod", "eu", "vpc")
+	ir, err := buildProjectIR(testProjectIRBuildInput([]*discovery.Module{mod}, nil, mustIntent(t, false, AllPlanResources(ResourceKindPlanBinary))))
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	data, err := MarshalIRJSON(ir)
+	if err != nil {
+		t.Fatalf("MarshalIRJSON() error = %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if raw["version"] != float64(IRJSONVersion) {
+		t.Fatalf("version = %v, want %d", raw["version"], IRJSONVersion)
+	}
+	for _, want := range []string{`"kind": "plan"`, `"type": "terraform_plan"`, `"path": "svc/prod/eu/vpc"`, `"environment": "prod"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("document missing %s:\n%s", want, data)
+		}
+	}
+}
+
+func TestUnmarshalIRJSON_Rejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "version", data: `{"version": 2, "jobs": []}`, want: "unsupported pipeline IR version 2"},
+		{name: "syntax", data: `{`, want: "decode pipeline IR"},
+		{
+			name: "unknown dependency",
+			data: `{"version": 1, "jobs": [{"name": "a", "kind": "command", "dependencies": ["b"], "operation": {"type": "commands", "commands": ["true"]}}]}`,
+			want: `depends on unknown job "b"`,
+		},
+		{
+			name: "binary",
+			data: `{"version": 1, "jobs": [{"name": "a", "kind": "plan", "module": {"path": "a"}, "operation": {"type": "terraform_plan", "terraform": {"binary": "bad", "module_path": "a"}}}]}`,
+			want: `pipeline job "a"`,
+		},
+		{
+			name: "workspace",
+			data: `{"version": 1, "jobs": [{"name": "a", "kind": "plan", "module": {"path": "a"}, "operation": {"type": "terraform_plan", "terraform": {"binary": "terraform", "module_path": "a", "workspace": "x; rm -rf /"}}}]}`,
+			want: `invalid workspace name "x; rm -rf /"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := UnmarshalIRJSON([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("UnmarshalIRJSON() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := IRJSONSchema()
+	if err != nil {
+		t.Fatalf("IRJSONSchema() error = %v", err)
+	}
+	for _, want := range []string{`"TerraCi Pipeline IR"`, `"requires_approval"`, `"input_artifacts"`, `"plugin_report"`} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("schema missing %s", want)
+		}
+	}
+}
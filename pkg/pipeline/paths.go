@@ -1,11 +1,20 @@
 package pipeline
 
-import "github.com/edelwud/terraci/pkg/workspacepath"
+import (
+	"path"
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/workspacepath"
+)
 
 const (
 	PlanBinaryFilename = "plan.tfplan"
 	PlanTextFilename   = "plan.txt"
 	PlanJSONFilename   = "plan.json"
+
+	TerraformCacheDir  = ".terraform"
+	TerragruntCacheDir = ".terragrunt-cache"
 )
 
 // WorkspacePath joins workspace-relative path components with POSIX
@@ -18,6 +27,25 @@ func ValidateWorkspacePath(value string) error {
 	return workspacepath.Validate(value)
 }
 
+// PlanFilename returns the plan file name of a Terraform workspace: name
+// itself for the default workspace, otherwise name with the workspace
+// before the extension ("plan.tfplan" → "plan.tenant-a.tfplan"), so the
+// workspaces of one module directory never overwrite each other's plans.
+func PlanFilename(name, workspace string) string {
+	if workspace == "" {
+		return name
+	}
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + workspace + ext
+}
+
+// ModulePlanPath returns the workspace-relative path of the plan file name
+// written by the module with the given ID, honoring its workspace.
+func ModulePlanPath(moduleID, name string) string {
+	dir, workspace := discovery.SplitID(moduleID)
+	return WorkspacePath(dir, PlanFilename(name, workspace))
+}
+
 func PlanBinaryPath(modulePath string) string {
 	return WorkspacePath(modulePath, PlanBinaryFilename)
 }
@@ -17,6 +17,17 @@ func TestCanonicalPlanPathsUseWorkspaceSeparators(t *testing.T) {
 	}
 }
 
+func TestModulePlanPath(t *testing.T) {
+	t.Parallel()
+
+	if got := ModulePlanPath("svc/prod/eu/vpc", PlanJSONFilename); got != "svc/prod/eu/vpc/plan.json" {
+		t.Fatalf("ModulePlanPath(default) = %q", got)
+	}
+	if got := ModulePlanPath("svc/prod/eu/vpc@tenant-a", PlanBinaryFilename); got != "svc/prod/eu/vpc/plan.tenant-a.tfplan" {
+		t.Fatalf("ModulePlanPath(workspace) = %q", got)
+	}
+}
+
 func TestWorkspacePathDoesNotHideParentSegments(t *testing.T) {
 	t.Parallel()
 
@@ -14,6 +14,23 @@ type GeneratedPipeline interface {
 	ToYAML() ([]byte, error)
 }
 
+// CommentPrefixer is implemented by generated pipelines whose output format
+// does not use '#' line comments. Writers use the prefix for any banner they
+// prepend to the serialized pipeline.
+type CommentPrefixer interface {
+	CommentPrefix() string
+}
+
+// CommentPrefix returns the line-comment prefix for p, defaulting to '#'.
+func CommentPrefix(p GeneratedPipeline) string {
+	if prefixer, ok := p.(CommentPrefixer); ok {
+		if prefix := prefixer.CommentPrefix(); prefix != "" {
+			return prefix
+		}
+	}
+	return "#"
+}
+
 // Generator transforms a pipeline IR into a provider-specific pipeline. The
 // IR is bound at construction time; callers do not pass modules to Generate
 // or DryRun because the IR already encodes the canonical execution plan.
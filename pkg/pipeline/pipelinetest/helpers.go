@@ -5,6 +5,7 @@ package pipelinetest
 import (
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/graph"
 	"github.com/edelwud/terraci/pkg/pipeline"
@@ -66,12 +67,42 @@ func MustCommandIR(tb testing.TB, opts ...pipeline.ContributedJobOptions) *pipel
 // MustSingleModuleIR builds a valid plan/apply IR for a single module.
 func MustSingleModuleIR(tb testing.TB, module *discovery.Module) *pipeline.IR {
 	tb.Helper()
-	depGraph := graph.NewDependencyGraph()
-	depGraph.AddNode(module)
 	intent, err := pipeline.ApplyBuildIntent()
 	if err != nil {
 		tb.Fatalf("ApplyBuildIntent() error = %v", err)
 	}
+	return mustSingleModuleIR(tb, module, intent)
+}
+
+// MustDestroySingleModuleIR builds a destroy plan/apply IR for a single module.
+func MustDestroySingleModuleIR(tb testing.TB, module *discovery.Module) *pipeline.IR {
+	tb.Helper()
+	intent, err := pipeline.DestroyBuildIntent()
+	if err != nil {
+		tb.Fatalf("DestroyBuildIntent() error = %v", err)
+	}
+	return mustSingleModuleIR(tb, module, intent)
+}
+
+// MustGatedSingleModuleIR builds a plan/apply IR whose apply job requires
+// manual approval.
+func MustGatedSingleModuleIR(tb testing.TB, module *discovery.Module) *pipeline.IR {
+	tb.Helper()
+	rule, err := config.NewApprovalRule(config.ApprovalRuleOptions{Match: map[string]string{"module": "*"}})
+	if err != nil {
+		tb.Fatalf("NewApprovalRule() error = %v", err)
+	}
+	intent, err := pipeline.ApplyBuildIntent()
+	if err != nil {
+		tb.Fatalf("ApplyBuildIntent() error = %v", err)
+	}
+	return mustSingleModuleIR(tb, module, intent.WithApprovals(rule))
+}
+
+func mustSingleModuleIR(tb testing.TB, module *discovery.Module, intent pipeline.BuildIntent) *pipeline.IR {
+	tb.Helper()
+	depGraph := graph.NewDependencyGraph()
+	depGraph.AddNode(module)
 	terraformConfig, err := pipeline.NewTerraformJobConfig(pipeline.TerraformJobConfigOptions{
 		Binary:      "terraform",
 		InitEnabled: true,
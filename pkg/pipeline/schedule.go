@@ -18,6 +18,8 @@ func (g JobGroup) Jobs() []Job { return cloneJobs(g.jobs) }
 func (g JobGroup) JobCount() int { return len(g.jobs) }
 
 // Schedule groups an IR into deterministic topological execution barriers.
+// Jobs that always run never share a group with jobs that do not, so
+// providers can relax the failure condition of a whole group.
 func Schedule(ir *IR) ([]JobGroup, error) {
 	if ir == nil {
 		return nil, nil
@@ -64,6 +66,7 @@ func Schedule(ir *IR) ([]JobGroup, error) {
 		if len(layer) == 0 {
 			break
 		}
+		layer = deferAlwaysRun(layer)
 
 		groups = append(groups, JobGroup{
 			name: dagGroupName(len(groups)),
@@ -92,6 +95,22 @@ func Schedule(ir *IR) ([]JobGroup, error) {
 	return groups, nil
 }
 
+// deferAlwaysRun keeps the jobs of a ready layer that do not always run,
+// leaving always-run jobs for a later group. A layer of only always-run jobs
+// is kept whole.
+func deferAlwaysRun(layer []Job) []Job {
+	kept := make([]Job, 0, len(layer))
+	for i := range layer {
+		if !layer[i].alwaysRun {
+			kept = append(kept, layer[i])
+		}
+	}
+	if len(kept) == 0 {
+		return layer
+	}
+	return kept
+}
+
 func dagGroupName(index int) string {
 	return fmt.Sprintf("dag-level-%d", index)
 }
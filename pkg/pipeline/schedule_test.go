@@ -1,6 +1,9 @@
 package pipeline
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestScheduleUsesTopologicalLayers(t *testing.T) {
 	t.Parallel()
@@ -45,6 +48,33 @@ func TestScheduleUsesTopologicalLayers(t *testing.T) {
 	}
 }
 
+func TestScheduleDefersAlwaysRunJobs(t *testing.T) {
+	t.Parallel()
+
+	ir := &IR{
+		jobs: []Job{
+			{name: "plan-0"},
+			{name: "notify", alwaysRun: true},
+			{name: "plan-1", dependencies: []JobDependency{{Job: "plan-0"}}},
+			{name: "collect", alwaysRun: true, dependencies: []JobDependency{{Job: "plan-1"}}},
+		},
+	}
+
+	groups, err := Schedule(ir)
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	want := [][]string{{"plan-0"}, {"plan-1"}, {"notify", "collect"}}
+	if len(groups) != len(want) {
+		t.Fatalf("groups = %v, want %v", groupNames(groups), want)
+	}
+	for i := range want {
+		if got := jobNamesInGroup(groups[i]); !slices.Equal(got, want[i]) {
+			t.Fatalf("group %d jobs = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
 func TestScheduleRejectsUnknownDependency(t *testing.T) {
 	t.Parallel()
 
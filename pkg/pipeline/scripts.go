@@ -3,6 +3,7 @@ package pipeline
 import (
 	"maps"
 
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/terraformrun"
 )
 
@@ -55,45 +56,49 @@ func (o PlanOutputs) Detailed() bool {
 
 // NewPlanOperation creates a typed terraform plan operation plus the resources
 // and artifact that must restore plan files at their original
-// workspace-relative paths.
-func (c TerraformJobConfig) NewPlanOperation(jobName, modulePath string, outputs PlanOutputs) (Operation, []ResourceSpec, Artifact) {
+// workspace-relative paths. Resources are keyed by the module ID, so the
+// workspaces of one directory produce distinct resources.
+func (c TerraformJobConfig) NewPlanOperation(jobName string, module *discovery.Module, outputs PlanOutputs) (Operation, []ResourceSpec, Artifact) {
+	modulePath := module.RelativePath
 	op := Operation{
 		typ: OperationTypeTerraformPlan,
 		terraform: &TerraformOperation{
 			binary:       c.binary,
 			kind:         OperationTypeTerraformPlan,
 			modulePath:   modulePath,
+			workspace:    module.Workspace,
 			initEnabled:  c.initEnabled,
-			planFile:     PlanBinaryPath(modulePath),
+			planFile:     WorkspacePath(modulePath, PlanFilename(PlanBinaryFilename, module.Workspace)),
 			detailedPlan: outputs.Detailed(),
 		},
 	}
 
 	resources := []ResourceSpec{
-		PlanResource(ResourceKindPlanBinary, modulePath, op.terraform.planFile),
+		PlanResource(ResourceKindPlanBinary, module.ID(), op.terraform.planFile),
 	}
 	if outputs.Text {
-		op.terraform.planTextFile = PlanTextPath(modulePath)
-		resources = append(resources, PlanResource(ResourceKindPlanText, modulePath, op.terraform.planTextFile))
+		op.terraform.planTextFile = WorkspacePath(modulePath, PlanFilename(PlanTextFilename, module.Workspace))
+		resources = append(resources, PlanResource(ResourceKindPlanText, module.ID(), op.terraform.planTextFile))
 	}
 	if outputs.JSON {
-		op.terraform.planJSONFile = PlanJSONPath(modulePath)
-		resources = append(resources, PlanResource(ResourceKindPlanJSON, modulePath, op.terraform.planJSONFile))
+		op.terraform.planJSONFile = WorkspacePath(modulePath, PlanFilename(PlanJSONFilename, module.Workspace))
+		resources = append(resources, PlanResource(ResourceKindPlanJSON, module.ID(), op.terraform.planJSONFile))
 	}
 
 	return op, resources, PlanArtifact(jobName, resourcePaths(resources))
 }
 
 // NewApplyOperation creates a typed terraform apply operation.
-func (c TerraformJobConfig) NewApplyOperation(modulePath string, usePlanFile bool) Operation {
+func (c TerraformJobConfig) NewApplyOperation(module *discovery.Module, usePlanFile bool) Operation {
 	return Operation{
 		typ: OperationTypeTerraformApply,
 		terraform: &TerraformOperation{
 			binary:      c.binary,
 			kind:        OperationTypeTerraformApply,
-			modulePath:  modulePath,
+			modulePath:  module.RelativePath,
+			workspace:   module.Workspace,
 			initEnabled: c.initEnabled,
-			planFile:    PlanBinaryPath(modulePath),
+			planFile:    WorkspacePath(module.RelativePath, PlanFilename(PlanBinaryFilename, module.Workspace)),
 			usePlanFile: usePlanFile,
 		},
 	}
@@ -3,6 +3,7 @@ package pipeline
 import (
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/terraformrun"
 )
 
@@ -25,7 +26,7 @@ func TestNewTerraformJobConfigFromProfile(t *testing.T) {
 		t.Fatalf("NewTerraformJobConfigFromProfile() error = %v", err)
 	}
 
-	op := config.NewApplyOperation("platform/stage/eu-central-1/vpc", false).Terraform()
+	op := config.NewApplyOperation(discovery.TestModule("platform", "stage", "eu-central-1", "vpc"), false).Terraform()
 	if op.Binary() != "tofu" {
 		t.Fatalf("Binary() = %q, want tofu", op.Binary())
 	}
@@ -24,6 +24,8 @@ type Job struct {
 	consumes       []ResourceSpec
 	produces       []ResourceSpec
 	allowFailure   bool
+	alwaysRun      bool // runs even when a dependency failed
+	approval       bool // apply waits for a manual approval
 	operation      Operation
 }
 
@@ -78,12 +80,14 @@ type TerraformOperation struct {
 	binary       terraformrun.Binary
 	kind         OperationType
 	modulePath   string
+	workspace    string
 	initEnabled  bool
 	planFile     string
 	planTextFile string
 	planJSONFile string
 	detailedPlan bool
 	usePlanFile  bool
+	destroy      bool
 }
 
 // Contribution describes provider-independent DAG jobs added by a plugin.
@@ -105,6 +109,7 @@ type ContributedJob struct {
 	consumes     []ResourceRequest
 	produces     []ResourceSpec
 	allowFailure bool
+	alwaysRun    bool
 }
 
 // Jobs returns the IR jobs in deterministic execution-plan order.
@@ -182,6 +187,25 @@ func (ir *IR) JobForModule(kind JobKind, module *discovery.Module) (Job, bool) {
 	return zero, false
 }
 
+// AlwaysRunDependencies returns the names of the jobs that an always-run job
+// depends on. Providers that stop at the first failed stage let these jobs
+// fail without stopping the pipeline so the always-run job still starts.
+func (ir *IR) AlwaysRunDependencies() map[string]bool {
+	deps := make(map[string]bool)
+	if ir == nil {
+		return deps
+	}
+	for i := range ir.jobs {
+		if !ir.jobs[i].alwaysRun {
+			continue
+		}
+		for _, dep := range ir.jobs[i].dependencies {
+			deps[dep.Job] = true
+		}
+	}
+	return deps
+}
+
 // HasDependency reports whether jobName depends on dependencyName.
 func (ir *IR) HasDependency(jobName, dependencyName string) bool {
 	job, ok := ir.FindJob(jobName)
@@ -211,6 +235,7 @@ func newCommandJob(job ContributedJob) Job {
 		outputArtifact: resultArtifactFromResources(job.Name(), produces),
 		produces:       produces,
 		allowFailure:   job.AllowFailure(),
+		alwaysRun:      job.AlwaysRun(),
 		operation:      newCommandOperation(job.Commands()),
 	}
 }
@@ -279,6 +304,16 @@ func (j Job) Produces() []ResourceSpec {
 // AllowFailure reports whether the job may fail without failing the pipeline.
 func (j Job) AllowFailure() bool { return j.allowFailure }
 
+// AlwaysRun reports whether the job runs even when one of its dependencies
+// failed. Its input artifacts are optional because failed producers may not
+// publish them.
+func (j Job) AlwaysRun() bool { return j.alwaysRun }
+
+// RequiresApproval reports whether the job must wait for a manual approval
+// before it runs. Only apply jobs of modules matched by an approval rule are
+// gated.
+func (j Job) RequiresApproval() bool { return j.approval }
+
 // Operation returns the executable job payload.
 func (j Job) Operation() Operation { return j.operation.clone() }
 
@@ -332,9 +367,22 @@ func (o TerraformOperation) Kind() OperationType { return o.kind }
 // Binary returns the Terraform-compatible executable name for this operation.
 func (o TerraformOperation) Binary() string { return o.binary.String() }
 
+// CacheDir returns the module-local directory where the binary keeps
+// downloaded providers and modules.
+func (o TerraformOperation) CacheDir() string {
+	if o.binary == terraformrun.BinaryTerragrunt {
+		return TerragruntCacheDir
+	}
+	return TerraformCacheDir
+}
+
 // ModulePath returns the workspace-relative module path.
 func (o TerraformOperation) ModulePath() string { return o.modulePath }
 
+// Workspace returns the Terraform workspace to select before the
+// operation; empty means the default workspace.
+func (o TerraformOperation) Workspace() string { return o.workspace }
+
 // InitEnabled reports whether terraform init should run before this operation.
 func (o TerraformOperation) InitEnabled() bool { return o.initEnabled }
 
@@ -353,6 +401,9 @@ func (o TerraformOperation) DetailedPlan() bool { return o.detailedPlan }
 // UsePlanFile reports whether apply should consume the binary plan file.
 func (o TerraformOperation) UsePlanFile() bool { return o.usePlanFile }
 
+// Destroy reports whether the operation plans or applies a destroy.
+func (o TerraformOperation) Destroy() bool { return o.destroy }
+
 func cloneArtifact(artifact Artifact) Artifact {
 	artifact.Paths = append([]string(nil), artifact.Paths...)
 	return artifact
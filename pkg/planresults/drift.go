@@ -0,0 +1,164 @@
+package planresults
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/edelwud/terraci/internal/terraform/plan"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/pipeline"
+)
+
+// DriftReportProducer is the report producer key for drift detection.
+const DriftReportProducer = "drift"
+
+// DriftStatus classifies one module in a drift scan.
+type DriftStatus string
+
+const (
+	DriftStatusDrifted DriftStatus = "drifted"
+	DriftStatusClean   DriftStatus = "clean"
+	DriftStatusErrored DriftStatus = "errored"
+)
+
+// DriftModule is the drift outcome of a single module plan.
+type DriftModule struct {
+	ModulePath string      `json:"module_path"`
+	Status     DriftStatus `json:"status"`
+	ToAdd      int         `json:"to_add,omitempty"`
+	ToChange   int         `json:"to_change,omitempty"`
+	ToDestroy  int         `json:"to_destroy,omitempty"`
+	ToImport   int         `json:"to_import,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// DriftSummary aggregates drift outcomes across modules.
+type DriftSummary struct {
+	Modules []DriftModule `json:"modules"`
+	Drifted int           `json:"drifted"`
+	Clean   int           `json:"clean"`
+	Errored int           `json:"errored"`
+}
+
+// HasDrift reports whether any module plan proposed changes.
+func (s *DriftSummary) HasDrift() bool {
+	return s != nil && s.Drifted > 0
+}
+
+// ScanDrift classifies module plans under rootDir as drifted, clean or
+// errored. modulePaths are module IDs relative to rootDir, so a workspace
+// module reads its plan.<workspace>.json; a listed module without a
+// readable plan file is errored. When modulePaths is empty every directory
+// containing a plan.json is scanned.
+func ScanDrift(rootDir string, modulePaths []string) (*DriftSummary, error) {
+	paths := slices.Clone(modulePaths)
+	if len(paths) == 0 {
+		ids, err := FindModulePlans(rootDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan for plan results: %w", err)
+		}
+		paths = ids
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	summary := &DriftSummary{Modules: make([]DriftModule, 0, len(paths))}
+	for _, modulePath := range paths {
+		module := scanDriftModule(rootDir, modulePath)
+		switch module.Status {
+		case DriftStatusDrifted:
+			summary.Drifted++
+		case DriftStatusClean:
+			summary.Clean++
+		case DriftStatusErrored:
+			summary.Errored++
+		}
+		summary.Modules = append(summary.Modules, module)
+	}
+	return summary, nil
+}
+
+func scanDriftModule(rootDir, modulePath string) DriftModule {
+	module := DriftModule{ModulePath: modulePath}
+	parsed, err := plan.ParseJSON(filepath.Join(rootDir, filepath.FromSlash(pipeline.ModulePlanPath(modulePath, pipeline.PlanJSONFilename))))
+	if err != nil {
+		module.Status = DriftStatusErrored
+		module.Error = err.Error()
+		if errors.Is(err, fs.ErrNotExist) {
+			module.Error = "plan.json not found"
+		}
+		return module
+	}
+	module.ToAdd = parsed.ToAdd
+	module.ToChange = parsed.ToChange
+	module.ToDestroy = parsed.ToDestroy
+	module.ToImport = parsed.ToImport
+	module.Status = DriftStatusClean
+	if parsed.HasChanges() {
+		module.Status = DriftStatusDrifted
+	}
+	return module
+}
+
+// BuildDriftReport renders a drift summary as a ci.Report. Errored modules
+// fail the report; drifted modules warn.
+func BuildDriftReport(summary *DriftSummary, run ci.ArtifactRun) (*ci.Report, error) {
+	if summary == nil {
+		return nil, errors.New("drift summary is nil")
+	}
+
+	rows := make([]ci.RenderRow, 0, len(summary.Modules))
+	for _, module := range summary.Modules {
+		if module.Status == DriftStatusClean {
+			continue
+		}
+		status, detail := ci.ReportStatusWarn, "-"
+		if module.Status == DriftStatusErrored {
+			status, detail = ci.ReportStatusFail, module.Error
+		}
+		rows = append(rows, ci.NewRenderRow(
+			ci.RenderModulePath(module.ModulePath),
+			ci.RenderStatus(status),
+			ci.RenderText(strconv.Itoa(module.ToAdd)),
+			ci.RenderText(strconv.Itoa(module.ToChange)),
+			ci.RenderText(strconv.Itoa(module.ToDestroy)),
+			ci.RenderText(strconv.Itoa(module.ToImport)),
+			ci.RenderText(detail),
+		))
+	}
+
+	summaryText := fmt.Sprintf("%d modules: %d drifted, %d clean, %d errored",
+		len(summary.Modules), summary.Drifted, summary.Clean, summary.Errored)
+	blocks := make([]ci.RenderBlock, 0, 1)
+	if len(rows) > 0 {
+		blocks = append(blocks, ci.NewTableBlock("", []ci.RenderColumn{
+			ci.NewRenderColumn("Module"),
+			ci.NewRenderColumn("Status"),
+			ci.NewRenderColumn("Add"),
+			ci.NewRenderColumn("Change"),
+			ci.NewRenderColumn("Destroy"),
+			ci.NewRenderColumn("Import"),
+			ci.NewRenderColumn("Error"),
+		}, rows))
+	}
+	report, err := ci.NewRenderedReport(ci.RenderedReportOptions{
+		Producer: DriftReportProducer,
+		Title:    "Drift Detection",
+		Status:   ci.StatusFromCounts(summary.Errored, summary.Drifted),
+		Summary:  summaryText,
+		Artifact: run.Artifact(),
+		Sections: []ci.RenderedSectionOptions{{
+			Title:   "Drift Detection",
+			Summary: summaryText,
+			Blocks:  blocks,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build drift report: %w", err)
+	}
+	return report, nil
+}
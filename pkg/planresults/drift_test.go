@@ -0,0 +1,100 @@
+package planresults
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+func writeDriftPlan(t *testing.T, root, modulePath, content string) {
+	t.Helper()
+	dir := filepath.Join(root, modulePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create dir %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "plan.json"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write plan.json: %v", err)
+	}
+}
+
+func TestScanDrift_ClassifiesModules(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeDriftPlan(t, tmpDir, "platform/stage/eu-central-1/vpc", samplePlanJSONWithChanges)
+	writeDriftPlan(t, tmpDir, "platform/stage/eu-central-1/eks", samplePlanJSONNoChanges)
+	writeDriftPlan(t, tmpDir, "platform/stage/eu-central-1/rds", "{not json")
+
+	summary, err := ScanDrift(tmpDir, []string{
+		"platform/stage/eu-central-1/vpc",
+		"platform/stage/eu-central-1/eks",
+		"platform/stage/eu-central-1/rds",
+		"platform/stage/eu-central-1/missing",
+	})
+	if err != nil {
+		t.Fatalf("ScanDrift() error = %v", err)
+	}
+	if summary.Drifted != 1 || summary.Clean != 1 || summary.Errored != 2 {
+		t.Fatalf("counts = drifted %d clean %d errored %d, want 1/1/2", summary.Drifted, summary.Clean, summary.Errored)
+	}
+	if !summary.HasDrift() {
+		t.Fatal("HasDrift() = false, want true")
+	}
+
+	byPath := make(map[string]DriftModule, len(summary.Modules))
+	for _, module := range summary.Modules {
+		byPath[module.ModulePath] = module
+	}
+	vpc := byPath["platform/stage/eu-central-1/vpc"]
+	if vpc.Status != DriftStatusDrifted || vpc.ToAdd != 1 || vpc.ToChange != 1 {
+		t.Fatalf("vpc = %+v, want drifted with 1 add and 1 change", vpc)
+	}
+	if missing := byPath["platform/stage/eu-central-1/missing"]; missing.Error != "plan.json not found" {
+		t.Fatalf("missing module error = %q, want plan.json not found", missing.Error)
+	}
+}
+
+func TestScanDrift_DiscoversPlansWhenNoModulesGiven(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeDriftPlan(t, tmpDir, "platform/prod/eu-central-1/eks", samplePlanJSONNoChanges)
+
+	summary, err := ScanDrift(tmpDir, nil)
+	if err != nil {
+		t.Fatalf("ScanDrift() error = %v", err)
+	}
+	if len(summary.Modules) != 1 || summary.Modules[0].ModulePath != "platform/prod/eu-central-1/eks" {
+		t.Fatalf("modules = %+v, want discovered eks module", summary.Modules)
+	}
+	if summary.HasDrift() {
+		t.Fatal("HasDrift() = true, want false")
+	}
+}
+
+func TestBuildDriftReport(t *testing.T) {
+	summary := &DriftSummary{
+		Modules: []DriftModule{
+			{ModulePath: "platform/stage/eu-central-1/vpc", Status: DriftStatusDrifted, ToChange: 2},
+			{ModulePath: "platform/stage/eu-central-1/eks", Status: DriftStatusClean},
+		},
+		Drifted: 1,
+		Clean:   1,
+	}
+	run, err := ci.NewArtifactRun(ci.ArtifactRunOptions{Producer: DriftReportProducer})
+	if err != nil {
+		t.Fatalf("NewArtifactRun() error = %v", err)
+	}
+
+	report, err := BuildDriftReport(summary, run)
+	if err != nil {
+		t.Fatalf("BuildDriftReport() error = %v", err)
+	}
+	if report.Producer() != DriftReportProducer {
+		t.Fatalf("Producer() = %q, want %q", report.Producer(), DriftReportProducer)
+	}
+	if report.Status() != ci.ReportStatusWarn {
+		t.Fatalf("Status() = %q, want warn", report.Status())
+	}
+	if report.Summary() != "2 modules: 1 drifted, 1 clean, 0 errored" {
+		t.Fatalf("Summary() = %q", report.Summary())
+	}
+}
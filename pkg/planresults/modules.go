@@ -3,7 +3,9 @@ package planresults
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/pipeline"
 )
 
@@ -30,3 +32,55 @@ func FindModulesWithPlan(rootDir string) ([]string, error) {
 
 	return paths, err
 }
+
+// FindModulePlans finds the plan JSON files under rootDir and returns the
+// IDs of the modules that wrote them, relative to rootDir. A plan.json is
+// the module's default workspace; plan.<workspace>.json belongs to the
+// module ID "<dir>@<workspace>".
+func FindModulePlans(rootDir string) ([]string, error) {
+	var ids []string
+
+	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return nil //nolint:nilerr // skip inaccessible paths
+		}
+		if info.IsDir() {
+			return nil
+		}
+		workspace, ok := planWorkspace(info.Name())
+		if !ok {
+			return nil
+		}
+		rel, err := filepath.Rel(rootDir, filepath.Dir(path))
+		if err != nil {
+			return nil //nolint:nilerr // skip paths outside rootDir
+		}
+		id := filepath.ToSlash(rel)
+		if workspace != "" {
+			id += discovery.WorkspaceSeparator + workspace
+		}
+		ids = append(ids, id)
+		return nil
+	})
+
+	return ids, err
+}
+
+// planWorkspace reports whether name is a plan JSON file and returns the
+// workspace encoded in it.
+func planWorkspace(name string) (string, bool) {
+	if name == pipeline.PlanJSONFilename {
+		return "", true
+	}
+	ext := filepath.Ext(pipeline.PlanJSONFilename)
+	prefix := strings.TrimSuffix(pipeline.PlanJSONFilename, ext) + "."
+	workspace, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return "", false
+	}
+	workspace, ok = strings.CutSuffix(workspace, ext)
+	if !ok || discovery.ValidateWorkspaceName(workspace) != nil {
+		return "", false
+	}
+	return workspace, true
+}
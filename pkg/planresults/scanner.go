@@ -9,40 +9,36 @@ import (
 
 	"github.com/edelwud/terraci/internal/terraform/plan"
 	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/pipeline"
 )
 
 // defaultPlanSegments is the default pattern segments when none are provided.
 var defaultPlanSegments = []string{"service", "environment", "region", "module"}
 
-// Scan scans for plan.json files in module directories
-// and builds a collection of plan results from their contents.
+// Scan scans for plan.json files in module directories, including the
+// plan.<workspace>.json files of workspace modules, and builds a collection
+// of plan results from their contents.
 // If segments is nil or empty, default segments (service/environment/region/module) are used.
 func Scan(rootDir string, segments []string) (*ci.PlanResultCollection, error) {
 	if len(segments) == 0 {
 		segments = defaultPlanSegments
 	}
 
-	moduleDirs, err := FindModulesWithPlan(rootDir)
+	moduleIDs, err := FindModulePlans(rootDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan for plan results: %w", err)
 	}
 
-	results := make([]ci.PlanResult, 0, len(moduleDirs))
-	for _, dir := range moduleDirs {
-		jsonPath := filepath.Join(dir, pipeline.PlanJSONFilename)
+	results := make([]ci.PlanResult, 0, len(moduleIDs))
+	for _, moduleID := range moduleIDs {
+		jsonPath := filepath.Join(rootDir, filepath.FromSlash(pipeline.ModulePlanPath(moduleID, pipeline.PlanJSONFilename)))
+		modulePath, _ := discovery.SplitID(moduleID)
 
-		modulePath := dir
-		if rootDir != "." {
-			if relPath, relErr := filepath.Rel(rootDir, dir); relErr == nil {
-				modulePath = filepath.ToSlash(relPath)
-			}
-		}
-
-		result, parseErr := parsePlanJSON(jsonPath, modulePath, segments)
+		result, parseErr := parsePlanJSON(jsonPath, moduleID, segments)
 		if parseErr != nil {
 			result, err = ci.NewPlanResult(ci.PlanResultOptions{
-				ModuleID:   filepath.ToSlash(modulePath),
+				ModuleID:   moduleID,
 				ModulePath: modulePath,
 				Status:     ci.PlanStatusFailed,
 				Summary:    "Failed to parse plan",
@@ -82,13 +78,14 @@ func ParseModulePathComponents(modulePath string, segments []string) map[string]
 	return components
 }
 
-func parsePlanJSON(jsonPath, modulePath string, segments []string) (ci.PlanResult, error) {
+func parsePlanJSON(jsonPath, moduleID string, segments []string) (ci.PlanResult, error) {
 	parsed, err := plan.ParseJSON(jsonPath)
 	if err != nil {
 		var empty ci.PlanResult
 		return empty, err
 	}
 
+	modulePath, _ := discovery.SplitID(moduleID)
 	components := ParseModulePathComponents(modulePath, segments)
 
 	txtPath := strings.TrimSuffix(jsonPath, ".json") + ".txt"
@@ -98,7 +95,7 @@ func parsePlanJSON(jsonPath, modulePath string, segments []string) (ci.PlanResul
 	}
 
 	return ci.NewPlanResult(ci.PlanResultOptions{
-		ModuleID:          filepath.ToSlash(modulePath),
+		ModuleID:          moduleID,
 		ModulePath:        modulePath,
 		Components:        components,
 		Status:            ci.PlanStatusFromPlan(parsed.HasChanges()),
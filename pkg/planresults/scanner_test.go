@@ -177,6 +177,44 @@ func TestScanPlanResults_WithSubmodule(t *testing.T) {
 	}
 }
 
+func TestScanPlanResults_Workspaces(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	dir := filepath.Join(tmpDir, "platform", "stage", "eu-central-1", "vpc")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	for name, content := range map[string]string{
+		"plan.json":          samplePlanJSONNoChanges,
+		"plan.tenant-a.json": samplePlanJSONWithChanges,
+		"plan.tenant-a.txt":  "Plan: 1 to add",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	collection, err := Scan(tmpDir, nil)
+	if err != nil {
+		t.Fatalf("ScanPlanResults failed: %v", err)
+	}
+
+	byID := make(map[string]ci.PlanResult)
+	for _, result := range collection.Results() {
+		byID[result.ModuleID()] = result
+	}
+	if len(byID) != 2 || byID["platform/stage/eu-central-1/vpc"].Status() != ci.PlanStatusNoChanges {
+		t.Fatalf("results = %v, want default and tenant-a plans", byID)
+	}
+	tenant := byID["platform/stage/eu-central-1/vpc@tenant-a"]
+	if tenant.Status() != ci.PlanStatusChanges || tenant.ModulePath() != "platform/stage/eu-central-1/vpc" {
+		t.Errorf("tenant-a = %s at %q, want changes in the module directory", tenant.Status(), tenant.ModulePath())
+	}
+	if tenant.Component("module") != "vpc" || tenant.RawPlanOutput() == "" {
+		t.Errorf("tenant-a components = %v, raw output = %q", tenant.Components(), tenant.RawPlanOutput())
+	}
+}
+
 func TestScanPlanResults_Empty(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -86,7 +86,7 @@ type ciProviderPlugin interface {
 func (r *Registry) ResolveCIProvider() (*plugin.ResolvedCIProvider, error) {
 	candidates := activeByCapability[ciProviderPlugin](r)
 	if len(candidates) == 0 {
-		return nil, errors.New("no active CI provider plugins — configure extensions.gitlab or extensions.github in .terraci.yaml")
+		return nil, errors.New("no active CI provider plugins — configure a CI provider extension (e.g. extensions.gitlab or extensions.github) in .terraci.yaml")
 	}
 
 	// Explicit selection wins over auto-detection. This is important for local
@@ -18,8 +18,9 @@ const (
 type Binary string
 
 const (
-	BinaryTerraform Binary = config.ExecutionBinaryTerraform
-	BinaryTofu      Binary = config.ExecutionBinaryTofu
+	BinaryTerraform  Binary = config.ExecutionBinaryTerraform
+	BinaryTofu       Binary = config.ExecutionBinaryTofu
+	BinaryTerragrunt Binary = config.ExecutionBinaryTerragrunt
 )
 
 func (b Binary) String() string {
@@ -34,7 +35,7 @@ func ParseBinary(raw string) (Binary, error) {
 	switch normalized := Binary(strings.TrimSpace(raw)); normalized {
 	case "":
 		return BinaryTerraform, nil
-	case BinaryTerraform, BinaryTofu:
+	case BinaryTerraform, BinaryTofu, BinaryTerragrunt:
 		return normalized, nil
 	default:
 		return "", fmt.Errorf("unsupported terraform binary %q", raw)
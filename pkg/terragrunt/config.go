@@ -0,0 +1,409 @@
+// Package terragrunt reads Terragrunt unit configuration. It follows include
+// and read_terragrunt_config chains far enough to collect the dependency and
+// dependencies blocks that order units in the dependency graph; it does not
+// render inputs or run Terragrunt itself.
+package terragrunt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/edelwud/terraci/internal/terraform/eval"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+// maxIncludeDepth bounds include chains so that a file including itself
+// (directly or through a cycle) fails instead of recursing forever.
+const maxIncludeDepth = 16
+
+// mergeStrategyNone is the include merge_strategy that keeps the included
+// file's blocks out of the unit.
+const mergeStrategyNone = "no_merge"
+
+// Unit is the dependency-relevant part of a Terragrunt unit configuration.
+type Unit struct {
+	// Dir is the absolute unit directory.
+	Dir string
+	// Dependencies are the unit's dependency blocks. A block declared in the
+	// unit overrides an included block with the same name.
+	Dependencies []Dependency
+	// Paths are the absolute directories listed in dependencies.paths of the
+	// unit and its merged includes, deduplicated in declaration order.
+	Paths []string
+	// Includes are the absolute paths of every included configuration file.
+	Includes []string
+}
+
+// Dependency is one dependency block of a unit.
+type Dependency struct {
+	Name string
+	// ConfigPath is the absolute directory of the unit the block points at.
+	ConfigPath string
+}
+
+// Parser reads Terragrunt configuration files relative to a repository root.
+// Parsed files are cached, so a root configuration shared by every unit is
+// read once. A Parser is safe for concurrent use.
+type Parser struct {
+	repoRoot string
+
+	mu    sync.Mutex
+	files map[string]*hclsyntax.Body
+}
+
+// NewParser creates a Parser. repoRoot backs get_repo_root() and the
+// repository-relative path functions.
+func NewParser(repoRoot string) *Parser {
+	if abs, err := filepath.Abs(repoRoot); err == nil {
+		repoRoot = abs
+	}
+	return &Parser{repoRoot: repoRoot, files: make(map[string]*hclsyntax.Body)}
+}
+
+// ParseUnit reads the terragrunt.hcl of the unit in dir together with its
+// includes.
+func (p *Parser) ParseUnit(dir string) (*Unit, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &scope{parser: p, originalDir: dir, terragruntDir: dir}
+	cfg, err := s.load(filepath.Join(dir, discovery.TerragruntUnitFile), 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Unit{
+		Dir:          dir,
+		Dependencies: cfg.dependencies,
+		Paths:        cfg.paths,
+		Includes:     cfg.includes,
+	}, nil
+}
+
+// body returns the parsed body of a configuration file.
+func (p *Parser) body(path string) (*hclsyntax.Body, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if body, ok := p.files[path]; ok {
+		return body, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, diags := hclsyntax.ParseConfig(content, path, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("%s: unexpected HCL body type %T", path, file.Body)
+	}
+	p.files[path] = body
+	return body, nil
+}
+
+// config is one evaluated configuration file with its merged includes.
+type config struct {
+	locals       map[string]cty.Value
+	attributes   map[string]cty.Value
+	dependencies []Dependency
+	paths        []string
+	includes     []string
+}
+
+// scope carries the directories Terragrunt functions resolve against. The
+// original dir is the unit being parsed; the terragrunt dir differs from it
+// only inside files loaded by read_terragrunt_config.
+type scope struct {
+	parser        *Parser
+	originalDir   string
+	terragruntDir string
+	// reading holds the files currently being read by read_terragrunt_config,
+	// guarding against read cycles.
+	reading []string
+}
+
+// load evaluates one configuration file. Includes are resolved first, so the
+// file's locals can reference exposed includes, as in Terragrunt.
+func (s *scope) load(path string, depth int) (*config, error) {
+	if depth > maxIncludeDepth {
+		return nil, fmt.Errorf("%s: include chain deeper than %d", path, maxIncludeDepth)
+	}
+	body, err := s.parser.body(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := s.context(path)
+	cfg := &config{}
+	var merged []*config
+	exposed := make(map[string]cty.Value)
+	for _, block := range blocksOfType(body, "include") {
+		target, ok, err := evalPath(block.Body, "path", ctx, filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("%s: include: %w", path, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: include block has no path", path)
+		}
+		included, err := s.load(target, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		cfg.includes = append(cfg.includes, target)
+		cfg.includes = append(cfg.includes, included.includes...)
+		if strategy, _ := evalString(block.Body, "merge_strategy", ctx); strategy != mergeStrategyNone {
+			merged = append(merged, included)
+		}
+		if expose, _ := evalBool(block.Body, "expose", ctx); !expose {
+			continue
+		}
+		if len(block.Labels) == 0 {
+			// A bare include is referenced directly as include.locals.
+			ctx.Variables["include"] = included.value()
+			continue
+		}
+		exposed[block.Labels[0]] = included.value()
+	}
+	if len(exposed) > 0 {
+		ctx.Variables["include"] = cty.ObjectVal(exposed)
+	}
+
+	cfg.locals = evalLocals(body, ctx)
+	ctx.Variables["local"] = eval.SafeObjectVal(cfg.locals)
+	cfg.attributes = evalAttributes(body, ctx)
+
+	for _, block := range blocksOfType(body, "dependency") {
+		if len(block.Labels) != 1 {
+			return nil, fmt.Errorf("%s: dependency block needs exactly one label", path)
+		}
+		target, ok, err := evalPath(block.Body, "config_path", ctx, s.terragruntDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: dependency %q: %w", path, block.Labels[0], err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: dependency %q has no config_path", path, block.Labels[0])
+		}
+		cfg.addDependency(Dependency{Name: block.Labels[0], ConfigPath: target})
+	}
+	for _, block := range blocksOfType(body, "dependencies") {
+		paths, err := evalPaths(block.Body, "paths", ctx, s.terragruntDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: dependencies: %w", path, err)
+		}
+		cfg.addPaths(paths...)
+	}
+
+	for _, included := range merged {
+		for _, dep := range included.dependencies {
+			cfg.addDependency(dep)
+		}
+		cfg.addPaths(included.paths...)
+	}
+	return cfg, nil
+}
+
+// read evaluates a file for read_terragrunt_config. The file's own directory
+// becomes the terragrunt dir while it is evaluated.
+func (s *scope) read(path string) (cty.Value, error) {
+	if slices.Contains(s.reading, path) {
+		return cty.NilVal, fmt.Errorf("read_terragrunt_config cycle through %s", path)
+	}
+	child := &scope{
+		parser:        s.parser,
+		originalDir:   s.originalDir,
+		terragruntDir: filepath.Dir(path),
+		reading:       append(slices.Clone(s.reading), path),
+	}
+	cfg, err := child.load(path, 0)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return cfg.value(), nil
+}
+
+// exists reports whether path names an existing file or directory.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// value is the object read_terragrunt_config and exposed includes return:
+// the evaluated top-level attributes plus a locals object.
+func (c *config) value() cty.Value {
+	values := make(map[string]cty.Value, len(c.attributes)+1)
+	for name, value := range c.attributes {
+		values[name] = value
+	}
+	values["locals"] = eval.SafeObjectVal(c.locals)
+	return cty.ObjectVal(values)
+}
+
+func (c *config) addDependency(dep Dependency) {
+	for _, existing := range c.dependencies {
+		if existing.Name == dep.Name {
+			return
+		}
+	}
+	c.dependencies = append(c.dependencies, dep)
+}
+
+func (c *config) addPaths(paths ...string) {
+	for _, path := range paths {
+		if !slices.Contains(c.paths, path) {
+			c.paths = append(c.paths, path)
+		}
+	}
+}
+
+func (s *scope) context(file string) *hcl.EvalContext {
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{"local": cty.EmptyObjectVal},
+		Functions: s.functions(file),
+	}
+}
+
+// evalLocals evaluates locals blocks in dependency order by retrying until no
+// further local resolves. Locals that reference unknown values (dependency
+// outputs, unsupported functions) are left out.
+func evalLocals(body *hclsyntax.Body, ctx *hcl.EvalContext) map[string]cty.Value {
+	pending := make(map[string]hcl.Expression)
+	for _, block := range blocksOfType(body, "locals") {
+		for name, attr := range block.Body.Attributes {
+			pending[name] = attr.Expr
+		}
+	}
+
+	locals := make(map[string]cty.Value, len(pending))
+	for progress := true; progress && len(pending) > 0; {
+		progress = false
+		ctx.Variables["local"] = eval.SafeObjectVal(locals)
+		for _, name := range sortedKeys(pending) {
+			value, diags := pending[name].Value(ctx)
+			if diags.HasErrors() || !value.IsWhollyKnown() {
+				continue
+			}
+			locals[name] = value
+			delete(pending, name)
+			progress = true
+		}
+	}
+	return locals
+}
+
+// evalAttributes evaluates the top-level attributes (inputs and the like)
+// that do not depend on unknown values.
+func evalAttributes(body *hclsyntax.Body, ctx *hcl.EvalContext) map[string]cty.Value {
+	values := make(map[string]cty.Value, len(body.Attributes))
+	for name, attr := range body.Attributes {
+		value, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() || !value.IsWhollyKnown() {
+			continue
+		}
+		values[name] = value
+	}
+	return values
+}
+
+func evalString(body *hclsyntax.Body, name string, ctx *hcl.EvalContext) (string, error) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return "", nil
+	}
+	value, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return "", fmt.Errorf("%s must be a known string", name)
+	}
+	return value.AsString(), nil
+}
+
+func evalBool(body *hclsyntax.Body, name string, ctx *hcl.EvalContext) (bool, error) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return false, nil
+	}
+	value, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return false, diags
+	}
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.Bool {
+		return false, fmt.Errorf("%s must be a known bool", name)
+	}
+	return value.True(), nil
+}
+
+// evalPath evaluates a path attribute and resolves it against base.
+func evalPath(body *hclsyntax.Body, name string, ctx *hcl.EvalContext, base string) (string, bool, error) {
+	if _, ok := body.Attributes[name]; !ok {
+		return "", false, nil
+	}
+	value, err := evalString(body, name, ctx)
+	if err != nil {
+		return "", false, err
+	}
+	return resolvePath(base, value), true, nil
+}
+
+// evalPaths evaluates a list-of-paths attribute and resolves each entry
+// against base.
+func evalPaths(body *hclsyntax.Body, name string, ctx *hcl.EvalContext, base string) ([]string, error) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return nil, nil
+	}
+	value, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if value.IsNull() || !value.IsWhollyKnown() || !value.CanIterateElements() {
+		return nil, fmt.Errorf("%s must be a known list of strings", name)
+	}
+	var paths []string
+	for it := value.ElementIterator(); it.Next(); {
+		_, element := it.Element()
+		if element.IsNull() || element.Type() != cty.String {
+			return nil, fmt.Errorf("%s must be a known list of strings", name)
+		}
+		paths = append(paths, resolvePath(base, element.AsString()))
+	}
+	return paths, nil
+}
+
+func resolvePath(base, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	return filepath.Clean(path)
+}
+
+func blocksOfType(body *hclsyntax.Body, typ string) []*hclsyntax.Block {
+	var blocks []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		if block.Type == typ {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,149 @@
+package terragrunt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseUnit_IncludeChain(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "terragrunt.hcl", `
+dependency "vpc" {
+  config_path = "${get_repo_root()}/${local.env}/vpc"
+}
+
+dependency "kms" {
+  config_path = "../kms"
+}
+
+locals {
+  env = read_terragrunt_config(find_in_parent_folders("env.hcl")).locals.environment
+}
+`)
+	writeFile(t, root, "prod/env.hcl", `locals { environment = "prod" }`)
+	writeFile(t, root, "prod/app/terragrunt.hcl", `
+include "root" {
+  path = find_in_parent_folders()
+}
+
+dependency "kms" {
+  config_path = "../shared-kms"
+}
+
+dependencies {
+  paths = ["../dns", "../dns"]
+}
+`)
+
+	unit, err := NewParser(root).ParseUnit(filepath.Join(root, "prod", "app"))
+	if err != nil {
+		t.Fatalf("ParseUnit() error = %v", err)
+	}
+
+	want := map[string]string{
+		"vpc": filepath.Join(root, "prod", "vpc"),
+		"kms": filepath.Join(root, "prod", "shared-kms"),
+	}
+	if len(unit.Dependencies) != len(want) {
+		t.Fatalf("dependencies = %+v, want %d", unit.Dependencies, len(want))
+	}
+	for _, dep := range unit.Dependencies {
+		if dep.ConfigPath != want[dep.Name] {
+			t.Errorf("dependency %q config_path = %q, want %q", dep.Name, dep.ConfigPath, want[dep.Name])
+		}
+	}
+	if len(unit.Paths) != 1 || unit.Paths[0] != filepath.Join(root, "prod", "dns") {
+		t.Errorf("paths = %v, want [prod/dns]", unit.Paths)
+	}
+	if len(unit.Includes) != 1 || unit.Includes[0] != filepath.Join(root, "terragrunt.hcl") {
+		t.Errorf("includes = %v", unit.Includes)
+	}
+}
+
+func TestParseUnit_NoMergeAndExpose(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "common.hcl", `
+locals { peer = "vpc" }
+
+dependency "ignored" {
+  config_path = "${get_original_terragrunt_dir()}/../ignored"
+}
+`)
+	writeFile(t, root, "stage/app/terragrunt.hcl", `
+include "common" {
+  path           = "${get_repo_root()}/common.hcl"
+  expose         = true
+  merge_strategy = "no_merge"
+}
+
+dependency "peer" {
+  config_path = "../${include.common.locals.peer}"
+}
+`)
+
+	unit, err := NewParser(root).ParseUnit(filepath.Join(root, "stage", "app"))
+	if err != nil {
+		t.Fatalf("ParseUnit() error = %v", err)
+	}
+	if len(unit.Dependencies) != 1 || unit.Dependencies[0].ConfigPath != filepath.Join(root, "stage", "vpc") {
+		t.Fatalf("dependencies = %+v, want only peer -> stage/vpc", unit.Dependencies)
+	}
+}
+
+func TestParseUnit_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "missing parent",
+			files: map[string]string{"app/terragrunt.hcl": `include { path = find_in_parent_folders("nope.hcl") }`},
+			want:  "no nope.hcl found in parent folders",
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"app/terragrunt.hcl": `include { path = "a.hcl" }`,
+				"app/a.hcl":          `include { path = "b.hcl" }`,
+				"app/b.hcl":          `include { path = "a.hcl" }`,
+			},
+			want: "include chain deeper than",
+		},
+		{
+			name:  "read cycle",
+			files: map[string]string{"app/terragrunt.hcl": `locals { x = read_terragrunt_config("terragrunt.hcl") }` + "\n" + `dependency "a" { config_path = local.x.locals.y }`},
+			want:  `dependency "a"`,
+		},
+		{
+			name:  "missing config_path",
+			files: map[string]string{"app/terragrunt.hcl": `dependency "vpc" {}`},
+			want:  `dependency "vpc" has no config_path`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for rel, content := range tt.files {
+				writeFile(t, root, rel, content)
+			}
+			_, err := NewParser(root).ParseUnit(filepath.Join(root, "app"))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ParseUnit() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package terragrunt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+// Dependency types recorded on edges extracted from Terragrunt units.
+const (
+	// DependencyTypeBlock marks an edge from a dependency "name" block; the
+	// block name is stored in Dependency.RemoteStateName.
+	DependencyTypeBlock = "terragrunt_dependency"
+	// DependencyTypePaths marks an edge from a dependencies.paths entry.
+	DependencyTypePaths = "terragrunt_dependencies"
+)
+
+// DependencyExtractor turns the dependency and dependencies blocks of
+// Terragrunt units into module dependencies. It is the Terragrunt
+// counterpart of parser.DependencyExtractor.
+type DependencyExtractor struct {
+	parser *Parser
+	index  *discovery.ModuleIndex
+}
+
+// NewDependencyExtractor creates an extractor for the units in index.
+// repoRoot is the directory module IDs are relative to.
+func NewDependencyExtractor(repoRoot string, index *discovery.ModuleIndex) *DependencyExtractor {
+	return &DependencyExtractor{parser: NewParser(repoRoot), index: index}
+}
+
+// ExtractDependencies extracts dependencies for a single unit. Dependencies
+// on paths that are not units in the index are reported in Errors.
+func (e *DependencyExtractor) ExtractDependencies(_ context.Context, module *discovery.Module) (*parser.ModuleDependencies, error) {
+	unit, err := e.parser.ParseUnit(module.Path)
+	if err != nil {
+		return nil, fmt.Errorf("parse terragrunt unit %s: %w", module.ID(), err)
+	}
+
+	deps := &parser.ModuleDependencies{
+		Module:              module,
+		Dependencies:        make([]*parser.Dependency, 0),
+		LibraryDependencies: make([]*parser.LibraryDependency, 0),
+		DependsOn:           make([]string, 0),
+		Errors:              make([]error, 0),
+	}
+	for _, dep := range unit.Dependencies {
+		e.addDependency(deps, dep.ConfigPath, DependencyTypeBlock, dep.Name)
+	}
+	for _, path := range unit.Paths {
+		e.addDependency(deps, path, DependencyTypePaths, "")
+	}
+	return deps, nil
+}
+
+// ExtractAllDependencies extracts dependencies for all units in the index.
+func (e *DependencyExtractor) ExtractAllDependencies(ctx context.Context) (map[string]*parser.ModuleDependencies, []error) {
+	results := make(map[string]*parser.ModuleDependencies)
+	errs := make([]error, 0)
+	for _, module := range e.index.All() {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		deps, err := e.ExtractDependencies(ctx, module)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results[module.ID()] = deps
+		errs = append(errs, deps.Errors...)
+	}
+	return results, errs
+}
+
+// addDependency adds edges to the unit at configPath. A unit deployed to
+// several workspaces is one module per workspace; the dependency then covers
+// all of them, like a directory pattern in dependency rules.
+func (e *DependencyExtractor) addDependency(deps *parser.ModuleDependencies, configPath, typ, name string) {
+	rel := relativePath(e.parser.repoRoot, configPath)
+	targets := e.index.AllByPath(rel)
+	if len(targets) == 0 {
+		source := "dependencies"
+		if name != "" {
+			source = fmt.Sprintf("dependency %q", name)
+		}
+		deps.Errors = append(deps.Errors, fmt.Errorf("no unit at path %q (from %s %s)", rel, deps.Module.ID(), source))
+		return
+	}
+
+	for _, target := range targets {
+		if target.RelativePath == deps.Module.RelativePath {
+			continue
+		}
+		deps.Dependencies = append(deps.Dependencies, &parser.Dependency{
+			From:            deps.Module,
+			To:              target,
+			Type:            typ,
+			RemoteStateName: name,
+		})
+		if !slices.Contains(deps.DependsOn, target.ID()) {
+			deps.DependsOn = append(deps.DependsOn, target.ID())
+		}
+	}
+}
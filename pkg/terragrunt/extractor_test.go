@@ -0,0 +1,105 @@
+package terragrunt
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+func TestDependencyExtractor_ExtractAllDependencies(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "platform/prod/eu/vpc/terragrunt.hcl", `# no dependencies`)
+	writeFile(t, root, "platform/prod/eu/eks/terragrunt.hcl", `
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`)
+	writeFile(t, root, "platform/prod/eu/app/terragrunt.hcl", `
+dependency "eks" {
+  config_path = "../eks"
+}
+
+dependency "db" {
+  config_path = "../db"
+}
+
+dependencies {
+  paths = ["../vpc", "../eks"]
+}
+`)
+
+	scanner := discovery.NewScanner(root, []string{"service", "environment", "region", "module"})
+	scanner.Terragrunt = true
+	modules, err := scanner.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	deps, errs := NewDependencyExtractor(root, discovery.NewModuleIndex(modules)).ExtractAllDependencies(context.Background())
+
+	if got := deps["platform/prod/eu/eks"].DependsOn; len(got) != 1 || got[0] != "platform/prod/eu/vpc" {
+		t.Errorf("eks DependsOn = %v, want [platform/prod/eu/vpc]", got)
+	}
+	app := deps["platform/prod/eu/app"]
+	if got := strings.Join(app.DependsOn, ","); got != "platform/prod/eu/eks,platform/prod/eu/vpc" {
+		t.Errorf("app DependsOn = %s", got)
+	}
+	if first := app.Dependencies[0]; first.Type != DependencyTypeBlock || first.RemoteStateName != "eks" {
+		t.Errorf("first app dependency = %+v", first)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `no unit at path "platform/prod/eu/db" (from platform/prod/eu/app dependency "db")`) {
+		t.Errorf("errors = %v", errs)
+	}
+}
+
+func TestDependencyExtractor_WorkspaceUnits(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "platform/prod/eu/vpc/terragrunt.hcl", `# no dependencies`)
+	writeFile(t, root, "platform/prod/eu/app/terragrunt.hcl", `
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`)
+
+	scanner := discovery.NewScanner(root, []string{"service", "environment", "region", "module"})
+	scanner.Terragrunt = true
+	modules, err := scanner.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	var expanded []*discovery.Module
+	for _, module := range modules {
+		if module.Get("module") == "vpc" {
+			expanded = append(expanded, module.WithWorkspace("tenant-a"), module.WithWorkspace("tenant-b"))
+			continue
+		}
+		expanded = append(expanded, module.WithWorkspace("tenant-a"))
+	}
+
+	deps, errs := NewDependencyExtractor(root, discovery.NewModuleIndex(expanded)).ExtractAllDependencies(context.Background())
+	if len(errs) != 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+	app := deps["platform/prod/eu/app@tenant-a"]
+	if got := strings.Join(app.DependsOn, ","); got != "platform/prod/eu/vpc@tenant-a,platform/prod/eu/vpc@tenant-b" {
+		t.Errorf("app DependsOn = %s, want both vpc workspaces", got)
+	}
+	if got := deps["platform/prod/eu/vpc@tenant-a"].DependsOn; len(got) != 0 {
+		t.Errorf("vpc DependsOn = %v, want none", got)
+	}
+}
+
+func TestDependencyExtractor_ParseError(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "a/terragrunt.hcl", `dependency "x" {`)
+	module := discovery.NewModule([]string{"module"}, []string{"a"}, filepath.Join(root, "a"), "a")
+
+	_, err := NewDependencyExtractor(root, discovery.NewModuleIndex([]*discovery.Module{module})).
+		ExtractDependencies(context.Background(), module)
+	if err == nil || !strings.Contains(err.Error(), "parse terragrunt unit a") {
+		t.Fatalf("ExtractDependencies() error = %v", err)
+	}
+}
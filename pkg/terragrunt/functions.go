@@ -0,0 +1,130 @@
+package terragrunt
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/edelwud/terraci/internal/terraform/eval"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+// functions returns the Terraform functions plus the Terragrunt built-ins that
+// matter for locating configuration. file is the configuration file being
+// evaluated, which the include-relative functions resolve against.
+func (s *scope) functions(file string) map[string]function.Function {
+	fileDir := filepath.Dir(file)
+	funcs := maps.Clone(eval.Functions())
+
+	funcs["find_in_parent_folders"] = s.findInParentFoldersFunc()
+	funcs["read_terragrunt_config"] = s.readTerragruntConfigFunc()
+	funcs["get_terragrunt_dir"] = stringFunc(s.terragruntDir)
+	funcs["get_original_terragrunt_dir"] = stringFunc(s.originalDir)
+	funcs["get_parent_terragrunt_dir"] = stringFunc(fileDir)
+	funcs["get_repo_root"] = stringFunc(s.parser.repoRoot)
+	funcs["path_relative_to_include"] = stringFunc(relativePath(fileDir, s.originalDir))
+	funcs["path_relative_from_include"] = stringFunc(relativePath(s.originalDir, fileDir))
+	funcs["get_path_from_repo_root"] = stringFunc(relativePath(s.parser.repoRoot, s.originalDir))
+	funcs["get_path_to_repo_root"] = stringFunc(relativePath(s.originalDir, s.parser.repoRoot))
+	funcs["get_env"] = getEnvFunc()
+	return funcs
+}
+
+// findInParentFoldersFunc searches the parents of the terragrunt dir for a
+// file, defaulting to terragrunt.hcl. The optional second argument is
+// returned when nothing is found.
+func (s *scope) findInParentFoldersFunc() function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{Name: "args", Type: cty.String},
+		Type:     function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			if len(args) > 2 {
+				return cty.NilVal, errors.New("find_in_parent_folders takes at most two arguments")
+			}
+			name := discovery.TerragruntUnitFile
+			if len(args) > 0 {
+				name = args[0].AsString()
+			}
+			for dir := s.terragruntDir; ; {
+				parent := filepath.Dir(dir)
+				if parent == dir {
+					break
+				}
+				dir = parent
+				if candidate := filepath.Join(dir, name); exists(candidate) {
+					return cty.StringVal(candidate), nil
+				}
+			}
+			if len(args) == 2 {
+				return args[1], nil
+			}
+			return cty.NilVal, fmt.Errorf("no %s found in parent folders of %s", name, s.terragruntDir)
+		},
+	})
+}
+
+// readTerragruntConfigFunc reads another configuration file and returns its
+// locals and evaluable attributes. The optional second argument is returned
+// when the file does not exist.
+func (s *scope) readTerragruntConfigFunc() function.Function {
+	return function.New(&function.Spec{
+		Params:   []function.Parameter{{Name: "path", Type: cty.String}},
+		VarParam: &function.Parameter{Name: "default", Type: cty.DynamicPseudoType},
+		Type:     function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			if len(args) > 2 {
+				return cty.NilVal, errors.New("read_terragrunt_config takes at most two arguments")
+			}
+			path := resolvePath(s.terragruntDir, args[0].AsString())
+			if !exists(path) && len(args) == 2 {
+				return args[1], nil
+			}
+			return s.read(path)
+		},
+	})
+}
+
+func getEnvFunc() function.Function {
+	return function.New(&function.Spec{
+		Params:   []function.Parameter{{Name: "name", Type: cty.String}},
+		VarParam: &function.Parameter{Name: "default", Type: cty.String},
+		Type:     function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			if len(args) > 2 {
+				return cty.NilVal, errors.New("get_env takes at most two arguments")
+			}
+			if value, ok := os.LookupEnv(args[0].AsString()); ok {
+				return cty.StringVal(value), nil
+			}
+			if len(args) == 2 {
+				return args[1], nil
+			}
+			return cty.NilVal, fmt.Errorf("environment variable %s is not set", args[0].AsString())
+		},
+	})
+}
+
+// stringFunc is a zero-argument function returning a fixed string.
+func stringFunc(value string) function.Function {
+	return function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func([]cty.Value, cty.Type) (cty.Value, error) {
+			return cty.StringVal(value), nil
+		},
+	})
+}
+
+// relativePath returns target relative to base in slash form, or target when
+// no relative path exists.
+func relativePath(base, target string) string {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return filepath.ToSlash(target)
+	}
+	return filepath.ToSlash(rel)
+}
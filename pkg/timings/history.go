@@ -0,0 +1,185 @@
+// Package timings keeps a history of job durations in a blob store so the
+// pipeline critical path can be estimated from past runs.
+package timings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/execution"
+	"github.com/edelwud/terraci/pkg/pipeline"
+)
+
+// Namespace is the blob store namespace of the timing history.
+const Namespace = "timings/modules"
+
+// Sample is one measured job of a module.
+type Sample struct {
+	ModuleID string
+	Kind     pipeline.JobKind
+	Duration time.Duration
+}
+
+// History stores the most recent job durations of every module, one blob
+// per module keyed by a hash of its ID.
+type History struct {
+	store   *blobcache.Cache
+	samples int
+}
+
+type historyEntry struct {
+	ModuleID  string                               `json:"module_id"`
+	Durations map[pipeline.JobKind][]time.Duration `json:"durations"`
+}
+
+// NewHistory creates a timing history over store keeping the last samples
+// durations per module and job kind.
+func NewHistory(store blobcache.Store, samples int) *History {
+	return &History{
+		store:   blobcache.New(store, Namespace, 0),
+		samples: max(samples, 1),
+	}
+}
+
+// Record appends samples to the history of their modules, dropping the
+// oldest durations beyond the sample limit.
+func (h *History) Record(ctx context.Context, samples []Sample) error {
+	byModule := make(map[string][]Sample)
+	for _, sample := range samples {
+		if sample.ModuleID == "" || sample.Duration <= 0 {
+			continue
+		}
+		byModule[sample.ModuleID] = append(byModule[sample.ModuleID], sample)
+	}
+
+	var errs []error
+	for _, moduleID := range slices.Sorted(maps.Keys(byModule)) {
+		entry, err := h.load(ctx, moduleID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, sample := range byModule[moduleID] {
+			durations := append(entry.Durations[sample.Kind], sample.Duration)
+			if len(durations) > h.samples {
+				durations = durations[len(durations)-h.samples:]
+			}
+			entry.Durations[sample.Kind] = durations
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("encode timings of %s: %w", moduleID, err))
+			continue
+		}
+		if _, err := h.store.Put(ctx, key(moduleID), data, blobcache.PutOptions{ContentType: "application/json"}); err != nil {
+			errs = append(errs, fmt.Errorf("store timings of %s: %w", moduleID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Estimates returns the expected duration of every listed module with
+// recorded history: the sum of the median durations of the given job kinds,
+// or of every recorded kind when none are given. Modules without history
+// are left out.
+func (h *History) Estimates(ctx context.Context, moduleIDs []string, kinds ...pipeline.JobKind) (map[string]time.Duration, error) {
+	estimates := make(map[string]time.Duration, len(moduleIDs))
+	for _, moduleID := range moduleIDs {
+		entry, err := h.load(ctx, moduleID)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			total    time.Duration
+			measured bool
+		)
+		for kind, durations := range entry.Durations {
+			if len(durations) == 0 || len(kinds) > 0 && !slices.Contains(kinds, kind) {
+				continue
+			}
+			total += median(durations)
+			measured = true
+		}
+		if measured {
+			estimates[moduleID] = total
+		}
+	}
+	return estimates, nil
+}
+
+func (h *History) load(ctx context.Context, moduleID string) (historyEntry, error) {
+	entry := historyEntry{ModuleID: moduleID, Durations: make(map[pipeline.JobKind][]time.Duration)}
+	data, _, ok, err := h.store.Get(ctx, key(moduleID))
+	if err != nil {
+		return entry, fmt.Errorf("load timings of %s: %w", moduleID, err)
+	}
+	if !ok {
+		return entry, nil
+	}
+	var stored historyEntry
+	// An unreadable or colliding entry restarts the module's history.
+	if json.Unmarshal(data, &stored) != nil || stored.ModuleID != moduleID || stored.Durations == nil {
+		return entry, nil
+	}
+	return stored, nil
+}
+
+// SamplesFromPlanResults returns the plan durations carried by collection.
+// Results without a duration are skipped.
+func SamplesFromPlanResults(collection *ci.PlanResultCollection) []Sample {
+	if collection == nil {
+		return nil
+	}
+	var samples []Sample
+	for _, result := range collection.Results() {
+		if result.Duration() > 0 {
+			samples = append(samples, Sample{ModuleID: result.ModuleID(), Kind: pipeline.JobKindPlan, Duration: result.Duration()})
+		}
+	}
+	return samples
+}
+
+// SamplesFromExecution returns the durations of the succeeded module jobs
+// of an executed pipeline. Failed jobs are skipped: they often stop early
+// and would skew the estimates.
+func SamplesFromExecution(ir *pipeline.IR, result *execution.Result) []Sample {
+	if ir == nil {
+		return nil
+	}
+	var samples []Sample
+	for _, jobResult := range result.Jobs() {
+		if jobResult.Failed() {
+			continue
+		}
+		job, ok := ir.FindJob(jobResult.Name())
+		if !ok || job.Module() == nil {
+			continue
+		}
+		samples = append(samples, Sample{ModuleID: job.Module().ID(), Kind: job.Kind(), Duration: jobResult.Duration()})
+	}
+	return samples
+}
+
+func key(moduleID string) string {
+	sum := sha256.Sum256([]byte(moduleID))
+	return hex.EncodeToString(sum[:])
+}
+
+func median(durations []time.Duration) time.Duration {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
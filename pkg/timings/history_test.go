@@ -0,0 +1,72 @@
+package timings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/execution"
+	"github.com/edelwud/terraci/pkg/execution/executiontest"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+)
+
+func TestHistory_RecordAndEstimate(t *testing.T) {
+	ctx := context.Background()
+	const vpc = "platform/stage/eu-central-1/vpc"
+	history := NewHistory(blobtest.NewMemoryStore(""), 3)
+
+	var samples []Sample
+	for _, minutes := range []int{9, 1, 2, 3} {
+		samples = append(samples, Sample{ModuleID: vpc, Kind: pipeline.JobKindPlan, Duration: time.Duration(minutes) * time.Minute})
+	}
+	samples = append(samples,
+		Sample{ModuleID: vpc, Kind: pipeline.JobKindApply, Duration: 5 * time.Minute},
+		Sample{ModuleID: "platform/stage/eu-central-1/eks", Kind: pipeline.JobKindPlan},
+	)
+	if err := history.Record(ctx, samples); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	estimates, err := history.Estimates(ctx, []string{vpc, "platform/stage/eu-central-1/eks"}, pipeline.JobKindPlan)
+	if err != nil {
+		t.Fatalf("Estimates() error = %v", err)
+	}
+	// The 9m sample is beyond the limit of 3; the median of 1m, 2m, 3m is 2m.
+	if len(estimates) != 1 || estimates[vpc] != 2*time.Minute {
+		t.Errorf("plan Estimates() = %v, want vpc 2m only", estimates)
+	}
+
+	estimates, err = history.Estimates(ctx, []string{vpc})
+	if err != nil {
+		t.Fatalf("Estimates() error = %v", err)
+	}
+	if estimates[vpc] != 7*time.Minute {
+		t.Errorf("Estimates() = %v, want plan and apply medians summed to 7m", estimates)
+	}
+}
+
+func TestSamplesFromExecution(t *testing.T) {
+	module := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	ir := pipelinetest.MustSingleModuleIR(t, module)
+	plan := pipelinetest.MustJobByKind(t, ir, pipeline.JobKindPlan)
+	apply := pipelinetest.MustJobByKind(t, ir, pipeline.JobKindApply)
+
+	started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := executiontest.MustResult(t, execution.ResultOptions{Jobs: []execution.JobResult{
+		executiontest.MustJobResult(t, execution.JobResultOptions{
+			Name: plan.Name(), Status: execution.JobStatusSucceeded, StartedAt: started, FinishedAt: started.Add(time.Minute),
+		}),
+		executiontest.MustJobResult(t, execution.JobResultOptions{
+			Name: apply.Name(), Status: execution.JobStatusFailed, StartedAt: started, FinishedAt: started.Add(time.Second),
+		}),
+	}})
+
+	samples := SamplesFromExecution(ir, result)
+	want := Sample{ModuleID: module.ID(), Kind: pipeline.JobKindPlan, Duration: time.Minute}
+	if len(samples) != 1 || samples[0] != want {
+		t.Errorf("SamplesFromExecution() = %+v, want only %+v", samples, want)
+	}
+}
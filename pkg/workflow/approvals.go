@@ -0,0 +1,20 @@
+package workflow
+
+import (
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+// RequiresApproval reports whether any approvals rule matches module, so its
+// apply job waits for a manual approval.
+func RequiresApproval(rules []config.ApprovalRule, module *discovery.Module) bool {
+	if module == nil {
+		return false
+	}
+	for _, rule := range rules {
+		if segmentsMatch(rule.Match(), module) {
+			return true
+		}
+	}
+	return false
+}
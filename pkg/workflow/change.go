@@ -2,7 +2,11 @@ package workflow
 
 import (
 	"context"
+	"path"
+	"path/filepath"
+	"strings"
 
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/discovery"
 )
 
@@ -26,3 +30,60 @@ type ChangeDetectionResult struct {
 type ChangeDetector interface {
 	DetectChanges(ctx context.Context, req ChangeDetectionRequest) (*ChangeDetectionResult, error)
 }
+
+// RevisionExportRequest describes one request to materialize the project at
+// a VCS revision. BaseRef is resolved like ChangeDetectionRequest.BaseRef.
+type RevisionExportRequest struct {
+	WorkDir string
+	BaseRef string
+	// DestDir receives the files of WorkDir as of the revision.
+	DestDir string
+	// Include reports whether a file, given relative to WorkDir in slash
+	// form, is exported. Nil exports every file.
+	Include func(path string) bool
+}
+
+// RevisionExportResult identifies the exported revision.
+type RevisionExportResult struct {
+	Ref    string
+	Commit string
+}
+
+// RevisionExporter materializes the project tree at a base revision so it
+// can be planned like the working tree. Change detectors may implement it.
+type RevisionExporter interface {
+	ExportRevision(ctx context.Context, req RevisionExportRequest) (*RevisionExportResult, error)
+}
+
+// projectInputSuffixes are the file name suffixes module discovery and the
+// parsers read: Terraform sources, tfvars, and HCL such as terragrunt.hcl,
+// the parents it includes and .terraform.lock.hcl.
+var projectInputSuffixes = []string{".tf", ".tf.json", ".tfvars", ".tfvars.json", ".hcl"}
+
+// ProjectInputs returns a RevisionExportRequest.Include filter that keeps
+// only the files needed to build the module graph: parser inputs, workspaces
+// files, config files and the files named by var_files rules. Other files,
+// such as docs, fixtures and binaries, are not exported.
+func ProjectInputs(varFiles []config.VarFileRule) func(string) bool {
+	names := map[string]bool{discovery.WorkspacesFile: true}
+	for _, name := range config.FileNames {
+		names[name] = true
+	}
+	for _, rule := range varFiles {
+		for _, file := range rule.Files() {
+			names[filepath.Base(file)] = true
+		}
+	}
+	return func(file string) bool {
+		name := path.Base(file)
+		if names[name] {
+			return true
+		}
+		for _, suffix := range projectInputSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				return true
+			}
+		}
+		return false
+	}
+}
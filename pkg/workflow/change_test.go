@@ -0,0 +1,42 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/config"
+)
+
+func TestProjectInputs(t *testing.T) {
+	rule, err := config.NewVarFileRule(config.VarFileRuleOptions{
+		Match: map[string]string{"environment": "prod"},
+		Files: []string{"../env/prod.vars"},
+	})
+	if err != nil {
+		t.Fatalf("NewVarFileRule() error = %v", err)
+	}
+	include := ProjectInputs([]config.VarFileRule{rule})
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "platform/prod/vpc/main.tf", want: true},
+		{path: "platform/prod/vpc/main.tf.json", want: true},
+		{path: "platform/prod/vpc/terraform.tfvars", want: true},
+		{path: "platform/prod/vpc/dev.auto.tfvars.json", want: true},
+		{path: "platform/prod/vpc/.terraform.lock.hcl", want: true},
+		{path: "platform/prod/vpc/terragrunt.hcl", want: true},
+		{path: "root.hcl", want: true},
+		{path: "platform/prod/vpc/workspaces", want: true},
+		{path: "platform/env/prod.vars", want: true},
+		{path: ".terraci.yaml", want: true},
+		{path: "README.md", want: false},
+		{path: "platform/prod/vpc/lambda.zip", want: false},
+		{path: "platform/prod/vpc/main.tf.bak", want: false},
+	}
+	for _, tt := range tests {
+		if got := include(tt.path); got != tt.want {
+			t.Errorf("include(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
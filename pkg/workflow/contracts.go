@@ -0,0 +1,119 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+// MissingOutput is a remote state output a consumer reads that its
+// producer does not declare.
+type MissingOutput struct {
+	Consumer    string
+	Producer    string
+	RemoteState string
+	Output      string
+	Range       hcl.Range
+}
+
+func (m MissingOutput) String() string {
+	return fmt.Sprintf("%s reads output %q of %s through data.terraform_remote_state.%s, which does not declare it",
+		m.Consumer, m.Output, m.Producer, m.RemoteState)
+}
+
+// CheckOutputContracts compares the outputs each consumer reads through
+// terraform_remote_state with the output blocks of the modules those remote
+// states resolve to. When consumers is nil every module in result is
+// checked. Modules are parsed with the parser dependencies were extracted
+// with, so var files and the parse cache apply here too.
+func CheckOutputContracts(ctx context.Context, result *Result, consumers []*discovery.Module) ([]MissingOutput, error) {
+	if result == nil {
+		return nil, nil
+	}
+	if consumers == nil {
+		consumers = result.Filtered.Modules
+	}
+
+	moduleParser := result.parser
+	if moduleParser == nil {
+		moduleParser = parser.NewParser(nil)
+	}
+	checker := &contractChecker{parser: moduleParser, parsed: make(map[string]*parser.ParsedModule)}
+	var missing []MissingOutput
+	for _, consumer := range consumers {
+		producers := remoteStateProducers(result.Dependencies[consumer.ID()])
+		if len(producers) == 0 {
+			continue
+		}
+		found, err := checker.check(ctx, consumer, producers)
+		if err != nil {
+			return nil, err
+		}
+		missing = append(missing, found...)
+	}
+	return missing, nil
+}
+
+// remoteStateProducers maps remote state names to the modules they resolve
+// to; a for_each remote state can resolve to several.
+func remoteStateProducers(deps *parser.ModuleDependencies) map[string][]*discovery.Module {
+	if deps == nil {
+		return nil
+	}
+	producers := make(map[string][]*discovery.Module)
+	for _, dep := range deps.Dependencies {
+		if dep.Type == parser.DependencyTypeRemoteState && dep.To != nil {
+			producers[dep.RemoteStateName] = append(producers[dep.RemoteStateName], dep.To)
+		}
+	}
+	return producers
+}
+
+type contractChecker struct {
+	parser *parser.Parser
+	parsed map[string]*parser.ParsedModule
+}
+
+func (c *contractChecker) check(ctx context.Context, consumer *discovery.Module, producers map[string][]*discovery.Module) ([]MissingOutput, error) {
+	parsed, err := c.parse(ctx, consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []MissingOutput
+	for _, ref := range parser.OutputReferences(parsed) {
+		for _, producer := range producers[ref.RemoteState] {
+			producerParsed, err := c.parse(ctx, producer)
+			if err != nil {
+				return nil, err
+			}
+			if parser.DeclaredOutputs(producerParsed)[ref.Output] {
+				continue
+			}
+			missing = append(missing, MissingOutput{
+				Consumer:    consumer.ID(),
+				Producer:    producer.ID(),
+				RemoteState: ref.RemoteState,
+				Output:      ref.Output,
+				Range:       ref.Range,
+			})
+		}
+	}
+	return missing, nil
+}
+
+func (c *contractChecker) parse(ctx context.Context, module *discovery.Module) (*parser.ParsedModule, error) {
+	if parsed, ok := c.parsed[module.ID()]; ok {
+		return parsed, nil
+	}
+	parsed, err := c.parser.ParseModule(ctx, module.Path)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", module.ID(), err)
+	}
+	c.parsed[module.ID()] = parsed
+	return parsed, nil
+}
@@ -0,0 +1,107 @@
+package workflow
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+func TestCheckOutputContracts(t *testing.T) {
+	tmpDir := t.TempDir()
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/vpc", `
+output "vpc_id" {
+  value = "vpc-123"
+}
+`)
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/eks", `
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = {
+    bucket = "state"
+    key    = "platform/prod/eu-central-1/vpc/terraform.tfstate"
+  }
+}
+
+locals {
+  vpc_id     = data.terraform_remote_state.vpc.outputs.vpc_id
+  subnet_ids = data.terraform_remote_state.vpc.outputs.subnet_ids
+}
+`)
+	createModuleTree(t, tmpDir, []string{"platform/prod/eu-central-1/dns"})
+
+	result, err := run(context.Background(), defaultOptions(tmpDir))
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	missing, err := CheckOutputContracts(context.Background(), result, nil)
+	if err != nil {
+		t.Fatalf("CheckOutputContracts: %v", err)
+	}
+	if len(missing) != 1 {
+		t.Fatalf("missing = %+v, want one missing output", missing)
+	}
+	got := missing[0]
+	if got.Consumer != "platform/prod/eu-central-1/eks" || got.Producer != "platform/prod/eu-central-1/vpc" ||
+		got.RemoteState != "vpc" || got.Output != "subnet_ids" {
+		t.Errorf("missing[0] = %+v", got)
+	}
+	if filepath.Base(got.Range.Filename) != "main.tf" || got.Range.Start.Line != 12 {
+		t.Errorf("missing[0] range = %s, want main.tf:12", got.Range)
+	}
+
+	// Changed-only runs check only the given consumers.
+	dns := result.Filtered.Index.ByID("platform/prod/eu-central-1/dns")
+	missing, err = CheckOutputContracts(context.Background(), result, []*discovery.Module{dns})
+	if err != nil {
+		t.Fatalf("CheckOutputContracts(dns): %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing for dns = %+v, want none", missing)
+	}
+}
+
+func TestCheckOutputContracts_UsesWorkflowParser(t *testing.T) {
+	tmpDir := t.TempDir()
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/vpc", `
+output "vpc_id" {
+  value = "vpc-123"
+}
+`)
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/eks", `
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config  = { bucket = "state", key = "platform/prod/eu-central-1/vpc/terraform.tfstate" }
+}
+
+locals {
+  vpc_id     = data.terraform_remote_state.vpc.outputs.vpc_id
+  subnet_ids = data.terraform_remote_state.vpc.outputs.subnet_ids
+}
+`)
+
+	opts := defaultOptions(tmpDir)
+	opts.ParseCache = parser.NewModuleCache(blobtest.NewMemoryStore(""), "test", 0)
+	for attempt := range 2 {
+		result, err := run(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		missing, err := CheckOutputContracts(context.Background(), result, nil)
+		if err != nil {
+			t.Fatalf("CheckOutputContracts: %v", err)
+		}
+		if len(missing) != 1 || missing[0].Output != "subnet_ids" {
+			t.Errorf("attempt %d: missing = %+v, want subnet_ids", attempt, missing)
+		}
+	}
+	// Only the first workflow run parses; contract checks and the second
+	// run are served from the cache.
+	if stats := opts.ParseCache.Stats(); stats.Misses != 2 || stats.Hits != 6 {
+		t.Errorf("Stats() = %+v, want contract checks served from the parse cache", stats)
+	}
+}
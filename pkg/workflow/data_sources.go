@@ -0,0 +1,42 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/diagnostic"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+// dataSourceRules converts the configured data source rules for the parser.
+// Config validation already compiles the patterns; a rule built without it
+// that fails to compile is reported and skipped.
+func dataSourceRules(rules []config.DataSourceRule) ([]parser.DataSourceRule, []diagnostic.Diagnostic) {
+	var (
+		parsed []parser.DataSourceRule
+		diags  []diagnostic.Diagnostic
+	)
+	for i, rule := range rules {
+		var pattern *regexp.Regexp
+		if rule.Pattern() != "" {
+			compiled, err := regexp.Compile(rule.Pattern())
+			if err != nil {
+				diags = append(diags, diagnostic.Warning(
+					fmt.Sprintf("data_sources[%d].pattern: %v", i, err),
+					diagnostic.WithSource("data_sources"),
+					diagnostic.WithCause(err),
+				))
+				continue
+			}
+			pattern = compiled
+		}
+		parsed = append(parsed, parser.DataSourceRule{
+			Type:      rule.Type(),
+			Attribute: rule.Attribute(),
+			Pattern:   pattern,
+			Module:    rule.Module(),
+		})
+	}
+	return parsed, diags
+}
@@ -0,0 +1,86 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/diagnostic"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/parser"
+)
+
+// addDeclaredDependencies merges the dependencies rules into deps as
+// declared edges between filtered modules. A rule side that selects no
+// discovered module is reported, since it usually means a typo or a renamed
+// module; one that only selects modules the filters dropped is not.
+func addDeclaredDependencies(rules []config.DependencyRule, discovered, filtered []*discovery.Module, deps map[string]*parser.ModuleDependencies) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	for i, rule := range rules {
+		for side, selector := range []config.ModuleSelector{rule.From(), rule.To()} {
+			if len(selectModules(selector, discovered)) > 0 {
+				continue
+			}
+			diags = append(diags, diagnostic.Warning(
+				fmt.Sprintf("dependencies[%d].%s matches no module", i, []string{"from", "to"}[side]),
+				diagnostic.WithSource("dependencies"),
+			))
+		}
+
+		to := selectModules(rule.To(), filtered)
+		for _, source := range selectModules(rule.From(), filtered) {
+			for _, target := range to {
+				if source != target {
+					addDeclaredDependency(deps, source, target)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+func addDeclaredDependency(deps map[string]*parser.ModuleDependencies, from, to *discovery.Module) {
+	moduleDeps := deps[from.ID()]
+	if moduleDeps == nil {
+		moduleDeps = &parser.ModuleDependencies{Module: from}
+		deps[from.ID()] = moduleDeps
+	}
+	moduleDeps.Dependencies = append(moduleDeps.Dependencies, &parser.Dependency{
+		From: from,
+		To:   to,
+		Type: parser.DependencyTypeDeclared,
+	})
+	if !slices.Contains(moduleDeps.DependsOn, to.ID()) {
+		moduleDeps.DependsOn = append(moduleDeps.DependsOn, to.ID())
+	}
+}
+
+func selectModules(selector config.ModuleSelector, modules []*discovery.Module) []*discovery.Module {
+	var selected []*discovery.Module
+	for _, module := range modules {
+		if selectorMatches(selector, module) {
+			selected = append(selected, module)
+		}
+	}
+	return selected
+}
+
+func selectorMatches(selector config.ModuleSelector, module *discovery.Module) bool {
+	if glob := selector.Glob(); glob != "" {
+		ok, _ := module.MatchGlob(glob)
+		return ok
+	}
+	return segmentsMatch(selector.Match(), module)
+}
+
+// segmentsMatch reports whether every segment pattern matches the module's
+// value for that segment.
+func segmentsMatch(match map[string]string, module *discovery.Module) bool {
+	for segment, pattern := range match {
+		if ok, _ := path.Match(pattern, module.Get(segment)); !ok {
+			return false
+		}
+	}
+	return true
+}
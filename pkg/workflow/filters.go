@@ -20,12 +20,18 @@ func optionsFromConfig(workDir string, cfg config.Config, ff *filter.Flags) Opti
 	opts := mergedFilterOptions(cfg, ff)
 	structure := cfg.Structure()
 	return Options{
-		WorkDir:        workDir,
-		Segments:       structure.Segments(),
-		Excludes:       opts.Excludes,
-		Includes:       opts.Includes,
-		SegmentFilters: opts.Segments,
-		LibraryPaths:   libraryPathsFromConfig(cfg),
+		WorkDir:         workDir,
+		Segments:        structure.Segments(),
+		Excludes:        opts.Excludes,
+		Includes:        opts.Includes,
+		SegmentFilters:  opts.Segments,
+		LibraryPaths:    libraryPathsFromConfig(cfg),
+		Terragrunt:      cfg.Execution().Binary() == config.ExecutionBinaryTerragrunt,
+		EarlyEvaluation: cfg.Execution().Binary() == config.ExecutionBinaryTofu,
+		VarFiles:        cfg.VarFiles(),
+		Workspaces:      cfg.Workspaces(),
+		Dependencies:    cfg.Dependencies(),
+		DataSources:     cfg.DataSources(),
 	}
 }
 
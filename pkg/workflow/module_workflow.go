@@ -4,15 +4,18 @@ package workflow
 
 import (
 	"context"
+	"errors"
 
 	log "github.com/caarlos0/log"
 
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/diagnostic"
 	"github.com/edelwud/terraci/pkg/discovery"
 	terrierrors "github.com/edelwud/terraci/pkg/errors"
 	"github.com/edelwud/terraci/pkg/filter"
 	"github.com/edelwud/terraci/pkg/graph"
 	"github.com/edelwud/terraci/pkg/parser"
+	"github.com/edelwud/terraci/pkg/terragrunt"
 )
 
 // Options configures module discovery, filtering, and graph building.
@@ -28,6 +31,35 @@ type Options struct {
 	// flagged Module.IsLibrary=true and routed into Result.Libraries instead
 	// of the executable target sets. Empty/nil disables the feature.
 	LibraryPaths []string
+
+	// Terragrunt discovers terragrunt.hcl units instead of .tf modules and
+	// extracts dependencies from their dependency/dependencies blocks.
+	Terragrunt bool
+
+	// EarlyEvaluation resolves variables and locals in module sources, as
+	// OpenTofu 1.8+ does. Set when the execution binary is tofu.
+	EarlyEvaluation bool
+
+	// VarFiles adds tfvars files to the evaluation of matching modules,
+	// after their terraform.tfvars and *.auto.tfvars files.
+	VarFiles []config.VarFileRule
+
+	// Workspaces deploy matching modules to several Terraform workspaces,
+	// in addition to the workspaces files found in module directories.
+	// Ignored in Terragrunt mode.
+	Workspaces []config.WorkspaceRule
+
+	// Dependencies declares edges static analysis cannot see; they are
+	// merged into the graph as declared rather than inferred.
+	Dependencies []config.DependencyRule
+
+	// DataSources turn data sources other than terraform_remote_state into
+	// dependencies on the modules producing their values.
+	DataSources []config.DataSourceRule
+
+	// ParseCache, when set, loads unchanged modules from a persistent parse
+	// cache instead of parsing them.
+	ParseCache *parser.ModuleCache
 }
 
 // ModuleSet keeps a module slice and its lookup index together.
@@ -76,10 +108,15 @@ type Result struct {
 	Graph        *graph.DependencyGraph
 	Dependencies map[string]*parser.ModuleDependencies
 	Diagnostics  diagnostic.List
+
+	// parser is the configured module parser dependencies were extracted
+	// with; nil in Terragrunt mode.
+	parser *parser.Parser
 }
 
 func run(ctx context.Context, opts Options) (*Result, error) {
 	scanner := discovery.NewScanner(opts.WorkDir, opts.Segments, opts.LibraryPaths...)
+	scanner.Terragrunt = opts.Terragrunt
 
 	allModules, err := scanner.Scan(ctx)
 	if err != nil {
@@ -92,6 +129,15 @@ func run(ctx context.Context, opts Options) (*Result, error) {
 		return nil, &terrierrors.NoModulesError{Dir: opts.WorkDir}
 	}
 
+	var workspaceDiags []diagnostic.Diagnostic
+	if !opts.Terragrunt {
+		scanned := len(allModules)
+		allModules, workspaceDiags = expandWorkspaces(opts.Workspaces, allModules)
+		if len(allModules) != scanned {
+			log.WithField("count", len(allModules)).Debug("expanded workspace modules")
+		}
+	}
+
 	executable, libraries := splitLibraries(allModules)
 
 	filtered, err := filter.Apply(executable, filter.Options{
@@ -114,11 +160,22 @@ func run(ctx context.Context, opts Options) (*Result, error) {
 	filteredSet := NewModuleSet(filtered)
 	librarySet := NewModuleSet(libraries)
 
-	hclParser := parser.NewParser(opts.Segments)
-
-	deps, warnings := parser.NewDependencyExtractor(hclParser, filteredSet.Index).ExtractAllDependencies(ctx)
+	varFiles, varFileDiags := varFilesByModule(opts.VarFiles, filtered)
+	dataSources, dataSourceDiags := dataSourceRules(opts.DataSources)
+	var moduleParser *parser.Parser
+	if !opts.Terragrunt {
+		moduleParser = newModuleParser(opts, varFiles, dataSources)
+	}
+	deps, warnings := extractDependencies(ctx, opts, moduleParser, filteredSet.Index)
+	declaredDiags := addDeclaredDependencies(opts.Dependencies, executable, filtered, deps)
+	logParseCache(opts.ParseCache)
 
 	depGraph := graph.BuildFromDependencies(filtered, deps)
+	diags := diagnosticsFromErrors(warnings).
+		Append(workspaceDiags...).
+		Append(varFileDiags...).
+		Append(dataSourceDiags...).
+		Append(declaredDiags...)
 
 	return &Result{
 		All:          allSet,
@@ -126,10 +183,44 @@ func run(ctx context.Context, opts Options) (*Result, error) {
 		Libraries:    librarySet,
 		Graph:        depGraph,
 		Dependencies: deps,
-		Diagnostics:  diagnosticsFromErrors(warnings),
+		Diagnostics:  diags,
+		parser:       moduleParser,
 	}, nil
 }
 
+func newModuleParser(opts Options, varFiles map[string][]string, dataSources []parser.DataSourceRule) *parser.Parser {
+	moduleParser := parser.NewParser(opts.Segments)
+	moduleParser.VarFiles = varFiles
+	moduleParser.DataSources = dataSources
+	moduleParser.EarlyEvaluation = opts.EarlyEvaluation
+	moduleParser.Cache = opts.ParseCache
+	return moduleParser
+}
+
+func extractDependencies(
+	ctx context.Context,
+	opts Options,
+	moduleParser *parser.Parser,
+	index *discovery.ModuleIndex,
+) (map[string]*parser.ModuleDependencies, []error) {
+	if opts.Terragrunt {
+		return terragrunt.NewDependencyExtractor(opts.WorkDir, index).ExtractAllDependencies(ctx)
+	}
+	return parser.NewDependencyExtractor(moduleParser, index).ExtractAllDependencies(ctx)
+}
+
+func logParseCache(cache *parser.ModuleCache) {
+	if cache == nil {
+		return
+	}
+	stats := cache.Stats()
+	log.WithField("hits", stats.Hits).
+		WithField("misses", stats.Misses).
+		WithField("skipped", stats.Skipped).
+		WithField("errors", stats.Errors).
+		Debug("parse cache")
+}
+
 func diagnosticsFromErrors(warnings []error) diagnostic.List {
 	if len(warnings) == 0 {
 		return diagnostic.List{}
@@ -139,6 +230,16 @@ func diagnosticsFromErrors(warnings []error) diagnostic.List {
 		if warning == nil {
 			continue
 		}
+		var unresolved *terrierrors.UnresolvedVariableError
+		if errors.As(warning, &unresolved) {
+			diags = append(diags, diagnostic.Warning(warning.Error(),
+				diagnostic.WithSource("parser"),
+				diagnostic.WithModule(unresolved.Module),
+				diagnostic.WithHint("set a default, a tfvars value or a var_files entry"),
+				diagnostic.WithCause(warning),
+			))
+			continue
+		}
 		diags = append(diags, diagnostic.Warning(warning.Error(), diagnostic.WithCause(warning)))
 	}
 	return diagnostic.NewList(diags...)
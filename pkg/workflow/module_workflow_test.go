@@ -8,9 +8,13 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
 	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/config/configtest"
+	"github.com/edelwud/terraci/pkg/discovery"
 	terrierrors "github.com/edelwud/terraci/pkg/errors"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/parser"
 )
 
 func configForLibraryTest(t testing.TB) config.Config {
@@ -320,6 +324,96 @@ resource "aws_eks_cluster" "main" {
 	}
 }
 
+func TestRun_TerragruntUnits(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A plain Terraform module is ignored in Terragrunt mode.
+	createModuleTree(t, tmpDir, []string{"platform/stage/eu-central-1/legacy"})
+	for path, content := range map[string]string{
+		"platform/stage/eu-central-1/vpc": `# root unit`,
+		"platform/stage/eu-central-1/eks": `dependency "vpc" { config_path = "../vpc" }`,
+	} {
+		dir := filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "terragrunt.hcl"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := defaultOptions(tmpDir)
+	opts.Terragrunt = true
+	result, err := run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if len(result.Filtered.Modules) != 2 {
+		t.Fatalf("FilteredModules = %d, want 2", len(result.Filtered.Modules))
+	}
+	deps := result.Graph.GetDependencies("platform/stage/eu-central-1/eks")
+	if !slices.Contains(deps, "platform/stage/eu-central-1/vpc") {
+		t.Errorf("expected eks to depend on vpc, got deps: %v", deps)
+	}
+}
+
+func TestRun_VarFilesAndUnresolvedVariables(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createModuleTree(t, tmpDir, []string{"platform/prod/eu-central-1/vpc"})
+	remoteState := `
+variable "environment" {}
+
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = {
+    bucket = "terraform-state"
+    key    = "platform/${var.environment}/eu-central-1/vpc/terraform.tfstate"
+  }
+}
+`
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/eks", remoteState)
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/app", remoteState)
+	if err := os.WriteFile(filepath.Join(tmpDir, "prod.tfvars"), []byte(`environment = "prod"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := config.NewVarFileRule(config.VarFileRuleOptions{
+		Match: map[string]string{"module": "eks"},
+		Files: []string{"../../../../prod.tfvars", "missing.tfvars"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := defaultOptions(tmpDir)
+	opts.VarFiles = []config.VarFileRule{rule}
+	result, err := run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if deps := result.Graph.GetDependencies("platform/prod/eu-central-1/eks"); !slices.Contains(deps, "platform/prod/eu-central-1/vpc") {
+		t.Errorf("expected eks to depend on vpc through the var-file, got deps: %v", deps)
+	}
+	if deps := result.Graph.GetDependencies("platform/prod/eu-central-1/app"); len(deps) != 0 {
+		t.Errorf("app deps = %v, want none", deps)
+	}
+
+	var unresolved, missing bool
+	for _, diag := range result.Diagnostics.All() {
+		switch {
+		case diag.Module() == "platform/prod/eu-central-1/app" && diag.Source() == "parser":
+			unresolved = diag.Message() == `variable "environment" has no value (from platform/prod/eu-central-1/app.vpc)`
+		case diag.Module() == "platform/prod/eu-central-1/eks" && diag.Source() == "var_files":
+			missing = diag.Message() == "var-file missing.tfvars not found"
+		}
+	}
+	if !unresolved || !missing {
+		t.Errorf("diagnostics = %v, want unresolved variable for app and missing var-file for eks", result.Diagnostics.Messages())
+	}
+}
+
 func TestRun_Indexes(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -519,3 +613,184 @@ func TestRun_GraphBuilt(t *testing.T) {
 		}
 	}
 }
+
+func TestRun_DeclaredDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	createModuleTree(t, tmpDir, []string{
+		"platform/prod/eu-central-1/iam",
+		"platform/prod/eu-central-1/app",
+		"platform/prod/us-east-1/app",
+	})
+
+	from, err := config.NewModuleSelector(config.ModuleSelectorOptions{Match: map[string]string{"module": "app"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := config.NewModuleSelector(config.ModuleSelectorOptions{Glob: "platform/prod/*/iam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing, err := config.NewModuleSelector(config.ModuleSelectorOptions{Glob: "platform/stage/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := make([]config.DependencyRule, 0, 2)
+	for _, opts := range []config.DependencyRuleOptions{{From: from, To: to}, {From: from, To: missing}} {
+		rule, ruleErr := config.NewDependencyRule(opts)
+		if ruleErr != nil {
+			t.Fatal(ruleErr)
+		}
+		rules = append(rules, rule)
+	}
+
+	opts := defaultOptions(tmpDir)
+	opts.Dependencies = rules
+	result, err := run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	for _, app := range []string{"platform/prod/eu-central-1/app", "platform/prod/us-east-1/app"} {
+		if deps := result.Graph.GetDependencies(app); !slices.Equal(deps, []string{"platform/prod/eu-central-1/iam"}) {
+			t.Errorf("%s deps = %v, want [iam]", app, deps)
+		}
+		if kind := result.Graph.EdgeKind(app, "platform/prod/eu-central-1/iam"); kind != graph.EdgeDeclared {
+			t.Errorf("%s -> iam kind = %q, want declared", app, kind)
+		}
+	}
+
+	var warned bool
+	for _, diag := range result.Diagnostics.All() {
+		if diag.Source() == "dependencies" && diag.Message() == "dependencies[1].to matches no module" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("diagnostics = %v, want a warning for the rule matching no module", result.Diagnostics.Messages())
+	}
+
+	// Filtering out iam drops its edges but does not make the rule look
+	// stale: it still matches a discovered module.
+	opts.Excludes = []string{"**/eu-central-1/**"}
+	result, err = run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("run filtered: %v", err)
+	}
+	if deps := result.Graph.GetDependencies("platform/prod/us-east-1/app"); len(deps) != 0 {
+		t.Errorf("filtered app deps = %v, want none", deps)
+	}
+	warnings := result.Diagnostics.Messages()
+	if slices.Contains(warnings, "dependencies[0].to matches no module") {
+		t.Errorf("diagnostics = %v, want no warning for a rule matching filtered-out modules", warnings)
+	}
+	if !slices.Contains(warnings, "dependencies[1].to matches no module") {
+		t.Errorf("diagnostics = %v, want the warning for the rule matching no discovered module", warnings)
+	}
+}
+
+func TestRun_DataSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	createModuleTree(t, tmpDir, []string{"platform/prod/eu-central-1/vpc"})
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/app", `
+data "tfe_outputs" "network" {
+  workspace = "vpc-prod"
+}
+`)
+
+	rule, err := config.NewDataSourceRule(config.DataSourceRuleOptions{
+		Type:      "tfe_outputs",
+		Attribute: "workspace",
+		Pattern:   `^(?P<module>[a-z]+)-(?P<env>[a-z]+)$`,
+		Module:    "platform/{env}/*/{module}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := defaultOptions(tmpDir)
+	opts.DataSources = []config.DataSourceRule{rule}
+	result, err := run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if deps := result.Graph.GetDependencies("platform/prod/eu-central-1/app"); !slices.Equal(deps, []string{"platform/prod/eu-central-1/vpc"}) {
+		t.Errorf("app deps = %v, want [vpc] through tfe_outputs", deps)
+	}
+	dep := result.Dependencies["platform/prod/eu-central-1/app"].Dependencies[0]
+	if dep.Type != parser.DependencyTypeDataSource {
+		t.Errorf("dependency type = %q, want %q", dep.Type, parser.DependencyTypeDataSource)
+	}
+}
+
+func TestRun_ParseCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	createModuleTree(t, tmpDir, []string{"platform/prod/eu-central-1/vpc"})
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/app", `
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = { bucket = "b", key = "platform/prod/eu-central-1/vpc/terraform.tfstate" }
+}
+`)
+
+	opts := defaultOptions(tmpDir)
+	opts.ParseCache = parser.NewModuleCache(blobtest.NewMemoryStore(""), "test", 0)
+	for range 2 {
+		result, err := run(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		if deps := result.Graph.GetDependencies("platform/prod/eu-central-1/app"); !slices.Equal(deps, []string{"platform/prod/eu-central-1/vpc"}) {
+			t.Fatalf("app deps = %v, want [vpc]", deps)
+		}
+	}
+
+	if stats := opts.ParseCache.Stats(); stats.Misses != 2 || stats.Hits != 2 {
+		t.Errorf("Stats() = %+v, want the second run served from the cache", stats)
+	}
+}
+
+func TestRun_Workspaces(t *testing.T) {
+	tmpDir := t.TempDir()
+	createModuleTree(t, tmpDir, []string{"platform/prod/eu-central-1/vpc"})
+	createModuleWithContent(t, tmpDir, "platform/prod/eu-central-1/app", `
+data "terraform_remote_state" "vpc" {
+  backend   = "s3"
+  workspace = "acme"
+  config = { bucket = "b", key = "platform/prod/eu-central-1/vpc/terraform.tfstate" }
+}
+`)
+	workspaces := filepath.Join(tmpDir, "platform/prod/eu-central-1/app", discovery.WorkspacesFile)
+	if err := os.WriteFile(workspaces, []byte("# tenants\nacme\nglobex\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := config.NewWorkspaceRule(config.WorkspaceRuleOptions{
+		Match: map[string]string{"module": "vpc"},
+		Names: []string{"default", "acme"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := defaultOptions(tmpDir)
+	opts.Workspaces = []config.WorkspaceRule{rule}
+	opts.Excludes = []string{"platform/prod/eu-central-1/app@globex"}
+	result, err := run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	ids := moduleIDs(result.Filtered.Modules)
+	slices.Sort(ids)
+	want := []string{
+		"platform/prod/eu-central-1/app@acme",
+		"platform/prod/eu-central-1/vpc",
+		"platform/prod/eu-central-1/vpc@acme",
+	}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("filtered = %v, want %v", ids, want)
+	}
+	if deps := result.Graph.GetDependencies("platform/prod/eu-central-1/app@acme"); !slices.Equal(deps, []string{"platform/prod/eu-central-1/vpc@acme"}) {
+		t.Errorf("app@acme deps = %v, want [vpc@acme]", deps)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/filter"
+	"github.com/edelwud/terraci/pkg/parser"
 )
 
 // ProjectRequest describes one canonical Terraform project planning request.
@@ -15,6 +16,10 @@ type ProjectRequest struct {
 	Config    config.Config
 	Filters   filter.Flags
 	Targeting TargetRequest
+
+	// ParseCache, when set, loads unchanged modules from a persistent parse
+	// cache instead of parsing them.
+	ParseCache *parser.ModuleCache
 }
 
 // TargetRequest controls optional executable target selection.
@@ -60,7 +65,9 @@ func PlanProject(ctx context.Context, req ProjectRequest) (*ProjectResult, error
 	}
 	flags := cloneFilterFlags(req.Filters)
 
-	result, err := run(ctx, optionsFromConfig(req.WorkDir, cfg, flags))
+	opts := optionsFromConfig(req.WorkDir, cfg, flags)
+	opts.ParseCache = req.ParseCache
+	result, err := run(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +177,7 @@ func filterModulesByPath(modules []*discovery.Module, modulePath string) []*disc
 	modulePath = workspacepath.Join(modulePath)
 	filtered := make([]*discovery.Module, 0, len(modules))
 	for _, module := range modules {
-		if module.ID() == modulePath {
+		if module.ID() == modulePath || module.RelativePath == modulePath {
 			filtered = append(filtered, module)
 		}
 	}
@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/diagnostic"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+// varFilesByModule resolves var_files rules to the extra tfvars files of each
+// module, keyed by module directory as the parser expects. Rules apply in
+// config order, so later rules override earlier ones. Files that do not
+// exist are reported and skipped.
+func varFilesByModule(rules []config.VarFileRule, modules []*discovery.Module) (map[string][]string, []diagnostic.Diagnostic) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	varFiles := make(map[string][]string)
+	seen := make(map[string]bool, len(modules))
+	var diags []diagnostic.Diagnostic
+	for _, module := range modules {
+		// Workspaces of one directory share its var-files.
+		if seen[module.Path] {
+			continue
+		}
+		seen[module.Path] = true
+		for _, rule := range rules {
+			if !segmentsMatch(rule.Match(), module) {
+				continue
+			}
+			for _, file := range rule.Files() {
+				full := file
+				if !filepath.IsAbs(full) {
+					full = filepath.Join(module.Path, file)
+				}
+				if _, err := os.Stat(full); err != nil {
+					diags = append(diags, diagnostic.Warning(
+						fmt.Sprintf("var-file %s not found", file),
+						diagnostic.WithSource("var_files"),
+						diagnostic.WithModule(module.ID()),
+						diagnostic.WithCause(err),
+					))
+					continue
+				}
+				varFiles[module.Path] = append(varFiles[module.Path], full)
+			}
+		}
+	}
+	return varFiles, diags
+}
@@ -0,0 +1,66 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/diagnostic"
+	"github.com/edelwud/terraci/pkg/discovery"
+)
+
+// expandWorkspaces replaces every module deployed to Terraform workspaces
+// with one module per workspace. Workspaces come from matching workspaces
+// rules followed by the module's workspaces file; the default workspace
+// keeps the plain module and is only deployed when listed. Library modules
+// are never expanded.
+func expandWorkspaces(rules []config.WorkspaceRule, modules []*discovery.Module) ([]*discovery.Module, []diagnostic.Diagnostic) {
+	expanded := make([]*discovery.Module, 0, len(modules))
+	var diags []diagnostic.Diagnostic
+	for _, module := range modules {
+		if module.IsLibrary {
+			expanded = append(expanded, module)
+			continue
+		}
+
+		var names []string
+		for _, rule := range rules {
+			if segmentsMatch(rule.Match(), module) {
+				names = append(names, rule.Names()...)
+			}
+		}
+		fromFile, err := discovery.ReadWorkspaces(module.Path)
+		if err != nil {
+			diags = append(diags, diagnostic.Warning(
+				fmt.Sprintf("%s file ignored", discovery.WorkspacesFile),
+				diagnostic.WithSource("workspaces"),
+				diagnostic.WithModule(module.ID()),
+				diagnostic.WithCause(err),
+			))
+		}
+		names = append(names, fromFile...)
+
+		if len(names) == 0 {
+			expanded = append(expanded, module)
+			continue
+		}
+		for _, name := range uniqueNames(names) {
+			if name == discovery.DefaultWorkspace {
+				expanded = append(expanded, module)
+				continue
+			}
+			expanded = append(expanded, module.WithWorkspace(name))
+		}
+	}
+	return expanded, diags
+}
+
+func uniqueNames(names []string) []string {
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
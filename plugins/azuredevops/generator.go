@@ -0,0 +1,36 @@
+package azuredevops
+
+import (
+	"os"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/plugin"
+	generatepkg "github.com/edelwud/terraci/plugins/azuredevops/internal/generate"
+	prpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/pr"
+)
+
+// ProviderName returns the provider name.
+func (p *Plugin) ProviderName() string { return p.Name() }
+
+// DetectEnv returns true if running in Azure Pipelines.
+func (p *Plugin) DetectEnv() bool {
+	return os.Getenv("TF_BUILD") != ""
+}
+
+// PipelineID returns the Azure Pipelines build ID.
+func (p *Plugin) PipelineID() string { return os.Getenv("BUILD_BUILDID") }
+
+// CommitSHA returns the Azure Pipelines source commit SHA.
+func (p *Plugin) CommitSHA() string { return os.Getenv("BUILD_SOURCEVERSION") }
+
+// NewGenerator creates a new Azure Pipelines generator bound to the pre-built
+// IR.
+func (p *Plugin) NewGenerator(ir *pipeline.IR) (pipeline.Generator, error) {
+	return generatepkg.NewGenerator(p.Config(), ir), nil
+}
+
+// NewCommentService creates a new PR comment service.
+func (p *Plugin) NewCommentService(_ *plugin.AppContext) ci.CommentService {
+	return prpkg.NewServiceFromEnv()
+}
@@ -0,0 +1,75 @@
+package azuredevops
+
+import (
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+)
+
+// InitContributor — contributes Azure DevOps Pipelines fields to the init wizard.
+
+const defaultAzureVMImage = "ubuntu-latest"
+
+var (
+	initConfigKey    = config.MustExtensionKey(pluginName)
+	keyAzureVMImage  = initwiz.MustStateKey[string]("azuredevops.vm_image")
+	keyAzurePoolName = initwiz.MustStateKey[string]("azuredevops.pool_name")
+)
+
+type initConfig struct {
+	Pool *configpkg.Pool `yaml:"pool"`
+}
+
+// InitGroups returns the init wizard group specs for Azure DevOps Pipelines.
+func (p *Plugin) InitGroups() ([]initwiz.InitGroup, error) {
+	showAzure := func(s *initwiz.StateMap) bool {
+		return initwiz.ProviderKey.Get(s) == pluginName
+	}
+
+	vmImage, err := initwiz.NewStringField(initwiz.StringFieldOptions{
+		Key:         keyAzureVMImage,
+		Title:       "Agent Image",
+		Description: "Microsoft-hosted agent image (ignored when a pool name is set)",
+		Default:     defaultAzureVMImage,
+		Placeholder: defaultAzureVMImage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	poolName, err := initwiz.NewStringField(initwiz.StringFieldOptions{
+		Key:         keyAzurePoolName,
+		Title:       "Agent Pool",
+		Description: "Self-hosted agent pool name (leave empty for Microsoft-hosted agents)",
+	})
+	if err != nil {
+		return nil, err
+	}
+	group, err := initwiz.NewInitGroup(initwiz.InitGroupOptions{
+		Title:    "Azure DevOps Pipelines",
+		Category: initwiz.CategoryProvider,
+		Order:    100,
+		ShowWhen: showAzure,
+		Fields:   []initwiz.InitField{vmImage, poolName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []initwiz.InitGroup{group}, nil
+}
+
+// BuildInitConfig builds the Azure DevOps Pipelines init contribution.
+func (p *Plugin) BuildInitConfig(state *initwiz.StateMap) (*initwiz.InitContribution, error) {
+	if initwiz.ProviderKey.Get(state) != pluginName {
+		return nil, nil
+	}
+
+	pool := &configpkg.Pool{Name: keyAzurePoolName.Get(state)}
+	if pool.Name == "" {
+		pool.VMImage = keyAzureVMImage.Get(state)
+		if pool.VMImage == "" {
+			pool.VMImage = defaultAzureVMImage
+		}
+	}
+
+	return initwiz.NewInitContribution(initConfigKey, initConfig{Pool: pool})
+}
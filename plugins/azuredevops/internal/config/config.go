@@ -0,0 +1,142 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+type Image = ci.Image
+
+// Config contains Azure DevOps Pipelines specific settings.
+type Config struct {
+	Trigger     []string          `yaml:"trigger,omitempty" json:"trigger,omitempty" jsonschema:"description=Branches whose pushes trigger the pipeline (defaults to main)"`
+	PR          []string          `yaml:"pr,omitempty" json:"pr,omitempty" jsonschema:"description=Target branches whose pull requests trigger the pipeline (defaults to main)"`
+	Pool        *Pool             `yaml:"pool,omitempty" json:"pool,omitempty" jsonschema:"description=Agent pool used by all jobs (defaults to the Microsoft-hosted ubuntu-latest image)"`
+	Container   *Image            `yaml:"container,omitempty" json:"container,omitempty" jsonschema:"description=Container image to run jobs in (optional)"`
+	Variables   map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" jsonschema:"description=Pipeline-level variables"`
+	JobDefaults *JobDefaults      `yaml:"job_defaults,omitempty" json:"job_defaults,omitempty" jsonschema:"description=Default settings applied to all jobs"`
+	Overwrites  []JobOverwrite    `yaml:"overwrites,omitempty" json:"overwrites,omitempty" jsonschema:"description=Job-level overrides for plan or apply jobs"`
+	// ApprovalNotifyUsers are notified by the ManualValidation job that gates
+	// apply jobs matched by a top-level approvals rule.
+	ApprovalNotifyUsers []string `yaml:"approval_notify_users,omitempty" json:"approval_notify_users,omitempty" jsonschema:"description=Users or groups notified to approve approval-gated apply jobs"`
+}
+
+// Clone returns a deep copy of the Azure DevOps configuration.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Trigger = append([]string(nil), c.Trigger...)
+	out.PR = append([]string(nil), c.PR...)
+	out.Pool = clonePool(c.Pool)
+	out.Container = cloneImagePointer(c.Container)
+	out.Variables = maps.Clone(c.Variables)
+	out.JobDefaults = cloneJobDefaults(c.JobDefaults)
+	out.Overwrites = cloneJobOverwrites(c.Overwrites)
+	out.ApprovalNotifyUsers = append([]string(nil), c.ApprovalNotifyUsers...)
+	return &out
+}
+
+// Pool selects a Microsoft-hosted image or a self-hosted agent pool.
+type Pool struct {
+	Name    string   `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"description=Self-hosted agent pool name"`
+	VMImage string   `yaml:"vm_image,omitempty" json:"vm_image,omitempty" jsonschema:"description=Microsoft-hosted agent image (e.g. ubuntu-latest)"`
+	Demands []string `yaml:"demands,omitempty" json:"demands,omitempty" jsonschema:"description=Agent capability demands for self-hosted pools"`
+}
+
+func clonePool(in *Pool) *Pool {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Demands = append([]string(nil), in.Demands...)
+	return &out
+}
+
+type JobDefaults struct {
+	Pool        *Pool             `yaml:"pool,omitempty" json:"pool,omitempty" jsonschema:"description=Override agent pool"`
+	Container   *Image            `yaml:"container,omitempty" json:"container,omitempty" jsonschema:"description=Container image for all jobs"`
+	Variables   map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" jsonschema:"description=Additional job variables"`
+	Condition   string            `yaml:"condition,omitempty" json:"condition,omitempty" jsonschema:"description=Azure Pipelines job condition"`
+	StepsBefore []ConfigStep      `yaml:"steps_before,omitempty" json:"steps_before,omitempty" jsonschema:"description=Extra steps before terraform commands"`
+	StepsAfter  []ConfigStep      `yaml:"steps_after,omitempty" json:"steps_after,omitempty" jsonschema:"description=Extra steps after terraform commands"`
+}
+
+func cloneJobDefaults(in *JobDefaults) *JobDefaults {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Pool = clonePool(in.Pool)
+	out.Container = cloneImagePointer(in.Container)
+	out.Variables = maps.Clone(in.Variables)
+	out.StepsBefore = cloneConfigSteps(in.StepsBefore)
+	out.StepsAfter = cloneConfigSteps(in.StepsAfter)
+	return &out
+}
+
+type JobOverwrite struct {
+	Type        JobOverwriteType  `yaml:"type" json:"type" jsonschema:"description=Type of jobs to override (plan\\, apply\\, or contributed job name),required"`
+	Pool        *Pool             `yaml:"pool,omitempty" json:"pool,omitempty" jsonschema:"description=Override agent pool"`
+	Container   *Image            `yaml:"container,omitempty" json:"container,omitempty" jsonschema:"description=Container image override"`
+	Variables   map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" jsonschema:"description=Additional job variables"`
+	Condition   string            `yaml:"condition,omitempty" json:"condition,omitempty" jsonschema:"description=Azure Pipelines job condition"`
+	StepsBefore []ConfigStep      `yaml:"steps_before,omitempty" json:"steps_before,omitempty" jsonschema:"description=Extra steps before terraform commands"`
+	StepsAfter  []ConfigStep      `yaml:"steps_after,omitempty" json:"steps_after,omitempty" jsonschema:"description=Extra steps after terraform commands"`
+}
+
+func cloneJobOverwrites(in []JobOverwrite) []JobOverwrite {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]JobOverwrite, len(in))
+	for i := range in {
+		out[i] = in[i]
+		out[i].Pool = clonePool(in[i].Pool)
+		out[i].Container = cloneImagePointer(in[i].Container)
+		out[i].Variables = maps.Clone(in[i].Variables)
+		out[i].StepsBefore = cloneConfigSteps(in[i].StepsBefore)
+		out[i].StepsAfter = cloneConfigSteps(in[i].StepsAfter)
+	}
+	return out
+}
+
+//nolint:revive // ConfigStep keeps the public config vocabulary explicit.
+type ConfigStep struct {
+	DisplayName string            `yaml:"display_name,omitempty" json:"display_name,omitempty" jsonschema:"description=Step display name"`
+	Task        string            `yaml:"task,omitempty" json:"task,omitempty" jsonschema:"description=Azure Pipelines task reference (e.g. TerraformInstaller@1)"`
+	Inputs      map[string]string `yaml:"inputs,omitempty" json:"inputs,omitempty" jsonschema:"description=Task inputs"`
+	Script      string            `yaml:"script,omitempty" json:"script,omitempty" jsonschema:"description=Shell script to run"`
+	Env         map[string]string `yaml:"env,omitempty" json:"env,omitempty" jsonschema:"description=Step environment variables"`
+}
+
+func cloneConfigSteps(in []ConfigStep) []ConfigStep {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]ConfigStep, len(in))
+	for i := range in {
+		out[i] = in[i]
+		out[i].Inputs = maps.Clone(in[i].Inputs)
+		out[i].Env = maps.Clone(in[i].Env)
+	}
+	return out
+}
+
+func cloneImagePointer(in *Image) *Image {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Entrypoint = append([]string(nil), in.Entrypoint...)
+	return &out
+}
+
+type JobOverwriteType string
+
+const (
+	OverwriteTypePlan  JobOverwriteType = "plan"
+	OverwriteTypeApply JobOverwriteType = "apply"
+)
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate runs the Azure DevOps plugin's config-shape sanity checks. Called
+// from the plugin's Preflight after DecodeAndSet — fails fast with a
+// descriptive error rather than emitting YAML-shaped garbage in the generated
+// pipeline.
+//
+// Catches pools that name both a hosted image and a self-hosted pool, steps
+// that are neither a script nor a task, and blank JobOverwrite.Type values.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if err := c.Pool.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("pool: %w", err))
+	}
+	if c.JobDefaults != nil {
+		if err := c.JobDefaults.Pool.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.pool: %w", err))
+		}
+		errs = append(errs, validateSteps("job_defaults.steps_before", c.JobDefaults.StepsBefore)...)
+		errs = append(errs, validateSteps("job_defaults.steps_after", c.JobDefaults.StepsAfter)...)
+	}
+	for i := range c.Overwrites {
+		ow := &c.Overwrites[i]
+		if err := ow.Type.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d]: %w", i, err))
+		}
+		if err := ow.Pool.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].pool: %w", i, err))
+		}
+		errs = append(errs, validateSteps(fmt.Sprintf("overwrites[%d].steps_before", i), ow.StepsBefore)...)
+		errs = append(errs, validateSteps(fmt.Sprintf("overwrites[%d].steps_after", i), ow.StepsAfter)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *Pool) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Name != "" && p.VMImage != "" {
+		return errors.New("name and vm_image are mutually exclusive")
+	}
+	if p.Name == "" && p.VMImage == "" {
+		return errors.New("either name or vm_image must be set")
+	}
+	return nil
+}
+
+func validateSteps(field string, steps []ConfigStep) []error {
+	var errs []error
+	for i, step := range steps {
+		switch {
+		case step.Script == "" && step.Task == "":
+			errs = append(errs, fmt.Errorf("%s[%d]: either script or task must be set", field, i))
+		case step.Script != "" && step.Task != "":
+			errs = append(errs, fmt.Errorf("%s[%d]: script and task are mutually exclusive", field, i))
+		}
+	}
+	return errs
+}
+
+func (t JobOverwriteType) validate() error {
+	if t == "" {
+		return errors.New("type must be set (plan, apply, or a contributed job name)")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "maps"
+
+func clonePool(in *Pool) *Pool {
+	if in == nil {
+		return nil
+	}
+	return &Pool{Name: in.Name, VMImage: in.VMImage, Demands: append([]string(nil), in.Demands...)}
+}
+
+func cloneContainer(in *Container) *Container {
+	if in == nil {
+		return nil
+	}
+	return &Container{Image: in.Image}
+}
+
+func cloneSteps(in []Step) []Step {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Step, len(in))
+	for i, step := range in {
+		out[i] = step.clone()
+	}
+	return out
+}
+
+func cloneJobs(in []Job) []Job {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Job, len(in))
+	for i := range in {
+		out[i] = in[i].clone()
+	}
+	return out
+}
+
+func cloneStages(in []Stage) []Stage {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Stage, len(in))
+	for i := range in {
+		out[i] = in[i].clone()
+	}
+	return out
+}
+
+func cloneStringMap(in map[string]string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	maps.Copy(out, in)
+	return out
+}
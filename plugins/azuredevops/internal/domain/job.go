@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// identifierPattern matches Azure Pipelines stage and job identifiers.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ServerPool is the pool of agentless jobs, which run tasks such as
+// ManualValidation on the Azure Pipelines server instead of an agent.
+const ServerPool = "server"
+
+type JobOptions struct {
+	Name        string
+	DisplayName string
+	// DependsOn lists jobs of the same stage that must finish first.
+	DependsOn []string
+	Pool      *Pool
+	// Server renders the job as agentless (pool: server); Pool and Container
+	// must be unset.
+	Server           bool
+	Container        *Container
+	Variables        map[string]string
+	Condition        string
+	ContinueOnError  bool
+	TimeoutInMinutes int
+	Steps            []Step
+}
+
+type Job struct {
+	name             string
+	displayName      string
+	dependsOn        []string
+	pool             *Pool
+	server           bool
+	container        *Container
+	variables        map[string]string
+	condition        string
+	continueOnError  bool
+	timeoutInMinutes int
+	steps            []Step
+}
+
+func NewJob(opts JobOptions) (Job, error) {
+	if err := validateIdentifier("job", opts.Name); err != nil {
+		return Job{}, err
+	}
+	if opts.Server {
+		if opts.Pool != nil || opts.Container != nil {
+			return Job{}, errors.New("azure devops server job cannot use a pool or container")
+		}
+	} else if opts.Pool == nil && opts.Container == nil {
+		return Job{}, errors.New("azure devops job pool or container is required")
+	}
+	for _, dep := range opts.DependsOn {
+		if err := validateIdentifier("job dependency", dep); err != nil {
+			return Job{}, err
+		}
+	}
+	if opts.TimeoutInMinutes < 0 {
+		return Job{}, errors.New("azure devops job timeout must not be negative")
+	}
+	if len(opts.Steps) == 0 {
+		return Job{}, errors.New("azure devops job steps are required")
+	}
+	return Job{
+		name:             opts.Name,
+		displayName:      opts.DisplayName,
+		dependsOn:        append([]string(nil), opts.DependsOn...),
+		pool:             clonePool(opts.Pool),
+		server:           opts.Server,
+		container:        cloneContainer(opts.Container),
+		variables:        cloneStringMap(opts.Variables),
+		condition:        opts.Condition,
+		continueOnError:  opts.ContinueOnError,
+		timeoutInMinutes: opts.TimeoutInMinutes,
+		steps:            cloneSteps(opts.Steps),
+	}, nil
+}
+
+func (j Job) Name() string { return j.name }
+
+func (j Job) DisplayName() string { return j.displayName }
+
+func (j Job) DependsOn() []string { return append([]string(nil), j.dependsOn...) }
+
+func (j Job) Pool() *Pool { return clonePool(j.pool) }
+
+func (j Job) Server() bool { return j.server }
+
+func (j Job) Container() *Container { return cloneContainer(j.container) }
+
+func (j Job) Variables() map[string]string { return cloneStringMap(j.variables) }
+
+func (j Job) Condition() string { return j.condition }
+
+func (j Job) ContinueOnError() bool { return j.continueOnError }
+
+func (j Job) TimeoutInMinutes() int { return j.timeoutInMinutes }
+
+func (j Job) Steps() []Step { return cloneSteps(j.steps) }
+
+func (j Job) clone() Job {
+	return Job{
+		name:             j.name,
+		displayName:      j.displayName,
+		dependsOn:        append([]string(nil), j.dependsOn...),
+		pool:             clonePool(j.pool),
+		server:           j.server,
+		container:        cloneContainer(j.container),
+		variables:        cloneStringMap(j.variables),
+		condition:        j.condition,
+		continueOnError:  j.continueOnError,
+		timeoutInMinutes: j.timeoutInMinutes,
+		steps:            cloneSteps(j.steps),
+	}
+}
+
+func validateIdentifier(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("azure devops %s name is required", kind)
+	}
+	if !identifierPattern.MatchString(name) {
+		return fmt.Errorf("azure devops %s name %q must contain only letters, digits, and underscores", kind, name)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v4"
+)
+
+func TestNewJobValidatesRequiredFields(t *testing.T) {
+	steps := []Step{NewStep(StepOptions{Checkout: CheckoutSelf})}
+	pool := &Pool{VMImage: "ubuntu-latest"}
+
+	if _, err := NewJob(JobOptions{Pool: pool, Steps: steps}); err == nil {
+		t.Fatal("NewJob() error = nil, want missing name error")
+	}
+	if _, err := NewJob(JobOptions{Name: "plan-vpc", Pool: pool, Steps: steps}); err == nil {
+		t.Fatal("NewJob() error = nil, want invalid identifier error")
+	}
+	if _, err := NewJob(JobOptions{Name: "plan_vpc", Steps: steps}); err == nil {
+		t.Fatal("NewJob() error = nil, want missing pool error")
+	}
+	if _, err := NewJob(JobOptions{Name: "plan_vpc", Pool: pool}); err == nil {
+		t.Fatal("NewJob() error = nil, want missing steps error")
+	}
+	if _, err := NewJob(JobOptions{Name: "plan_vpc", Pool: pool, Steps: steps}); err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+}
+
+func TestNewJobServerJobs(t *testing.T) {
+	steps := []Step{NewStep(StepOptions{Task: "ManualValidation@0"})}
+
+	if _, err := NewJob(JobOptions{Name: "approve_vpc", Server: true, Pool: &Pool{VMImage: "ubuntu-latest"}, Steps: steps}); err == nil {
+		t.Fatal("NewJob() error = nil, want server job pool error")
+	}
+	if _, err := NewJob(JobOptions{Name: "apply_vpc", Server: true, DependsOn: []string{"approve-vpc"}, Steps: steps}); err == nil {
+		t.Fatal("NewJob() error = nil, want invalid dependency error")
+	}
+	job, err := NewJob(JobOptions{Name: "approve_vpc", Server: true, TimeoutInMinutes: 60, Steps: steps})
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+	out, err := yaml.Marshal(job)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	for _, want := range []string{"pool: server", "timeoutInMinutes: 60"} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("server job YAML missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Pipeline represents a multi-stage Azure Pipelines definition.
+type Pipeline struct {
+	trigger   []string
+	pr        []string
+	variables map[string]string
+	stages    []Stage
+}
+
+type PipelineOptions struct {
+	Trigger   []string
+	PR        []string
+	Variables map[string]string
+}
+
+type PipelineBuilder struct {
+	opts   PipelineOptions
+	stages []Stage
+	jobs   map[string]string
+}
+
+func EmptyPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+func NewPipelineBuilder(opts PipelineOptions) *PipelineBuilder {
+	return &PipelineBuilder{
+		opts: PipelineOptions{
+			Trigger:   append([]string(nil), opts.Trigger...),
+			PR:        append([]string(nil), opts.PR...),
+			Variables: cloneStringMap(opts.Variables),
+		},
+		jobs: make(map[string]string),
+	}
+}
+
+// AddStage appends a stage. Stage dependencies must reference stages added
+// earlier, and job names must be unique across the whole pipeline so that
+// artifacts and display names stay unambiguous.
+func (b *PipelineBuilder) AddStage(stage Stage) error {
+	if b == nil {
+		return errors.New("azure devops pipeline builder is nil")
+	}
+	if stage.name == "" {
+		return errors.New("azure devops stage name is required")
+	}
+	for _, existing := range b.stages {
+		if existing.name == stage.name {
+			return fmt.Errorf("duplicate azure devops stage %q", stage.name)
+		}
+	}
+	for _, dep := range stage.dependsOn {
+		if !b.hasStage(dep) {
+			return fmt.Errorf("azure devops stage %q depends on unknown stage %q", stage.name, dep)
+		}
+	}
+	for _, job := range stage.jobs {
+		if owner, exists := b.jobs[job.name]; exists {
+			return fmt.Errorf("duplicate azure devops job %q in stages %q and %q", job.name, owner, stage.name)
+		}
+	}
+	for _, job := range stage.jobs {
+		b.jobs[job.name] = stage.name
+	}
+	b.stages = append(b.stages, stage.clone())
+	return nil
+}
+
+func (b *PipelineBuilder) Build() (*Pipeline, error) {
+	if b == nil {
+		return nil, errors.New("azure devops pipeline builder is nil")
+	}
+	return &Pipeline{
+		trigger:   append([]string(nil), b.opts.Trigger...),
+		pr:        append([]string(nil), b.opts.PR...),
+		variables: cloneStringMap(b.opts.Variables),
+		stages:    cloneStages(b.stages),
+	}, nil
+}
+
+func (b *PipelineBuilder) hasStage(name string) bool {
+	for _, stage := range b.stages {
+		if stage.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Pipeline) Trigger() []string {
+	if p == nil {
+		return nil
+	}
+	return append([]string(nil), p.trigger...)
+}
+
+func (p *Pipeline) PR() []string {
+	if p == nil {
+		return nil
+	}
+	return append([]string(nil), p.pr...)
+}
+
+func (p *Pipeline) Variables() map[string]string {
+	if p == nil {
+		return nil
+	}
+	return cloneStringMap(p.variables)
+}
+
+func (p *Pipeline) StageNames() []string {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.stages))
+	for _, stage := range p.stages {
+		names = append(names, stage.name)
+	}
+	return names
+}
+
+func (p *Pipeline) Stage(name string) (Stage, bool) {
+	if p == nil {
+		return Stage{}, false
+	}
+	for _, stage := range p.stages {
+		if stage.name == name {
+			return stage.clone(), true
+		}
+	}
+	return Stage{}, false
+}
+
+// StageOf returns the name of the stage that owns jobName.
+func (p *Pipeline) StageOf(jobName string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	for _, stage := range p.stages {
+		for _, job := range stage.jobs {
+			if job.name == jobName {
+				return stage.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *Pipeline) Job(name string) (Job, bool) {
+	if p == nil {
+		return Job{}, false
+	}
+	for _, stage := range p.stages {
+		for _, job := range stage.jobs {
+			if job.name == name {
+				return job.clone(), true
+			}
+		}
+	}
+	return Job{}, false
+}
+
+func (p *Pipeline) JobNames() []string {
+	if p == nil {
+		return nil
+	}
+	var names []string
+	for _, stage := range p.stages {
+		for _, job := range stage.jobs {
+			names = append(names, job.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Pipeline) JobCount() int {
+	if p == nil {
+		return 0
+	}
+	count := 0
+	for _, stage := range p.stages {
+		count += len(stage.jobs)
+	}
+	return count
+}
+
+// JobRunsAfter reports whether the stage owning jobName transitively depends
+// on the stage owning dependency. Azure Pipelines orders jobs across stages
+// only through stage dependsOn edges.
+func (p *Pipeline) JobRunsAfter(jobName, dependency string) bool {
+	from, ok := p.StageOf(jobName)
+	if !ok {
+		return false
+	}
+	to, ok := p.StageOf(dependency)
+	if !ok || from == to {
+		return false
+	}
+	visited := make(map[string]bool)
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		stage, ok := p.Stage(current)
+		if !ok {
+			continue
+		}
+		for _, dep := range stage.dependsOn {
+			if dep == to {
+				return true
+			}
+			if !visited[dep] {
+				visited[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package domain
+
+import "testing"
+
+func TestPipelineGettersReturnDefensiveCopies(t *testing.T) {
+	job := mustJob(t, "plan_vpc", map[string]string{"TF_MODULE": "vpc"})
+	stage := mustStage(t, StageOptions{Name: "dag_level_0"}, job)
+	builder := NewPipelineBuilder(PipelineOptions{Variables: map[string]string{"GLOBAL": "true"}})
+	mustAddStage(t, builder, stage)
+	pipeline := mustBuildPipeline(t, builder)
+
+	got, ok := pipeline.Job("plan_vpc")
+	if !ok {
+		t.Fatal("plan_vpc job not found")
+	}
+	vars := got.Variables()
+	vars["TF_MODULE"] = "changed"
+	if got.Variables()["TF_MODULE"] != "vpc" {
+		t.Fatalf("Job.Variables() leaked mutation: %#v", got.Variables())
+	}
+	pipelineVars := pipeline.Variables()
+	pipelineVars["GLOBAL"] = "changed"
+	if pipeline.Variables()["GLOBAL"] != "true" {
+		t.Fatalf("Pipeline.Variables() leaked mutation: %#v", pipeline.Variables())
+	}
+}
+
+func TestPipelineBuilderValidatesStages(t *testing.T) {
+	first := mustStage(t, StageOptions{Name: "dag_level_0"}, mustJob(t, "plan_vpc", nil))
+	duplicateJob := mustStage(t, StageOptions{Name: "dag_level_1", DependsOn: []string{"dag_level_0"}}, mustJob(t, "plan_vpc", nil))
+	unknownDep := mustStage(t, StageOptions{Name: "dag_level_2", DependsOn: []string{"missing"}}, mustJob(t, "plan_eks", nil))
+
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddStage(t, builder, first)
+	if err := builder.AddStage(first); err == nil {
+		t.Fatal("AddStage() error = nil, want duplicate stage error")
+	}
+	if err := builder.AddStage(duplicateJob); err == nil {
+		t.Fatal("AddStage() error = nil, want duplicate job error")
+	}
+	if err := builder.AddStage(unknownDep); err == nil {
+		t.Fatal("AddStage() error = nil, want unknown dependency error")
+	}
+}
+
+func TestStageBuilderValidatesJobs(t *testing.T) {
+	if _, err := NewStageBuilder(StageOptions{Name: "dag_level_0"}).Build(); err == nil {
+		t.Fatal("Build() error = nil, want empty stage error")
+	}
+	builder := NewStageBuilder(StageOptions{Name: "dag-level-0"})
+	if err := builder.AddJob(mustJob(t, "plan_vpc", nil)); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	if err := builder.AddJob(mustJob(t, "plan_vpc", nil)); err == nil {
+		t.Fatal("AddJob() error = nil, want duplicate job error")
+	}
+	if _, err := builder.Build(); err == nil {
+		t.Fatal("Build() error = nil, want invalid stage identifier error")
+	}
+}
+
+func TestPipelineJobRunsAfterFollowsStageDependencies(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddStage(t, builder, mustStage(t, StageOptions{Name: "s0"}, mustJob(t, "plan_vpc", nil)))
+	mustAddStage(t, builder, mustStage(t, StageOptions{Name: "s1", DependsOn: []string{"s0"}}, mustJob(t, "apply_vpc", nil)))
+	mustAddStage(t, builder, mustStage(t, StageOptions{Name: "s2", DependsOn: []string{"s1"}}, mustJob(t, "plan_eks", nil)))
+	pipeline := mustBuildPipeline(t, builder)
+
+	if !pipeline.JobRunsAfter("plan_eks", "plan_vpc") {
+		t.Fatal("JobRunsAfter(plan_eks, plan_vpc) = false, want transitive true")
+	}
+	if pipeline.JobRunsAfter("plan_vpc", "plan_eks") {
+		t.Fatal("JobRunsAfter(plan_vpc, plan_eks) = true, want false")
+	}
+}
+
+func mustJob(tb testing.TB, name string, variables map[string]string) Job {
+	tb.Helper()
+	job, err := NewJob(JobOptions{
+		Name:      name,
+		Pool:      &Pool{VMImage: "ubuntu-latest"},
+		Variables: variables,
+		Steps:     []Step{NewStep(StepOptions{Checkout: CheckoutSelf})},
+	})
+	if err != nil {
+		tb.Fatalf("NewJob(%q) error = %v", name, err)
+	}
+	return job
+}
+
+func mustStage(tb testing.TB, opts StageOptions, jobs ...Job) Stage {
+	tb.Helper()
+	builder := NewStageBuilder(opts)
+	for _, job := range jobs {
+		if err := builder.AddJob(job); err != nil {
+			tb.Fatalf("AddJob(%q) error = %v", job.Name(), err)
+		}
+	}
+	stage, err := builder.Build()
+	if err != nil {
+		tb.Fatalf("Build() stage %q error = %v", opts.Name, err)
+	}
+	return stage
+}
+
+func mustAddStage(tb testing.TB, builder *PipelineBuilder, stage Stage) {
+	tb.Helper()
+	if err := builder.AddStage(stage); err != nil {
+		tb.Fatalf("AddStage(%q) error = %v", stage.Name(), err)
+	}
+}
+
+func mustBuildPipeline(tb testing.TB, builder *PipelineBuilder) *Pipeline {
+	tb.Helper()
+	pipeline, err := builder.Build()
+	if err != nil {
+		tb.Fatalf("Build() error = %v", err)
+	}
+	return pipeline
+}
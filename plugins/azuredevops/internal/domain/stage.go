@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type StageOptions struct {
+	Name        string
+	DisplayName string
+	DependsOn   []string
+	Condition   string
+}
+
+// StageBuilder assembles a stage's jobs while rejecting duplicate job names.
+type StageBuilder struct {
+	opts StageOptions
+	jobs []Job
+	seen map[string]struct{}
+}
+
+type Stage struct {
+	name        string
+	displayName string
+	dependsOn   []string
+	condition   string
+	jobs        []Job
+}
+
+func NewStageBuilder(opts StageOptions) *StageBuilder {
+	return &StageBuilder{
+		opts: StageOptions{
+			Name:        opts.Name,
+			DisplayName: opts.DisplayName,
+			DependsOn:   append([]string(nil), opts.DependsOn...),
+			Condition:   opts.Condition,
+		},
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (b *StageBuilder) AddJob(job Job) error {
+	if b == nil {
+		return errors.New("azure devops stage builder is nil")
+	}
+	if _, exists := b.seen[job.name]; exists {
+		return fmt.Errorf("duplicate azure devops job %q in stage %q", job.name, b.opts.Name)
+	}
+	b.seen[job.name] = struct{}{}
+	b.jobs = append(b.jobs, job.clone())
+	return nil
+}
+
+func (b *StageBuilder) Build() (Stage, error) {
+	if b == nil {
+		return Stage{}, errors.New("azure devops stage builder is nil")
+	}
+	if err := validateIdentifier("stage", b.opts.Name); err != nil {
+		return Stage{}, err
+	}
+	if len(b.jobs) == 0 {
+		return Stage{}, fmt.Errorf("azure devops stage %q has no jobs", b.opts.Name)
+	}
+	return Stage{
+		name:        b.opts.Name,
+		displayName: b.opts.DisplayName,
+		dependsOn:   append([]string(nil), b.opts.DependsOn...),
+		condition:   b.opts.Condition,
+		jobs:        cloneJobs(b.jobs),
+	}, nil
+}
+
+func (s Stage) Name() string { return s.name }
+
+func (s Stage) DisplayName() string { return s.displayName }
+
+func (s Stage) DependsOn() []string { return append([]string(nil), s.dependsOn...) }
+
+func (s Stage) HasDependency(name string) bool {
+	return slices.Contains(s.dependsOn, name)
+}
+
+func (s Stage) Condition() string { return s.condition }
+
+func (s Stage) Jobs() []Job { return cloneJobs(s.jobs) }
+
+func (s Stage) clone() Stage {
+	return Stage{
+		name:        s.name,
+		displayName: s.displayName,
+		dependsOn:   append([]string(nil), s.dependsOn...),
+		condition:   s.condition,
+		jobs:        cloneJobs(s.jobs),
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+// CheckoutSelf is the checkout target for the repository that triggered the run.
+const CheckoutSelf = "self"
+
+type StepOptions struct {
+	DisplayName     string
+	Checkout        string
+	Script          string
+	Task            string
+	Inputs          map[string]string
+	Env             map[string]string
+	Condition       string
+	ContinueOnError bool
+}
+
+type Step struct {
+	displayName     string
+	checkout        string
+	script          string
+	task            string
+	inputs          map[string]string
+	env             map[string]string
+	condition       string
+	continueOnError bool
+}
+
+func NewStep(opts StepOptions) Step {
+	return Step{
+		displayName:     opts.DisplayName,
+		checkout:        opts.Checkout,
+		script:          opts.Script,
+		task:            opts.Task,
+		inputs:          cloneStringMap(opts.Inputs),
+		env:             cloneStringMap(opts.Env),
+		condition:       opts.Condition,
+		continueOnError: opts.ContinueOnError,
+	}
+}
+
+func (s Step) DisplayName() string { return s.displayName }
+
+func (s Step) Checkout() string { return s.checkout }
+
+func (s Step) Script() string { return s.script }
+
+func (s Step) Task() string { return s.task }
+
+func (s Step) Inputs() map[string]string { return cloneStringMap(s.inputs) }
+
+func (s Step) Env() map[string]string { return cloneStringMap(s.env) }
+
+func (s Step) Condition() string { return s.condition }
+
+func (s Step) ContinueOnError() bool { return s.continueOnError }
+
+func (s Step) clone() Step {
+	return NewStep(StepOptions{
+		DisplayName:     s.displayName,
+		Checkout:        s.checkout,
+		Script:          s.script,
+		Task:            s.task,
+		Inputs:          s.inputs,
+		Env:             s.env,
+		Condition:       s.condition,
+		ContinueOnError: s.continueOnError,
+	})
+}
@@ -0,0 +1,14 @@
+package domain
+
+// Pool selects the agents that run a job. Exactly one of Name or VMImage is
+// rendered; Demands only apply to self-hosted pools.
+type Pool struct {
+	Name    string   `yaml:"name,omitempty"`
+	VMImage string   `yaml:"vmImage,omitempty"`
+	Demands []string `yaml:"demands,omitempty"`
+}
+
+// Container is the job container resource.
+type Container struct {
+	Image string `yaml:"image"`
+}
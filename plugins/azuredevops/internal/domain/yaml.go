@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+
+	"go.yaml.in/yaml/v4"
+)
+
+func (p *Pipeline) ToYAML() ([]byte, error) {
+	type pipelineYAML struct {
+		Trigger   []string          `yaml:"trigger,omitempty"`
+		PR        []string          `yaml:"pr,omitempty"`
+		Variables map[string]string `yaml:"variables,omitempty"`
+		Stages    []Stage           `yaml:"stages"`
+	}
+	payload := pipelineYAML{Stages: []Stage{}}
+	if p != nil {
+		payload.Trigger = append([]string(nil), p.trigger...)
+		payload.PR = append([]string(nil), p.pr...)
+		payload.Variables = cloneStringMap(p.variables)
+		if len(p.stages) > 0 {
+			payload.Stages = cloneStages(p.stages)
+		}
+	}
+	data, err := yaml.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal azure pipeline: %w", err)
+	}
+
+	header := []byte("# Generated by terraci — do not edit\n")
+	return append(header, data...), nil
+}
+
+func (s Stage) MarshalYAML() (any, error) {
+	return struct {
+		Stage       string   `yaml:"stage"`
+		DisplayName string   `yaml:"displayName,omitempty"`
+		DependsOn   []string `yaml:"dependsOn"`
+		Condition   string   `yaml:"condition,omitempty"`
+		Jobs        []Job    `yaml:"jobs"`
+	}{
+		Stage:       s.name,
+		DisplayName: s.displayName,
+		DependsOn:   append([]string{}, s.dependsOn...),
+		Condition:   s.condition,
+		Jobs:        cloneJobs(s.jobs),
+	}, nil
+}
+
+func (j Job) MarshalYAML() (any, error) {
+	var container string
+	if j.container != nil {
+		container = j.container.Image
+	}
+	var pool any
+	switch {
+	case j.server:
+		pool = ServerPool
+	case j.pool != nil:
+		pool = clonePool(j.pool)
+	}
+	return struct {
+		Job              string            `yaml:"job"`
+		DisplayName      string            `yaml:"displayName,omitempty"`
+		DependsOn        []string          `yaml:"dependsOn,omitempty"`
+		Pool             any               `yaml:"pool,omitempty"`
+		Container        string            `yaml:"container,omitempty"`
+		Condition        string            `yaml:"condition,omitempty"`
+		ContinueOnError  bool              `yaml:"continueOnError,omitempty"`
+		TimeoutInMinutes int               `yaml:"timeoutInMinutes,omitempty"`
+		Variables        map[string]string `yaml:"variables,omitempty"`
+		Steps            []Step            `yaml:"steps"`
+	}{
+		Job:              j.name,
+		DisplayName:      j.displayName,
+		DependsOn:        append([]string(nil), j.dependsOn...),
+		Pool:             pool,
+		Container:        container,
+		Condition:        j.condition,
+		ContinueOnError:  j.continueOnError,
+		TimeoutInMinutes: j.timeoutInMinutes,
+		Variables:        cloneStringMap(j.variables),
+		Steps:            cloneSteps(j.steps),
+	}, nil
+}
+
+func (s Step) MarshalYAML() (any, error) {
+	return struct {
+		Checkout        string            `yaml:"checkout,omitempty"`
+		Script          string            `yaml:"script,omitempty"`
+		Task            string            `yaml:"task,omitempty"`
+		DisplayName     string            `yaml:"displayName,omitempty"`
+		Inputs          map[string]string `yaml:"inputs,omitempty"`
+		Env             map[string]string `yaml:"env,omitempty"`
+		Condition       string            `yaml:"condition,omitempty"`
+		ContinueOnError bool              `yaml:"continueOnError,omitempty"`
+	}{
+		Checkout:        s.checkout,
+		Script:          s.script,
+		Task:            s.task,
+		DisplayName:     s.displayName,
+		Inputs:          cloneStringMap(s.inputs),
+		Env:             cloneStringMap(s.env),
+		Condition:       s.condition,
+		ContinueOnError: s.continueOnError,
+	}, nil
+}
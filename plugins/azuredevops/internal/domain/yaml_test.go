@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineToYAMLUsesGeneratedHeader(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{Trigger: []string{"main"}})
+	mustAddStage(t, builder, mustStage(t, StageOptions{Name: "dag_level_0"}, mustJob(t, "plan_vpc", nil)))
+	out, err := mustBuildPipeline(t, builder).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.HasPrefix(string(out), "# Generated by terraci") {
+		t.Fatalf("ToYAML() missing generated header:\n%s", string(out))
+	}
+	if !strings.Contains(string(out), "dependsOn: []") {
+		t.Fatalf("ToYAML() must render explicit empty dependsOn for root stages:\n%s", string(out))
+	}
+}
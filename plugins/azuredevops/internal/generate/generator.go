@@ -0,0 +1,131 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/domain"
+)
+
+// Generator transforms a pipeline IR into a multi-stage Azure Pipelines
+// definition. The IR is bound at construction time.
+type Generator struct {
+	settings settings
+	ir       *pipeline.IR
+}
+
+// NewGenerator creates a new Azure DevOps pipeline generator bound to the
+// supplied IR.
+func NewGenerator(cfg *configpkg.Config, ir *pipeline.IR) *Generator {
+	return &Generator{
+		settings: newSettings(cfg),
+		ir:       ir,
+	}
+}
+
+func (g *Generator) Generate() (pipeline.GeneratedPipeline, error) {
+	if g.ir == nil {
+		return domainpkg.EmptyPipeline(), nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.transform(g.ir)
+}
+
+func (g *Generator) DryRun() (*pipeline.DryRunResult, error) {
+	if g.ir == nil {
+		return &pipeline.DryRunResult{}, nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.ir.DryRun(g.ir.ModuleCount()), nil
+}
+
+// transform renders every IR barrier group as one stage. Jobs inside a group
+// are independent by construction; cross-group job edges become stage
+// dependsOn edges because Azure Pipelines only orders jobs across stages.
+func (g *Generator) transform(ir *pipeline.IR) (*domainpkg.Pipeline, error) {
+	groups, err := pipeline.Schedule(ir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := domainpkg.NewPipelineBuilder(domainpkg.PipelineOptions{
+		Trigger:   g.settings.triggerBranches(),
+		PR:        g.settings.prBranches(),
+		Variables: g.settings.variables(),
+	})
+	builder := newJobBuilder(g.settings)
+	stageOfJob := make(map[string]string, len(ir.Jobs()))
+
+	for _, group := range groups {
+		name := stageIdentifier(group.Name())
+		jobs := group.Jobs()
+		stage := domainpkg.NewStageBuilder(domainpkg.StageOptions{
+			Name:        name,
+			DisplayName: group.Name(),
+			DependsOn:   stageDependencies(jobs, stageOfJob),
+			Condition:   stageCondition(jobs),
+		})
+		for i := range jobs {
+			rendered, err := builder.renderJobs(jobs[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, job := range rendered {
+				if err := stage.AddJob(job); err != nil {
+					return nil, err
+				}
+			}
+		}
+		built, err := stage.Build()
+		if err != nil {
+			return nil, err
+		}
+		if err := out.AddStage(built); err != nil {
+			return nil, err
+		}
+		for i := range jobs {
+			stageOfJob[jobs[i].Name()] = name
+		}
+	}
+
+	return out.Build()
+}
+
+// stageDependencies returns the distinct stages that own dependencies of the
+// supplied jobs, in first-seen order.
+func stageDependencies(jobs []pipeline.Job, stageOfJob map[string]string) []string {
+	seen := make(map[string]struct{})
+	deps := make([]string, 0)
+	for i := range jobs {
+		for _, dep := range jobs[i].Dependencies() {
+			stage, ok := stageOfJob[dep.Job]
+			if !ok {
+				continue
+			}
+			if _, exists := seen[stage]; exists {
+				continue
+			}
+			seen[stage] = struct{}{}
+			deps = append(deps, stage)
+		}
+	}
+	return deps
+}
+
+// stageCondition lets a stage of always-run jobs start after failed stages.
+// The schedule never mixes always-run jobs with other jobs in one group.
+func stageCondition(jobs []pipeline.Job) string {
+	if len(jobs) > 0 && jobs[0].AlwaysRun() {
+		return alwaysCondition
+	}
+	return ""
+}
+
+func stageIdentifier(groupName string) string {
+	return strings.ReplaceAll(groupName, "-", "_")
+}
@@ -0,0 +1,212 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+)
+
+func testContribution(tb testing.TB, opts ...pipeline.ContributedJobOptions) *pipeline.Contribution {
+	tb.Helper()
+	jobs := make([]pipeline.ContributedJob, 0, len(opts))
+	for _, opt := range opts {
+		job, err := pipeline.NewContributedJob(opt)
+		if err != nil {
+			tb.Fatalf("NewContributedJob() error = %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	contribution, err := pipeline.NewContribution(jobs...)
+	if err != nil {
+		tb.Fatalf("NewContribution() error = %v", err)
+	}
+	return contribution
+}
+
+func testContributionSet(tb testing.TB, contributions ...*pipeline.Contribution) pipeline.ContributionSet {
+	tb.Helper()
+	set, err := pipeline.NewContributionSet(contributions...)
+	if err != nil {
+		tb.Fatalf("NewContributionSet() error = %v", err)
+	}
+	return set
+}
+
+func costContribution(tb testing.TB) pipeline.ContributionSet {
+	tb.Helper()
+	return testContributionSet(tb, testContribution(tb, pipeline.ContributedJobOptions{
+		Name:     "cost-estimation",
+		Commands: []string{"terraci cost"},
+		Consumes: []pipeline.ResourceRequest{
+			pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+		},
+		Produces: []pipeline.ResourceSpec{
+			pipeline.PluginResource(pipeline.ResourceKindPluginResult, "cost", ".terraci/cost-results.json"),
+			pipeline.PluginResource(pipeline.ResourceKindPluginReport, "cost", ".terraci/cost-report.json"),
+		},
+		AllowFailure: true,
+	}))
+}
+
+func TestGenerate_WithSummaryContribution(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "terraci-summary",
+			Commands: []string{"terraci summary"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+		}))).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		job("terraci-summary").
+		displayName("terraci-summary").
+		runsAfter("plan-platform-stage-eu-central-1-vpc").
+		runsAfter("plan-platform-stage-eu-central-1-eks").
+		stepScriptContains("terraci summary")
+}
+
+func TestGenerate_PlanAndApplyJobOverwrites(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.JobDefaults = &configpkg.JobDefaults{
+				Container:   &configpkg.Image{Name: "default:latest"},
+				Variables:   map[string]string{"DEFAULT": "true", "SHARED": "default"},
+				StepsBefore: []configpkg.ConfigStep{{DisplayName: "Default setup", Script: "echo default setup"}},
+				StepsAfter:  []configpkg.ConfigStep{{DisplayName: "Default cleanup", Script: "echo default cleanup"}},
+			}
+			cfg.Overwrites = []configpkg.JobOverwrite{
+				{
+					Type:        configpkg.OverwriteTypePlan,
+					Pool:        &configpkg.Pool{Name: "plan-agents"},
+					Container:   &configpkg.Image{Name: "plan:latest"},
+					Variables:   map[string]string{"PLAN": "true", "SHARED": "plan"},
+					StepsBefore: []configpkg.ConfigStep{{DisplayName: "Plan setup", Script: "echo plan setup"}},
+				},
+				{
+					Type:      configpkg.OverwriteTypeApply,
+					Variables: map[string]string{"APPLY": "true"},
+				},
+			}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		poolName("plan-agents").
+		containerImage("plan:latest").
+		variable("DEFAULT", "true").
+		variable("SHARED", "plan").
+		variable("PLAN", "true").
+		variable("TF_MODULE", "vpc").
+		stepNamed("Default setup").
+		stepNamed("Plan setup").
+		stepNamed("Default cleanup")
+
+	assertPipeline(t, out).
+		job("apply-platform-stage-eu-central-1-vpc").
+		vmImage("ubuntu-latest").
+		containerImage("default:latest").
+		variable("SHARED", "default").
+		variable("APPLY", "true").
+		variable("TF_MODULE", "vpc").
+		stepNamed("Default setup")
+}
+
+func TestGenerate_ContributedJobOverwriteByName(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:      "cost-estimation",
+				Container: &configpkg.Image{Name: "cost-specific:1.0"},
+				Pool:      &configpkg.Pool{Name: "cost-agents"},
+			}}
+		}).
+		withContributions(costContribution(t)).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		job("cost-estimation").
+		containerImage("cost-specific:1.0").
+		poolName("cost-agents")
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		vmImage("ubuntu-latest")
+}
+
+func TestGenerate_ArtifactRestoreContract(t *testing.T) {
+	module := createTestModule("vpc")
+	planName := "plan-platform-stage-eu-central-1-vpc"
+	planArtifact := pipeline.PlanArtifactName(planName)
+	resultArtifact := pipeline.ResultArtifact("cost-estimation", ".terraci/cost-results.json", ".terraci/cost-report.json")
+
+	out := newGeneratorScenario(t).
+		withContributions(costContribution(t)).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		job(planName).
+		stepNamed("Stage plan artifacts").
+		stepScriptContains(".terraci/artifacts/"+planArtifact).
+		stepScriptContains("plan.json").
+		stepInput("Publish plan artifacts", "artifact", planArtifact).
+		stepInput("Publish plan artifacts", "targetPath", ".terraci/artifacts/"+planArtifact).
+		stepCondition("Publish plan artifacts", "succeededOrFailed()")
+
+	assertPipeline(t, out).
+		job("apply-platform-stage-eu-central-1-vpc").
+		stepInput("Download "+planArtifact, "artifactName", planArtifact).
+		stepInput("Download "+planArtifact, "buildType", "current").
+		stepInput("Download "+planArtifact, "targetPath", workspaceDir).
+		noStepTask(publishArtifactTask)
+
+	assertPipeline(t, out).
+		job("cost-estimation").
+		stepInput("Download "+planArtifact, "artifactName", planArtifact).
+		stepInput("Publish cost-estimation results", "artifact", resultArtifact.Name).
+		stepCondition("Publish cost-estimation results", "succeededOrFailed()")
+}
+
+func TestGenerate_AlwaysRunContributionRunsAfterFailures(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "drift-collector",
+			Commands: []string{"terraci drift"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			AlwaysRun: true,
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		withPlanOnly().
+		generate()
+
+	assertPipeline(t, out).
+		job("drift-collector").
+		runsAfter("plan-platform-stage-eu-central-1-vpc").
+		condition("always()").
+		stageCondition("always()")
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		condition("").
+		stageCondition("")
+}
@@ -0,0 +1,325 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/domain"
+)
+
+func TestGenerate_SingleModule(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		jobCount(2).
+		stageCount(2).
+		hasJob("plan-platform-stage-eu-central-1-vpc").
+		hasJob("apply-platform-stage-eu-central-1-vpc")
+
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		displayName("plan-platform-stage-eu-central-1-vpc").
+		vmImage("ubuntu-latest").
+		stepScriptContains("terraform init").
+		stepScriptContains("terraform plan").
+		stepTask(publishArtifactTask)
+}
+
+func TestGenerate_TriggerBranches(t *testing.T) {
+	module := createTestModule("vpc")
+	tests := []struct {
+		name        string
+		configure   func(*configpkg.Config)
+		wantTrigger []string
+		wantPR      []string
+	}{
+		{
+			name:        "defaults to main",
+			configure:   func(*configpkg.Config) {},
+			wantTrigger: []string{"main"},
+			wantPR:      []string{"main"},
+		},
+		{
+			name: "configured branches",
+			configure: func(cfg *configpkg.Config) {
+				cfg.Trigger = []string{"master", "release/*"}
+				cfg.PR = []string{"master"}
+			},
+			wantTrigger: []string{"master", "release/*"},
+			wantPR:      []string{"master"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := newGeneratorScenario(t).
+				withConfig(tt.configure).
+				withModules(module).
+				withDependencies(map[string][]string{module.ID(): {}}).
+				generate()
+
+			if got := out.Trigger(); !slices.Equal(got, tt.wantTrigger) {
+				t.Fatalf("Trigger() = %v, want %v", got, tt.wantTrigger)
+			}
+			if got := out.PR(); !slices.Equal(got, tt.wantPR) {
+				t.Fatalf("PR() = %v, want %v", got, tt.wantPR)
+			}
+		})
+	}
+}
+
+func TestGenerate_RejectsInvalidIR(t *testing.T) {
+	t.Parallel()
+
+	generated, err := NewGenerator(nil, nil).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	out, ok := generated.(*domainpkg.Pipeline)
+	if !ok || out.JobCount() != 0 {
+		t.Fatalf("Generate() = %#v, want empty pipeline", generated)
+	}
+}
+
+func TestGenerate_WithDependencies(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		job("apply-platform-stage-eu-central-1-eks").
+		runsAfter("apply-platform-stage-eu-central-1-vpc").
+		runsAfter("plan-platform-stage-eu-central-1-eks")
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		notAfter("apply-platform-stage-eu-central-1-eks")
+}
+
+func TestGenerate_RootStagesHaveNoDependencies(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	stages := out.StageNames()
+	first, ok := out.Stage(stages[0])
+	if !ok {
+		t.Fatalf("stage %q not found", stages[0])
+	}
+	if deps := first.DependsOn(); len(deps) != 0 {
+		t.Fatalf("first stage dependsOn = %v, want none", deps)
+	}
+	second, ok := out.Stage(stages[1])
+	if !ok {
+		t.Fatalf("stage %q not found", stages[1])
+	}
+	if !second.HasDependency(stages[0]) {
+		t.Fatalf("second stage dependsOn = %v, want %q", second.DependsOn(), stages[0])
+	}
+}
+
+func TestGenerate_PlanOnly(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withPlanOnly().
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		jobCount(1).
+		hasJob("plan-platform-stage-eu-central-1-vpc").
+		noJob("apply-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_PlanOnlyWithDeps(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withPlanOnly().
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		jobCount(2).
+		job("plan-platform-stage-eu-central-1-eks").
+		runsAfter("plan-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_CustomBinary(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withTerraformConfig(func(cfg *pipeline.TerraformJobConfigOptions) { cfg.Binary = "tofu" }).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		stepScriptContains("tofu plan")
+}
+
+func TestGenerate_WithContainer(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Container = &configpkg.Image{Name: "hashicorp/terraform:1.6"}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		containerImage("hashicorp/terraform:1.6")
+}
+
+func TestGenerate_SelfHostedPoolFromOverwrite(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:      configpkg.OverwriteTypeApply,
+				Pool:      &configpkg.Pool{Name: "prod-agents"},
+				Condition: "eq(variables['Build.SourceBranch'], 'refs/heads/main')",
+			}}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		job("apply-platform-stage-eu-central-1-vpc").
+		poolName("prod-agents").
+		condition("eq(variables['Build.SourceBranch'], 'refs/heads/main')")
+	assertPipeline(t, out).
+		job("plan-platform-stage-eu-central-1-vpc").
+		vmImage("ubuntu-latest").
+		condition("")
+}
+
+func TestGenerate_StepsBefore(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.JobDefaults = &configpkg.JobDefaults{
+				StepsBefore: []configpkg.ConfigStep{
+					{DisplayName: "Setup Azure credentials", Task: "AzureCLI@2", Inputs: map[string]string{"azureSubscription": "prod"}},
+				},
+			}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	job, ok := out.Job(jobIdentifier("plan-platform-stage-eu-central-1-vpc"))
+	if !ok {
+		t.Fatal("plan job not found")
+	}
+
+	setupIdx := -1
+	planIdx := -1
+	for i, step := range job.Steps() {
+		if step.DisplayName() == "Setup Azure credentials" {
+			setupIdx = i
+		}
+		if strings.HasPrefix(step.DisplayName(), "Plan ") {
+			planIdx = i
+		}
+	}
+	if setupIdx == -1 {
+		t.Fatal("steps_before step not found in plan job")
+	}
+	if planIdx == -1 {
+		t.Fatal("plan step not found in plan job")
+	}
+	if setupIdx >= planIdx {
+		t.Errorf("steps_before should appear before plan step: setup=%d, plan=%d", setupIdx, planIdx)
+	}
+}
+
+func TestGenerate_ApprovalRuleAddsManualValidationJob(t *testing.T) {
+	ir := pipelinetest.MustGatedSingleModuleIR(t, citest.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	result, err := NewGenerator(&configpkg.Config{ApprovalNotifyUsers: []string{"platform@example.com"}}, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	out, ok := result.(*domainpkg.Pipeline)
+	if !ok {
+		t.Fatalf("Generate() = %T, want *domain.Pipeline", result)
+	}
+
+	assertPipeline(t, out).
+		jobCount(3).
+		job("approve-apply-platform-prod-eu-central-1-vpc").
+		stepTask(manualValidationTask).
+		stepInput("Approve apply-platform-prod-eu-central-1-vpc", "notifyUsers", "platform@example.com")
+
+	approval, _ := out.Job(approvalJobIdentifier("apply-platform-prod-eu-central-1-vpc"))
+	if !approval.Server() || approval.Pool() != nil {
+		t.Fatalf("approval job server = %v, pool = %+v, want agentless job", approval.Server(), approval.Pool())
+	}
+	apply, _ := out.Job(jobIdentifier("apply-platform-prod-eu-central-1-vpc"))
+	if deps := apply.DependsOn(); len(deps) != 1 || deps[0] != approval.Name() {
+		t.Fatalf("apply dependsOn = %v, want [%s]", deps, approval.Name())
+	}
+	plan, _ := out.Job(jobIdentifier("plan-platform-prod-eu-central-1-vpc"))
+	if len(plan.DependsOn()) != 0 {
+		t.Fatalf("plan dependsOn = %v, want none", plan.DependsOn())
+	}
+}
+
+func TestJobIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"plan-platform-stage-eu-central-1-vpc": "plan_platform_stage_eu_central_1_vpc",
+		"terraci-summary":                      "terraci_summary",
+		"1st-job":                              "_1st_job",
+		"already_valid":                        "already_valid",
+	}
+	for in, want := range tests {
+		if got := jobIdentifier(in); got != want {
+			t.Errorf("jobIdentifier(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	result := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		dryRun()
+
+	citest.AssertDryRun(t, result, citest.DryRunExpectation{
+		TotalModules:    2,
+		AffectedModules: 2,
+		Jobs:            4,
+		Stages:          4,
+		JobGroups:       4,
+	})
+}
@@ -0,0 +1,275 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/cishell"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/domain"
+)
+
+const (
+	publishArtifactTask  = "PublishPipelineArtifact@1"
+	downloadArtifactTask = "DownloadPipelineArtifact@2"
+	manualValidationTask = "ManualValidation@0"
+	workspaceDir         = "$(System.DefaultWorkingDirectory)"
+	alwaysCondition      = "always()"
+	// approvalTimeoutMinutes keeps approval jobs open for a day before the
+	// validation is rejected.
+	approvalTimeoutMinutes = 1440
+)
+
+type jobBuilder struct {
+	settings settings
+}
+
+func newJobBuilder(settings settings) jobBuilder {
+	return jobBuilder{settings: settings}
+}
+
+// renderJobs converts one IR job into Azure Pipelines jobs. Approval-gated
+// jobs are preceded by an agentless ManualValidation job in the same stage,
+// and the gated job depends on it.
+func (b jobBuilder) renderJobs(irJob pipeline.Job) ([]domainpkg.Job, error) {
+	var jobs []domainpkg.Job
+	var dependsOn []string
+	if irJob.RequiresApproval() {
+		approval, err := b.renderApprovalJob(irJob)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, approval)
+		dependsOn = append(dependsOn, approval.Name())
+	}
+	job, err := b.renderJob(irJob, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	return append(jobs, job), nil
+}
+
+func (b jobBuilder) renderApprovalJob(irJob pipeline.Job) (domainpkg.Job, error) {
+	return domainpkg.NewJob(domainpkg.JobOptions{
+		Name:             approvalJobIdentifier(irJob.Name()),
+		DisplayName:      "Approve " + irJob.Name(),
+		Server:           true,
+		TimeoutInMinutes: approvalTimeoutMinutes,
+		Steps: []domainpkg.Step{domainpkg.NewStep(domainpkg.StepOptions{
+			DisplayName: "Approve " + irJob.Name(),
+			Task:        manualValidationTask,
+			Inputs: map[string]string{
+				"notifyUsers":  b.settings.approvalNotifyUsers(),
+				"instructions": "Approve " + irJob.Name() + " to continue.",
+				"onTimeout":    "reject",
+			},
+		})},
+	})
+}
+
+func (b jobBuilder) renderJob(irJob pipeline.Job, dependsOn []string) (domainpkg.Job, error) {
+	profile, err := b.settings.jobProfile(jobOverwriteType(irJob))
+	if err != nil {
+		var zero domainpkg.Job
+		return zero, err
+	}
+
+	steps := []domainpkg.Step{checkoutStep()}
+	for _, input := range irJob.InputArtifacts() {
+		if !input.Configured() {
+			continue
+		}
+		steps = append(steps, downloadArtifactStep("Download "+input.Artifact.Name, input.Artifact.Name, input.Optional))
+	}
+	steps = append(steps, profile.stepsBefore...)
+	operation := irJob.Operation()
+	steps = append(steps, scriptStep(runStepName(irJob), strings.Join(cishell.RenderOperation(operation), "\n")))
+	steps = append(steps, profile.stepsAfter...)
+	outputArtifact := irJob.OutputArtifact()
+	if outputArtifact.Configured() {
+		var stageName, publishName string
+		switch operation.Type() {
+		case pipeline.OperationTypeTerraformPlan:
+			stageName = "Stage plan artifacts"
+			publishName = "Publish plan artifacts"
+		case pipeline.OperationTypeCommands:
+			stageName = fmt.Sprintf("Stage %s results", irJob.Name())
+			publishName = fmt.Sprintf("Publish %s results", irJob.Name())
+		case pipeline.OperationTypeTerraformApply:
+			stageName = fmt.Sprintf("Stage %s artifacts", irJob.Name())
+			publishName = fmt.Sprintf("Publish %s artifacts", irJob.Name())
+		}
+		steps = append(steps,
+			stageArtifactStep(stageName, outputArtifact, artifactRequired(irJob)),
+			publishArtifactStep(publishName, outputArtifact),
+		)
+	}
+
+	return domainpkg.NewJob(domainpkg.JobOptions{
+		Name:            jobIdentifier(irJob.Name()),
+		DisplayName:     irJob.Name(),
+		DependsOn:       dependsOn,
+		Pool:            profile.pool,
+		Container:       profile.container,
+		Variables:       mergeJobVariables(irJob.Env(), profile.variables),
+		Condition:       jobCondition(irJob, profile.condition),
+		ContinueOnError: irJob.AllowFailure(),
+		Steps:           steps,
+	})
+}
+
+// jobCondition returns the job condition. Always-run jobs start even when a
+// dependency failed; a configured condition still applies on top.
+func jobCondition(irJob pipeline.Job, configured string) string {
+	if !irJob.AlwaysRun() {
+		return configured
+	}
+	if configured == "" {
+		return alwaysCondition
+	}
+	return "and(" + alwaysCondition + ", " + configured + ")"
+}
+
+// jobIdentifier converts an IR job name into an Azure Pipelines identifier,
+// which may only contain letters, digits, and underscores.
+func jobIdentifier(name string) string {
+	var sb strings.Builder
+	sb.Grow(len(name))
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			sb.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+func approvalJobIdentifier(name string) string {
+	return "approve_" + jobIdentifier(name)
+}
+
+func jobOverwriteType(irJob pipeline.Job) configpkg.JobOverwriteType {
+	switch irJob.Operation().Type() {
+	case pipeline.OperationTypeTerraformPlan:
+		return configpkg.OverwriteTypePlan
+	case pipeline.OperationTypeTerraformApply:
+		return configpkg.OverwriteTypeApply
+	case pipeline.OperationTypeCommands:
+		return configpkg.JobOverwriteType(irJob.Name())
+	default:
+		return ""
+	}
+}
+
+func runStepName(irJob pipeline.Job) string {
+	module := irJob.Module()
+	if module == nil {
+		return "Run " + irJob.Name()
+	}
+	switch irJob.Operation().Type() {
+	case pipeline.OperationTypeTerraformPlan:
+		return "Plan " + module.ID()
+	case pipeline.OperationTypeTerraformApply:
+		return "Apply " + module.ID()
+	case pipeline.OperationTypeCommands:
+		return "Run " + irJob.Name()
+	default:
+		return "Run"
+	}
+}
+
+func artifactRequired(irJob pipeline.Job) bool {
+	return irJob.Operation().Type() == pipeline.OperationTypeTerraformPlan
+}
+
+func checkoutStep() domainpkg.Step {
+	return domainpkg.NewStep(domainpkg.StepOptions{Checkout: domainpkg.CheckoutSelf})
+}
+
+func scriptStep(name, script string) domainpkg.Step {
+	return domainpkg.NewStep(domainpkg.StepOptions{DisplayName: name, Script: script})
+}
+
+func downloadArtifactStep(name, artifact string, optional bool) domainpkg.Step {
+	return domainpkg.NewStep(domainpkg.StepOptions{
+		DisplayName: name,
+		Task:        downloadArtifactTask,
+		Inputs: map[string]string{
+			"buildType":    "current",
+			"artifactName": artifact,
+			"targetPath":   workspaceDir,
+		},
+		Condition:       optionalCondition(optional),
+		ContinueOnError: optional,
+	})
+}
+
+func publishArtifactStep(name string, artifact pipeline.Artifact) domainpkg.Step {
+	return domainpkg.NewStep(domainpkg.StepOptions{
+		DisplayName: name,
+		Task:        publishArtifactTask,
+		Inputs: map[string]string{
+			"targetPath":      artifactStageDir(artifact),
+			"artifact":        artifact.Name,
+			"publishLocation": "pipeline",
+		},
+		Condition: "succeededOrFailed()",
+	})
+}
+
+func stageArtifactStep(name string, artifact pipeline.Artifact, required bool) domainpkg.Step {
+	stageDir := artifactStageDir(artifact)
+	lines := []string{
+		"set -eu",
+		"stage_dir=" + shellQuote(stageDir),
+		`rm -rf "$stage_dir"`,
+		`mkdir -p "$stage_dir"`,
+	}
+	for _, path := range artifact.Paths {
+		lines = append(lines,
+			"artifact_path="+shellQuote(path),
+			`if [ -e "$artifact_path" ]; then`,
+			`  artifact_dest="$stage_dir/$artifact_path"`,
+			`  mkdir -p "$(dirname "$artifact_dest")"`,
+			`  if [ -d "$artifact_path" ]; then cp -R "$artifact_path" "$artifact_dest"; else cp "$artifact_path" "$artifact_dest"; fi`,
+			`fi`,
+		)
+	}
+	if required {
+		lines = append(lines,
+			`if ! find "$stage_dir" -type f | grep -q .; then`,
+			"  echo "+shellQuote("No artifact files staged for "+artifact.Name),
+			"  exit 1",
+			`fi`,
+		)
+	}
+
+	return domainpkg.NewStep(domainpkg.StepOptions{
+		DisplayName: name,
+		Script:      strings.Join(lines, "\n"),
+		Condition:   "succeededOrFailed()",
+	})
+}
+
+func optionalCondition(optional bool) string {
+	if optional {
+		return "succeededOrFailed()"
+	}
+	return ""
+}
+
+func artifactStageDir(artifact pipeline.Artifact) string {
+	return ".terraci/artifacts/" + artifact.Name
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "'\\''") + "'"
+}
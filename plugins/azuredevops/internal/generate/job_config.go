@@ -0,0 +1,126 @@
+package generate
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/config/overwrite"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/domain"
+)
+
+type jobProfile struct {
+	pool        *domainpkg.Pool
+	container   *domainpkg.Container
+	variables   map[string]string
+	condition   string
+	stepsBefore []domainpkg.Step
+	stepsAfter  []domainpkg.Step
+}
+
+func (s settings) jobProfile(jobType configpkg.JobOverwriteType) (jobProfile, error) {
+	cfg := s.configOrDefault()
+	profile := jobProfile{
+		pool:      convertPool(cfg.Pool),
+		container: convertContainer(cfg.Container),
+	}
+	if profile.pool == nil {
+		profile.pool = &domainpkg.Pool{VMImage: defaultVMImage}
+	}
+
+	if cfg.JobDefaults != nil {
+		applyJobDefaults(&profile, cfg.JobDefaults)
+	}
+
+	err := overwrite.ApplyMatching(
+		&profile,
+		jobType,
+		cfg.Overwrites,
+		overwrite.ByKey(func(ow *configpkg.JobOverwrite) configpkg.JobOverwriteType { return ow.Type }),
+		applyJobOverwrite,
+	)
+	if err != nil {
+		return jobProfile{}, err
+	}
+	return profile, nil
+}
+
+func applyJobDefaults(profile *jobProfile, defaults *configpkg.JobDefaults) {
+	if defaults.Pool != nil {
+		profile.pool = convertPool(defaults.Pool)
+	}
+	if defaults.Container != nil {
+		profile.container = convertContainer(defaults.Container)
+	}
+	mergeProfileVariables(profile, defaults.Variables)
+	if defaults.Condition != "" {
+		profile.condition = defaults.Condition
+	}
+	profile.stepsBefore = appendConfigSteps(profile.stepsBefore, defaults.StepsBefore)
+	profile.stepsAfter = appendConfigSteps(profile.stepsAfter, defaults.StepsAfter)
+}
+
+func applyJobOverwrite(profile *jobProfile, ow *configpkg.JobOverwrite) {
+	if ow.Pool != nil {
+		profile.pool = convertPool(ow.Pool)
+	}
+	if ow.Container != nil {
+		profile.container = convertContainer(ow.Container)
+	}
+	mergeProfileVariables(profile, ow.Variables)
+	if ow.Condition != "" {
+		profile.condition = ow.Condition
+	}
+	profile.stepsBefore = appendConfigSteps(profile.stepsBefore, ow.StepsBefore)
+	profile.stepsAfter = appendConfigSteps(profile.stepsAfter, ow.StepsAfter)
+}
+
+func mergeProfileVariables(profile *jobProfile, variables map[string]string) {
+	if len(variables) == 0 {
+		return
+	}
+	if profile.variables == nil {
+		profile.variables = make(map[string]string, len(variables))
+	}
+	maps.Copy(profile.variables, variables)
+}
+
+func appendConfigSteps(steps []domainpkg.Step, configs []configpkg.ConfigStep) []domainpkg.Step {
+	for _, step := range configs {
+		steps = append(steps, convertConfigStep(step))
+	}
+	return steps
+}
+
+func convertPool(pool *configpkg.Pool) *domainpkg.Pool {
+	if pool == nil {
+		return nil
+	}
+	return &domainpkg.Pool{Name: pool.Name, VMImage: pool.VMImage, Demands: pool.Demands}
+}
+
+func convertContainer(image *configpkg.Image) *domainpkg.Container {
+	if image == nil {
+		return nil
+	}
+	return &domainpkg.Container{Image: image.Name}
+}
+
+func mergeJobVariables(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(base)+len(overrides))
+	maps.Copy(result, base)
+	maps.Copy(result, overrides)
+	return result
+}
+
+func convertConfigStep(step configpkg.ConfigStep) domainpkg.Step {
+	return domainpkg.NewStep(domainpkg.StepOptions{
+		DisplayName: step.DisplayName,
+		Task:        step.Task,
+		Inputs:      step.Inputs,
+		Script:      step.Script,
+		Env:         step.Env,
+	})
+}
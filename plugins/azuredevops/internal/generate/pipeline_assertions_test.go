@@ -0,0 +1,224 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	domainpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/domain"
+)
+
+type pipelineAssert struct {
+	t        *testing.T
+	pipeline *domainpkg.Pipeline
+}
+
+func assertPipeline(t *testing.T, pipeline *domainpkg.Pipeline) *pipelineAssert {
+	t.Helper()
+	return &pipelineAssert{t: t, pipeline: pipeline}
+}
+
+func (a *pipelineAssert) jobCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.JobCount(); got != expected {
+		a.t.Fatalf("expected %d jobs, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) stageCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := len(a.pipeline.StageNames()); got != expected {
+		a.t.Fatalf("expected %d stages, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) hasJob(name string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Job(jobIdentifier(name)); !ok {
+		a.t.Fatalf("expected job %q to exist", name)
+	}
+	return a
+}
+
+func (a *pipelineAssert) noJob(name string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Job(jobIdentifier(name)); ok {
+		a.t.Fatalf("expected job %q to not exist", name)
+	}
+	return a
+}
+
+func (a *pipelineAssert) job(name string) *jobAssert {
+	a.t.Helper()
+	job, ok := a.pipeline.Job(jobIdentifier(name))
+	if !ok {
+		a.t.Fatalf("expected job %q to exist", name)
+	}
+	return &jobAssert{t: a.t, name: name, pipeline: a.pipeline, job: job}
+}
+
+type jobAssert struct {
+	t        *testing.T
+	name     string
+	pipeline *domainpkg.Pipeline
+	job      domainpkg.Job
+}
+
+// runsAfter asserts that the stage graph orders the job after dependency.
+func (a *jobAssert) runsAfter(dependency string) *jobAssert {
+	a.t.Helper()
+	if !a.pipeline.JobRunsAfter(jobIdentifier(a.name), jobIdentifier(dependency)) {
+		a.t.Fatalf("expected job %q to run after %q", a.name, dependency)
+	}
+	return a
+}
+
+func (a *jobAssert) notAfter(dependency string) *jobAssert {
+	a.t.Helper()
+	if a.pipeline.JobRunsAfter(jobIdentifier(a.name), jobIdentifier(dependency)) {
+		a.t.Fatalf("expected job %q not to run after %q", a.name, dependency)
+	}
+	return a
+}
+
+func (a *jobAssert) displayName(expected string) *jobAssert {
+	a.t.Helper()
+	if a.job.DisplayName() != expected {
+		a.t.Fatalf("expected job %q displayName=%q, got %q", a.name, expected, a.job.DisplayName())
+	}
+	return a
+}
+
+func (a *jobAssert) condition(expected string) *jobAssert {
+	a.t.Helper()
+	if a.job.Condition() != expected {
+		a.t.Fatalf("expected job %q condition=%q, got %q", a.name, expected, a.job.Condition())
+	}
+	return a
+}
+
+// stageCondition asserts the condition of the stage that owns the job.
+func (a *jobAssert) stageCondition(expected string) *jobAssert {
+	a.t.Helper()
+	stageName, ok := a.pipeline.StageOf(jobIdentifier(a.name))
+	if !ok {
+		a.t.Fatalf("expected job %q to belong to a stage", a.name)
+	}
+	stage, _ := a.pipeline.Stage(stageName)
+	if stage.Condition() != expected {
+		a.t.Fatalf("expected stage %q condition=%q, got %q", stageName, expected, stage.Condition())
+	}
+	return a
+}
+
+func (a *jobAssert) vmImage(expected string) *jobAssert {
+	a.t.Helper()
+	pool := a.job.Pool()
+	if pool == nil {
+		a.t.Fatalf("expected job %q to have pool", a.name)
+	}
+	if pool.VMImage != expected {
+		a.t.Fatalf("expected job %q vmImage=%q, got %q", a.name, expected, pool.VMImage)
+	}
+	return a
+}
+
+func (a *jobAssert) poolName(expected string) *jobAssert {
+	a.t.Helper()
+	pool := a.job.Pool()
+	if pool == nil {
+		a.t.Fatalf("expected job %q to have pool", a.name)
+	}
+	if pool.Name != expected {
+		a.t.Fatalf("expected job %q pool name=%q, got %q", a.name, expected, pool.Name)
+	}
+	return a
+}
+
+func (a *jobAssert) variable(name, expected string) *jobAssert {
+	a.t.Helper()
+	if got := a.job.Variables()[name]; got != expected {
+		a.t.Fatalf("expected job %q variable %s=%q, got %q", a.name, name, expected, got)
+	}
+	return a
+}
+
+func (a *jobAssert) containerImage(expected string) *jobAssert {
+	a.t.Helper()
+	container := a.job.Container()
+	if container == nil {
+		a.t.Fatalf("expected job %q to have container", a.name)
+	}
+	if container.Image != expected {
+		a.t.Fatalf("expected job %q container=%q, got %q", a.name, expected, container.Image)
+	}
+	return a
+}
+
+func (a *jobAssert) stepTask(task string) *jobAssert {
+	a.t.Helper()
+	for _, step := range a.job.Steps() {
+		if step.Task() == task {
+			return a
+		}
+	}
+	a.t.Fatalf("expected job %q to use task %q", a.name, task)
+	return a
+}
+
+func (a *jobAssert) noStepTask(task string) *jobAssert {
+	a.t.Helper()
+	for _, step := range a.job.Steps() {
+		if step.Task() == task {
+			a.t.Fatalf("expected job %q not to use task %q", a.name, task)
+		}
+	}
+	return a
+}
+
+func (a *jobAssert) stepScriptContains(fragment string) *jobAssert {
+	a.t.Helper()
+	for _, step := range a.job.Steps() {
+		if strings.Contains(step.Script(), fragment) {
+			return a
+		}
+	}
+	a.t.Fatalf("expected job %q script steps to contain %q", a.name, fragment)
+	return a
+}
+
+func (a *jobAssert) stepNamed(name string) *jobAssert {
+	a.t.Helper()
+	a.step(name)
+	return a
+}
+
+func (a *jobAssert) stepInput(stepName, key, expected string) *jobAssert {
+	a.t.Helper()
+	step := a.step(stepName)
+	if step.Inputs()[key] != expected {
+		a.t.Fatalf("expected job %q step %q input %s=%q, got %q", a.name, stepName, key, expected, step.Inputs()[key])
+	}
+	return a
+}
+
+func (a *jobAssert) stepCondition(stepName, expected string) *jobAssert {
+	a.t.Helper()
+	step := a.step(stepName)
+	if step.Condition() != expected {
+		a.t.Fatalf("expected job %q step %q condition=%q, got %q", a.name, stepName, expected, step.Condition())
+	}
+	return a
+}
+
+func (a *jobAssert) step(name string) domainpkg.Step {
+	a.t.Helper()
+	for _, step := range a.job.Steps() {
+		if step.DisplayName() == name {
+			return step
+		}
+	}
+	a.t.Fatalf("expected job %q to have step named %q", a.name, name)
+	return domainpkg.Step{}
+}
@@ -0,0 +1,57 @@
+package generate
+
+import (
+	"maps"
+	"strings"
+
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+)
+
+const (
+	defaultVMImage = "ubuntu-latest"
+	defaultBranch  = "main"
+)
+
+type settings struct {
+	config *configpkg.Config
+}
+
+func newSettings(cfg *configpkg.Config) settings {
+	return settings{config: cfg}
+}
+
+func (s settings) configOrDefault() *configpkg.Config {
+	if s.config == nil {
+		return &configpkg.Config{
+			Pool: &configpkg.Pool{VMImage: defaultVMImage},
+		}
+	}
+	return s.config
+}
+
+func (s settings) variables() map[string]string {
+	variables := make(map[string]string)
+	maps.Copy(variables, s.configOrDefault().Variables)
+	return variables
+}
+
+// triggerBranches returns the branches whose pushes run the pipeline.
+func (s settings) triggerBranches() []string {
+	return branchesOrDefault(s.configOrDefault().Trigger)
+}
+
+// prBranches returns the target branches whose pull requests run the pipeline.
+func (s settings) prBranches() []string {
+	return branchesOrDefault(s.configOrDefault().PR)
+}
+
+func branchesOrDefault(branches []string) []string {
+	if len(branches) == 0 {
+		return []string{defaultBranch}
+	}
+	return append([]string(nil), branches...)
+}
+
+func (s settings) approvalNotifyUsers() string {
+	return strings.Join(s.configOrDefault().ApprovalNotifyUsers, ",")
+}
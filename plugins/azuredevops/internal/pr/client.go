@@ -0,0 +1,209 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiVersion     = "7.1"
+	requestTimeout = 30 * time.Second
+	maxErrorBody   = 512
+)
+
+// Thread is a pull request comment thread returned by the Azure DevOps REST API.
+type Thread struct {
+	ID        int64     `json:"id"`
+	Comments  []Comment `json:"comments"`
+	IsDeleted bool      `json:"isDeleted"`
+}
+
+// Comment is a single comment inside a pull request thread.
+type Comment struct {
+	ID        int64  `json:"id"`
+	Content   string `json:"content"`
+	IsDeleted bool   `json:"isDeleted"`
+}
+
+// Client is a minimal Azure DevOps Git REST client for pull request threads
+// and labels. Requests are scoped to one project repository.
+type Client struct {
+	httpClient    *http.Client
+	collectionURI string
+	project       string
+	repositoryID  string
+	authorization string
+}
+
+// NewClient creates an Azure DevOps client. token is sent as a bearer token
+// (System.AccessToken); use NewPATClient for personal access tokens.
+func NewClient(collectionURI, project, repositoryID, token string) *Client {
+	client := newClient(collectionURI, project, repositoryID)
+	if token != "" {
+		client.authorization = "Bearer " + token
+	}
+	return client
+}
+
+// NewPATClient creates an Azure DevOps client authenticated with a personal
+// access token.
+func NewPATClient(collectionURI, project, repositoryID, pat string) *Client {
+	client := newClient(collectionURI, project, repositoryID)
+	if pat != "" {
+		client.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+pat))
+	}
+	return client
+}
+
+func newClient(collectionURI, project, repositoryID string) *Client {
+	return &Client{
+		httpClient:    &http.Client{Timeout: requestTimeout},
+		collectionURI: strings.TrimSuffix(collectionURI, "/"),
+		project:       project,
+		repositoryID:  repositoryID,
+	}
+}
+
+// NewClientFromEnv creates a client from Azure Pipelines predefined
+// variables. AZURE_DEVOPS_EXT_PAT wins over SYSTEM_ACCESSTOKEN so local runs
+// can use a personal access token.
+func NewClientFromEnv() *Client {
+	collectionURI := firstEnv("SYSTEM_COLLECTIONURI", "SYSTEM_TEAMFOUNDATIONCOLLECTIONURI")
+	project := firstEnv("SYSTEM_TEAMPROJECTID", "SYSTEM_TEAMPROJECT")
+	repositoryID := os.Getenv("BUILD_REPOSITORY_ID")
+
+	if pat := os.Getenv("AZURE_DEVOPS_EXT_PAT"); pat != "" {
+		return NewPATClient(collectionURI, project, repositoryID, pat)
+	}
+	return NewClient(collectionURI, project, repositoryID, os.Getenv("SYSTEM_ACCESSTOKEN"))
+}
+
+// HasToken returns true if the client can authenticate against a repository.
+func (c *Client) HasToken() bool {
+	return c.authorization != "" && c.collectionURI != "" && c.project != "" && c.repositoryID != ""
+}
+
+// ListThreads returns all comment threads of a pull request.
+func (c *Client) ListThreads(ctx context.Context, prID int) ([]Thread, error) {
+	var page struct {
+		Value []Thread `json:"value"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.pullRequestPath(prID, "threads"), nil, &page); err != nil {
+		return nil, err
+	}
+	return page.Value, nil
+}
+
+// CreateThread opens a new closed thread with a single text comment. Closed
+// threads keep the summary visible without blocking comment-resolution
+// branch policies.
+func (c *Client) CreateThread(ctx context.Context, prID int, body string) (*Thread, error) {
+	payload := map[string]any{
+		"comments": []map[string]any{{
+			"parentCommentId": 0,
+			"content":         body,
+			"commentType":     "text",
+		}},
+		"status": "closed",
+	}
+	var created Thread
+	if err := c.do(ctx, http.MethodPost, c.pullRequestPath(prID, "threads"), payload, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateComment replaces the content of an existing thread comment.
+func (c *Client) UpdateComment(ctx context.Context, prID int, threadID, commentID int64, body string) (*Comment, error) {
+	path := c.pullRequestPath(prID, "threads", strconv.FormatInt(threadID, 10), "comments", strconv.FormatInt(commentID, 10))
+	var updated Comment
+	if err := c.do(ctx, http.MethodPatch, path, map[string]string{"content": body}, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// AddLabel attaches a label to a pull request, creating it when needed.
+func (c *Client) AddLabel(ctx context.Context, prID int, label string) error {
+	return c.do(ctx, http.MethodPost, c.pullRequestPath(prID, "labels"), map[string]string{"name": label}, nil)
+}
+
+// RemoveLabel detaches a label from a pull request.
+func (c *Client) RemoveLabel(ctx context.Context, prID int, label string) error {
+	return c.do(ctx, http.MethodDelete, c.pullRequestPath(prID, "labels", label), nil, nil)
+}
+
+func (c *Client) pullRequestPath(prID int, segments ...string) string {
+	parts := []string{
+		c.collectionURI,
+		url.PathEscape(c.project),
+		"_apis/git/repositories",
+		url.PathEscape(c.repositoryID),
+		"pullRequests",
+		strconv.Itoa(prID),
+	}
+	for _, segment := range segments {
+		parts = append(parts, url.PathEscape(segment))
+	}
+	return strings.Join(parts, "/") + "?api-version=" + apiVersion
+}
+
+func (c *Client) do(ctx context.Context, method, endpoint string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody)) //nolint:errcheck // best-effort error context
+		return fmt.Errorf("%s %s: unexpected status %d: %s", method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
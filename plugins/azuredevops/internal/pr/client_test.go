@@ -0,0 +1,132 @@
+package pr
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewClient_WithToken(t *testing.T) {
+	client := NewClient("https://dev.azure.com/org", "infra", "repo", "token123")
+	if !client.HasToken() {
+		t.Error("expected HasToken() to be true when token and repository are provided")
+	}
+}
+
+func TestNewClient_WithoutToken(t *testing.T) {
+	client := NewClient("https://dev.azure.com/org", "infra", "repo", "")
+	if client.HasToken() {
+		t.Error("expected HasToken() to be false when token is empty")
+	}
+}
+
+func TestNewClientFromEnv_PrefersPAT(t *testing.T) {
+	t.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/org/")
+	t.Setenv("SYSTEM_TEAMPROJECTID", "project-id")
+	t.Setenv("BUILD_REPOSITORY_ID", "repo-id")
+	t.Setenv("AZURE_DEVOPS_EXT_PAT", "pat")
+	t.Setenv("SYSTEM_ACCESSTOKEN", "system-token")
+
+	client := NewClientFromEnv()
+	if !client.HasToken() {
+		t.Fatal("expected HasToken() to be true")
+	}
+	if client.authorization != "Basic OnBhdA==" {
+		t.Errorf("authorization = %q, want PAT basic auth", client.authorization)
+	}
+	if client.collectionURI != "https://dev.azure.com/org" {
+		t.Errorf("collectionURI = %q, want trailing slash trimmed", client.collectionURI)
+	}
+}
+
+func TestClient_ThreadLifecycle(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want bearer token", got)
+		}
+		if got := r.URL.Query().Get("api-version"); got != apiVersion {
+			t.Errorf("api-version = %q, want %q", got, apiVersion)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, map[string]any{"value": []Thread{{ID: 7, Comments: []Comment{{ID: 1, Content: "body"}}}}})
+		case http.MethodPost:
+			var payload struct {
+				Status   string `json:"status"`
+				Comments []struct {
+					Content string `json:"content"`
+				} `json:"comments"`
+			}
+			decodeJSON(t, r.Body, &payload)
+			if payload.Status != "closed" || len(payload.Comments) != 1 || payload.Comments[0].Content != "new" {
+				t.Errorf("unexpected create payload: %+v", payload)
+			}
+			writeJSON(t, w, Thread{ID: 8})
+		case http.MethodPatch:
+			writeJSON(t, w, Comment{ID: 1, Content: "updated"})
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "infra", "repo", "token")
+	threads, err := client.ListThreads(t.Context(), 42)
+	if err != nil {
+		t.Fatalf("ListThreads() error = %v", err)
+	}
+	if len(threads) != 1 || threads[0].ID != 7 {
+		t.Fatalf("ListThreads() = %+v, want thread 7", threads)
+	}
+	if _, err := client.CreateThread(t.Context(), 42, "new"); err != nil {
+		t.Fatalf("CreateThread() error = %v", err)
+	}
+	if _, err := client.UpdateComment(t.Context(), 42, 7, 1, "updated"); err != nil {
+		t.Fatalf("UpdateComment() error = %v", err)
+	}
+
+	want := []string{
+		"GET /infra/_apis/git/repositories/repo/pullRequests/42/threads",
+		"POST /infra/_apis/git/repositories/repo/pullRequests/42/threads",
+		"PATCH /infra/_apis/git/repositories/repo/pullRequests/42/threads/7/comments/1",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request[%d] = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestClient_ReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "infra", "repo", "token")
+	if err := client.AddLabel(t.Context(), 42, "terraform"); err == nil {
+		t.Fatal("AddLabel() error = nil, want HTTP status error")
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encode response: %v", err)
+	}
+}
+
+func decodeJSON(t *testing.T, r io.Reader, v any) {
+	t.Helper()
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		t.Errorf("decode request: %v", err)
+	}
+}
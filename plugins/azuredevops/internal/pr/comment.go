@@ -0,0 +1,24 @@
+package pr
+
+import "github.com/edelwud/terraci/pkg/ci"
+
+// FindTerraCIComment returns the first live thread comment carrying the
+// TerraCI marker, together with its owning thread ID.
+func FindTerraCIComment(threads []Thread) (threadID int64, comment *Comment) {
+	for i := range threads {
+		thread := &threads[i]
+		if thread.IsDeleted {
+			continue
+		}
+		for j := range thread.Comments {
+			candidate := &thread.Comments[j]
+			if candidate.IsDeleted {
+				continue
+			}
+			if ci.HasCommentMarker(candidate.Content) {
+				return thread.ID, candidate
+			}
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,42 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+func TestFindTerraCIComment(t *testing.T) {
+	threads := []Thread{
+		{ID: 10, Comments: []Comment{{ID: 1, Content: "Some other comment"}}},
+		{ID: 20, Comments: []Comment{
+			{ID: 1, Content: "reply"},
+			{ID: 2, Content: ci.CommentMarker + "\n\n## Terraform Plan"},
+		}},
+	}
+
+	threadID, found := FindTerraCIComment(threads)
+	if found == nil {
+		t.Fatal("expected to find terraci comment")
+	}
+	if threadID != 20 || found.ID != 2 {
+		t.Errorf("expected thread 20 comment 2, got thread %d comment %d", threadID, found.ID)
+	}
+}
+
+func TestFindTerraCIComment_SkipsDeleted(t *testing.T) {
+	threads := []Thread{
+		{ID: 10, IsDeleted: true, Comments: []Comment{{ID: 1, Content: ci.CommentMarker}}},
+		{ID: 20, Comments: []Comment{{ID: 1, Content: ci.CommentMarker, IsDeleted: true}}},
+	}
+
+	if _, found := FindTerraCIComment(threads); found != nil {
+		t.Errorf("expected deleted threads and comments to be ignored, found %#v", found)
+	}
+}
+
+func TestFindTerraCIComment_NilThreads(t *testing.T) {
+	if _, found := FindTerraCIComment(nil); found != nil {
+		t.Error("expected nil for nil threads input")
+	}
+}
@@ -0,0 +1,46 @@
+package pr
+
+import (
+	"os"
+	"strconv"
+)
+
+// azureReposProvider is the BUILD_REPOSITORY_PROVIDER value for Azure Repos
+// Git. Pull requests hosted elsewhere (e.g. GitHub) cannot receive threads
+// through the Azure DevOps Git API.
+const azureReposProvider = "TfsGit"
+
+// Context contains information about the current Azure Pipelines PR context.
+type Context struct {
+	CollectionURI string
+	Project       string
+	RepositoryID  string
+	PRID          int
+	SourceBranch  string
+	TargetBranch  string
+	BuildID       string
+	CommitSHA     string
+	InPR          bool
+}
+
+// DetectContext detects if we're running in an Azure Repos PR validation build.
+func DetectContext() *Context {
+	ctx := &Context{
+		CollectionURI: firstEnv("SYSTEM_COLLECTIONURI", "SYSTEM_TEAMFOUNDATIONCOLLECTIONURI"),
+		Project:       firstEnv("SYSTEM_TEAMPROJECTID", "SYSTEM_TEAMPROJECT"),
+		RepositoryID:  os.Getenv("BUILD_REPOSITORY_ID"),
+		SourceBranch:  os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"),
+		TargetBranch:  os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"),
+		BuildID:       os.Getenv("BUILD_BUILDID"),
+		CommitSHA:     os.Getenv("BUILD_SOURCEVERSION"),
+	}
+
+	if provider := os.Getenv("BUILD_REPOSITORY_PROVIDER"); provider != "" && provider != azureReposProvider {
+		return ctx
+	}
+	if id, err := strconv.Atoi(os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTID")); err == nil && id > 0 {
+		ctx.PRID = id
+		ctx.InPR = true
+	}
+	return ctx
+}
@@ -0,0 +1,52 @@
+package pr
+
+import "testing"
+
+func TestDetectContext_InPR(t *testing.T) {
+	t.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/org/")
+	t.Setenv("SYSTEM_TEAMPROJECTID", "")
+	t.Setenv("SYSTEM_TEAMPROJECT", "infra")
+	t.Setenv("BUILD_REPOSITORY_ID", "repo-id")
+	t.Setenv("BUILD_REPOSITORY_PROVIDER", "TfsGit")
+	t.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "42")
+	t.Setenv("SYSTEM_PULLREQUEST_SOURCEBRANCH", "refs/heads/feature")
+	t.Setenv("SYSTEM_PULLREQUEST_TARGETBRANCH", "refs/heads/main")
+	t.Setenv("BUILD_BUILDID", "123")
+	t.Setenv("BUILD_SOURCEVERSION", "abc123")
+
+	ctx := DetectContext()
+
+	if !ctx.InPR {
+		t.Fatal("expected InPR to be true")
+	}
+	if ctx.PRID != 42 {
+		t.Errorf("expected PRID 42, got %d", ctx.PRID)
+	}
+	if ctx.Project != "infra" {
+		t.Errorf("expected Project %q, got %q", "infra", ctx.Project)
+	}
+	if ctx.BuildID != "123" || ctx.CommitSHA != "abc123" {
+		t.Errorf("unexpected build metadata: %+v", ctx)
+	}
+	if ctx.TargetBranch != "refs/heads/main" {
+		t.Errorf("expected TargetBranch %q, got %q", "refs/heads/main", ctx.TargetBranch)
+	}
+}
+
+func TestDetectContext_NotInPR(t *testing.T) {
+	t.Setenv("BUILD_REPOSITORY_PROVIDER", "TfsGit")
+	t.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "")
+
+	if ctx := DetectContext(); ctx.InPR {
+		t.Error("expected InPR to be false without a pull request ID")
+	}
+}
+
+func TestDetectContext_ExternalRepositoryProvider(t *testing.T) {
+	t.Setenv("BUILD_REPOSITORY_PROVIDER", "GitHub")
+	t.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "42")
+
+	if ctx := DetectContext(); ctx.InPR {
+		t.Error("expected InPR to be false for pull requests hosted outside Azure Repos")
+	}
+}
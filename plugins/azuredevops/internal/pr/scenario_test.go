@@ -0,0 +1,123 @@
+package pr
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeThreadClient struct {
+	hasToken  bool
+	threads   []Thread
+	listErr   error
+	createErr error
+	updateErr error
+
+	createdPRID      int
+	createdBody      string
+	updatedThreadID  int64
+	updatedCommentID int64
+	updatedBody      string
+	addedLabels      []string
+	removedLabels    []string
+}
+
+func (f *fakeThreadClient) HasToken() bool {
+	return f.hasToken
+}
+
+func (f *fakeThreadClient) ListThreads(_ context.Context, _ int) ([]Thread, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	return f.threads, nil
+}
+
+func (f *fakeThreadClient) CreateThread(_ context.Context, prID int, body string) (*Thread, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.createdPRID = prID
+	f.createdBody = body
+	return &Thread{ID: 1, Comments: []Comment{{ID: 1, Content: body}}}, nil
+}
+
+func (f *fakeThreadClient) UpdateComment(_ context.Context, _ int, threadID, commentID int64, body string) (*Comment, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	f.updatedThreadID = threadID
+	f.updatedCommentID = commentID
+	f.updatedBody = body
+	return &Comment{ID: commentID, Content: body}, nil
+}
+
+func (f *fakeThreadClient) AddLabel(_ context.Context, _ int, label string) error {
+	f.addedLabels = append(f.addedLabels, label)
+	return nil
+}
+
+func (f *fakeThreadClient) RemoveLabel(_ context.Context, _ int, label string) error {
+	f.removedLabels = append(f.removedLabels, label)
+	return nil
+}
+
+type serviceScenario struct {
+	t       *testing.T
+	service *Service
+	client  *fakeThreadClient
+}
+
+func newServiceScenario(t *testing.T) *serviceScenario {
+	t.Helper()
+	client := &fakeThreadClient{hasToken: true}
+	service := NewService(client, &Context{
+		InPR: true,
+		PRID: 1,
+	})
+	return &serviceScenario{
+		t:       t,
+		service: service,
+		client:  client,
+	}
+}
+
+func (s *serviceScenario) withContext(ctx *Context) *serviceScenario {
+	s.t.Helper()
+	s.service.context = ctx
+	return s
+}
+
+func (s *serviceScenario) withToken(hasToken bool) *serviceScenario {
+	s.t.Helper()
+	s.client.hasToken = hasToken
+	return s
+}
+
+func (s *serviceScenario) withThreads(threads ...Thread) *serviceScenario {
+	s.t.Helper()
+	s.client.threads = threads
+	return s
+}
+
+func (s *serviceScenario) withListError(err error) *serviceScenario {
+	s.t.Helper()
+	s.client.listErr = err
+	return s
+}
+
+func (s *serviceScenario) withCreateError(err error) *serviceScenario {
+	s.t.Helper()
+	s.client.createErr = err
+	return s
+}
+
+func (s *serviceScenario) withUpdateError(err error) *serviceScenario {
+	s.t.Helper()
+	s.client.updateErr = err
+	return s
+}
+
+func (s *serviceScenario) upsert(body string) error {
+	s.t.Helper()
+	return s.service.UpsertComment(context.Background(), body)
+}
@@ -0,0 +1,105 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+type threadClient interface {
+	HasToken() bool
+	ListThreads(ctx context.Context, prID int) ([]Thread, error)
+	CreateThread(ctx context.Context, prID int, body string) (*Thread, error)
+	UpdateComment(ctx context.Context, prID int, threadID, commentID int64, body string) (*Comment, error)
+	AddLabel(ctx context.Context, prID int, label string) error
+	RemoveLabel(ctx context.Context, prID int, label string) error
+}
+
+type Service struct {
+	client  threadClient
+	context *Context
+}
+
+func NewService(client threadClient, ctx *Context) *Service {
+	return &Service{
+		client:  client,
+		context: ctx,
+	}
+}
+
+func NewServiceFromEnv() *Service {
+	return NewService(NewClientFromEnv(), DetectContext())
+}
+
+func (s *Service) IsEnabled() bool {
+	if !s.context.InPR {
+		return false
+	}
+	if !s.client.HasToken() {
+		return false
+	}
+	return true
+}
+
+func (s *Service) UpsertComment(ctx context.Context, body string) error {
+	if !s.IsEnabled() {
+		return nil
+	}
+
+	threads, err := s.client.ListThreads(ctx, s.context.PRID)
+	if err != nil {
+		return fmt.Errorf("failed to list PR threads: %w", err)
+	}
+
+	threadID, existing := FindTerraCIComment(threads)
+	if existing != nil {
+		if _, err := s.client.UpdateComment(ctx, s.context.PRID, threadID, existing.ID, body); err != nil {
+			return fmt.Errorf("failed to update PR comment: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := s.client.CreateThread(ctx, s.context.PRID, body); err != nil {
+		return fmt.Errorf("failed to create PR thread: %w", err)
+	}
+	return nil
+}
+
+// CurrentCommentBody returns the current TerraCI PR comment body, if present.
+func (s *Service) CurrentCommentBody(ctx context.Context) (body string, found bool, err error) {
+	if !s.IsEnabled() {
+		return "", false, nil
+	}
+	threads, err := s.client.ListThreads(ctx, s.context.PRID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list PR threads: %w", err)
+	}
+	_, existing := FindTerraCIComment(threads)
+	if existing == nil {
+		return "", false, nil
+	}
+	return existing.Content, true, nil
+}
+
+// SyncLabels synchronizes TerraCI-managed PR labels.
+func (s *Service) SyncLabels(ctx context.Context, previous, current []string) error {
+	if !s.IsEnabled() {
+		return nil
+	}
+	add, remove := ci.DiffManagedLabels(previous, current)
+	for _, label := range remove {
+		if err := s.client.RemoveLabel(ctx, s.context.PRID, label); err != nil {
+			return fmt.Errorf("remove PR label %q: %w", label, err)
+		}
+	}
+	for _, label := range add {
+		if err := s.client.AddLabel(ctx, s.context.PRID, label); err != nil {
+			return fmt.Errorf("add PR label %q: %w", label, err)
+		}
+	}
+	return nil
+}
+
+var _ ci.CommentService = (*Service)(nil)
+var _ ci.ManagedLabelService = (*Service)(nil)
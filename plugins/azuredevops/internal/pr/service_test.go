@@ -0,0 +1,109 @@
+package pr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/ci/citest"
+)
+
+func TestService_IsEnabled(t *testing.T) {
+	citest.RunEnabledCases(t, []citest.EnabledCase[*Context, struct{}]{
+		{Name: "not in PR", Context: &Context{InPR: false}, HasToken: true, Expected: false},
+		{Name: "in PR without token", Context: &Context{InPR: true}, HasToken: false, Expected: false},
+		{Name: "in PR with token", Context: &Context{InPR: true}, HasToken: true, Expected: true},
+	}, func(t *testing.T, ctx *Context, _ struct{}, hasToken bool) bool {
+		return newServiceScenario(t).withContext(ctx).withToken(hasToken).service.IsEnabled()
+	})
+}
+
+func TestService_UpsertComment_Disabled(t *testing.T) {
+	if err := newServiceScenario(t).
+		withContext(&Context{InPR: false}).
+		withToken(false).
+		upsert("test body"); err != nil {
+		t.Errorf("expected nil error for disabled service, got: %v", err)
+	}
+}
+
+func TestService_UpsertComment_CreateNew(t *testing.T) {
+	scenario := newServiceScenario(t)
+
+	if err := scenario.upsert(ci.CommentMarker + "\n\n## Test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	citest.AssertCreateOnly(t, scenario.client.createdBody, scenario.client.updatedBody)
+}
+
+func TestService_UpsertComment_UpdateExisting(t *testing.T) {
+	scenario := newServiceScenario(t).withThreads(
+		Thread{ID: 5, Comments: []Comment{{ID: 42, Content: "old comment " + ci.CommentMarker}}},
+	)
+
+	if err := scenario.upsert(ci.CommentMarker + "\n\n## Test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	citest.AssertUpdateOnly(t, scenario.client.createdBody, scenario.client.updatedBody, scenario.client.updatedCommentID, 42)
+	if scenario.client.updatedThreadID != 5 {
+		t.Errorf("updated thread = %d, want 5", scenario.client.updatedThreadID)
+	}
+}
+
+func TestService_UpsertComment_Errors(t *testing.T) {
+	existing := Thread{ID: 5, Comments: []Comment{{ID: 7, Content: ci.CommentMarker + " existing"}}}
+	tests := []struct {
+		name     string
+		scenario func(*testing.T) *serviceScenario
+	}{
+		{name: "list", scenario: func(t *testing.T) *serviceScenario {
+			return newServiceScenario(t).withListError(fmt.Errorf("boom"))
+		}},
+		{name: "create", scenario: func(t *testing.T) *serviceScenario {
+			return newServiceScenario(t).withCreateError(fmt.Errorf("boom"))
+		}},
+		{name: "update", scenario: func(t *testing.T) *serviceScenario {
+			return newServiceScenario(t).withThreads(existing).withUpdateError(fmt.Errorf("boom"))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.scenario(t).upsert("test body"); err == nil {
+				t.Errorf("expected error when %s fails", tt.name)
+			}
+		})
+	}
+}
+
+func TestService_CurrentCommentBody(t *testing.T) {
+	body := ci.EmbedManagedLabels(ci.CommentMarker+"\n\n## Test", []string{"terraform"})
+	got, found, err := newServiceScenario(t).
+		withThreads(Thread{ID: 5, Comments: []Comment{{ID: 7, Content: body}}}).
+		service.CurrentCommentBody(t.Context())
+	if err != nil {
+		t.Fatalf("CurrentCommentBody() error = %v", err)
+	}
+	if !found {
+		t.Fatal("CurrentCommentBody() found = false, want true")
+	}
+	if got != body {
+		t.Fatalf("CurrentCommentBody() body = %q, want %q", got, body)
+	}
+}
+
+func TestService_SyncLabels_AddsAndRemovesManagedDiff(t *testing.T) {
+	scenario := newServiceScenario(t)
+
+	err := scenario.service.SyncLabels(t.Context(), []string{"keep", "old-a", "old-b"}, []string{"keep", "terraform"})
+	if err != nil {
+		t.Fatalf("SyncLabels() error = %v", err)
+	}
+
+	if got := strings.Join(scenario.client.removedLabels, ","); got != "old-a,old-b" {
+		t.Fatalf("removed labels = %v, want [old-a old-b]", scenario.client.removedLabels)
+	}
+	if got := strings.Join(scenario.client.addedLabels, ","); got != "terraform" {
+		t.Fatalf("added labels = %v, want [terraform]", scenario.client.addedLabels)
+	}
+}
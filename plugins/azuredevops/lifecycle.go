@@ -0,0 +1,26 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/edelwud/terraci/pkg/plugin"
+	prpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/pr"
+	"github.com/edelwud/terraci/plugins/internal/ciplugin"
+)
+
+// Preflight validates the loaded plugin config and detects PR context when
+// running inside Azure Pipelines.
+func (p *Plugin) Preflight(_ context.Context, _ *plugin.AppContext) error {
+	var cfg ciplugin.ConfigValidator
+	if c := p.Config(); c != nil {
+		cfg = c
+	}
+	return ciplugin.Preflight(cfg, p.DetectEnv, ciplugin.PreflightLog{
+		ProviderName: pluginName,
+		ContextLabel: "PR",
+		DetectInContext: func() (any, bool) {
+			ctx := prpkg.DetectContext()
+			return ctx.PRID, ctx.InPR
+		},
+	})
+}
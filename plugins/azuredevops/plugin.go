@@ -0,0 +1,32 @@
+// Package azuredevops provides the Azure DevOps Pipelines plugin for TerraCi.
+// It registers a pipeline generator and PR comment service.
+package azuredevops
+
+import (
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/registry"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+)
+
+// pluginName is the canonical provider name of the Azure DevOps plugin.
+const pluginName = "azuredevops"
+
+func init() {
+	registry.RegisterFactory(func() plugin.Plugin {
+		return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+			PluginName: pluginName,
+			PluginDesc: "Azure DevOps Pipelines generation and PR comments",
+			EnableMode: plugin.EnabledWhenConfigured,
+			DefaultCfg: func() *configpkg.Config {
+				return &configpkg.Config{
+					Pool: &configpkg.Pool{VMImage: "ubuntu-latest"},
+				}
+			},
+		}}
+	})
+}
+
+// Plugin is the Azure DevOps Pipelines plugin.
+type Plugin struct {
+	plugin.BasePlugin[*configpkg.Config]
+}
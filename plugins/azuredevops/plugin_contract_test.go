@@ -0,0 +1,171 @@
+package azuredevops
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	"github.com/edelwud/terraci/pkg/plugin/plugintest"
+	configpkg "github.com/edelwud/terraci/plugins/azuredevops/internal/config"
+)
+
+func TestPlugin_SDKContracts(t *testing.T) {
+	p := newContractPlugin()
+
+	t.Run("config", func(t *testing.T) {
+		plugintest.AssertBaseConfigPlugin[*configpkg.Config](t, plugintest.BaseConfigPluginContract[*configpkg.Config]{
+			Plugin:  p,
+			Default: &configpkg.Config{Pool: &configpkg.Pool{VMImage: "ubuntu-latest"}},
+			Configured: &configpkg.Config{
+				Pool:      &configpkg.Pool{Name: "self-hosted", Demands: []string{"terraform"}},
+				Container: &configpkg.Image{Name: "hashicorp/terraform:1.6", Entrypoint: []string{"/bin/sh"}},
+				Variables: map[string]string{"TF_INPUT": "false"},
+				JobDefaults: &configpkg.JobDefaults{
+					Variables:   map[string]string{"DEFAULT": "true"},
+					StepsBefore: []configpkg.ConfigStep{{DisplayName: "setup", Task: "AzureCLI@2", Inputs: map[string]string{"azureSubscription": "prod"}}},
+				},
+			},
+			Decoded: &configpkg.Config{
+				Pool:      &configpkg.Pool{VMImage: "ubuntu-24.04"},
+				Variables: map[string]string{"DECODED": "true"},
+				JobDefaults: &configpkg.JobDefaults{
+					Condition:  "succeeded()",
+					StepsAfter: []configpkg.ConfigStep{{DisplayName: "cleanup", Script: "echo done", Env: map[string]string{"DONE": "true"}}},
+				},
+			},
+			Mutate: mutateAzureConfig,
+			Equal:  equalAzureConfig,
+		})
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		plugintest.AssertPreflightable(t, plugintest.PreflightableContract{
+			Plugin:     newContractPlugin(),
+			AppContext: plugintest.NewAppContext(t, t.TempDir()),
+		})
+	})
+
+	t.Run("init contributor", func(t *testing.T) {
+		state := initwiz.NewStateMap()
+		initwiz.ProviderKey.Set(state, pluginName)
+		plugintest.AssertInitContributor(t, plugintest.InitContributorContract{
+			Contributor:        newContractPlugin(),
+			State:              state,
+			ExpectedPluginKey:  pluginName,
+			ExpectContribution: true,
+			DecodeTarget:       &configpkg.Config{},
+		})
+	})
+
+	t.Run("ci provider", func(t *testing.T) {
+		t.Setenv("TF_BUILD", "True")
+		p := newContractPlugin()
+		plugintest.AssertCIProvider(t, plugintest.CIProviderContract{
+			EnvDetector:    p,
+			InfoProvider:   p,
+			Generator:      p,
+			CommentFactory: p,
+			AppContext:     plugintest.NewAppContext(t, t.TempDir()),
+			IR:             pipelinetest.MustCommandIR(t),
+			ExpectedName:   pluginName,
+			AssertEnv: func(tb testing.TB, detected bool) {
+				tb.Helper()
+				if !detected {
+					tb.Fatal("DetectEnv() = false, want true")
+				}
+			},
+			AssertComment: func(tb testing.TB, service ci.CommentService, ok bool) {
+				tb.Helper()
+				if !ok || service == nil {
+					tb.Fatal("NewCommentService() did not return a service")
+				}
+			},
+		})
+	})
+}
+
+func newContractPlugin() *Plugin {
+	return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+		PluginName: pluginName,
+		PluginDesc: "Azure DevOps Pipelines generation and PR comments",
+		EnableMode: plugin.EnabledWhenConfigured,
+		DefaultCfg: func() *configpkg.Config {
+			return &configpkg.Config{Pool: &configpkg.Pool{VMImage: "ubuntu-latest"}}
+		},
+	}}
+}
+
+func mutateAzureConfig(c *configpkg.Config) {
+	if c == nil {
+		return
+	}
+	if c.Pool != nil {
+		c.Pool.Demands = append(c.Pool.Demands, "mutated")
+	}
+	if c.Container != nil {
+		c.Container.Entrypoint = append(c.Container.Entrypoint, "mutated")
+	}
+	if c.Variables == nil {
+		c.Variables = map[string]string{}
+	}
+	c.Variables["MUTATED"] = "true"
+	if c.JobDefaults != nil {
+		if c.JobDefaults.Variables == nil {
+			c.JobDefaults.Variables = map[string]string{}
+		}
+		c.JobDefaults.Variables["MUTATED"] = "true"
+		if len(c.JobDefaults.StepsBefore) > 0 {
+			c.JobDefaults.StepsBefore[0].Inputs["mutated"] = "true"
+		}
+		if len(c.JobDefaults.StepsAfter) > 0 {
+			c.JobDefaults.StepsAfter[0].Env["mutated"] = "true"
+		}
+	}
+}
+
+func equalAzureConfig(got, want *configpkg.Config) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalAzurePool(got.Pool, want.Pool) &&
+		equalAzureImage(got.Container, want.Container) &&
+		maps.Equal(got.Variables, want.Variables) &&
+		equalAzureDefaults(got.JobDefaults, want.JobDefaults)
+}
+
+func equalAzurePool(got, want *configpkg.Pool) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Name == want.Name && got.VMImage == want.VMImage && slices.Equal(got.Demands, want.Demands)
+}
+
+func equalAzureImage(got, want *configpkg.Image) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Name == want.Name && slices.Equal(got.Entrypoint, want.Entrypoint)
+}
+
+func equalAzureDefaults(got, want *configpkg.JobDefaults) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalAzurePool(got.Pool, want.Pool) &&
+		got.Condition == want.Condition &&
+		maps.Equal(got.Variables, want.Variables) &&
+		slices.EqualFunc(got.StepsBefore, want.StepsBefore, equalAzureStep) &&
+		slices.EqualFunc(got.StepsAfter, want.StepsAfter, equalAzureStep)
+}
+
+func equalAzureStep(got, want configpkg.ConfigStep) bool {
+	return got.DisplayName == want.DisplayName &&
+		got.Task == want.Task &&
+		got.Script == want.Script &&
+		maps.Equal(got.Inputs, want.Inputs) &&
+		maps.Equal(got.Env, want.Env)
+}
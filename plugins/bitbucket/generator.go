@@ -0,0 +1,28 @@
+package bitbucket
+
+import (
+	"os"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	generatepkg "github.com/edelwud/terraci/plugins/bitbucket/internal/generate"
+)
+
+// ProviderName returns the provider name.
+func (p *Plugin) ProviderName() string { return p.Name() }
+
+// DetectEnv returns true if running in Bitbucket Pipelines.
+func (p *Plugin) DetectEnv() bool {
+	return os.Getenv("BITBUCKET_BUILD_NUMBER") != ""
+}
+
+// PipelineID returns the Bitbucket Pipelines build number.
+func (p *Plugin) PipelineID() string { return os.Getenv("BITBUCKET_BUILD_NUMBER") }
+
+// CommitSHA returns the Bitbucket Pipelines commit SHA.
+func (p *Plugin) CommitSHA() string { return os.Getenv("BITBUCKET_COMMIT") }
+
+// NewGenerator creates a new Bitbucket Pipelines generator bound to the
+// pre-built IR.
+func (p *Plugin) NewGenerator(ir *pipeline.IR) (pipeline.Generator, error) {
+	return generatepkg.NewGenerator(p.Config(), ir), nil
+}
@@ -0,0 +1,72 @@
+package bitbucket
+
+import (
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+)
+
+// InitContributor — contributes Bitbucket Pipelines fields to the init wizard.
+
+const (
+	defaultTerraformImage = "hashicorp/terraform:1.6"
+	defaultTofuImage      = "ghcr.io/opentofu/opentofu:1.6"
+)
+
+var (
+	initConfigKey     = config.MustExtensionKey(pluginName)
+	keyBitbucketImage = initwiz.MustStateKey[string]("bitbucket.image")
+)
+
+type initConfig struct {
+	Image *configpkg.Image `yaml:"image,omitempty"`
+}
+
+// InitGroups returns the init wizard group specs for Bitbucket Pipelines.
+func (p *Plugin) InitGroups() ([]initwiz.InitGroup, error) {
+	showBitbucket := func(s *initwiz.StateMap) bool {
+		return initwiz.ProviderKey.Get(s) == pluginName
+	}
+
+	image, err := initwiz.NewStringField(initwiz.StringFieldOptions{
+		Key:         keyBitbucketImage,
+		Title:       "Docker Image",
+		Description: "Docker image for terraform steps",
+		Default:     defaultTerraformImage,
+		Placeholder: defaultTerraformImage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	group, err := initwiz.NewInitGroup(initwiz.InitGroupOptions{
+		Title:    "Bitbucket Pipelines",
+		Category: initwiz.CategoryProvider,
+		Order:    100,
+		ShowWhen: showBitbucket,
+		Fields:   []initwiz.InitField{image},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []initwiz.InitGroup{group}, nil
+}
+
+// BuildInitConfig builds the Bitbucket Pipelines init contribution.
+func (p *Plugin) BuildInitConfig(state *initwiz.StateMap) (*initwiz.InitContribution, error) {
+	if initwiz.ProviderKey.Get(state) != pluginName {
+		return nil, nil
+	}
+
+	binary := initwiz.BinaryKey.Get(state)
+	image := keyBitbucketImage.Get(state)
+	if image == "" || (binary == "tofu" && image == defaultTerraformImage) {
+		image = defaultTerraformImage
+		if binary == "tofu" {
+			image = defaultTofuImage
+		}
+	}
+
+	return initwiz.NewInitContribution(initConfigKey, initConfig{
+		Image: &configpkg.Image{Name: image},
+	})
+}
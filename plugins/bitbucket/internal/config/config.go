@@ -0,0 +1,117 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+type Image = ci.Image
+
+// Config contains Bitbucket Pipelines specific settings.
+type Config struct {
+	Image       *Image            `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image for all steps"`
+	Size        StepSize          `yaml:"size,omitempty" json:"size,omitempty" jsonschema:"description=Step size multiplier,enum=1x,enum=2x,enum=4x,enum=8x"`
+	Variables   map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" jsonschema:"description=Variables exported at the start of every step"`
+	JobDefaults *JobDefaults      `yaml:"job_defaults,omitempty" json:"job_defaults,omitempty" jsonschema:"description=Default settings applied to all steps"`
+	Overwrites  []JobOverwrite    `yaml:"overwrites,omitempty" json:"overwrites,omitempty" jsonschema:"description=Step-level overrides for plan or apply jobs"`
+}
+
+// Clone returns a deep copy of the Bitbucket Pipelines configuration.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Image = cloneImagePointer(c.Image)
+	out.Variables = maps.Clone(c.Variables)
+	out.JobDefaults = cloneJobDefaults(c.JobDefaults)
+	out.Overwrites = cloneJobOverwrites(c.Overwrites)
+	return &out
+}
+
+type JobDefaults struct {
+	Image        *Image            `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image override"`
+	Size         StepSize          `yaml:"size,omitempty" json:"size,omitempty" jsonschema:"description=Step size multiplier,enum=1x,enum=2x,enum=4x,enum=8x"`
+	RunsOn       []string          `yaml:"runs_on,omitempty" json:"runs_on,omitempty" jsonschema:"description=Self-hosted runner labels"`
+	Deployment   string            `yaml:"deployment,omitempty" json:"deployment,omitempty" jsonschema:"description=Bitbucket deployment environment"`
+	Trigger      Trigger           `yaml:"trigger,omitempty" json:"trigger,omitempty" jsonschema:"description=Step trigger (apply steps default to manual),enum=automatic,enum=manual"`
+	Variables    map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" jsonschema:"description=Additional variables exported by the step"`
+	BeforeScript []string          `yaml:"before_script,omitempty" json:"before_script,omitempty" jsonschema:"description=Commands to run before terraform commands"`
+	AfterScript  []string          `yaml:"after_script,omitempty" json:"after_script,omitempty" jsonschema:"description=Commands to run after the step (always runs)"`
+}
+
+func cloneJobDefaults(in *JobDefaults) *JobDefaults {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Image = cloneImagePointer(in.Image)
+	out.RunsOn = append([]string(nil), in.RunsOn...)
+	out.Variables = maps.Clone(in.Variables)
+	out.BeforeScript = append([]string(nil), in.BeforeScript...)
+	out.AfterScript = append([]string(nil), in.AfterScript...)
+	return &out
+}
+
+type JobOverwrite struct {
+	Type         JobOverwriteType  `yaml:"type" json:"type" jsonschema:"description=Type of jobs to override (plan\\, apply\\, or contributed job name),required"`
+	Image        *Image            `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image override"`
+	Size         StepSize          `yaml:"size,omitempty" json:"size,omitempty" jsonschema:"description=Step size multiplier,enum=1x,enum=2x,enum=4x,enum=8x"`
+	RunsOn       []string          `yaml:"runs_on,omitempty" json:"runs_on,omitempty" jsonschema:"description=Self-hosted runner labels"`
+	Deployment   string            `yaml:"deployment,omitempty" json:"deployment,omitempty" jsonschema:"description=Bitbucket deployment environment"`
+	Trigger      Trigger           `yaml:"trigger,omitempty" json:"trigger,omitempty" jsonschema:"description=Step trigger,enum=automatic,enum=manual"`
+	Variables    map[string]string `yaml:"variables,omitempty" json:"variables,omitempty" jsonschema:"description=Additional variables exported by the step"`
+	BeforeScript []string          `yaml:"before_script,omitempty" json:"before_script,omitempty" jsonschema:"description=Commands to run before terraform commands"`
+	AfterScript  []string          `yaml:"after_script,omitempty" json:"after_script,omitempty" jsonschema:"description=Commands to run after the step (always runs)"`
+}
+
+func cloneJobOverwrites(in []JobOverwrite) []JobOverwrite {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]JobOverwrite, len(in))
+	for i := range in {
+		out[i] = in[i]
+		out[i].Image = cloneImagePointer(in[i].Image)
+		out[i].RunsOn = append([]string(nil), in[i].RunsOn...)
+		out[i].Variables = maps.Clone(in[i].Variables)
+		out[i].BeforeScript = append([]string(nil), in[i].BeforeScript...)
+		out[i].AfterScript = append([]string(nil), in[i].AfterScript...)
+	}
+	return out
+}
+
+func cloneImagePointer(in *Image) *Image {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Entrypoint = append([]string(nil), in.Entrypoint...)
+	return &out
+}
+
+type JobOverwriteType string
+
+const (
+	OverwriteTypePlan  JobOverwriteType = "plan"
+	OverwriteTypeApply JobOverwriteType = "apply"
+)
+
+// Trigger controls whether a step starts automatically or waits for a user.
+type Trigger string
+
+const (
+	TriggerAutomatic Trigger = "automatic"
+	TriggerManual    Trigger = "manual"
+)
+
+// StepSize is the Bitbucket step resource multiplier.
+type StepSize string
+
+const (
+	StepSize1x StepSize = "1x"
+	StepSize2x StepSize = "2x"
+	StepSize4x StepSize = "4x"
+	StepSize8x StepSize = "8x"
+)
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate runs the Bitbucket plugin's config-shape sanity checks. Called
+// from the plugin's Preflight so unsupported values fail before any YAML is
+// rendered.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if err := validateImage(c.Image); err != nil {
+		errs = append(errs, fmt.Errorf("image: %w", err))
+	}
+	if err := c.Size.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("size: %w", err))
+	}
+	if d := c.JobDefaults; d != nil {
+		if err := validateImage(d.Image); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.image: %w", err))
+		}
+		if err := d.Size.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.size: %w", err))
+		}
+		if err := d.Trigger.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.trigger: %w", err))
+		}
+	}
+	for i := range c.Overwrites {
+		o := &c.Overwrites[i]
+		if err := o.Type.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d]: %w", i, err))
+		}
+		if err := validateImage(o.Image); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].image: %w", i, err))
+		}
+		if err := o.Size.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].size: %w", i, err))
+		}
+		if err := o.Trigger.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].trigger: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateImage(img *Image) error {
+	if img == nil {
+		return nil
+	}
+	if img.Name == "" {
+		return errors.New("name must be set")
+	}
+	if img.HasEntrypoint() {
+		return errors.New("entrypoint overrides are not supported by Bitbucket Pipelines")
+	}
+	return nil
+}
+
+func (t JobOverwriteType) validate() error {
+	if t == "" {
+		return errors.New("type must be set (plan, apply, or a contributed job name)")
+	}
+	return nil
+}
+
+func (t Trigger) validate() error {
+	switch t {
+	case "", TriggerAutomatic, TriggerManual:
+		return nil
+	default:
+		return fmt.Errorf("unsupported trigger %q (want automatic or manual)", t)
+	}
+}
+
+func (s StepSize) validate() error {
+	switch s {
+	case "", StepSize1x, StepSize2x, StepSize4x, StepSize8x:
+		return nil
+	default:
+		return fmt.Errorf("unsupported size %q (want 1x, 2x, 4x, or 8x)", s)
+	}
+}
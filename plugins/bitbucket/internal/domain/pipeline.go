@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Pipeline represents a bitbucket-pipelines.yml document. Bitbucket runs the
+// entries of the step list strictly in order; an entry is either a single
+// step or a parallel group whose steps share one barrier.
+type Pipeline struct {
+	image  string
+	groups []stepGroup
+}
+
+type stepGroup struct {
+	steps    []Step
+	parallel bool
+}
+
+func (g stepGroup) clone() stepGroup {
+	return stepGroup{steps: cloneSteps(g.steps), parallel: g.parallel}
+}
+
+type PipelineOptions struct {
+	Image string
+}
+
+type PipelineBuilder struct {
+	opts   PipelineOptions
+	groups []stepGroup
+	steps  map[string]int
+}
+
+func EmptyPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+func NewPipelineBuilder(opts PipelineOptions) *PipelineBuilder {
+	return &PipelineBuilder{
+		opts:  opts,
+		steps: make(map[string]int),
+	}
+}
+
+// AddStep appends a single step that runs after every previously added entry.
+func (b *PipelineBuilder) AddStep(step Step) error {
+	return b.addGroup(stepGroup{steps: []Step{step}})
+}
+
+// AddParallel appends a parallel group. A group with one step is rendered as
+// a plain step. Bitbucket requires every step of a group to share the same
+// trigger, so mixing manual and automatic steps is rejected.
+func (b *PipelineBuilder) AddParallel(steps ...Step) error {
+	if len(steps) == 0 {
+		return errors.New("bitbucket parallel group requires at least one step")
+	}
+	for i := 1; i < len(steps); i++ {
+		if steps[i].Manual() != steps[0].Manual() {
+			return fmt.Errorf("bitbucket parallel group mixes manual step %q with automatic steps", manualStepName(steps))
+		}
+	}
+	return b.addGroup(stepGroup{steps: steps, parallel: len(steps) > 1})
+}
+
+func (b *PipelineBuilder) addGroup(group stepGroup) error {
+	if b == nil {
+		return errors.New("bitbucket pipeline builder is nil")
+	}
+	seen := make(map[string]struct{}, len(group.steps))
+	for _, step := range group.steps {
+		if step.name == "" {
+			return errors.New("bitbucket step name is required")
+		}
+		if _, exists := b.steps[step.name]; exists {
+			return fmt.Errorf("duplicate bitbucket step %q", step.name)
+		}
+		if _, exists := seen[step.name]; exists {
+			return fmt.Errorf("duplicate bitbucket step %q", step.name)
+		}
+		seen[step.name] = struct{}{}
+	}
+	if len(b.groups) == 0 && group.steps[0].Manual() {
+		return fmt.Errorf("bitbucket step %q cannot be manual: the first step of a pipeline always runs automatically", group.steps[0].name)
+	}
+	index := len(b.groups)
+	for _, step := range group.steps {
+		b.steps[step.name] = index
+	}
+	b.groups = append(b.groups, group.clone())
+	return nil
+}
+
+func (b *PipelineBuilder) Build() (*Pipeline, error) {
+	if b == nil {
+		return nil, errors.New("bitbucket pipeline builder is nil")
+	}
+	groups := make([]stepGroup, len(b.groups))
+	for i := range b.groups {
+		groups[i] = b.groups[i].clone()
+	}
+	return &Pipeline{image: b.opts.Image, groups: groups}, nil
+}
+
+func manualStepName(steps []Step) string {
+	for _, step := range steps {
+		if step.Manual() {
+			return step.name
+		}
+	}
+	return ""
+}
+
+func (p *Pipeline) Image() string {
+	if p == nil {
+		return ""
+	}
+	return p.image
+}
+
+// GroupCount returns the number of sequential entries in the step list.
+func (p *Pipeline) GroupCount() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.groups)
+}
+
+// GroupSteps returns the names of the steps in the entry at index.
+func (p *Pipeline) GroupSteps(index int) []string {
+	if p == nil || index < 0 || index >= len(p.groups) {
+		return nil
+	}
+	names := make([]string, 0, len(p.groups[index].steps))
+	for _, step := range p.groups[index].steps {
+		names = append(names, step.name)
+	}
+	return names
+}
+
+// GroupOf returns the index of the step-list entry that owns stepName.
+func (p *Pipeline) GroupOf(stepName string) (int, bool) {
+	if p == nil {
+		return 0, false
+	}
+	for i, group := range p.groups {
+		for _, step := range group.steps {
+			if step.name == stepName {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (p *Pipeline) Step(name string) (Step, bool) {
+	index, ok := p.GroupOf(name)
+	if !ok {
+		return Step{}, false
+	}
+	for _, step := range p.groups[index].steps {
+		if step.name == name {
+			return step.clone(), true
+		}
+	}
+	return Step{}, false
+}
+
+func (p *Pipeline) StepNames() []string {
+	if p == nil {
+		return nil
+	}
+	var names []string
+	for _, group := range p.groups {
+		for _, step := range group.steps {
+			names = append(names, step.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Pipeline) StepCount() int {
+	if p == nil {
+		return 0
+	}
+	count := 0
+	for _, group := range p.groups {
+		count += len(group.steps)
+	}
+	return count
+}
+
+// StepRunsAfter reports whether stepName belongs to a later entry of the step
+// list than dependency, which is the only ordering Bitbucket guarantees.
+func (p *Pipeline) StepRunsAfter(stepName, dependency string) bool {
+	from, ok := p.GroupOf(stepName)
+	if !ok {
+		return false
+	}
+	to, ok := p.GroupOf(dependency)
+	if !ok {
+		return false
+	}
+	return from > to
+}
@@ -0,0 +1,102 @@
+package domain
+
+import "testing"
+
+func TestPipelineGettersReturnDefensiveCopies(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{Image: "hashicorp/terraform:1.6"})
+	mustAddParallel(t, builder, mustStep(t, "plan-vpc", ""), mustStep(t, "plan-eks", ""))
+	pipeline := mustBuildPipeline(t, builder)
+
+	step, ok := pipeline.Step("plan-vpc")
+	if !ok {
+		t.Fatal("plan-vpc step not found")
+	}
+	script := step.Script()
+	script[0] = "changed"
+	if step.Script()[0] != "terraform plan" {
+		t.Fatalf("Step.Script() leaked mutation: %#v", step.Script())
+	}
+	if got := pipeline.StepNames(); len(got) != 2 || got[0] != "plan-eks" || got[1] != "plan-vpc" {
+		t.Fatalf("StepNames() = %v", got)
+	}
+}
+
+func TestPipelineBuilderValidatesSteps(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	if err := builder.AddParallel(); err == nil {
+		t.Fatal("AddParallel() error = nil, want empty group error")
+	}
+	if err := builder.AddStep(mustStep(t, "apply-vpc", TriggerManual)); err == nil {
+		t.Fatal("AddStep() error = nil, want manual first step error")
+	}
+	mustAddParallel(t, builder, mustStep(t, "plan-vpc", ""))
+	if err := builder.AddStep(mustStep(t, "plan-vpc", "")); err == nil {
+		t.Fatal("AddStep() error = nil, want duplicate step error")
+	}
+	if err := builder.AddParallel(mustStep(t, "plan-eks", ""), mustStep(t, "plan-eks", "")); err == nil {
+		t.Fatal("AddParallel() error = nil, want duplicate step error")
+	}
+	if err := builder.AddParallel(mustStep(t, "apply-vpc", TriggerManual), mustStep(t, "summary", "")); err == nil {
+		t.Fatal("AddParallel() error = nil, want mixed trigger error")
+	}
+	mustAddParallel(t, builder, mustStep(t, "apply-vpc", TriggerManual), mustStep(t, "apply-eks", TriggerManual))
+}
+
+func TestNewStepValidates(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewStep(StepOptions{Script: []string{"true"}}); err == nil {
+		t.Fatal("NewStep() error = nil, want missing name error")
+	}
+	if _, err := NewStep(StepOptions{Name: "plan"}); err == nil {
+		t.Fatal("NewStep() error = nil, want missing script error")
+	}
+	if _, err := NewStep(StepOptions{Name: "plan", Script: []string{"true"}, Trigger: "later"}); err == nil {
+		t.Fatal("NewStep() error = nil, want trigger error")
+	}
+}
+
+func TestPipelineStepRunsAfter(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddParallel(t, builder, mustStep(t, "plan-vpc", ""), mustStep(t, "plan-eks", ""))
+	mustAddParallel(t, builder, mustStep(t, "apply-vpc", TriggerManual))
+	pipeline := mustBuildPipeline(t, builder)
+
+	if !pipeline.StepRunsAfter("apply-vpc", "plan-vpc") {
+		t.Fatal("apply-vpc should run after plan-vpc")
+	}
+	if pipeline.StepRunsAfter("plan-eks", "plan-vpc") {
+		t.Fatal("parallel siblings must not be ordered")
+	}
+	if pipeline.StepRunsAfter("missing", "plan-vpc") {
+		t.Fatal("unknown steps must not be ordered")
+	}
+	if got := pipeline.GroupCount(); got != 2 {
+		t.Fatalf("GroupCount() = %d, want 2", got)
+	}
+}
+
+func mustStep(t *testing.T, name string, trigger Trigger) Step {
+	t.Helper()
+	step, err := NewStep(StepOptions{Name: name, Trigger: trigger, Script: []string{"terraform plan"}})
+	if err != nil {
+		t.Fatalf("NewStep() error = %v", err)
+	}
+	return step
+}
+
+func mustAddParallel(t *testing.T, builder *PipelineBuilder, steps ...Step) {
+	t.Helper()
+	if err := builder.AddParallel(steps...); err != nil {
+		t.Fatalf("AddParallel() error = %v", err)
+	}
+}
+
+func mustBuildPipeline(t *testing.T, builder *PipelineBuilder) *Pipeline {
+	t.Helper()
+	pipeline, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return pipeline
+}
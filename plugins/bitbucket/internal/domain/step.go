@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Trigger controls whether a step starts automatically or waits for a user.
+type Trigger string
+
+const (
+	TriggerAutomatic Trigger = "automatic"
+	TriggerManual    Trigger = "manual"
+)
+
+type StepOptions struct {
+	Name        string
+	Image       string
+	Size        string
+	RunsOn      []string
+	Deployment  string
+	Trigger     Trigger
+	Script      []string
+	AfterScript []string
+	Artifacts   []string
+}
+
+// Step is a single Bitbucket Pipelines step.
+type Step struct {
+	name        string
+	image       string
+	size        string
+	runsOn      []string
+	deployment  string
+	trigger     Trigger
+	script      []string
+	afterScript []string
+	artifacts   []string
+}
+
+func NewStep(opts StepOptions) (Step, error) {
+	if opts.Name == "" {
+		return Step{}, errors.New("bitbucket step name is required")
+	}
+	if len(opts.Script) == 0 {
+		return Step{}, fmt.Errorf("bitbucket step %q script is required", opts.Name)
+	}
+	switch opts.Trigger {
+	case "", TriggerAutomatic, TriggerManual:
+	default:
+		return Step{}, fmt.Errorf("bitbucket step %q has unsupported trigger %q", opts.Name, opts.Trigger)
+	}
+	return Step{
+		name:        opts.Name,
+		image:       opts.Image,
+		size:        opts.Size,
+		runsOn:      cloneStrings(opts.RunsOn),
+		deployment:  opts.Deployment,
+		trigger:     opts.Trigger,
+		script:      cloneStrings(opts.Script),
+		afterScript: cloneStrings(opts.AfterScript),
+		artifacts:   cloneStrings(opts.Artifacts),
+	}, nil
+}
+
+func (s Step) Name() string { return s.name }
+
+func (s Step) Image() string { return s.image }
+
+func (s Step) Size() string { return s.size }
+
+func (s Step) RunsOn() []string { return cloneStrings(s.runsOn) }
+
+func (s Step) Deployment() string { return s.deployment }
+
+func (s Step) Trigger() Trigger { return s.trigger }
+
+// Manual reports whether the step waits for a user to start it.
+func (s Step) Manual() bool { return s.trigger == TriggerManual }
+
+func (s Step) Script() []string { return cloneStrings(s.script) }
+
+func (s Step) AfterScript() []string { return cloneStrings(s.afterScript) }
+
+func (s Step) Artifacts() []string { return cloneStrings(s.artifacts) }
+
+func (s Step) clone() Step {
+	out := s
+	out.runsOn = cloneStrings(s.runsOn)
+	out.script = cloneStrings(s.script)
+	out.afterScript = cloneStrings(s.afterScript)
+	out.artifacts = cloneStrings(s.artifacts)
+	return out
+}
+
+func cloneStrings(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	return append([]string(nil), in...)
+}
+
+func cloneSteps(in []Step) []Step {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Step, len(in))
+	for i := range in {
+		out[i] = in[i].clone()
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+
+	"go.yaml.in/yaml/v4"
+)
+
+func (p *Pipeline) ToYAML() ([]byte, error) {
+	type pipelinesYAML struct {
+		Default []stepGroup `yaml:"default"`
+	}
+	type pipelineYAML struct {
+		Image     string        `yaml:"image,omitempty"`
+		Pipelines pipelinesYAML `yaml:"pipelines"`
+	}
+	payload := pipelineYAML{Pipelines: pipelinesYAML{Default: []stepGroup{}}}
+	if p != nil {
+		payload.Image = p.image
+		for i := range p.groups {
+			payload.Pipelines.Default = append(payload.Pipelines.Default, p.groups[i].clone())
+		}
+	}
+	data, err := yaml.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bitbucket pipeline: %w", err)
+	}
+
+	header := []byte("# Generated by terraci — do not edit\n")
+	return append(header, data...), nil
+}
+
+type stepEntry struct {
+	Step Step `yaml:"step"`
+}
+
+type parallelEntry struct {
+	Parallel struct {
+		Steps []stepEntry `yaml:"steps"`
+	} `yaml:"parallel"`
+}
+
+func (g stepGroup) MarshalYAML() (any, error) {
+	if !g.parallel {
+		return stepEntry{Step: g.steps[0].clone()}, nil
+	}
+	var entry parallelEntry
+	for _, step := range g.steps {
+		entry.Parallel.Steps = append(entry.Parallel.Steps, stepEntry{Step: step.clone()})
+	}
+	return entry, nil
+}
+
+func (s Step) MarshalYAML() (any, error) {
+	return struct {
+		Name        string   `yaml:"name"`
+		Image       string   `yaml:"image,omitempty"`
+		Size        string   `yaml:"size,omitempty"`
+		RunsOn      []string `yaml:"runs-on,omitempty"`
+		Deployment  string   `yaml:"deployment,omitempty"`
+		Trigger     Trigger  `yaml:"trigger,omitempty"`
+		Script      []string `yaml:"script"`
+		AfterScript []string `yaml:"after-script,omitempty"`
+		Artifacts   []string `yaml:"artifacts,omitempty"`
+	}{
+		Name:        s.name,
+		Image:       s.image,
+		Size:        s.size,
+		RunsOn:      cloneStrings(s.runsOn),
+		Deployment:  s.deployment,
+		Trigger:     s.trigger,
+		Script:      cloneStrings(s.script),
+		AfterScript: cloneStrings(s.afterScript),
+		Artifacts:   cloneStrings(s.artifacts),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineToYAML(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{Image: "hashicorp/terraform:1.6"})
+	plan, err := NewStep(StepOptions{
+		Name:      "plan-vpc",
+		Script:    []string{"cd vpc", "terraform plan -out=plan.tfplan"},
+		Artifacts: []string{"vpc/plan.tfplan"},
+	})
+	if err != nil {
+		t.Fatalf("NewStep() error = %v", err)
+	}
+	mustAddParallel(t, builder, plan, mustStep(t, "plan-eks", ""))
+	apply, err := NewStep(StepOptions{
+		Name:       "apply-vpc",
+		Trigger:    TriggerManual,
+		Deployment: "production",
+		Script:     []string{"terraform apply plan.tfplan"},
+	})
+	if err != nil {
+		t.Fatalf("NewStep() error = %v", err)
+	}
+	mustAddParallel(t, builder, apply)
+
+	data, err := mustBuildPipeline(t, builder).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"# Generated by terraci",
+		"image: hashicorp/terraform:1.6",
+		"pipelines:\n    default:\n        - parallel:\n            steps:\n                - step:\n                    name: plan-vpc",
+		"artifacts:\n                        - vpc/plan.tfplan",
+		"        - step:\n            name: apply-vpc",
+		"deployment: production",
+		"trigger: manual",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ToYAML() missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestEmptyPipelineToYAML(t *testing.T) {
+	t.Parallel()
+
+	data, err := EmptyPipeline().ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(data), "default: []") {
+		t.Fatalf("ToYAML() = %s, want empty default step list", data)
+	}
+}
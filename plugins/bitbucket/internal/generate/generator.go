@@ -0,0 +1,83 @@
+package generate
+
+import (
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/domain"
+)
+
+// Generator transforms a pipeline IR into a Bitbucket Pipelines definition.
+// The IR is bound at construction time.
+type Generator struct {
+	settings settings
+	ir       *pipeline.IR
+}
+
+// NewGenerator creates a new Bitbucket Pipelines generator bound to the
+// supplied IR.
+func NewGenerator(cfg *configpkg.Config, ir *pipeline.IR) *Generator {
+	return &Generator{
+		settings: newSettings(cfg),
+		ir:       ir,
+	}
+}
+
+func (g *Generator) Generate() (pipeline.GeneratedPipeline, error) {
+	if g.ir == nil {
+		return domainpkg.EmptyPipeline(), nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.transform(g.ir)
+}
+
+func (g *Generator) DryRun() (*pipeline.DryRunResult, error) {
+	if g.ir == nil {
+		return &pipeline.DryRunResult{}, nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.ir.DryRun(g.ir.ModuleCount()), nil
+}
+
+func (g *Generator) transform(ir *pipeline.IR) (*domainpkg.Pipeline, error) {
+	jobs := ir.Jobs()
+	profiles := make(map[string]jobProfile, len(jobs))
+	for i := range jobs {
+		profile, err := g.settings.jobProfile(jobOverwriteType(jobs[i]))
+		if err != nil {
+			return nil, err
+		}
+		if jobs[i].RequiresApproval() {
+			profile.trigger = domainpkg.TriggerManual
+		}
+		profiles[jobs[i].Name()] = profile
+	}
+
+	levels, err := collapseLevels(ir, func(job pipeline.Job) bool {
+		return profiles[job.Name()].manual()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := domainpkg.NewPipelineBuilder(domainpkg.PipelineOptions{Image: g.settings.image()})
+	builder := newStepBuilder(g.settings, ir.AlwaysRunDependencies())
+	for _, level := range levels {
+		steps := make([]domainpkg.Step, 0, len(level.jobs))
+		for i := range level.jobs {
+			step, err := builder.renderStep(level.jobs[i], profiles[level.jobs[i].Name()])
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+		if err := out.AddParallel(steps...); err != nil {
+			return nil, err
+		}
+	}
+
+	return out.Build()
+}
@@ -0,0 +1,121 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+)
+
+func testContribution(tb testing.TB, opts ...pipeline.ContributedJobOptions) *pipeline.Contribution {
+	tb.Helper()
+	jobs := make([]pipeline.ContributedJob, 0, len(opts))
+	for _, opt := range opts {
+		job, err := pipeline.NewContributedJob(opt)
+		if err != nil {
+			tb.Fatalf("NewContributedJob() error = %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	contribution, err := pipeline.NewContribution(jobs...)
+	if err != nil {
+		tb.Fatalf("NewContribution() error = %v", err)
+	}
+	return contribution
+}
+
+func testContributionSet(tb testing.TB, contributions ...*pipeline.Contribution) pipeline.ContributionSet {
+	tb.Helper()
+	set, err := pipeline.NewContributionSet(contributions...)
+	if err != nil {
+		tb.Fatalf("NewContributionSet() error = %v", err)
+	}
+	return set
+}
+
+func TestGenerate_ContributedJobRunsBeforeManualApply(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "cost-estimation",
+			Commands: []string{"terraci cost"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			Produces: []pipeline.ResourceSpec{
+				pipeline.PluginResource(pipeline.ResourceKindPluginResult, "cost", ".terraci/cost-results.json"),
+				pipeline.PluginResource(pipeline.ResourceKindPluginReport, "cost", ".terraci/cost-report.json"),
+			},
+			AllowFailure: true,
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		group(0, "plan-platform-stage-eu-central-1-vpc").
+		group(1, "cost-estimation").
+		group(2, "apply-platform-stage-eu-central-1-vpc")
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		hasArtifact("platform/stage/eu-central-1/vpc/plan.json")
+	assertPipeline(t, out).
+		step("cost-estimation").
+		automatic().
+		scriptContains("terraci cost").
+		hasArtifact(".terraci/cost-results.json").
+		hasArtifact(".terraci/cost-report.json")
+}
+
+func TestGenerate_ContributedJobOverwriteByName(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:    "terraci-summary",
+				Trigger: configpkg.TriggerManual,
+			}}
+		}).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "terraci-summary",
+			Commands: []string{"terraci summary"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		groupCount(2).
+		group(1, "apply-platform-stage-eu-central-1-vpc", "terraci-summary").
+		step("terraci-summary").
+		manual()
+}
+
+func TestGenerate_AlwaysRunContributionRunsAfterFailures(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "drift-collector",
+			Commands: []string{"terraci drift"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			AlwaysRun: true,
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		withPlanOnly().
+		generate()
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		scriptContains("sh -ec ").
+		scriptContains("|| echo 'plan-platform-stage-eu-central-1-vpc failed; continuing so dependent steps run'")
+	assertPipeline(t, out).
+		step("drift-collector").
+		scriptContains("terraci drift")
+}
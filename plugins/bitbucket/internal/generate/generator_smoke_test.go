@@ -0,0 +1,230 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/domain"
+)
+
+func TestGenerate_SingleModule(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stepCount(2).
+		groupCount(2).
+		group(0, "plan-platform-stage-eu-central-1-vpc").
+		group(1, "apply-platform-stage-eu-central-1-vpc")
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		automatic().
+		scriptContains(`export TF_MODULE="vpc"`).
+		scriptContains("terraform init").
+		scriptContains("terraform plan").
+		hasArtifact("platform/stage/eu-central-1/vpc/plan.tfplan")
+
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-vpc").
+		manual().
+		scriptContains("terraform apply").
+		noArtifacts()
+}
+
+func TestGenerate_RejectsInvalidIR(t *testing.T) {
+	t.Parallel()
+
+	generated, err := NewGenerator(nil, nil).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	out, ok := generated.(*domainpkg.Pipeline)
+	if !ok || out.StepCount() != 0 {
+		t.Fatalf("Generate() = %#v, want empty pipeline", generated)
+	}
+}
+
+func TestGenerate_ParallelLevels(t *testing.T) {
+	vpc := createTestModule("vpc")
+	dns := createTestModule("dns")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withModules(vpc, dns, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			dns.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		group(0, "plan-platform-stage-eu-central-1-dns", "plan-platform-stage-eu-central-1-vpc").
+		group(1, "apply-platform-stage-eu-central-1-dns", "apply-platform-stage-eu-central-1-vpc")
+
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-eks").
+		runsAfter("apply-platform-stage-eu-central-1-vpc").
+		runsAfter("plan-platform-stage-eu-central-1-eks")
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-eks").
+		runsAfter("apply-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_PlanOnly(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withPlanOnly().
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		stepCount(2).
+		noStep("apply-platform-stage-eu-central-1-vpc").
+		step("plan-platform-stage-eu-central-1-eks").
+		automatic().
+		runsAfter("plan-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_AutomaticApplyFromOverwrite(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:       configpkg.OverwriteTypeApply,
+				Trigger:    configpkg.TriggerAutomatic,
+				Deployment: "production",
+			}}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-vpc").
+		automatic().
+		deployment("production")
+}
+
+func TestGenerate_ApprovalRuleKeepsApplyManual(t *testing.T) {
+	ir := pipelinetest.MustGatedSingleModuleIR(t, citest.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	cfg := &configpkg.Config{Overwrites: []configpkg.JobOverwrite{{
+		Type:    configpkg.OverwriteTypeApply,
+		Trigger: configpkg.TriggerAutomatic,
+	}}}
+	result, err := NewGenerator(cfg, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	out, ok := result.(*domainpkg.Pipeline)
+	if !ok {
+		t.Fatalf("Generate() = %T, want *domain.Pipeline", result)
+	}
+
+	assertPipeline(t, out).
+		step("plan-platform-prod-eu-central-1-vpc").
+		automatic()
+	assertPipeline(t, out).
+		step("apply-platform-prod-eu-central-1-vpc").
+		manual()
+}
+
+func TestGenerate_ManualFirstStepIsRejected(t *testing.T) {
+	module := createTestModule("vpc")
+	scenario := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{Type: configpkg.OverwriteTypePlan, Trigger: configpkg.TriggerManual}}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}})
+
+	if _, err := scenario.generator().Generate(); err == nil {
+		t.Fatal("Generate() error = nil, want manual first step error")
+	}
+}
+
+func TestGenerate_CustomBinary(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withTerraformConfig(func(cfg *pipeline.TerraformJobConfigOptions) { cfg.Binary = "tofu" }).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		scriptContains("tofu plan")
+}
+
+func TestGenerate_JobDefaultsAndOverwrites(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Variables = map[string]string{"TF_IN_AUTOMATION": "true", "SHARED": "pipeline"}
+			cfg.JobDefaults = &configpkg.JobDefaults{
+				Variables:    map[string]string{"SHARED": "default"},
+				BeforeScript: []string{"aws sts get-caller-identity"},
+				AfterScript:  []string{"echo done"},
+			}
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:  configpkg.OverwriteTypePlan,
+				Image: &configpkg.Image{Name: "ghcr.io/opentofu/opentofu:1.6"},
+			}}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		image("ghcr.io/opentofu/opentofu:1.6").
+		scriptContains(`export TF_IN_AUTOMATION="true"`).
+		scriptContains(`export SHARED="default"`).
+		scriptLineBefore("export SHARED", "aws sts get-caller-identity").
+		scriptLineBefore("aws sts get-caller-identity", "terraform plan").
+		afterScript("echo done")
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-vpc").
+		image("")
+}
+
+func TestExportLines(t *testing.T) {
+	t.Parallel()
+
+	got := exportLines(map[string]string{"B": `say "hi"`, "A": "$BITBUCKET_BRANCH"})
+	want := []string{`export A="$BITBUCKET_BRANCH"`, `export B="say \"hi\""`}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("exportLines() = %v, want %v", got, want)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	result := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		dryRun()
+
+	citest.AssertDryRun(t, result, citest.DryRunExpectation{
+		TotalModules:    2,
+		AffectedModules: 2,
+		Jobs:            4,
+		Stages:          4,
+		JobGroups:       4,
+	})
+}
@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/cishell"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/domain"
+)
+
+type stepBuilder struct {
+	settings settings
+	// continueOnFailure holds the jobs whose failure must not stop later
+	// steps.
+	continueOnFailure map[string]bool
+}
+
+func newStepBuilder(settings settings, continueOnFailure map[string]bool) stepBuilder {
+	return stepBuilder{settings: settings, continueOnFailure: continueOnFailure}
+}
+
+// renderStep converts one IR job into a Bitbucket step. Bitbucket steps have
+// no per-step environment, so job variables are exported at the top of the
+// script. Output artifacts are declared on the producing step; Bitbucket
+// restores them automatically in every later step.
+func (b stepBuilder) renderStep(irJob pipeline.Job, profile jobProfile) (domainpkg.Step, error) {
+	script := exportLines(mergeJobVariables(b.settings.variables(), irJob.Env(), profile.variables))
+	script = append(script, profile.beforeScript...)
+	operation := cishell.RenderOperation(irJob.Operation())
+	if b.continueOnFailure[irJob.Name()] {
+		operation = tolerateFailure(irJob.Name(), operation)
+	}
+	script = append(script, operation...)
+
+	var artifacts []string
+	if output := irJob.OutputArtifact(); output.Configured() {
+		artifacts = append(artifacts, output.Paths...)
+	}
+
+	return domainpkg.NewStep(domainpkg.StepOptions{
+		Name:        irJob.Name(),
+		Image:       profile.image,
+		Size:        profile.size,
+		RunsOn:      profile.runsOn,
+		Deployment:  profile.deployment,
+		Trigger:     profile.trigger,
+		Script:      script,
+		AfterScript: profile.afterScript,
+		Artifacts:   artifacts,
+	})
+}
+
+// tolerateFailure runs script in a strict child shell and turns its failure
+// into a log line.
+func tolerateFailure(name string, script []string) []string {
+	return []string{
+		"sh -ec " + shellQuote(strings.Join(script, "\n")) + " || echo " + shellQuote(name+" failed; continuing so dependent steps run"),
+	}
+}
+
+func jobOverwriteType(irJob pipeline.Job) configpkg.JobOverwriteType {
+	switch irJob.Operation().Type() {
+	case pipeline.OperationTypeTerraformPlan:
+		return configpkg.OverwriteTypePlan
+	case pipeline.OperationTypeTerraformApply:
+		return configpkg.OverwriteTypeApply
+	case pipeline.OperationTypeCommands:
+		return configpkg.JobOverwriteType(irJob.Name())
+	default:
+		return ""
+	}
+}
+
+// exportLines renders variables as sorted shell exports. Values are double
+// quoted so references to Bitbucket variables still expand.
+func exportLines(variables map[string]string) []string {
+	if len(variables) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, "export "+key+"="+doubleQuote(variables[key]))
+	}
+	return lines
+}
+
+func doubleQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`")
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,128 @@
+package generate
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/config/overwrite"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/domain"
+)
+
+type jobProfile struct {
+	image        string
+	size         string
+	runsOn       []string
+	deployment   string
+	trigger      domainpkg.Trigger
+	variables    map[string]string
+	beforeScript []string
+	afterScript  []string
+}
+
+// manual reports whether steps rendered from this profile wait for a user.
+func (p jobProfile) manual() bool {
+	return p.trigger == domainpkg.TriggerManual
+}
+
+// jobProfile resolves step settings for jobType. Apply steps start manual so
+// that nothing changes infrastructure without a click; job_defaults and
+// overwrites may still switch them to automatic.
+func (s settings) jobProfile(jobType configpkg.JobOverwriteType) (jobProfile, error) {
+	cfg := s.configOrDefault()
+	profile := jobProfile{size: string(cfg.Size)}
+	if jobType == configpkg.OverwriteTypeApply {
+		profile.trigger = domainpkg.TriggerManual
+	}
+
+	if cfg.JobDefaults != nil {
+		applyJobDefaults(&profile, cfg.JobDefaults)
+	}
+
+	err := overwrite.ApplyMatching(
+		&profile,
+		jobType,
+		cfg.Overwrites,
+		overwrite.ByKey(func(ow *configpkg.JobOverwrite) configpkg.JobOverwriteType { return ow.Type }),
+		applyJobOverwrite,
+	)
+	if err != nil {
+		return jobProfile{}, err
+	}
+	return profile, nil
+}
+
+func applyJobDefaults(profile *jobProfile, defaults *configpkg.JobDefaults) {
+	if defaults.Image != nil {
+		profile.image = defaults.Image.Name
+	}
+	if defaults.Size != "" {
+		profile.size = string(defaults.Size)
+	}
+	if len(defaults.RunsOn) > 0 {
+		profile.runsOn = append([]string(nil), defaults.RunsOn...)
+	}
+	if defaults.Deployment != "" {
+		profile.deployment = defaults.Deployment
+	}
+	if defaults.Trigger != "" {
+		profile.trigger = convertTrigger(defaults.Trigger)
+	}
+	mergeProfileVariables(profile, defaults.Variables)
+	profile.beforeScript = append(profile.beforeScript, defaults.BeforeScript...)
+	profile.afterScript = append(profile.afterScript, defaults.AfterScript...)
+}
+
+func applyJobOverwrite(profile *jobProfile, ow *configpkg.JobOverwrite) {
+	if ow.Image != nil {
+		profile.image = ow.Image.Name
+	}
+	if ow.Size != "" {
+		profile.size = string(ow.Size)
+	}
+	if len(ow.RunsOn) > 0 {
+		profile.runsOn = append([]string(nil), ow.RunsOn...)
+	}
+	if ow.Deployment != "" {
+		profile.deployment = ow.Deployment
+	}
+	if ow.Trigger != "" {
+		profile.trigger = convertTrigger(ow.Trigger)
+	}
+	mergeProfileVariables(profile, ow.Variables)
+	profile.beforeScript = append(profile.beforeScript, ow.BeforeScript...)
+	profile.afterScript = append(profile.afterScript, ow.AfterScript...)
+}
+
+// convertTrigger maps the config trigger onto the domain. Automatic is the
+// Bitbucket default, so it is rendered by omitting the key.
+func convertTrigger(trigger configpkg.Trigger) domainpkg.Trigger {
+	if trigger == configpkg.TriggerManual {
+		return domainpkg.TriggerManual
+	}
+	return ""
+}
+
+func mergeProfileVariables(profile *jobProfile, variables map[string]string) {
+	if len(variables) == 0 {
+		return
+	}
+	if profile.variables == nil {
+		profile.variables = make(map[string]string, len(variables))
+	}
+	maps.Copy(profile.variables, variables)
+}
+
+// mergeJobVariables layers variable maps; later layers win.
+func mergeJobVariables(layers ...map[string]string) map[string]string {
+	var result map[string]string
+	for _, layer := range layers {
+		if len(layer) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		maps.Copy(result, layer)
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	domainpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/domain"
+)
+
+type pipelineAssert struct {
+	t        *testing.T
+	pipeline *domainpkg.Pipeline
+}
+
+func assertPipeline(t *testing.T, pipeline *domainpkg.Pipeline) *pipelineAssert {
+	t.Helper()
+	return &pipelineAssert{t: t, pipeline: pipeline}
+}
+
+func (a *pipelineAssert) stepCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.StepCount(); got != expected {
+		a.t.Fatalf("expected %d steps, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) groupCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.GroupCount(); got != expected {
+		a.t.Fatalf("expected %d step groups, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) group(index int, expected ...string) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.GroupSteps(index); !slices.Equal(got, expected) {
+		a.t.Fatalf("expected group %d steps %v, got %v", index, expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) hasStep(name string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Step(name); !ok {
+		a.t.Fatalf("expected step %q to exist", name)
+	}
+	return a
+}
+
+func (a *pipelineAssert) noStep(name string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Step(name); ok {
+		a.t.Fatalf("expected step %q to not exist", name)
+	}
+	return a
+}
+
+func (a *pipelineAssert) step(name string) *stepAssert {
+	a.t.Helper()
+	step, ok := a.pipeline.Step(name)
+	if !ok {
+		a.t.Fatalf("expected step %q to exist", name)
+	}
+	return &stepAssert{t: a.t, name: name, pipeline: a.pipeline, step: step}
+}
+
+type stepAssert struct {
+	t        *testing.T
+	name     string
+	pipeline *domainpkg.Pipeline
+	step     domainpkg.Step
+}
+
+func (a *stepAssert) runsAfter(dependency string) *stepAssert {
+	a.t.Helper()
+	if !a.pipeline.StepRunsAfter(a.name, dependency) {
+		a.t.Fatalf("expected step %q to run after %q", a.name, dependency)
+	}
+	return a
+}
+
+func (a *stepAssert) manual() *stepAssert {
+	a.t.Helper()
+	if !a.step.Manual() {
+		a.t.Fatalf("expected step %q to be manual", a.name)
+	}
+	return a
+}
+
+func (a *stepAssert) automatic() *stepAssert {
+	a.t.Helper()
+	if a.step.Manual() {
+		a.t.Fatalf("expected step %q to be automatic", a.name)
+	}
+	return a
+}
+
+func (a *stepAssert) image(expected string) *stepAssert {
+	a.t.Helper()
+	if a.step.Image() != expected {
+		a.t.Fatalf("expected step %q image=%q, got %q", a.name, expected, a.step.Image())
+	}
+	return a
+}
+
+func (a *stepAssert) deployment(expected string) *stepAssert {
+	a.t.Helper()
+	if a.step.Deployment() != expected {
+		a.t.Fatalf("expected step %q deployment=%q, got %q", a.name, expected, a.step.Deployment())
+	}
+	return a
+}
+
+func (a *stepAssert) scriptContains(fragment string) *stepAssert {
+	a.t.Helper()
+	for _, line := range a.step.Script() {
+		if strings.Contains(line, fragment) {
+			return a
+		}
+	}
+	a.t.Fatalf("expected step %q script to contain %q, got %v", a.name, fragment, a.step.Script())
+	return a
+}
+
+func (a *stepAssert) scriptLineBefore(first, second string) *stepAssert {
+	a.t.Helper()
+	script := a.step.Script()
+	firstIdx := slices.IndexFunc(script, func(line string) bool { return strings.Contains(line, first) })
+	secondIdx := slices.IndexFunc(script, func(line string) bool { return strings.Contains(line, second) })
+	if firstIdx == -1 || secondIdx == -1 || firstIdx >= secondIdx {
+		a.t.Fatalf("expected step %q script line %q before %q, got %v", a.name, first, second, script)
+	}
+	return a
+}
+
+func (a *stepAssert) afterScript(expected ...string) *stepAssert {
+	a.t.Helper()
+	if got := a.step.AfterScript(); !slices.Equal(got, expected) {
+		a.t.Fatalf("expected step %q after-script %v, got %v", a.name, expected, got)
+	}
+	return a
+}
+
+func (a *stepAssert) hasArtifact(path string) *stepAssert {
+	a.t.Helper()
+	if !slices.Contains(a.step.Artifacts(), path) {
+		a.t.Fatalf("expected step %q artifacts to contain %q, got %v", a.name, path, a.step.Artifacts())
+	}
+	return a
+}
+
+func (a *stepAssert) noArtifacts() *stepAssert {
+	a.t.Helper()
+	if got := a.step.Artifacts(); len(got) != 0 {
+		a.t.Fatalf("expected step %q to declare no artifacts, got %v", a.name, got)
+	}
+	return a
+}
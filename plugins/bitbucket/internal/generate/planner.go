@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+)
+
+// levelGroup is one sequential entry of the Bitbucket step list. Bitbucket
+// has no DAG edges between steps: entries run strictly in order, and the
+// jobs of one entry run in parallel.
+type levelGroup struct {
+	jobs   []pipeline.Job
+	manual bool
+}
+
+// collapseLevels flattens the IR DAG into ordered level groups. Every
+// scheduler barrier becomes one group; a barrier that mixes manual and
+// automatic jobs is split so automatic jobs run first, because a parallel
+// group shares a single trigger. Jobs within a barrier are independent, so
+// splitting never reorders a dependent before its producer.
+func collapseLevels(ir *pipeline.IR, manual func(pipeline.Job) bool) ([]levelGroup, error) {
+	barriers, err := pipeline.Schedule(ir)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]levelGroup, 0, len(barriers))
+	for _, barrier := range barriers {
+		var automaticJobs, manualJobs []pipeline.Job
+		for _, job := range barrier.Jobs() {
+			if manual(job) {
+				manualJobs = append(manualJobs, job)
+			} else {
+				automaticJobs = append(automaticJobs, job)
+			}
+		}
+		if len(automaticJobs) > 0 {
+			levels = append(levels, levelGroup{jobs: automaticJobs})
+		}
+		if len(manualJobs) > 0 {
+			levels = append(levels, levelGroup{jobs: manualJobs, manual: true})
+		}
+	}
+
+	if err := verifyLevelOrder(levels); err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+// verifyLevelOrder guarantees that every dependency lives in a strictly
+// earlier group than its dependent.
+func verifyLevelOrder(levels []levelGroup) error {
+	position := make(map[string]int)
+	for i, level := range levels {
+		for j := range level.jobs {
+			position[level.jobs[j].Name()] = i
+		}
+	}
+	for i, level := range levels {
+		for j := range level.jobs {
+			for _, dep := range level.jobs[j].Dependencies() {
+				at, ok := position[dep.Job]
+				if !ok {
+					return fmt.Errorf("job %q depends on unscheduled job %q", level.jobs[j].Name(), dep.Job)
+				}
+				if at >= i {
+					return fmt.Errorf("job %q would run before or alongside its dependency %q", level.jobs[j].Name(), dep.Job)
+				}
+			}
+		}
+	}
+	return nil
+}
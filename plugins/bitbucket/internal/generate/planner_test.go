@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/pipeline"
+)
+
+func isApplyJob(job pipeline.Job) bool {
+	return job.Kind() == pipeline.JobKindApply
+}
+
+func TestCollapseLevels_DependentsRunAfterProducers(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	rds := createTestModule("rds")
+	app := createTestModule("app")
+	dns := createTestModule("dns")
+
+	tests := []struct {
+		name    string
+		modules []*discovery.Module
+		deps    map[string][]string
+		apply   bool
+	}{
+		{
+			name:    "chain",
+			modules: []*discovery.Module{vpc, eks, app},
+			deps:    map[string][]string{eks.ID(): {vpc.ID()}, app.ID(): {eks.ID()}},
+			apply:   true,
+		},
+		{
+			name:    "diamond",
+			modules: []*discovery.Module{vpc, eks, rds, app},
+			deps:    map[string][]string{eks.ID(): {vpc.ID()}, rds.ID(): {vpc.ID()}, app.ID(): {eks.ID(), rds.ID()}},
+			apply:   true,
+		},
+		{
+			name:    "independent roots",
+			modules: []*discovery.Module{vpc, dns, app},
+			deps:    map[string][]string{app.ID(): {vpc.ID()}},
+			apply:   true,
+		},
+		{
+			name:    "plan only chain",
+			modules: []*discovery.Module{vpc, eks, app},
+			deps:    map[string][]string{eks.ID(): {vpc.ID()}, app.ID(): {eks.ID()}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			ir := mustBuildIRWithApply(t, cfg.Bitbucket, cfg.Terraform, pipeline.EmptyContributionSet(),
+				citest.DependencyGraph(tt.modules, tt.deps), tt.modules, tt.modules, tt.apply)
+
+			levels, err := collapseLevels(ir, isApplyJob)
+			if err != nil {
+				t.Fatalf("collapseLevels() error = %v", err)
+			}
+
+			position := make(map[string]int)
+			scheduled := 0
+			for i, level := range levels {
+				if len(level.jobs) == 0 {
+					t.Fatalf("level %d is empty", i)
+				}
+				for _, job := range level.jobs {
+					if level.manual != isApplyJob(job) {
+						t.Fatalf("level %d (manual=%v) contains job %q with mismatched trigger", i, level.manual, job.Name())
+					}
+					position[job.Name()] = i
+					scheduled++
+				}
+			}
+			if scheduled != len(ir.Jobs()) {
+				t.Fatalf("scheduled %d jobs, IR has %d", scheduled, len(ir.Jobs()))
+			}
+			for _, job := range ir.Jobs() {
+				for _, dep := range job.Dependencies() {
+					if position[dep.Job] >= position[job.Name()] {
+						t.Fatalf("job %q (level %d) does not run after dependency %q (level %d)",
+							job.Name(), position[job.Name()], dep.Job, position[dep.Job])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCollapseLevels_SplitsMixedTriggerBarrier(t *testing.T) {
+	vpc := createTestModule("vpc")
+	cfg := createTestConfig()
+	contributions := testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+		Name:     "terraci-summary",
+		Commands: []string{"terraci summary"},
+		Consumes: []pipeline.ResourceRequest{pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON)},
+	}))
+	modules := []*discovery.Module{vpc}
+	ir := mustBuildIRWithApply(t, cfg.Bitbucket, cfg.Terraform, contributions,
+		citest.DependencyGraph(modules, nil), modules, modules, true)
+
+	levels, err := collapseLevels(ir, isApplyJob)
+	if err != nil {
+		t.Fatalf("collapseLevels() error = %v", err)
+	}
+	if len(levels) != 3 {
+		t.Fatalf("collapseLevels() returned %d levels, want 3", len(levels))
+	}
+	if levels[1].manual || levels[1].jobs[0].Name() != "terraci-summary" {
+		t.Fatalf("level 1 = %q (manual=%v), want automatic terraci-summary", levels[1].jobs[0].Name(), levels[1].manual)
+	}
+	if !levels[2].manual || levels[2].jobs[0].Name() != "apply-platform-stage-eu-central-1-vpc" {
+		t.Fatalf("level 2 = %q (manual=%v), want manual apply", levels[2].jobs[0].Name(), levels[2].manual)
+	}
+}
+
+func TestVerifyLevelOrder_RejectsSameLevelDependency(t *testing.T) {
+	vpc := createTestModule("vpc")
+	cfg := createTestConfig()
+	modules := []*discovery.Module{vpc}
+	ir := mustBuildIRWithApply(t, cfg.Bitbucket, cfg.Terraform, pipeline.EmptyContributionSet(),
+		citest.DependencyGraph(modules, nil), modules, modules, true)
+
+	if err := verifyLevelOrder([]levelGroup{{jobs: ir.Jobs()}}); err == nil {
+		t.Fatal("verifyLevelOrder() error = nil, want ordering error")
+	}
+}
@@ -0,0 +1,121 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/domain"
+)
+
+func createTestModule(module string) *discovery.Module {
+	return citest.TestModule("platform", "stage", "eu-central-1", module)
+}
+
+type testCfg struct {
+	Bitbucket     *configpkg.Config
+	Terraform     pipeline.TerraformJobConfigOptions
+	Contributions pipeline.ContributionSet
+}
+
+func createTestConfig() *testCfg {
+	return &testCfg{
+		Bitbucket: &configpkg.Config{
+			Image: &configpkg.Image{Name: "hashicorp/terraform:1.6"},
+		},
+		Terraform: defaultTerraformConfigOptions(),
+	}
+}
+
+type generatorScenario struct {
+	t             *testing.T
+	cfg           *testCfg
+	modules       []*discovery.Module
+	dependencies  map[string][]string
+	targetModules []*discovery.Module
+	applyEnabled  bool
+}
+
+func newGeneratorScenario(t *testing.T) *generatorScenario {
+	t.Helper()
+	return &generatorScenario{
+		t:            t,
+		cfg:          createTestConfig(),
+		applyEnabled: true,
+	}
+}
+
+func (s *generatorScenario) withConfig(apply func(*configpkg.Config)) *generatorScenario {
+	s.t.Helper()
+	apply(s.cfg.Bitbucket)
+	return s
+}
+
+func (s *generatorScenario) withContributions(contributions pipeline.ContributionSet) *generatorScenario {
+	s.t.Helper()
+	s.cfg.Contributions = contributions
+	return s
+}
+
+func (s *generatorScenario) withTerraformConfig(apply func(*pipeline.TerraformJobConfigOptions)) *generatorScenario {
+	s.t.Helper()
+	opts := s.cfg.Terraform
+	apply(&opts)
+	s.cfg.Terraform = opts
+	return s
+}
+
+func (s *generatorScenario) withModules(modules ...*discovery.Module) *generatorScenario {
+	s.t.Helper()
+	s.modules = modules
+	return s
+}
+
+func (s *generatorScenario) withDependencies(deps map[string][]string) *generatorScenario {
+	s.t.Helper()
+	s.dependencies = deps
+	return s
+}
+
+func (s *generatorScenario) withPlanOnly() *generatorScenario {
+	s.t.Helper()
+	s.applyEnabled = false
+	return s
+}
+
+func (s *generatorScenario) generator() *Generator {
+	s.t.Helper()
+	depGraph := citest.DependencyGraph(s.modules, s.dependencies)
+	return newTestGeneratorWithTargetsAndApply(s.t, s.cfg.Bitbucket, s.cfg.Terraform, s.cfg.Contributions, depGraph, s.modules, s.generateTargets(), s.applyEnabled)
+}
+
+func (s *generatorScenario) generate() *domainpkg.Pipeline {
+	s.t.Helper()
+	result, err := s.generator().Generate()
+	if err != nil {
+		s.t.Fatalf("Generate failed: %v", err)
+	}
+	out, ok := result.(*domainpkg.Pipeline)
+	if !ok {
+		s.t.Fatal("expected *Pipeline type")
+	}
+	return out
+}
+
+func (s *generatorScenario) dryRun() *pipeline.DryRunResult {
+	s.t.Helper()
+	result, err := s.generator().DryRun()
+	if err != nil {
+		s.t.Fatalf("DryRun failed: %v", err)
+	}
+	return result
+}
+
+func (s *generatorScenario) generateTargets() []*discovery.Module {
+	if s.targetModules != nil {
+		return s.targetModules
+	}
+	return s.modules
+}
@@ -0,0 +1,35 @@
+package generate
+
+import (
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+)
+
+const defaultImage = "hashicorp/terraform:1.6"
+
+type settings struct {
+	config *configpkg.Config
+}
+
+func newSettings(cfg *configpkg.Config) settings {
+	return settings{config: cfg}
+}
+
+func (s settings) configOrDefault() *configpkg.Config {
+	if s.config == nil {
+		return &configpkg.Config{
+			Image: &configpkg.Image{Name: defaultImage},
+		}
+	}
+	return s.config
+}
+
+func (s settings) image() string {
+	if img := s.configOrDefault().Image; img != nil {
+		return img.Name
+	}
+	return ""
+}
+
+func (s settings) variables() map[string]string {
+	return s.configOrDefault().Variables
+}
@@ -0,0 +1,32 @@
+// Package bitbucket provides the Bitbucket Pipelines plugin for TerraCi.
+// It registers a pipeline generator.
+package bitbucket
+
+import (
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/registry"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+)
+
+// pluginName is the canonical provider name of the Bitbucket Pipelines plugin.
+const pluginName = "bitbucket"
+
+func init() {
+	registry.RegisterFactory(func() plugin.Plugin {
+		return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+			PluginName: pluginName,
+			PluginDesc: "Bitbucket Pipelines generation",
+			EnableMode: plugin.EnabledWhenConfigured,
+			DefaultCfg: func() *configpkg.Config {
+				return &configpkg.Config{
+					Image: &configpkg.Image{Name: defaultTerraformImage},
+				}
+			},
+		}}
+	})
+}
+
+// Plugin is the Bitbucket Pipelines plugin.
+type Plugin struct {
+	plugin.BasePlugin[*configpkg.Config]
+}
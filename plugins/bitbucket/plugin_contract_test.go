@@ -0,0 +1,161 @@
+package bitbucket
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	"github.com/edelwud/terraci/pkg/plugin/plugintest"
+	configpkg "github.com/edelwud/terraci/plugins/bitbucket/internal/config"
+)
+
+func TestPlugin_SDKContracts(t *testing.T) {
+	p := newContractPlugin()
+
+	t.Run("config", func(t *testing.T) {
+		plugintest.AssertBaseConfigPlugin[*configpkg.Config](t, plugintest.BaseConfigPluginContract[*configpkg.Config]{
+			Plugin:  p,
+			Default: &configpkg.Config{Image: &configpkg.Image{Name: defaultTerraformImage}},
+			Configured: &configpkg.Config{
+				Image:     &configpkg.Image{Name: "hashicorp/terraform:1.9"},
+				Size:      configpkg.StepSize2x,
+				Variables: map[string]string{"TF_INPUT": "false"},
+				JobDefaults: &configpkg.JobDefaults{
+					RunsOn:       []string{"self.hosted"},
+					Variables:    map[string]string{"DEFAULT": "true"},
+					BeforeScript: []string{"echo setup"},
+				},
+			},
+			Decoded: &configpkg.Config{
+				Image:     &configpkg.Image{Name: defaultTofuImage},
+				Variables: map[string]string{"DECODED": "true"},
+				Overwrites: []configpkg.JobOverwrite{{
+					Type:       configpkg.OverwriteTypeApply,
+					Deployment: "production",
+					Trigger:    configpkg.TriggerManual,
+				}},
+			},
+			Mutate: mutateBitbucketConfig,
+			Equal:  equalBitbucketConfig,
+		})
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		plugintest.AssertPreflightable(t, plugintest.PreflightableContract{
+			Plugin:     newContractPlugin(),
+			AppContext: plugintest.NewAppContext(t, t.TempDir()),
+		})
+	})
+
+	t.Run("init contributor", func(t *testing.T) {
+		state := initwiz.NewStateMap()
+		initwiz.ProviderKey.Set(state, pluginName)
+		plugintest.AssertInitContributor(t, plugintest.InitContributorContract{
+			Contributor:        newContractPlugin(),
+			State:              state,
+			ExpectedPluginKey:  pluginName,
+			ExpectContribution: true,
+			DecodeTarget:       &configpkg.Config{},
+		})
+	})
+
+	t.Run("ci provider", func(t *testing.T) {
+		t.Setenv("BITBUCKET_BUILD_NUMBER", "42")
+		p := newContractPlugin()
+		plugintest.AssertCIProvider(t, plugintest.CIProviderContract{
+			EnvDetector:  p,
+			InfoProvider: p,
+			Generator:    p,
+			AppContext:   plugintest.NewAppContext(t, t.TempDir()),
+			IR:           pipelinetest.MustCommandIR(t),
+			ExpectedName: pluginName,
+			AssertEnv: func(tb testing.TB, detected bool) {
+				tb.Helper()
+				if !detected {
+					tb.Fatal("DetectEnv() = false, want true")
+				}
+			},
+		})
+	})
+}
+
+func newContractPlugin() *Plugin {
+	return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+		PluginName: pluginName,
+		PluginDesc: "Bitbucket Pipelines generation",
+		EnableMode: plugin.EnabledWhenConfigured,
+		DefaultCfg: func() *configpkg.Config {
+			return &configpkg.Config{Image: &configpkg.Image{Name: defaultTerraformImage}}
+		},
+	}}
+}
+
+func mutateBitbucketConfig(c *configpkg.Config) {
+	if c == nil {
+		return
+	}
+	if c.Image != nil {
+		c.Image.Name = "mutated"
+	}
+	if c.Variables == nil {
+		c.Variables = map[string]string{}
+	}
+	c.Variables["MUTATED"] = "true"
+	if c.JobDefaults != nil {
+		c.JobDefaults.RunsOn = append(c.JobDefaults.RunsOn, "mutated")
+		if c.JobDefaults.Variables == nil {
+			c.JobDefaults.Variables = map[string]string{}
+		}
+		c.JobDefaults.Variables["MUTATED"] = "true"
+	}
+	for i := range c.Overwrites {
+		c.Overwrites[i].Deployment = "mutated"
+	}
+}
+
+func equalBitbucketConfig(got, want *configpkg.Config) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalBitbucketImage(got.Image, want.Image) &&
+		got.Size == want.Size &&
+		maps.Equal(got.Variables, want.Variables) &&
+		equalBitbucketDefaults(got.JobDefaults, want.JobDefaults) &&
+		slices.EqualFunc(got.Overwrites, want.Overwrites, equalBitbucketOverwrite)
+}
+
+func equalBitbucketImage(got, want *configpkg.Image) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Name == want.Name && slices.Equal(got.Entrypoint, want.Entrypoint)
+}
+
+func equalBitbucketDefaults(got, want *configpkg.JobDefaults) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalBitbucketImage(got.Image, want.Image) &&
+		got.Size == want.Size &&
+		slices.Equal(got.RunsOn, want.RunsOn) &&
+		got.Deployment == want.Deployment &&
+		got.Trigger == want.Trigger &&
+		maps.Equal(got.Variables, want.Variables) &&
+		slices.Equal(got.BeforeScript, want.BeforeScript) &&
+		slices.Equal(got.AfterScript, want.AfterScript)
+}
+
+func equalBitbucketOverwrite(got, want configpkg.JobOverwrite) bool {
+	return got.Type == want.Type &&
+		equalBitbucketImage(got.Image, want.Image) &&
+		got.Size == want.Size &&
+		slices.Equal(got.RunsOn, want.RunsOn) &&
+		got.Deployment == want.Deployment &&
+		got.Trigger == want.Trigger &&
+		maps.Equal(got.Variables, want.Variables) &&
+		slices.Equal(got.BeforeScript, want.BeforeScript) &&
+		slices.Equal(got.AfterScript, want.AfterScript)
+}
@@ -0,0 +1,27 @@
+package buildkite
+
+import (
+	"os"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	generatepkg "github.com/edelwud/terraci/plugins/buildkite/internal/generate"
+)
+
+// ProviderName returns the provider name.
+func (p *Plugin) ProviderName() string { return p.Name() }
+
+// DetectEnv returns true if running in a Buildkite job.
+func (p *Plugin) DetectEnv() bool {
+	return os.Getenv("BUILDKITE") == "true"
+}
+
+// PipelineID returns the Buildkite build ID.
+func (p *Plugin) PipelineID() string { return os.Getenv("BUILDKITE_BUILD_ID") }
+
+// CommitSHA returns the Buildkite commit SHA.
+func (p *Plugin) CommitSHA() string { return os.Getenv("BUILDKITE_COMMIT") }
+
+// NewGenerator creates a new Buildkite generator bound to the pre-built IR.
+func (p *Plugin) NewGenerator(ir *pipeline.IR) (pipeline.Generator, error) {
+	return generatepkg.NewGenerator(p.Config(), ir), nil
+}
@@ -0,0 +1,64 @@
+package buildkite
+
+import (
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+)
+
+// InitContributor — contributes Buildkite fields to the init wizard.
+
+const defaultQueue = "default"
+
+var (
+	initConfigKey     = config.MustExtensionKey(pluginName)
+	keyBuildkiteQueue = initwiz.MustStateKey[string]("buildkite.queue")
+)
+
+type initConfig struct {
+	Agents map[string]string `yaml:"agents,omitempty"`
+}
+
+// InitGroups returns the init wizard group specs for Buildkite.
+func (p *Plugin) InitGroups() ([]initwiz.InitGroup, error) {
+	showBuildkite := func(s *initwiz.StateMap) bool {
+		return initwiz.ProviderKey.Get(s) == pluginName
+	}
+
+	queue, err := initwiz.NewStringField(initwiz.StringFieldOptions{
+		Key:         keyBuildkiteQueue,
+		Title:       "Agent Queue",
+		Description: "Buildkite agent queue that runs terraform steps",
+		Default:     defaultQueue,
+		Placeholder: defaultQueue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	group, err := initwiz.NewInitGroup(initwiz.InitGroupOptions{
+		Title:    "Buildkite",
+		Category: initwiz.CategoryProvider,
+		Order:    100,
+		ShowWhen: showBuildkite,
+		Fields:   []initwiz.InitField{queue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []initwiz.InitGroup{group}, nil
+}
+
+// BuildInitConfig builds the Buildkite init contribution.
+func (p *Plugin) BuildInitConfig(state *initwiz.StateMap) (*initwiz.InitContribution, error) {
+	if initwiz.ProviderKey.Get(state) != pluginName {
+		return nil, nil
+	}
+
+	queue := keyBuildkiteQueue.Get(state)
+	if queue == "" {
+		queue = defaultQueue
+	}
+
+	return initwiz.NewInitContribution(initConfigKey, initConfig{
+		Agents: map[string]string{"queue": queue},
+	})
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+type Image = ci.Image
+
+// Config contains Buildkite specific settings.
+type Config struct {
+	Image       *Image            `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image to run steps in via the docker plugin (optional)"`
+	Agents      map[string]string `yaml:"agents,omitempty" json:"agents,omitempty" jsonschema:"description=Agent targeting rules (e.g. queue: terraform)"`
+	Env         map[string]string `yaml:"env,omitempty" json:"env,omitempty" jsonschema:"description=Pipeline-level environment variables"`
+	BlockApply  *bool             `yaml:"block_apply,omitempty" json:"block_apply,omitempty" jsonschema:"description=Insert a block step before every apply step,default=true"`
+	JobDefaults *JobDefaults      `yaml:"job_defaults,omitempty" json:"job_defaults,omitempty" jsonschema:"description=Default settings applied to all steps"`
+	Overwrites  []JobOverwrite    `yaml:"overwrites,omitempty" json:"overwrites,omitempty" jsonschema:"description=Step-level overrides for plan or apply jobs"`
+}
+
+// Clone returns a deep copy of the Buildkite configuration.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Image = cloneImagePointer(c.Image)
+	out.Agents = maps.Clone(c.Agents)
+	out.Env = maps.Clone(c.Env)
+	if c.BlockApply != nil {
+		blockApply := *c.BlockApply
+		out.BlockApply = &blockApply
+	}
+	out.JobDefaults = cloneJobDefaults(c.JobDefaults)
+	out.Overwrites = cloneJobOverwrites(c.Overwrites)
+	return &out
+}
+
+// BlockApplyEnabled reports whether apply steps are gated by a block step.
+func (c *Config) BlockApplyEnabled() bool {
+	if c == nil || c.BlockApply == nil {
+		return true
+	}
+	return *c.BlockApply
+}
+
+type JobDefaults struct {
+	Image            *Image            `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image override"`
+	Agents           map[string]string `yaml:"agents,omitempty" json:"agents,omitempty" jsonschema:"description=Agent targeting rules"`
+	Env              map[string]string `yaml:"env,omitempty" json:"env,omitempty" jsonschema:"description=Additional environment variables"`
+	CommandsBefore   []string          `yaml:"commands_before,omitempty" json:"commands_before,omitempty" jsonschema:"description=Commands to run before terraform commands"`
+	CommandsAfter    []string          `yaml:"commands_after,omitempty" json:"commands_after,omitempty" jsonschema:"description=Commands to run after terraform commands"`
+	TimeoutInMinutes int               `yaml:"timeout_in_minutes,omitempty" json:"timeout_in_minutes,omitempty" jsonschema:"description=Step timeout in minutes"`
+}
+
+func cloneJobDefaults(in *JobDefaults) *JobDefaults {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Image = cloneImagePointer(in.Image)
+	out.Agents = maps.Clone(in.Agents)
+	out.Env = maps.Clone(in.Env)
+	out.CommandsBefore = append([]string(nil), in.CommandsBefore...)
+	out.CommandsAfter = append([]string(nil), in.CommandsAfter...)
+	return &out
+}
+
+type JobOverwrite struct {
+	Type             JobOverwriteType  `yaml:"type" json:"type" jsonschema:"description=Type of jobs to override (plan\\, apply\\, or contributed job name),required"`
+	Image            *Image            `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image override"`
+	Agents           map[string]string `yaml:"agents,omitempty" json:"agents,omitempty" jsonschema:"description=Agent targeting rules"`
+	Env              map[string]string `yaml:"env,omitempty" json:"env,omitempty" jsonschema:"description=Additional environment variables"`
+	CommandsBefore   []string          `yaml:"commands_before,omitempty" json:"commands_before,omitempty" jsonschema:"description=Commands to run before terraform commands"`
+	CommandsAfter    []string          `yaml:"commands_after,omitempty" json:"commands_after,omitempty" jsonschema:"description=Commands to run after terraform commands"`
+	TimeoutInMinutes int               `yaml:"timeout_in_minutes,omitempty" json:"timeout_in_minutes,omitempty" jsonschema:"description=Step timeout in minutes"`
+}
+
+func cloneJobOverwrites(in []JobOverwrite) []JobOverwrite {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]JobOverwrite, len(in))
+	for i := range in {
+		out[i] = in[i]
+		out[i].Image = cloneImagePointer(in[i].Image)
+		out[i].Agents = maps.Clone(in[i].Agents)
+		out[i].Env = maps.Clone(in[i].Env)
+		out[i].CommandsBefore = append([]string(nil), in[i].CommandsBefore...)
+		out[i].CommandsAfter = append([]string(nil), in[i].CommandsAfter...)
+	}
+	return out
+}
+
+func cloneImagePointer(in *Image) *Image {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Entrypoint = append([]string(nil), in.Entrypoint...)
+	return &out
+}
+
+type JobOverwriteType string
+
+const (
+	OverwriteTypePlan  JobOverwriteType = "plan"
+	OverwriteTypeApply JobOverwriteType = "apply"
+)
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate runs the Buildkite plugin's config-shape sanity checks. Called
+// from the plugin's Preflight so malformed settings fail before any YAML is
+// rendered.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if err := validateImage(c.Image); err != nil {
+		errs = append(errs, fmt.Errorf("image: %w", err))
+	}
+	if d := c.JobDefaults; d != nil {
+		if err := validateImage(d.Image); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.image: %w", err))
+		}
+		if d.TimeoutInMinutes < 0 {
+			errs = append(errs, errors.New("job_defaults.timeout_in_minutes must not be negative"))
+		}
+	}
+	for i := range c.Overwrites {
+		o := &c.Overwrites[i]
+		if err := o.Type.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d]: %w", i, err))
+		}
+		if err := validateImage(o.Image); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].image: %w", i, err))
+		}
+		if o.TimeoutInMinutes < 0 {
+			errs = append(errs, fmt.Errorf("overwrites[%d].timeout_in_minutes must not be negative", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateImage(img *Image) error {
+	if img == nil {
+		return nil
+	}
+	if img.Name == "" {
+		return errors.New("name must be set")
+	}
+	if len(img.Entrypoint) > 1 {
+		return errors.New("entrypoint supports at most one element with the docker plugin")
+	}
+	return nil
+}
+
+func (t JobOverwriteType) validate() error {
+	if t == "" {
+		return errors.New("type must be set (plan, apply, or a contributed job name)")
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// Pipeline represents a Buildkite pipeline document as accepted by
+// `buildkite-agent pipeline upload`. Steps carry explicit keys and
+// depends_on edges, so Buildkite schedules them as a DAG.
+type Pipeline struct {
+	env    map[string]string
+	agents map[string]string
+	steps  []Step
+	index  map[string]int
+}
+
+type PipelineOptions struct {
+	Env    map[string]string
+	Agents map[string]string
+}
+
+type PipelineBuilder struct {
+	opts  PipelineOptions
+	steps []Step
+	index map[string]int
+}
+
+func EmptyPipeline() *Pipeline {
+	return &Pipeline{index: map[string]int{}}
+}
+
+func NewPipelineBuilder(opts PipelineOptions) *PipelineBuilder {
+	return &PipelineBuilder{
+		opts: PipelineOptions{
+			Env:    maps.Clone(opts.Env),
+			Agents: maps.Clone(opts.Agents),
+		},
+		index: make(map[string]int),
+	}
+}
+
+// AddStep appends a step. Every depends_on entry must name a step that was
+// already added, which keeps the rendered graph acyclic.
+func (b *PipelineBuilder) AddStep(step Step) error {
+	if b == nil {
+		return errors.New("buildkite pipeline builder is nil")
+	}
+	if step.key == "" {
+		return errors.New("buildkite step key is required")
+	}
+	if _, exists := b.index[step.key]; exists {
+		return fmt.Errorf("duplicate buildkite step key %q", step.key)
+	}
+	for _, dep := range step.dependsOn {
+		if _, ok := b.index[dep]; !ok {
+			return fmt.Errorf("buildkite step %q depends on unknown step %q", step.key, dep)
+		}
+	}
+	b.index[step.key] = len(b.steps)
+	b.steps = append(b.steps, step.clone())
+	return nil
+}
+
+func (b *PipelineBuilder) Build() (*Pipeline, error) {
+	if b == nil {
+		return nil, errors.New("buildkite pipeline builder is nil")
+	}
+	steps := make([]Step, len(b.steps))
+	for i := range b.steps {
+		steps[i] = b.steps[i].clone()
+	}
+	return &Pipeline{
+		env:    maps.Clone(b.opts.Env),
+		agents: maps.Clone(b.opts.Agents),
+		steps:  steps,
+		index:  maps.Clone(b.index),
+	}, nil
+}
+
+func (p *Pipeline) Env() map[string]string {
+	if p == nil {
+		return nil
+	}
+	return maps.Clone(p.env)
+}
+
+func (p *Pipeline) Agents() map[string]string {
+	if p == nil {
+		return nil
+	}
+	return maps.Clone(p.agents)
+}
+
+func (p *Pipeline) Step(key string) (Step, bool) {
+	if p == nil {
+		return Step{}, false
+	}
+	i, ok := p.index[key]
+	if !ok {
+		return Step{}, false
+	}
+	return p.steps[i].clone(), true
+}
+
+// StepKeys returns step keys in document order.
+func (p *Pipeline) StepKeys() []string {
+	if p == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(p.steps))
+	for _, step := range p.steps {
+		keys = append(keys, step.key)
+	}
+	return keys
+}
+
+func (p *Pipeline) StepCount() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.steps)
+}
+
+// CommandStepCount returns the number of steps that run commands.
+func (p *Pipeline) CommandStepCount() int {
+	if p == nil {
+		return 0
+	}
+	count := 0
+	for _, step := range p.steps {
+		if !step.Block() {
+			count++
+		}
+	}
+	return count
+}
+
+// StepRunsAfter reports whether key transitively depends on dependency.
+func (p *Pipeline) StepRunsAfter(key, dependency string) bool {
+	if p == nil {
+		return false
+	}
+	start, ok := p.index[key]
+	if !ok {
+		return false
+	}
+	if _, ok := p.index[dependency]; !ok {
+		return false
+	}
+	visited := make(map[string]struct{})
+	queue := cloneStrings(p.steps[start].dependsOn)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == dependency {
+			return true
+		}
+		if _, seen := visited[current]; seen {
+			continue
+		}
+		visited[current] = struct{}{}
+		if i, ok := p.index[current]; ok {
+			queue = append(queue, p.steps[i].dependsOn...)
+		}
+	}
+	return false
+}
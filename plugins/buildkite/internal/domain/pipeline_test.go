@@ -0,0 +1,106 @@
+package domain
+
+import "testing"
+
+func TestPipelineGettersReturnDefensiveCopies(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{Env: map[string]string{"TF_IN_AUTOMATION": "1"}})
+	mustAddStep(t, builder, mustCommandStep(t, "plan-vpc"))
+	pipeline := mustBuildPipeline(t, builder)
+
+	step, ok := pipeline.Step("plan-vpc")
+	if !ok {
+		t.Fatal("plan-vpc step not found")
+	}
+	commands := step.CommandLines()
+	commands[0] = "changed"
+	if step.CommandLines()[0] != "terraform plan" {
+		t.Fatalf("Step.CommandLines() leaked mutation: %#v", step.CommandLines())
+	}
+	env := pipeline.Env()
+	env["TF_IN_AUTOMATION"] = "changed"
+	if pipeline.Env()["TF_IN_AUTOMATION"] != "1" {
+		t.Fatalf("Pipeline.Env() leaked mutation: %#v", pipeline.Env())
+	}
+}
+
+func TestPipelineBuilderValidatesSteps(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddStep(t, builder, mustCommandStep(t, "plan-vpc"))
+	if err := builder.AddStep(mustCommandStep(t, "plan-vpc")); err == nil {
+		t.Fatal("AddStep() error = nil, want duplicate key error")
+	}
+	if err := builder.AddStep(mustCommandStep(t, "apply-vpc", "approve-apply-vpc")); err == nil {
+		t.Fatal("AddStep() error = nil, want unknown dependency error")
+	}
+	if err := builder.AddStep(Step{}); err == nil {
+		t.Fatal("AddStep() error = nil, want missing key error")
+	}
+}
+
+func TestNewStepsValidate(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewCommandStep(CommandStepOptions{Commands: []string{"true"}}); err == nil {
+		t.Fatal("NewCommandStep() error = nil, want missing key error")
+	}
+	if _, err := NewCommandStep(CommandStepOptions{Key: "plan"}); err == nil {
+		t.Fatal("NewCommandStep() error = nil, want missing commands error")
+	}
+	if _, err := NewCommandStep(CommandStepOptions{Key: "plan", Commands: []string{"true"}, Docker: &Docker{}}); err == nil {
+		t.Fatal("NewCommandStep() error = nil, want docker image error")
+	}
+	if _, err := NewBlockStep(BlockStepOptions{Key: "approve"}); err == nil {
+		t.Fatal("NewBlockStep() error = nil, want missing label error")
+	}
+}
+
+func TestPipelineStepRunsAfter(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddStep(t, builder, mustCommandStep(t, "plan-vpc"))
+	mustAddStep(t, builder, mustCommandStep(t, "plan-eks"))
+	block, err := NewBlockStep(BlockStepOptions{Key: "approve-apply-vpc", Label: "Apply vpc", DependsOn: []string{"plan-vpc"}})
+	if err != nil {
+		t.Fatalf("NewBlockStep() error = %v", err)
+	}
+	mustAddStep(t, builder, block)
+	mustAddStep(t, builder, mustCommandStep(t, "apply-vpc", "approve-apply-vpc"))
+	pipeline := mustBuildPipeline(t, builder)
+
+	if !pipeline.StepRunsAfter("apply-vpc", "plan-vpc") {
+		t.Fatal("apply-vpc should run after plan-vpc through the block step")
+	}
+	if pipeline.StepRunsAfter("plan-eks", "plan-vpc") {
+		t.Fatal("independent steps must not be ordered")
+	}
+	if pipeline.StepRunsAfter("missing", "plan-vpc") {
+		t.Fatal("unknown steps must not be ordered")
+	}
+	if got := pipeline.CommandStepCount(); got != 3 {
+		t.Fatalf("CommandStepCount() = %d, want 3", got)
+	}
+}
+
+func mustCommandStep(t *testing.T, key string, dependsOn ...string) Step {
+	t.Helper()
+	step, err := NewCommandStep(CommandStepOptions{Key: key, Label: key, Commands: []string{"terraform plan"}, DependsOn: dependsOn})
+	if err != nil {
+		t.Fatalf("NewCommandStep() error = %v", err)
+	}
+	return step
+}
+
+func mustAddStep(t *testing.T, builder *PipelineBuilder, step Step) {
+	t.Helper()
+	if err := builder.AddStep(step); err != nil {
+		t.Fatalf("AddStep() error = %v", err)
+	}
+}
+
+func mustBuildPipeline(t *testing.T, builder *PipelineBuilder) *Pipeline {
+	t.Helper()
+	pipeline, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return pipeline
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// StepKind distinguishes the Buildkite step types terraci emits.
+type StepKind string
+
+const (
+	StepKindCommand StepKind = "command"
+	StepKindBlock   StepKind = "block"
+)
+
+// Docker configures the docker plugin for a command step.
+type Docker struct {
+	Image string
+	// Entrypoint overrides the image entrypoint when set; an empty string
+	// disables it.
+	Entrypoint *string
+}
+
+func (d *Docker) clone() *Docker {
+	if d == nil {
+		return nil
+	}
+	out := *d
+	if d.Entrypoint != nil {
+		entrypoint := *d.Entrypoint
+		out.Entrypoint = &entrypoint
+	}
+	return &out
+}
+
+type CommandStepOptions struct {
+	Key              string
+	Label            string
+	Commands         []string
+	DependsOn        []string
+	Env              map[string]string
+	Agents           map[string]string
+	ArtifactPaths    []string
+	SoftFail         bool
+	AllowDepFailure  bool
+	TimeoutInMinutes int
+	Docker           *Docker
+}
+
+type BlockStepOptions struct {
+	Key       string
+	Label     string
+	DependsOn []string
+}
+
+// Step is a single entry of a Buildkite pipeline: either a command step that
+// runs a script or a block step that waits for a user to unblock it.
+type Step struct {
+	kind             StepKind
+	key              string
+	label            string
+	commands         []string
+	dependsOn        []string
+	env              map[string]string
+	agents           map[string]string
+	artifactPaths    []string
+	softFail         bool
+	allowDepFailure  bool
+	timeoutInMinutes int
+	docker           *Docker
+}
+
+func NewCommandStep(opts CommandStepOptions) (Step, error) {
+	if opts.Key == "" {
+		return Step{}, errors.New("buildkite step key is required")
+	}
+	if len(opts.Commands) == 0 {
+		return Step{}, fmt.Errorf("buildkite step %q commands are required", opts.Key)
+	}
+	if opts.TimeoutInMinutes < 0 {
+		return Step{}, fmt.Errorf("buildkite step %q timeout must not be negative", opts.Key)
+	}
+	if opts.Docker != nil && opts.Docker.Image == "" {
+		return Step{}, fmt.Errorf("buildkite step %q docker image is required", opts.Key)
+	}
+	return Step{
+		kind:             StepKindCommand,
+		key:              opts.Key,
+		label:            opts.Label,
+		commands:         cloneStrings(opts.Commands),
+		dependsOn:        cloneStrings(opts.DependsOn),
+		env:              maps.Clone(opts.Env),
+		agents:           maps.Clone(opts.Agents),
+		artifactPaths:    cloneStrings(opts.ArtifactPaths),
+		softFail:         opts.SoftFail,
+		allowDepFailure:  opts.AllowDepFailure,
+		timeoutInMinutes: opts.TimeoutInMinutes,
+		docker:           opts.Docker.clone(),
+	}, nil
+}
+
+func NewBlockStep(opts BlockStepOptions) (Step, error) {
+	if opts.Key == "" {
+		return Step{}, errors.New("buildkite step key is required")
+	}
+	if opts.Label == "" {
+		return Step{}, fmt.Errorf("buildkite block step %q label is required", opts.Key)
+	}
+	return Step{
+		kind:      StepKindBlock,
+		key:       opts.Key,
+		label:     opts.Label,
+		dependsOn: cloneStrings(opts.DependsOn),
+	}, nil
+}
+
+func (s Step) Kind() StepKind { return s.kind }
+
+func (s Step) Key() string { return s.key }
+
+func (s Step) Label() string { return s.label }
+
+// Block reports whether the step is a manual approval gate.
+func (s Step) Block() bool { return s.kind == StepKindBlock }
+
+func (s Step) CommandLines() []string { return cloneStrings(s.commands) }
+
+func (s Step) DependsOn() []string { return cloneStrings(s.dependsOn) }
+
+func (s Step) Env() map[string]string { return maps.Clone(s.env) }
+
+func (s Step) Agents() map[string]string { return maps.Clone(s.agents) }
+
+func (s Step) ArtifactPaths() []string { return cloneStrings(s.artifactPaths) }
+
+func (s Step) SoftFail() bool { return s.softFail }
+
+func (s Step) AllowDependencyFailure() bool { return s.allowDepFailure }
+
+func (s Step) TimeoutInMinutes() int { return s.timeoutInMinutes }
+
+func (s Step) Docker() *Docker { return s.docker.clone() }
+
+func (s Step) clone() Step {
+	out := s
+	out.commands = cloneStrings(s.commands)
+	out.dependsOn = cloneStrings(s.dependsOn)
+	out.env = maps.Clone(s.env)
+	out.agents = maps.Clone(s.agents)
+	out.artifactPaths = cloneStrings(s.artifactPaths)
+	out.docker = s.docker.clone()
+	return out
+}
+
+func cloneStrings(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	return append([]string(nil), in...)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// DockerPlugin is the pinned docker plugin reference used for steps that run
+// inside an image.
+const DockerPlugin = "docker#v5.12.0"
+
+func (p *Pipeline) ToYAML() ([]byte, error) {
+	type pipelineYAML struct {
+		Env    map[string]string `yaml:"env,omitempty"`
+		Agents map[string]string `yaml:"agents,omitempty"`
+		Steps  []Step            `yaml:"steps"`
+	}
+	payload := pipelineYAML{Steps: []Step{}}
+	if p != nil {
+		payload.Env = p.Env()
+		payload.Agents = p.Agents()
+		for i := range p.steps {
+			payload.Steps = append(payload.Steps, p.steps[i].clone())
+		}
+	}
+	data, err := yaml.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal buildkite pipeline: %w", err)
+	}
+
+	header := []byte("# Generated by terraci — do not edit\n")
+	return append(header, data...), nil
+}
+
+func (s Step) MarshalYAML() (any, error) {
+	if s.Block() {
+		return struct {
+			Block     string   `yaml:"block"`
+			Key       string   `yaml:"key"`
+			DependsOn []string `yaml:"depends_on,omitempty"`
+		}{
+			Block:     s.label,
+			Key:       s.key,
+			DependsOn: cloneStrings(s.dependsOn),
+		}, nil
+	}
+
+	type dockerYAML struct {
+		Image      string  `yaml:"image"`
+		Entrypoint *string `yaml:"entrypoint,omitempty"`
+	}
+	var plugins []map[string]dockerYAML
+	if s.docker != nil {
+		plugins = append(plugins, map[string]dockerYAML{
+			DockerPlugin: {Image: s.docker.Image, Entrypoint: s.docker.clone().Entrypoint},
+		})
+	}
+	return struct {
+		Label            string                  `yaml:"label,omitempty"`
+		Key              string                  `yaml:"key"`
+		DependsOn        []string                `yaml:"depends_on,omitempty"`
+		Commands         []string                `yaml:"commands"`
+		Env              map[string]string       `yaml:"env,omitempty"`
+		Agents           map[string]string       `yaml:"agents,omitempty"`
+		ArtifactPaths    []string                `yaml:"artifact_paths,omitempty"`
+		SoftFail         bool                    `yaml:"soft_fail,omitempty"`
+		AllowDepFailure  bool                    `yaml:"allow_dependency_failure,omitempty"`
+		TimeoutInMinutes int                     `yaml:"timeout_in_minutes,omitempty"`
+		Plugins          []map[string]dockerYAML `yaml:"plugins,omitempty"`
+	}{
+		Label:            s.label,
+		Key:              s.key,
+		DependsOn:        cloneStrings(s.dependsOn),
+		Commands:         cloneStrings(s.commands),
+		Env:              s.Env(),
+		Agents:           s.Agents(),
+		ArtifactPaths:    cloneStrings(s.artifactPaths),
+		SoftFail:         s.softFail,
+		AllowDepFailure:  s.allowDepFailure,
+		TimeoutInMinutes: s.timeoutInMinutes,
+		Plugins:          plugins,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineToYAML(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{Agents: map[string]string{"queue": "terraform"}})
+	plan, err := NewCommandStep(CommandStepOptions{
+		Key:           "plan-vpc",
+		Label:         "plan-vpc",
+		Commands:      []string{"cd vpc", "terraform plan -out=plan.tfplan"},
+		ArtifactPaths: []string{"vpc/plan.tfplan"},
+		Docker:        &Docker{Image: "hashicorp/terraform:1.6"},
+	})
+	if err != nil {
+		t.Fatalf("NewCommandStep() error = %v", err)
+	}
+	mustAddStep(t, builder, plan)
+	block, err := NewBlockStep(BlockStepOptions{Key: "approve-apply-vpc", Label: "Apply vpc", DependsOn: []string{"plan-vpc"}})
+	if err != nil {
+		t.Fatalf("NewBlockStep() error = %v", err)
+	}
+	mustAddStep(t, builder, block)
+	apply, err := NewCommandStep(CommandStepOptions{
+		Key:       "apply-vpc",
+		Commands:  []string{"terraform apply plan.tfplan"},
+		DependsOn: []string{"approve-apply-vpc", "plan-vpc"},
+		SoftFail:  true,
+	})
+	if err != nil {
+		t.Fatalf("NewCommandStep() error = %v", err)
+	}
+	mustAddStep(t, builder, apply)
+
+	data, err := mustBuildPipeline(t, builder).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"# Generated by terraci",
+		"agents:\n    queue: terraform",
+		"    - label: plan-vpc\n      key: plan-vpc",
+		"artifact_paths:\n        - vpc/plan.tfplan",
+		"plugins:\n        - " + DockerPlugin + ":\n            image: hashicorp/terraform:1.6",
+		"    - block: Apply vpc\n      key: approve-apply-vpc\n      depends_on:\n        - plan-vpc",
+		"depends_on:\n        - approve-apply-vpc\n        - plan-vpc",
+		"soft_fail: true",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ToYAML() missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDockerEntrypointRendering(t *testing.T) {
+	t.Parallel()
+
+	empty := ""
+	step, err := NewCommandStep(CommandStepOptions{
+		Key:      "plan",
+		Commands: []string{"terraform plan"},
+		Docker:   &Docker{Image: "hashicorp/terraform:1.6", Entrypoint: &empty},
+	})
+	if err != nil {
+		t.Fatalf("NewCommandStep() error = %v", err)
+	}
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddStep(t, builder, step)
+	data, err := mustBuildPipeline(t, builder).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(data), `entrypoint: ""`) {
+		t.Fatalf("ToYAML() = %s, want empty entrypoint override", data)
+	}
+}
+
+func TestEmptyPipelineToYAML(t *testing.T) {
+	t.Parallel()
+
+	data, err := EmptyPipeline().ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(data), "steps: []") {
+		t.Fatalf("ToYAML() = %s, want empty step list", data)
+	}
+}
@@ -0,0 +1,72 @@
+package generate
+
+import (
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/buildkite/internal/domain"
+)
+
+// Generator transforms a pipeline IR into a Buildkite pipeline suitable for
+// `buildkite-agent pipeline upload`. The IR is bound at construction time.
+type Generator struct {
+	settings settings
+	ir       *pipeline.IR
+}
+
+// NewGenerator creates a new Buildkite generator bound to the supplied IR.
+func NewGenerator(cfg *configpkg.Config, ir *pipeline.IR) *Generator {
+	return &Generator{
+		settings: newSettings(cfg),
+		ir:       ir,
+	}
+}
+
+func (g *Generator) Generate() (pipeline.GeneratedPipeline, error) {
+	if g.ir == nil {
+		return domainpkg.EmptyPipeline(), nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.transform(g.ir)
+}
+
+func (g *Generator) DryRun() (*pipeline.DryRunResult, error) {
+	if g.ir == nil {
+		return &pipeline.DryRunResult{}, nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.ir.DryRun(g.ir.ModuleCount()), nil
+}
+
+// transform emits steps level by level so every depends_on target is
+// declared before the step that references it.
+func (g *Generator) transform(ir *pipeline.IR) (*domainpkg.Pipeline, error) {
+	groups, err := pipeline.Schedule(ir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := domainpkg.NewPipelineBuilder(domainpkg.PipelineOptions{
+		Env:    g.settings.env(),
+		Agents: g.settings.agents(),
+	})
+	builder := newStepBuilder(g.settings)
+	for _, group := range groups {
+		for _, job := range group.Jobs() {
+			steps, err := builder.renderSteps(job)
+			if err != nil {
+				return nil, err
+			}
+			for _, step := range steps {
+				if err := out.AddStep(step); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return out.Build()
+}
@@ -0,0 +1,171 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+)
+
+func testContribution(tb testing.TB, opts ...pipeline.ContributedJobOptions) *pipeline.Contribution {
+	tb.Helper()
+	jobs := make([]pipeline.ContributedJob, 0, len(opts))
+	for _, opt := range opts {
+		job, err := pipeline.NewContributedJob(opt)
+		if err != nil {
+			tb.Fatalf("NewContributedJob() error = %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	contribution, err := pipeline.NewContribution(jobs...)
+	if err != nil {
+		tb.Fatalf("NewContribution() error = %v", err)
+	}
+	return contribution
+}
+
+func testContributionSet(tb testing.TB, contributions ...*pipeline.Contribution) pipeline.ContributionSet {
+	tb.Helper()
+	set, err := pipeline.NewContributionSet(contributions...)
+	if err != nil {
+		tb.Fatalf("NewContributionSet() error = %v", err)
+	}
+	return set
+}
+
+func costContribution(tb testing.TB) pipeline.ContributionSet {
+	tb.Helper()
+	return testContributionSet(tb, testContribution(tb, pipeline.ContributedJobOptions{
+		Name:     "cost-estimation",
+		Commands: []string{"terraci cost"},
+		Consumes: []pipeline.ResourceRequest{
+			pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+		},
+		Produces: []pipeline.ResourceSpec{
+			pipeline.PluginResource(pipeline.ResourceKindPluginResult, "cost", ".terraci/cost-results.json"),
+		},
+		AllowFailure: true,
+	}))
+}
+
+func TestGenerate_PlanAndApplyJobOverwrites(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Image = &configpkg.Image{Name: "hashicorp/terraform:1.9", Entrypoint: []string{""}}
+			cfg.JobDefaults = &configpkg.JobDefaults{
+				Env:            map[string]string{"DEFAULT": "true", "SHARED": "default"},
+				CommandsBefore: []string{"echo default setup"},
+				CommandsAfter:  []string{"echo default cleanup"},
+			}
+			cfg.Overwrites = []configpkg.JobOverwrite{
+				{
+					Type:             configpkg.OverwriteTypePlan,
+					Image:            &configpkg.Image{Name: "plan:latest"},
+					Agents:           map[string]string{"size": "large"},
+					Env:              map[string]string{"SHARED": "plan"},
+					CommandsBefore:   []string{"echo plan setup"},
+					TimeoutInMinutes: 30,
+				},
+				{
+					Type: configpkg.OverwriteTypeApply,
+					Env:  map[string]string{"APPLY": "true"},
+				},
+			}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		dockerImage("plan:latest").
+		agent("queue", "terraform").
+		agent("size", "large").
+		env("DEFAULT", "true").
+		env("SHARED", "plan").
+		env("TF_MODULE", "vpc").
+		timeout(30).
+		commandOrder("echo default setup", "echo plan setup").
+		commandOrder("echo plan setup", "terraform plan").
+		commandOrder("terraform plan", "echo default cleanup")
+
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-vpc").
+		dockerImage("hashicorp/terraform:1.9").
+		noAgents().
+		env("SHARED", "default").
+		env("APPLY", "true").
+		timeout(0)
+}
+
+func TestGenerate_ContributedJobArtifacts(t *testing.T) {
+	module := createTestModule("vpc")
+	planKey := "plan-platform-stage-eu-central-1-vpc"
+	out := newGeneratorScenario(t).
+		withContributions(costContribution(t)).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("cost-estimation").
+		runsAfter(planKey).
+		softFail(true).
+		artifactPath(".terraci/cost-results.json").
+		commandContains("buildkite-agent artifact download 'platform/stage/eu-central-1/vpc/plan.json' . --step '"+planKey+"'").
+		commandOrder("buildkite-agent artifact download", "terraci cost")
+	assertPipeline(t, out).
+		step(planKey).
+		softFail(false).
+		artifactPath("platform/stage/eu-central-1/vpc/plan.json")
+}
+
+func TestGenerate_ContributedJobOverwriteByName(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:   "cost-estimation",
+				Image:  &configpkg.Image{Name: "cost-specific:1.0"},
+				Agents: map[string]string{"queue": "cost"},
+			}}
+		}).
+		withContributions(costContribution(t)).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("cost-estimation").
+		dockerImage("cost-specific:1.0").
+		agent("queue", "cost")
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		noAgents()
+}
+
+func TestGenerate_AlwaysRunContributionRunsAfterFailures(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "drift-collector",
+			Commands: []string{"terraci drift"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			AlwaysRun: true,
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		withPlanOnly().
+		generate()
+
+	assertPipeline(t, out).
+		step("drift-collector").
+		runsAfter("plan-platform-stage-eu-central-1-vpc").
+		allowDependencyFailure(true)
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		allowDependencyFailure(false)
+}
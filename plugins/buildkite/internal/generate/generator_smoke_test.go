@@ -0,0 +1,193 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/buildkite/internal/domain"
+)
+
+func TestGenerate_SingleModule(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		commandStepCount(2).
+		hasStep("plan-platform-stage-eu-central-1-vpc").
+		hasStep("apply-platform-stage-eu-central-1-vpc")
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		noAgents().
+		env("TF_MODULE", "vpc").
+		commandContains("terraform init").
+		commandContains("terraform plan").
+		artifactPath("platform/stage/eu-central-1/vpc/plan.tfplan")
+	if got := out.Agents()["queue"]; got != "terraform" {
+		t.Fatalf("pipeline agents queue = %q, want terraform", got)
+	}
+}
+
+func TestGenerate_RejectsInvalidIR(t *testing.T) {
+	t.Parallel()
+
+	generated, err := NewGenerator(nil, nil).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	out, ok := generated.(*domainpkg.Pipeline)
+	if !ok || out.StepCount() != 0 {
+		t.Fatalf("Generate() = %#v, want empty pipeline", generated)
+	}
+}
+
+func TestGenerate_DependsOnFollowsIRDependencies(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-eks").
+		dependsOn("plan-platform-stage-eu-central-1-eks").
+		dependsOn("apply-platform-stage-eu-central-1-vpc").
+		runsAfter("approve-apply-platform-stage-eu-central-1-eks")
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-eks").
+		runsAfter("apply-platform-stage-eu-central-1-vpc")
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		notAfter("apply-platform-stage-eu-central-1-eks")
+}
+
+func TestGenerate_BlockStepBeforeApply(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		step("approve-apply-platform-stage-eu-central-1-eks").
+		block().
+		dependsOn("plan-platform-stage-eu-central-1-eks").
+		dependsOn("apply-platform-stage-eu-central-1-vpc")
+	assertPipeline(t, out).
+		step("apply-platform-stage-eu-central-1-vpc").
+		dependsOn("approve-apply-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_BlockApplyDisabled(t *testing.T) {
+	module := createTestModule("vpc")
+	blockApply := false
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) { cfg.BlockApply = &blockApply }).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		noStep("approve-apply-platform-stage-eu-central-1-vpc").
+		step("apply-platform-stage-eu-central-1-vpc").
+		dependsOn("plan-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_ApprovalRuleForcesBlockStep(t *testing.T) {
+	blockApply := false
+	ir := pipelinetest.MustGatedSingleModuleIR(t, citest.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	result, err := NewGenerator(&configpkg.Config{BlockApply: &blockApply}, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	out, ok := result.(*domainpkg.Pipeline)
+	if !ok {
+		t.Fatal("expected *Pipeline type")
+	}
+
+	assertPipeline(t, out).
+		step("approve-apply-platform-prod-eu-central-1-vpc").
+		block().
+		dependsOn("plan-platform-prod-eu-central-1-vpc")
+	assertPipeline(t, out).
+		step("apply-platform-prod-eu-central-1-vpc").
+		dependsOn("approve-apply-platform-prod-eu-central-1-vpc")
+}
+
+func TestGenerate_PlanOnly(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withPlanOnly().
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		commandStepCount(1).
+		hasStep("plan-platform-stage-eu-central-1-vpc").
+		noStep("apply-platform-stage-eu-central-1-vpc").
+		noStep("approve-apply-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_CustomBinary(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withTerraformConfig(func(cfg *pipeline.TerraformJobConfigOptions) { cfg.Binary = "tofu" }).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		step("plan-platform-stage-eu-central-1-vpc").
+		commandContains("tofu plan")
+}
+
+func TestStepKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"plan-platform-stage-eu-central-1-vpc": "plan-platform-stage-eu-central-1-vpc",
+		"terraci summary":                      "terraci-summary",
+		"scope:job_name":                       "scope:job_name",
+		"apply/eks.v2":                         "apply-eks-v2",
+	}
+	for in, want := range tests {
+		if got := stepKey(in); got != want {
+			t.Errorf("stepKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	result := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		dryRun()
+
+	citest.AssertDryRun(t, result, citest.DryRunExpectation{
+		TotalModules:    2,
+		AffectedModules: 2,
+		Jobs:            4,
+		Stages:          4,
+		JobGroups:       4,
+	})
+}
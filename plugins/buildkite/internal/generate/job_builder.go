@@ -0,0 +1,154 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/cishell"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/buildkite/internal/domain"
+)
+
+type stepBuilder struct {
+	settings settings
+}
+
+func newStepBuilder(settings settings) stepBuilder {
+	return stepBuilder{settings: settings}
+}
+
+// renderSteps converts one IR job into Buildkite steps. Apply jobs are
+// preceded by a block step when block_apply is enabled or the job requires
+// approval; the block waits on the job's own dependencies and the apply step
+// waits on the block.
+func (b stepBuilder) renderSteps(irJob pipeline.Job) ([]domainpkg.Step, error) {
+	profile, err := b.settings.jobProfile(jobOverwriteType(irJob))
+	if err != nil {
+		return nil, err
+	}
+
+	key := stepKey(irJob.Name())
+	dependsOn := dependencyKeys(irJob)
+
+	var steps []domainpkg.Step
+	isApply := irJob.Operation().Type() == pipeline.OperationTypeTerraformApply
+	if isApply && (b.settings.blockApply() || irJob.RequiresApproval()) {
+		block, err := domainpkg.NewBlockStep(domainpkg.BlockStepOptions{
+			Key:       approvalKey(key),
+			Label:     "Approve " + irJob.Name(),
+			DependsOn: dependsOn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, block)
+		dependsOn = append([]string{block.Key()}, dependsOn...)
+	}
+
+	commands := downloadCommands(irJob.InputArtifacts())
+	commands = append(commands, profile.commandsBefore...)
+	commands = append(commands, cishell.RenderOperation(irJob.Operation())...)
+	commands = append(commands, profile.commandsAfter...)
+
+	var artifactPaths []string
+	if output := irJob.OutputArtifact(); output.Configured() {
+		artifactPaths = append(artifactPaths, output.Paths...)
+	}
+
+	step, err := domainpkg.NewCommandStep(domainpkg.CommandStepOptions{
+		Key:              key,
+		Label:            irJob.Name(),
+		Commands:         commands,
+		DependsOn:        dependsOn,
+		Env:              mergeMaps(irJob.Env(), profile.env),
+		Agents:           stepAgents(b.settings.agents(), profile.agents),
+		ArtifactPaths:    artifactPaths,
+		SoftFail:         irJob.AllowFailure(),
+		AllowDepFailure:  irJob.AlwaysRun(),
+		TimeoutInMinutes: profile.timeoutInMinutes,
+		Docker:           profile.docker,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(steps, step), nil
+}
+
+func jobOverwriteType(irJob pipeline.Job) configpkg.JobOverwriteType {
+	switch irJob.Operation().Type() {
+	case pipeline.OperationTypeTerraformPlan:
+		return configpkg.OverwriteTypePlan
+	case pipeline.OperationTypeTerraformApply:
+		return configpkg.OverwriteTypeApply
+	case pipeline.OperationTypeCommands:
+		return configpkg.JobOverwriteType(irJob.Name())
+	default:
+		return ""
+	}
+}
+
+func dependencyKeys(irJob pipeline.Job) []string {
+	deps := irJob.Dependencies()
+	if len(deps) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		keys = append(keys, stepKey(dep.Job))
+	}
+	return keys
+}
+
+// stepAgents returns step-level agent rules. Buildkite replaces rather than
+// merges the pipeline agents when a step declares its own, so overrides are
+// layered on top of the pipeline defaults here.
+func stepAgents(pipelineAgents, profileAgents map[string]string) map[string]string {
+	if len(profileAgents) == 0 {
+		return nil
+	}
+	return mergeMaps(pipelineAgents, profileAgents)
+}
+
+// downloadCommands restores input artifacts from the step that uploaded
+// them. Downloads run from the checkout root so paths land where the
+// producer saw them; optional inputs tolerate a missing artifact.
+func downloadCommands(inputs []pipeline.InputArtifact) []string {
+	var commands []string
+	for _, input := range inputs {
+		if !input.Configured() {
+			continue
+		}
+		for _, path := range input.Artifact.Paths {
+			command := "buildkite-agent artifact download " + shellQuote(path) + " . --step " + shellQuote(stepKey(input.ProducerJob))
+			if input.Optional {
+				command += " || true"
+			}
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// stepKey converts an IR job name into a Buildkite step key, which may only
+// contain letters, digits, dashes, underscores, and colons.
+func stepKey(name string) string {
+	var sb strings.Builder
+	sb.Grow(len(name))
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == ':':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('-')
+		}
+	}
+	return sb.String()
+}
+
+func approvalKey(key string) string {
+	return "approve-" + key
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/config/overwrite"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/buildkite/internal/domain"
+)
+
+type jobProfile struct {
+	docker           *domainpkg.Docker
+	agents           map[string]string
+	env              map[string]string
+	commandsBefore   []string
+	commandsAfter    []string
+	timeoutInMinutes int
+}
+
+// jobProfile resolves step settings for jobType: the top-level image first,
+// then job_defaults, then every matching overwrite.
+func (s settings) jobProfile(jobType configpkg.JobOverwriteType) (jobProfile, error) {
+	cfg := s.configOrDefault()
+	profile := jobProfile{docker: convertImage(cfg.Image)}
+
+	if cfg.JobDefaults != nil {
+		applyJobDefaults(&profile, cfg.JobDefaults)
+	}
+
+	err := overwrite.ApplyMatching(
+		&profile,
+		jobType,
+		cfg.Overwrites,
+		overwrite.ByKey(func(ow *configpkg.JobOverwrite) configpkg.JobOverwriteType { return ow.Type }),
+		applyJobOverwrite,
+	)
+	if err != nil {
+		return jobProfile{}, err
+	}
+	return profile, nil
+}
+
+func applyJobDefaults(profile *jobProfile, defaults *configpkg.JobDefaults) {
+	if defaults.Image != nil {
+		profile.docker = convertImage(defaults.Image)
+	}
+	mergeProfileMap(&profile.agents, defaults.Agents)
+	mergeProfileMap(&profile.env, defaults.Env)
+	profile.commandsBefore = append(profile.commandsBefore, defaults.CommandsBefore...)
+	profile.commandsAfter = append(profile.commandsAfter, defaults.CommandsAfter...)
+	if defaults.TimeoutInMinutes > 0 {
+		profile.timeoutInMinutes = defaults.TimeoutInMinutes
+	}
+}
+
+func applyJobOverwrite(profile *jobProfile, ow *configpkg.JobOverwrite) {
+	if ow.Image != nil {
+		profile.docker = convertImage(ow.Image)
+	}
+	mergeProfileMap(&profile.agents, ow.Agents)
+	mergeProfileMap(&profile.env, ow.Env)
+	profile.commandsBefore = append(profile.commandsBefore, ow.CommandsBefore...)
+	profile.commandsAfter = append(profile.commandsAfter, ow.CommandsAfter...)
+	if ow.TimeoutInMinutes > 0 {
+		profile.timeoutInMinutes = ow.TimeoutInMinutes
+	}
+}
+
+// convertImage maps a config image onto the docker plugin. A single
+// entrypoint element overrides the image entrypoint; validation rejects
+// longer entrypoints.
+func convertImage(img *configpkg.Image) *domainpkg.Docker {
+	if img == nil || img.Name == "" {
+		return nil
+	}
+	docker := &domainpkg.Docker{Image: img.Name}
+	if len(img.Entrypoint) > 0 {
+		entrypoint := img.Entrypoint[0]
+		docker.Entrypoint = &entrypoint
+	}
+	return docker
+}
+
+func mergeProfileMap(dst *map[string]string, src map[string]string) {
+	if len(src) == 0 {
+		return
+	}
+	if *dst == nil {
+		*dst = make(map[string]string, len(src))
+	}
+	maps.Copy(*dst, src)
+}
+
+// mergeMaps layers string maps; later layers win.
+func mergeMaps(layers ...map[string]string) map[string]string {
+	var result map[string]string
+	for _, layer := range layers {
+		if len(layer) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		maps.Copy(result, layer)
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	domainpkg "github.com/edelwud/terraci/plugins/buildkite/internal/domain"
+)
+
+type pipelineAssert struct {
+	t        *testing.T
+	pipeline *domainpkg.Pipeline
+}
+
+func assertPipeline(t *testing.T, pipeline *domainpkg.Pipeline) *pipelineAssert {
+	t.Helper()
+	return &pipelineAssert{t: t, pipeline: pipeline}
+}
+
+func (a *pipelineAssert) commandStepCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.CommandStepCount(); got != expected {
+		a.t.Fatalf("expected %d command steps, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) hasStep(key string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Step(key); !ok {
+		a.t.Fatalf("expected step %q to exist", key)
+	}
+	return a
+}
+
+func (a *pipelineAssert) noStep(key string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Step(key); ok {
+		a.t.Fatalf("expected step %q to not exist", key)
+	}
+	return a
+}
+
+func (a *pipelineAssert) step(key string) *stepAssert {
+	a.t.Helper()
+	step, ok := a.pipeline.Step(key)
+	if !ok {
+		a.t.Fatalf("expected step %q to exist", key)
+	}
+	return &stepAssert{t: a.t, key: key, pipeline: a.pipeline, step: step}
+}
+
+type stepAssert struct {
+	t        *testing.T
+	key      string
+	pipeline *domainpkg.Pipeline
+	step     domainpkg.Step
+}
+
+// runsAfter asserts that the depends_on graph orders the step after dependency.
+func (a *stepAssert) runsAfter(dependency string) *stepAssert {
+	a.t.Helper()
+	if !a.pipeline.StepRunsAfter(a.key, dependency) {
+		a.t.Fatalf("expected step %q to run after %q", a.key, dependency)
+	}
+	return a
+}
+
+func (a *stepAssert) notAfter(dependency string) *stepAssert {
+	a.t.Helper()
+	if a.pipeline.StepRunsAfter(a.key, dependency) {
+		a.t.Fatalf("expected step %q not to run after %q", a.key, dependency)
+	}
+	return a
+}
+
+func (a *stepAssert) dependsOn(dependency string) *stepAssert {
+	a.t.Helper()
+	if !slices.Contains(a.step.DependsOn(), dependency) {
+		a.t.Fatalf("expected step %q depends_on to contain %q, got %v", a.key, dependency, a.step.DependsOn())
+	}
+	return a
+}
+
+func (a *stepAssert) block() *stepAssert {
+	a.t.Helper()
+	if !a.step.Block() {
+		a.t.Fatalf("expected step %q to be a block step", a.key)
+	}
+	return a
+}
+
+func (a *stepAssert) env(name, expected string) *stepAssert {
+	a.t.Helper()
+	if got := a.step.Env()[name]; got != expected {
+		a.t.Fatalf("expected step %q env %s=%q, got %q", a.key, name, expected, got)
+	}
+	return a
+}
+
+func (a *stepAssert) agent(name, expected string) *stepAssert {
+	a.t.Helper()
+	if got := a.step.Agents()[name]; got != expected {
+		a.t.Fatalf("expected step %q agent %s=%q, got %q", a.key, name, expected, got)
+	}
+	return a
+}
+
+func (a *stepAssert) noAgents() *stepAssert {
+	a.t.Helper()
+	if agents := a.step.Agents(); len(agents) != 0 {
+		a.t.Fatalf("expected step %q to inherit pipeline agents, got %v", a.key, agents)
+	}
+	return a
+}
+
+func (a *stepAssert) dockerImage(expected string) *stepAssert {
+	a.t.Helper()
+	docker := a.step.Docker()
+	if docker == nil {
+		a.t.Fatalf("expected step %q to use the docker plugin", a.key)
+	}
+	if docker.Image != expected {
+		a.t.Fatalf("expected step %q docker image=%q, got %q", a.key, expected, docker.Image)
+	}
+	return a
+}
+
+func (a *stepAssert) softFail(expected bool) *stepAssert {
+	a.t.Helper()
+	if a.step.SoftFail() != expected {
+		a.t.Fatalf("expected step %q soft_fail=%v, got %v", a.key, expected, a.step.SoftFail())
+	}
+	return a
+}
+
+func (a *stepAssert) timeout(expected int) *stepAssert {
+	a.t.Helper()
+	if got := a.step.TimeoutInMinutes(); got != expected {
+		a.t.Fatalf("expected step %q timeout_in_minutes=%d, got %d", a.key, expected, got)
+	}
+	return a
+}
+
+func (a *stepAssert) artifactPath(expected string) *stepAssert {
+	a.t.Helper()
+	if !slices.Contains(a.step.ArtifactPaths(), expected) {
+		a.t.Fatalf("expected step %q artifact_paths to contain %q, got %v", a.key, expected, a.step.ArtifactPaths())
+	}
+	return a
+}
+
+func (a *stepAssert) commandContains(fragment string) *stepAssert {
+	a.t.Helper()
+	a.commandIndex(fragment)
+	return a
+}
+
+func (a *stepAssert) commandOrder(first, second string) *stepAssert {
+	a.t.Helper()
+	if a.commandIndex(first) >= a.commandIndex(second) {
+		a.t.Fatalf("expected step %q command %q before %q: %v", a.key, first, second, a.step.CommandLines())
+	}
+	return a
+}
+
+func (a *stepAssert) commandIndex(fragment string) int {
+	a.t.Helper()
+	for i, command := range a.step.CommandLines() {
+		if strings.Contains(command, fragment) {
+			return i
+		}
+	}
+	a.t.Fatalf("expected step %q commands to contain %q, got %v", a.key, fragment, a.step.CommandLines())
+	return -1
+}
+
+func (a *stepAssert) allowDependencyFailure(expected bool) *stepAssert {
+	a.t.Helper()
+	if a.step.AllowDependencyFailure() != expected {
+		a.t.Fatalf("expected step %q allow_dependency_failure=%v, got %v", a.key, expected, a.step.AllowDependencyFailure())
+	}
+	return a
+}
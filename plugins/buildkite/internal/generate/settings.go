@@ -0,0 +1,32 @@
+package generate
+
+import (
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+)
+
+type settings struct {
+	config *configpkg.Config
+}
+
+func newSettings(cfg *configpkg.Config) settings {
+	return settings{config: cfg}
+}
+
+func (s settings) configOrDefault() *configpkg.Config {
+	if s.config == nil {
+		return &configpkg.Config{}
+	}
+	return s.config
+}
+
+func (s settings) env() map[string]string {
+	return s.configOrDefault().Env
+}
+
+func (s settings) agents() map[string]string {
+	return s.configOrDefault().Agents
+}
+
+func (s settings) blockApply() bool {
+	return s.configOrDefault().BlockApplyEnabled()
+}
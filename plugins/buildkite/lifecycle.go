@@ -0,0 +1,27 @@
+package buildkite
+
+import (
+	"context"
+	"os"
+
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/plugins/internal/ciplugin"
+)
+
+// Preflight validates the loaded plugin config and detects PR context when
+// running inside a Buildkite job.
+func (p *Plugin) Preflight(_ context.Context, _ *plugin.AppContext) error {
+	var cfg ciplugin.ConfigValidator
+	if c := p.Config(); c != nil {
+		cfg = c
+	}
+	return ciplugin.Preflight(cfg, p.DetectEnv, ciplugin.PreflightLog{
+		ProviderName: pluginName,
+		ContextLabel: "PR",
+		DetectInContext: func() (any, bool) {
+			// Buildkite sets BUILDKITE_PULL_REQUEST to "false" outside PR builds.
+			id := os.Getenv("BUILDKITE_PULL_REQUEST")
+			return id, id != "" && id != "false"
+		},
+	})
+}
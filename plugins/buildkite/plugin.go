@@ -0,0 +1,31 @@
+// Package buildkite provides the Buildkite plugin for TerraCi.
+// It registers a pipeline generator whose output is meant to be piped into
+// `buildkite-agent pipeline upload`.
+package buildkite
+
+import (
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/registry"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+)
+
+// pluginName is the canonical provider name of the Buildkite plugin.
+const pluginName = "buildkite"
+
+func init() {
+	registry.RegisterFactory(func() plugin.Plugin {
+		return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+			PluginName: pluginName,
+			PluginDesc: "Buildkite dynamic pipeline generation",
+			EnableMode: plugin.EnabledWhenConfigured,
+			DefaultCfg: func() *configpkg.Config {
+				return &configpkg.Config{}
+			},
+		}}
+	})
+}
+
+// Plugin is the Buildkite plugin.
+type Plugin struct {
+	plugin.BasePlugin[*configpkg.Config]
+}
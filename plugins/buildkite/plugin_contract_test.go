@@ -0,0 +1,170 @@
+package buildkite
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	"github.com/edelwud/terraci/pkg/plugin/plugintest"
+	configpkg "github.com/edelwud/terraci/plugins/buildkite/internal/config"
+)
+
+func TestPlugin_SDKContracts(t *testing.T) {
+	p := newContractPlugin()
+	blockApply := false
+
+	t.Run("config", func(t *testing.T) {
+		plugintest.AssertBaseConfigPlugin[*configpkg.Config](t, plugintest.BaseConfigPluginContract[*configpkg.Config]{
+			Plugin:  p,
+			Default: &configpkg.Config{},
+			Configured: &configpkg.Config{
+				Image:  &configpkg.Image{Name: "hashicorp/terraform:1.9"},
+				Agents: map[string]string{"queue": "terraform"},
+				Env:    map[string]string{"TF_INPUT": "false"},
+				JobDefaults: &configpkg.JobDefaults{
+					Env:            map[string]string{"DEFAULT": "true"},
+					CommandsBefore: []string{"echo setup"},
+				},
+			},
+			Decoded: &configpkg.Config{
+				BlockApply: &blockApply,
+				Env:        map[string]string{"DECODED": "true"},
+				Overwrites: []configpkg.JobOverwrite{{
+					Type:   configpkg.OverwriteTypeApply,
+					Agents: map[string]string{"queue": "production"},
+				}},
+			},
+			Mutate: mutateBuildkiteConfig,
+			Equal:  equalBuildkiteConfig,
+		})
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		plugintest.AssertPreflightable(t, plugintest.PreflightableContract{
+			Plugin:     newContractPlugin(),
+			AppContext: plugintest.NewAppContext(t, t.TempDir()),
+		})
+	})
+
+	t.Run("init contributor", func(t *testing.T) {
+		state := initwiz.NewStateMap()
+		initwiz.ProviderKey.Set(state, pluginName)
+		plugintest.AssertInitContributor(t, plugintest.InitContributorContract{
+			Contributor:        newContractPlugin(),
+			State:              state,
+			ExpectedPluginKey:  pluginName,
+			ExpectContribution: true,
+			DecodeTarget:       &configpkg.Config{},
+		})
+	})
+
+	t.Run("ci provider", func(t *testing.T) {
+		t.Setenv("BUILDKITE", "true")
+		p := newContractPlugin()
+		plugintest.AssertCIProvider(t, plugintest.CIProviderContract{
+			EnvDetector:  p,
+			InfoProvider: p,
+			Generator:    p,
+			AppContext:   plugintest.NewAppContext(t, t.TempDir()),
+			IR:           pipelinetest.MustCommandIR(t),
+			ExpectedName: pluginName,
+			AssertEnv: func(tb testing.TB, detected bool) {
+				tb.Helper()
+				if !detected {
+					tb.Fatal("DetectEnv() = false, want true")
+				}
+			},
+		})
+	})
+}
+
+func newContractPlugin() *Plugin {
+	return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+		PluginName: pluginName,
+		PluginDesc: "Buildkite dynamic pipeline generation",
+		EnableMode: plugin.EnabledWhenConfigured,
+		DefaultCfg: func() *configpkg.Config {
+			return &configpkg.Config{}
+		},
+	}}
+}
+
+func mutateBuildkiteConfig(c *configpkg.Config) {
+	if c == nil {
+		return
+	}
+	if c.Image != nil {
+		c.Image.Name = "mutated"
+	}
+	if c.Env == nil {
+		c.Env = map[string]string{}
+	}
+	c.Env["MUTATED"] = "true"
+	if c.BlockApply != nil {
+		*c.BlockApply = !*c.BlockApply
+	}
+	if c.JobDefaults != nil {
+		c.JobDefaults.CommandsBefore = append(c.JobDefaults.CommandsBefore, "mutated")
+		if c.JobDefaults.Env == nil {
+			c.JobDefaults.Env = map[string]string{}
+		}
+		c.JobDefaults.Env["MUTATED"] = "true"
+	}
+	for i := range c.Overwrites {
+		if c.Overwrites[i].Agents == nil {
+			c.Overwrites[i].Agents = map[string]string{}
+		}
+		c.Overwrites[i].Agents["queue"] = "mutated"
+	}
+}
+
+func equalBuildkiteConfig(got, want *configpkg.Config) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalBuildkiteImage(got.Image, want.Image) &&
+		maps.Equal(got.Agents, want.Agents) &&
+		maps.Equal(got.Env, want.Env) &&
+		equalBoolPointer(got.BlockApply, want.BlockApply) &&
+		equalBuildkiteDefaults(got.JobDefaults, want.JobDefaults) &&
+		slices.EqualFunc(got.Overwrites, want.Overwrites, equalBuildkiteOverwrite)
+}
+
+func equalBoolPointer(got, want *bool) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func equalBuildkiteImage(got, want *configpkg.Image) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Name == want.Name && slices.Equal(got.Entrypoint, want.Entrypoint)
+}
+
+func equalBuildkiteDefaults(got, want *configpkg.JobDefaults) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalBuildkiteImage(got.Image, want.Image) &&
+		maps.Equal(got.Agents, want.Agents) &&
+		maps.Equal(got.Env, want.Env) &&
+		slices.Equal(got.CommandsBefore, want.CommandsBefore) &&
+		slices.Equal(got.CommandsAfter, want.CommandsAfter) &&
+		got.TimeoutInMinutes == want.TimeoutInMinutes
+}
+
+func equalBuildkiteOverwrite(got, want configpkg.JobOverwrite) bool {
+	return got.Type == want.Type &&
+		equalBuildkiteImage(got.Image, want.Image) &&
+		maps.Equal(got.Agents, want.Agents) &&
+		maps.Equal(got.Env, want.Env) &&
+		slices.Equal(got.CommandsBefore, want.CommandsBefore) &&
+		slices.Equal(got.CommandsAfter, want.CommandsAfter) &&
+		got.TimeoutInMinutes == want.TimeoutInMinutes
+}
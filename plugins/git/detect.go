@@ -48,3 +48,32 @@ func (p *Plugin) DetectChanges(ctx context.Context, req workflow.ChangeDetection
 		LibraryPaths: libraries,
 	}, nil
 }
+
+// ExportRevision writes the work directory as of the merge base of the base
+// ref and HEAD into req.DestDir.
+func (p *Plugin) ExportRevision(ctx context.Context, req workflow.RevisionExportRequest) (*workflow.RevisionExportResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	workDir := req.WorkDir
+	if workDir == "" {
+		workDir = "."
+	}
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve workdir %q: %w", workDir, err)
+	}
+
+	client := gitclient.NewClient(absWorkDir)
+	if !client.IsGitRepo() {
+		return nil, fmt.Errorf("not a git repository: %s", absWorkDir)
+	}
+
+	ref := client.ResolveBaseRef(req.BaseRef)
+	commit, err := client.ExportTree(ref, req.DestDir, req.Include)
+	if err != nil {
+		return nil, fmt.Errorf("export %q: %w", ref, err)
+	}
+	return &workflow.RevisionExportResult{Ref: ref, Commit: commit.String()}, nil
+}
@@ -78,6 +78,46 @@ func TestDetectChanges_RelativeWorkDirAndLibraryPaths(t *testing.T) {
 	}
 }
 
+func TestExportRevision_Subdirectory(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disableTestCommitSigning(t, repo)
+	addTestCommit(t, repoDir, repo, map[string]string{
+		"README.md":         "root",
+		"infra/vpc/main.tf": "# v1",
+	}, "initial")
+	addTestCommit(t, repoDir, repo, map[string]string{
+		"infra/vpc/main.tf": "# v2",
+		"infra/eks/main.tf": "# eks",
+	}, "add eks")
+
+	destDir := t.TempDir()
+	result, err := (&Plugin{}).ExportRevision(context.Background(), workflow.RevisionExportRequest{
+		WorkDir: filepath.Join(repoDir, "infra"),
+		BaseRef: "HEAD~1",
+		DestDir: destDir,
+	})
+	if err != nil {
+		t.Fatalf("ExportRevision() error = %v", err)
+	}
+	if result.Ref != "HEAD~1" || len(result.Commit) != 40 {
+		t.Errorf("result = %+v", result)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destDir, "vpc", "main.tf"))
+	if err != nil || string(content) != "# v1" {
+		t.Errorf("vpc/main.tf = %q, %v; want the base revision", content, err)
+	}
+	for _, absent := range []string{filepath.Join("eks", "main.tf"), "README.md"} {
+		if _, err := os.Stat(filepath.Join(destDir, absent)); !os.IsNotExist(err) {
+			t.Errorf("%s exported, want only files of the work directory at the base revision", absent)
+		}
+	}
+}
+
 func disableTestCommitSigning(t *testing.T, repo *gogit.Repository) {
 	t.Helper()
 	cfg, err := repo.Config()
@@ -123,3 +163,55 @@ func moduleIDs(modules []*discovery.Module) []string {
 	}
 	return ids
 }
+
+func TestExportRevision_IncludeFilterAndSymlinks(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disableTestCommitSigning(t, repo)
+	if err := os.Symlink("main.tf", filepath.Join(repoDir, "link.tf")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	addTestCommit(t, repoDir, repo, map[string]string{
+		"main.tf":        "# vpc",
+		"terragrunt.hcl": "# unit",
+		"workspaces":     "default\n",
+		"docs/guide.md":  "# guide",
+		"assets/app.zip": "binary",
+	}, "initial")
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Add("link.tf"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Commit("add link", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := t.TempDir()
+	if _, err := (&Plugin{}).ExportRevision(context.Background(), workflow.RevisionExportRequest{
+		WorkDir: repoDir,
+		BaseRef: "HEAD",
+		DestDir: destDir,
+		Include: workflow.ProjectInputs(nil),
+	}); err != nil {
+		t.Fatalf("ExportRevision() error = %v", err)
+	}
+
+	for _, present := range []string{"main.tf", "terragrunt.hcl", "workspaces"} {
+		if _, err := os.Stat(filepath.Join(destDir, present)); err != nil {
+			t.Errorf("%s not exported: %v", present, err)
+		}
+	}
+	for _, absent := range []string{"link.tf", filepath.Join("docs", "guide.md"), filepath.Join("assets", "app.zip")} {
+		if _, err := os.Lstat(filepath.Join(destDir, absent)); !os.IsNotExist(err) {
+			t.Errorf("%s exported, want only project inputs", absent)
+		}
+	}
+}
@@ -60,19 +60,27 @@ func (d *ChangedModulesDetector) filesToModules(files []string) []*discovery.Mod
 			continue
 		}
 		if mod := d.findOwningModule(pathpkg.Dir(cleanWorkspacePath(file))); mod != nil {
-			seen[mod.ID()] = true
+			seen[moduleDir(mod)] = true
 		}
 	}
 
+	// A change to a module directory affects every workspace deployed from it.
 	modules := make([]*discovery.Module, 0, len(seen))
 	for _, module := range d.index.All() {
-		if seen[module.ID()] {
+		if seen[moduleDir(module)] {
 			modules = append(modules, module)
 		}
 	}
 	return modules
 }
 
+// moduleDir returns the normalized relative directory of module, shared by
+// all of its workspaces.
+func moduleDir(module *discovery.Module) string {
+	dir, _ := discovery.SplitID(module.ID())
+	return dir
+}
+
 // findOwningModule walks up from dir until it finds a known module.
 func (d *ChangedModulesDetector) findOwningModule(dir string) *discovery.Module {
 	if d.index == nil {
@@ -106,7 +114,7 @@ func (d *ChangedModulesDetector) findByRelativePath(path string) *discovery.Modu
 	}
 	normalized := cleanWorkspacePath(path)
 	for _, m := range d.index.All() {
-		if m.ID() == normalized {
+		if moduleDir(m) == normalized {
 			return m
 		}
 	}
@@ -165,6 +173,9 @@ func (d *ChangedModulesDetector) absoluteLibraryPath(relPath string) string {
 // isTerraformRelated checks if a file is terraform-related.
 func isTerraformRelated(file string) bool {
 	file = cleanWorkspacePath(file)
+	if pathpkg.Base(file) == discovery.WorkspacesFile {
+		return true
+	}
 	for _, ext := range terraformExtensions {
 		if strings.HasSuffix(file, ext) {
 			return true
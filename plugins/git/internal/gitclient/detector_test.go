@@ -21,6 +21,7 @@ func TestIsTerraformRelated(t *testing.T) {
 		{"config.tf.json", true},
 		{"modules/vpc/main.tf", true},
 		{"service/prod/us-east-1/vpc/outputs.tf", true},
+		{"service/prod/us-east-1/vpc/workspaces", true},
 		{"readme.md", false},
 		{"script.sh", false},
 		{"terraform.tfstate", false},
@@ -130,6 +131,21 @@ func TestFilesToModules(t *testing.T) {
 	}
 }
 
+func TestFilesToModules_Workspaces(t *testing.T) {
+	vpc := makeModule("myapp/prod/us-east-1/vpc")
+	tenant := vpc.WithWorkspace("tenant-a")
+	eks := makeModule("myapp/prod/us-east-1/eks")
+	index := discovery.NewModuleIndex([]*discovery.Module{vpc, tenant, eks})
+	detector := NewChangedModulesDetector(NewClient(t.TempDir()), index, "")
+
+	for _, file := range []string{"myapp/prod/us-east-1/vpc/main.tf", "myapp/prod/us-east-1/vpc/workspaces"} {
+		modules := detector.filesToModules([]string{file})
+		if len(modules) != 2 || modules[0] != vpc || modules[1] != tenant {
+			t.Errorf("filesToModules(%s) = %v, want every workspace of vpc", file, modules)
+		}
+	}
+}
+
 func TestFilesToLibraryPaths(t *testing.T) {
 	index := discovery.NewModuleIndex(nil)
 	client := NewClient(t.TempDir())
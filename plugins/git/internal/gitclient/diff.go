@@ -47,16 +47,9 @@ func (c *Client) GetChangedFiles(baseRef string) ([]string, error) {
 		return nil, ErrShallowRepository
 	}
 
-	if baseRef == "" {
-		baseRef = defaultBaseRef
-	}
-
-	baseHash, err := c.getMergeBase(baseRef, "HEAD")
+	baseHash, err := c.baseCommit(baseRef)
 	if err != nil {
-		baseHash, err = c.resolveRef(baseRef)
-		if err != nil {
-			return nil, fmt.Errorf("resolve base ref %q: %w; fetch the base branch/history before running --changed-only or pass --base-ref to an available ref", baseRef, err)
-		}
+		return nil, fmt.Errorf("%w; fetch the base branch/history before running --changed-only or pass --base-ref to an available ref", err)
 	}
 
 	headRef, err := repo.Head()
@@ -67,6 +60,22 @@ func (c *Client) GetChangedFiles(baseRef string) ([]string, error) {
 	return c.diffCommits(repo, baseHash, headRef.Hash())
 }
 
+// baseCommit returns the merge base of baseRef and HEAD, or baseRef itself
+// when the histories share no ancestor.
+func (c *Client) baseCommit(baseRef string) (plumbing.Hash, error) {
+	if baseRef == "" {
+		baseRef = defaultBaseRef
+	}
+	baseHash, err := c.getMergeBase(baseRef, "HEAD")
+	if err != nil {
+		baseHash, err = c.resolveRef(baseRef)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("resolve base ref %q: %w", baseRef, err)
+		}
+	}
+	return baseHash, nil
+}
+
 // GetChangedFilesFromCommit returns files changed in a specific commit.
 func (c *Client) GetChangedFilesFromCommit(commitHash string) ([]string, error) {
 	repo, err := c.openRepo()
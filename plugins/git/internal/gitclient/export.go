@@ -0,0 +1,135 @@
+package gitclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/caarlos0/log"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/filemode"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// ExportTree writes the files of the client's work directory as of the
+// merge base of baseRef and HEAD into destDir and returns the exported
+// commit. include, when non-nil, selects files by their slash path relative
+// to the work directory; every selected blob is read from the object store,
+// so callers should keep it to the files they need. Symlinks are skipped
+// with a warning and submodules are skipped. A work directory that did not
+// exist at the base commit exports nothing.
+func (c *Client) ExportTree(baseRef, destDir string, include func(string) bool) (plumbing.Hash, error) {
+	repo, err := c.openRepo()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("open repository: %w", err)
+	}
+	subdir, err := c.repoSubdir()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	hash, err := c.baseCommit(baseRef)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("get commit %s: %w", hash, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("get tree of %s: %w", hash, err)
+	}
+	if subdir != "" {
+		tree, err = tree.Tree(subdir)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return hash, nil
+		}
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("get tree %s at %s: %w", subdir, hash, err)
+		}
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if f.Mode == filemode.Symlink {
+			log.WithField("path", f.Name).Warn("git: symlink not exported from base revision")
+			return nil
+		}
+		if f.Mode != filemode.Regular && f.Mode != filemode.Deprecated && f.Mode != filemode.Executable {
+			return nil
+		}
+		if include != nil && !include(f.Name) {
+			return nil
+		}
+		return writeBlob(f, filepath.Join(destDir, filepath.FromSlash(f.Name)))
+	})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("export %s: %w", hash, err)
+	}
+	return hash, nil
+}
+
+// repoSubdir returns the work directory relative to the repository root in
+// slash form, or "" when it is the root.
+func (c *Client) repoSubdir() (string, error) {
+	repo, err := c.openRepo()
+	if err != nil {
+		return "", fmt.Errorf("open repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("get worktree: %w", err)
+	}
+
+	root, err := filepath.EvalSymlinks(worktree.Filesystem().Root())
+	if err != nil {
+		return "", fmt.Errorf("resolve repository root: %w", err)
+	}
+	workDir, err := filepath.Abs(c.WorkDir)
+	if err != nil {
+		return "", fmt.Errorf("resolve workdir: %w", err)
+	}
+	if workDir, err = filepath.EvalSymlinks(workDir); err != nil {
+		return "", fmt.Errorf("resolve workdir: %w", err)
+	}
+
+	rel, err := filepath.Rel(root, workDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("workdir %s is outside repository %s", workDir, root)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func writeBlob(f *object.File, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	reader, err := f.Reader()
+	if err != nil {
+		return fmt.Errorf("read %s: %w", f.Name, err)
+	}
+	defer reader.Close()
+
+	perm := os.FileMode(0o644)
+	if f.Mode == filemode.Executable {
+		perm = 0o755
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, reader)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write %s: %w", dest, err)
+	}
+	return nil
+}
@@ -16,6 +16,9 @@ type Config struct {
 	Permissions map[string]string `yaml:"permissions,omitempty" json:"permissions,omitempty" jsonschema:"description=Workflow-level permissions (e.g. id-token: write for OIDC)"`
 	JobDefaults *JobDefaults      `yaml:"job_defaults,omitempty" json:"job_defaults,omitempty" jsonschema:"description=Default settings applied to all jobs"`
 	Overwrites  []JobOverwrite    `yaml:"overwrites,omitempty" json:"overwrites,omitempty" jsonschema:"description=Job-level overrides for plan or apply jobs"`
+	Matrix      bool              `yaml:"matrix,omitempty" json:"matrix,omitempty" jsonschema:"description=Collapse same-level module jobs that share a job config into one job with a strategy.matrix,default=false"`
+	// ApprovalEnvironment gates apply jobs matched by a top-level approvals rule.
+	ApprovalEnvironment string `yaml:"approval_environment,omitempty" json:"approval_environment,omitempty" jsonschema:"description=GitHub Actions environment (with required reviewers) used for approval-gated apply jobs,default=approval"`
 }
 
 // Clone returns a deep copy of the GitHub Actions configuration.
@@ -46,3 +46,17 @@ func cloneStringMap(in map[string]string) map[string]string {
 	maps.Copy(out, in)
 	return out
 }
+
+func cloneStrategy(in *Strategy) *Strategy {
+	if in == nil {
+		return nil
+	}
+	out := &Strategy{FailFast: in.FailFast}
+	if len(in.Matrix.Include) > 0 {
+		out.Matrix.Include = make([]map[string]string, len(in.Matrix.Include))
+		for i, entry := range in.Matrix.Include {
+			out.Matrix.Include[i] = cloneStringMap(entry)
+		}
+	}
+	return out
+}
@@ -13,6 +13,7 @@ type JobOptions struct {
 	If          string
 	Environment string
 	Concurrency *Concurrency
+	Strategy    *Strategy
 	Env         map[string]string
 	Steps       []Step
 }
@@ -25,6 +26,7 @@ type Job struct {
 	ifExpr      string
 	environment string
 	concurrency *Concurrency
+	strategy    *Strategy
 	env         map[string]string
 	steps       []Step
 }
@@ -44,6 +46,7 @@ func NewJob(opts JobOptions) (Job, error) {
 		ifExpr:      opts.If,
 		environment: opts.Environment,
 		concurrency: cloneConcurrency(opts.Concurrency),
+		strategy:    cloneStrategy(opts.Strategy),
 		env:         cloneStringMap(opts.Env),
 		steps:       cloneSteps(opts.Steps),
 	}, nil
@@ -67,6 +70,8 @@ func (j Job) Environment() string { return j.environment }
 
 func (j Job) Concurrency() *Concurrency { return cloneConcurrency(j.concurrency) }
 
+func (j Job) Strategy() *Strategy { return cloneStrategy(j.strategy) }
+
 func (j Job) Env() map[string]string { return cloneStringMap(j.env) }
 
 func (j Job) Steps() []Step { return cloneSteps(j.steps) }
@@ -80,6 +85,7 @@ func (j Job) clone() Job {
 		ifExpr:      j.ifExpr,
 		environment: j.environment,
 		concurrency: cloneConcurrency(j.concurrency),
+		strategy:    cloneStrategy(j.strategy),
 		env:         cloneStringMap(j.env),
 		steps:       cloneSteps(j.steps),
 	}
@@ -23,3 +23,12 @@ type Container struct {
 	Image string            `yaml:"image"`
 	Env   map[string]string `yaml:"env,omitempty"`
 }
+
+type Strategy struct {
+	FailFast bool   `yaml:"fail-fast"`
+	Matrix   Matrix `yaml:"matrix"`
+}
+
+type Matrix struct {
+	Include []map[string]string `yaml:"include"`
+}
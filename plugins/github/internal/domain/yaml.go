@@ -45,6 +45,7 @@ func (j Job) MarshalYAML() (any, error) {
 		If          string            `yaml:"if,omitempty"`
 		Environment string            `yaml:"environment,omitempty"`
 		Concurrency *Concurrency      `yaml:"concurrency,omitempty"`
+		Strategy    *Strategy         `yaml:"strategy,omitempty"`
 		Env         map[string]string `yaml:"env,omitempty"`
 		Steps       []Step            `yaml:"steps"`
 	}{
@@ -55,6 +56,7 @@ func (j Job) MarshalYAML() (any, error) {
 		If:          j.ifExpr,
 		Environment: j.environment,
 		Concurrency: cloneConcurrency(j.concurrency),
+		Strategy:    cloneStrategy(j.strategy),
 		Env:         cloneStringMap(j.env),
 		Steps:       cloneSteps(j.steps),
 	}, nil
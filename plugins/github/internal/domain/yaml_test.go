@@ -22,3 +22,33 @@ func TestWorkflowToYAMLUsesGeneratedHeader(t *testing.T) {
 		t.Fatalf("ToYAML() missing generated header:\n%s", string(out))
 	}
 }
+
+func TestJobToYAMLRendersMatrixStrategy(t *testing.T) {
+	step := NewStep(StepOptions{Name: "Plan", Run: "cd ${{ matrix.path }}"})
+	job, err := NewJob(JobOptions{
+		RunsOn: "ubuntu-latest",
+		Strategy: &Strategy{Matrix: Matrix{Include: []map[string]string{
+			{"job": "plan-vpc", "path": "vpc"},
+			{"job": "plan-dns", "path": "dns"},
+		}}},
+		Steps: []Step{step},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := NewWorkflowBuilder(WorkflowOptions{Name: "Terraform"})
+	mustAddWorkflowJob(t, builder, "plan-dag-level-0", job)
+	out, err := mustBuildWorkflow(t, builder).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	for _, want := range []string{
+		"strategy:\n            fail-fast: false\n            matrix:\n                include:\n",
+		"- job: plan-vpc\n                      path: vpc\n",
+		"- job: plan-dns\n                      path: dns\n",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("ToYAML() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -52,8 +52,14 @@ func (g *Generator) transform(ir *pipeline.IR) (*domainpkg.Workflow, error) {
 		Permissions: g.settings.permissions(),
 		Env:         g.settings.env(),
 	})
-	builder := newJobBuilder(g.settings)
+	if g.settings.matrix() {
+		if err := g.transformMatrix(ir, workflow); err != nil {
+			return nil, err
+		}
+		return workflow.Build()
+	}
 
+	builder := newJobBuilder(g.settings)
 	jobs := ir.Jobs()
 	for i := range jobs {
 		irJob := jobs[i]
@@ -1,10 +1,16 @@
 package generate
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
 	configpkg "github.com/edelwud/terraci/plugins/github/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/github/internal/domain"
 )
 
 func testContribution(tb testing.TB, opts ...pipeline.ContributedJobOptions) *pipeline.Contribution {
@@ -306,3 +312,153 @@ func TestGenerate_ArtifactRestoreContract(t *testing.T) {
 		stepWith("Upload cost-estimation results", "name", resultArtifact.Name).
 		stepWith("Upload cost-estimation results", "include-hidden-files", "true")
 }
+
+func TestGenerate_MatrixModeFoldsSameLevelModules(t *testing.T) {
+	vpc := createTestModule("vpc")
+	dns := createTestModule("dns")
+	workflow := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) { cfg.Matrix = true }).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "terraci-summary",
+			Commands: []string{"terraci summary"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+		}))).
+		withModules(vpc, dns).
+		withDependencies(map[string][]string{vpc.ID(): {}, dns.ID(): {}}).
+		generate()
+
+	assertWorkflow(t, workflow).
+		noJob("plan-platform-stage-eu-central-1-vpc").
+		noJob("plan-platform-stage-eu-central-1-dns").
+		hasJob("plan-dag-level-0")
+	assertWorkflow(t, workflow).
+		job("terraci-summary").
+		hasNeed("plan-dag-level-0").
+		stepWith("Download "+pipeline.PlanArtifactName("plan-platform-stage-eu-central-1-vpc"), "name", pipeline.PlanArtifactName("plan-platform-stage-eu-central-1-vpc"))
+
+	planJob, _ := workflow.Job("plan-dag-level-0")
+	strategy := planJob.Strategy()
+	if strategy == nil || strategy.FailFast || len(strategy.Matrix.Include) != 2 {
+		t.Fatalf("plan matrix strategy = %+v, want 2 entries without fail-fast", strategy)
+	}
+	for _, entry := range strategy.Matrix.Include {
+		if entry["artifact"] != pipeline.PlanArtifactName(entry["job"]) {
+			t.Errorf("matrix entry %v: artifact not named after its job", entry)
+		}
+	}
+}
+
+func TestGenerate_MatrixModeKeepsSingleJobsAndSplitsLargeLevels(t *testing.T) {
+	modules := make([]*discovery.Module, 0, maxMatrixEntries+1)
+	deps := make(map[string][]string, maxMatrixEntries+1)
+	for i := range maxMatrixEntries + 1 {
+		module := createTestModule(fmt.Sprintf("m%03d", i))
+		modules = append(modules, module)
+		deps[module.ID()] = nil
+	}
+	workflow := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) { cfg.Matrix = true }).
+		withPlanOnly().
+		withModules(modules...).
+		withDependencies(deps).
+		generate()
+
+	assertWorkflow(t, workflow).jobCount(2).hasJob("plan-dag-level-0").hasJob("plan-dag-level-0-2")
+
+	single := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) { cfg.Matrix = true }).
+		withModules(createTestModule("vpc")).
+		generate()
+	assertWorkflow(t, single).
+		hasJob("plan-platform-stage-eu-central-1-vpc").
+		hasJob("apply-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_ApprovalGatedApplyUsesApprovalEnvironment(t *testing.T) {
+	module := citest.TestModule("platform", "prod", "eu-central-1", "vpc")
+	ir := pipelinetest.MustGatedSingleModuleIR(t, module)
+
+	generated, err := NewGenerator(&configpkg.Config{RunsOn: "ubuntu-latest"}, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	workflow, ok := generated.(*domainpkg.Workflow)
+	if !ok {
+		t.Fatalf("Generate() = %T, want *domain.Workflow", generated)
+	}
+	assertWorkflow(t, workflow).job("plan-platform-prod-eu-central-1-vpc").noEnvironment()
+	assertWorkflow(t, workflow).job("apply-platform-prod-eu-central-1-vpc").environment(defaultApprovalEnvironment)
+
+	generated, err = NewGenerator(&configpkg.Config{
+		RunsOn:              "ubuntu-latest",
+		ApprovalEnvironment: "prod-eu",
+		Overwrites: []configpkg.JobOverwrite{{
+			Type:        configpkg.OverwriteTypeApply,
+			Environment: "prod-eu",
+		}},
+	}, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	assertWorkflow(t, generated.(*domainpkg.Workflow)).job("apply-platform-prod-eu-central-1-vpc").environment("prod-eu")
+}
+
+func TestGenerate_ApprovalGatedApplyRejectsOtherEnvironment(t *testing.T) {
+	module := citest.TestModule("platform", "prod", "eu-central-1", "vpc")
+	ir := pipelinetest.MustGatedSingleModuleIR(t, module)
+
+	for _, matrix := range []bool{false, true} {
+		_, err := NewGenerator(&configpkg.Config{
+			RunsOn:              "ubuntu-latest",
+			Matrix:              matrix,
+			ApprovalEnvironment: "production",
+			Overwrites: []configpkg.JobOverwrite{{
+				Type:        configpkg.OverwriteTypeApply,
+				Environment: "prod-eu",
+			}},
+		}, ir).Generate()
+		if err == nil || !strings.Contains(err.Error(), `"prod-eu" is not approval_environment "production"`) {
+			t.Fatalf("Generate(matrix=%v) error = %v, want the dropped approval gate rejected", matrix, err)
+		}
+	}
+
+	// Plan jobs are not gated, so the override still applies to them.
+	generated, err := NewGenerator(&configpkg.Config{
+		RunsOn: "ubuntu-latest",
+		Overwrites: []configpkg.JobOverwrite{{
+			Type:        configpkg.OverwriteTypePlan,
+			Environment: "prod-eu",
+		}},
+	}, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	assertWorkflow(t, generated.(*domainpkg.Workflow)).job("plan-platform-prod-eu-central-1-vpc").environment("prod-eu")
+}
+
+func TestGenerate_AlwaysRunContributionRunsAfterFailures(t *testing.T) {
+	module := createTestModule("vpc")
+	workflow := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "drift-collector",
+			Commands: []string{"terraci drift"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			AlwaysRun: true,
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		withPlanOnly().
+		generate()
+
+	assertWorkflow(t, workflow).
+		job("drift-collector").
+		hasNeed("plan-platform-stage-eu-central-1-vpc").
+		condition("always()")
+	assertWorkflow(t, workflow).
+		job("plan-platform-stage-eu-central-1-vpc").
+		condition("")
+}
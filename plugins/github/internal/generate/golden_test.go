@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/edelwud/terraci/pkg/discovery"
+	configpkg "github.com/edelwud/terraci/plugins/github/internal/config"
 )
 
 // updateGolden allows refreshing the YAML fixtures with `go test -update`.
@@ -54,6 +55,21 @@ func TestGoldenYAML(t *testing.T) {
 			},
 			goldenRel: "testdata/golden/plan_only.yaml",
 		},
+		{
+			name: "matrix_same_level",
+			scenario: func(t *testing.T) *generatorScenario {
+				vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+				dns := discovery.TestModule("platform", "stage", "eu-central-1", "dns")
+				eks := discovery.TestModule("platform", "stage", "eu-central-1", "eks")
+				return newGeneratorScenario(t).
+					withConfig(func(cfg *configpkg.Config) { cfg.Matrix = true }).
+					withModules(vpc, dns, eks).
+					withDependencies(map[string][]string{
+						eks.ID(): {vpc.ID(), dns.ID()},
+					})
+			},
+			goldenRel: "testdata/golden/matrix_same_level.yaml",
+		},
 	}
 
 	for _, tc := range cases {
@@ -17,10 +17,13 @@ const (
 
 type jobBuilder struct {
 	settings settings
+	// aliases maps IR job names to the workflow job that runs them. Only
+	// populated in matrix mode, where several IR jobs share one workflow job.
+	aliases map[string]string
 }
 
 func newJobBuilder(settings settings) jobBuilder {
-	return jobBuilder{settings: settings}
+	return jobBuilder{settings: settings, aliases: make(map[string]string)}
 }
 
 func (b jobBuilder) renderJob(irJob pipeline.Job) (domainpkg.Job, error) {
@@ -39,15 +42,7 @@ func (b jobBuilder) renderJob(irJob pipeline.Job) (domainpkg.Job, error) {
 	}
 	steps = append(steps, profile.stepsBefore...)
 	operation := irJob.Operation()
-	scriptLines := cishell.RenderOperation(operation)
-	if irJob.AllowFailure() {
-		scriptLines = nil
-		for _, command := range cishell.RenderOperation(operation) {
-			scriptLines = append(scriptLines, command+" || true")
-		}
-	}
-
-	steps = append(steps, runStep(runStepName(irJob), strings.Join(scriptLines, "\n")))
+	steps = append(steps, runStep(runStepName(irJob), strings.Join(renderScript(irJob), "\n")))
 	steps = append(steps, profile.stepsAfter...)
 	outputArtifact := irJob.OutputArtifact()
 	if outputArtifact.Configured() {
@@ -69,13 +64,18 @@ func (b jobBuilder) renderJob(irJob pipeline.Job) (domainpkg.Job, error) {
 		)
 	}
 
+	environment, err := b.environment(irJob, profile)
+	if err != nil {
+		var zero domainpkg.Job
+		return zero, err
+	}
 	job := domainpkg.JobOptions{
 		RunsOn:      profile.runsOn,
-		Needs:       pipeline.DependencyNames(irJob.Dependencies()),
+		Needs:       b.needs(irJob),
 		Env:         mergeJobEnv(irJob.Env(), profile.env),
 		Steps:       steps,
-		If:          profile.ifExpr,
-		Environment: profile.environment,
+		If:          jobIf(irJob, profile.ifExpr),
+		Environment: environment,
 	}
 	if profile.container != nil {
 		job.Container = profile.container
@@ -89,6 +89,65 @@ func (b jobBuilder) renderJob(irJob pipeline.Job) (domainpkg.Job, error) {
 	return domainpkg.NewJob(job)
 }
 
+// environment returns the job's GitHub environment. Approval-gated jobs run
+// in the approval environment, whose required reviewers hold the job until it
+// is approved. A configured environment other than the approval environment
+// would silently drop the gate, so it is rejected for those jobs.
+func (b jobBuilder) environment(irJob pipeline.Job, profile jobProfile) (string, error) {
+	if !irJob.RequiresApproval() {
+		return profile.environment, nil
+	}
+	approval := b.settings.approvalEnvironment()
+	if profile.environment != "" && profile.environment != approval {
+		return "", fmt.Errorf("%s: requires approval, but its configured environment %q is not approval_environment %q; set approval_environment to %q or drop the environment override",
+			irJob.Name(), profile.environment, approval, profile.environment)
+	}
+	return approval, nil
+}
+
+// jobIf returns the job condition. Always-run jobs start even when a needed
+// job failed; a configured condition still applies on top.
+func jobIf(irJob pipeline.Job, configured string) string {
+	if !irJob.AlwaysRun() {
+		return configured
+	}
+	if configured == "" {
+		return "always()"
+	}
+	return "always() && (" + configured + ")"
+}
+
+// needs resolves the job's dependencies to workflow job names, collapsing
+// dependencies that were folded into the same matrix job.
+func (b jobBuilder) needs(irJobs ...pipeline.Job) []string {
+	var needs []string
+	seen := make(map[string]bool)
+	for i := range irJobs {
+		for _, name := range pipeline.DependencyNames(irJobs[i].Dependencies()) {
+			if alias, ok := b.aliases[name]; ok {
+				name = alias
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			needs = append(needs, name)
+		}
+	}
+	return needs
+}
+
+func renderScript(irJob pipeline.Job) []string {
+	scriptLines := cishell.RenderOperation(irJob.Operation())
+	if !irJob.AllowFailure() {
+		return scriptLines
+	}
+	for i, command := range scriptLines {
+		scriptLines[i] = command + " || true"
+	}
+	return scriptLines
+}
+
 func jobOverwriteType(irJob pipeline.Job) configpkg.JobOverwriteType {
 	switch irJob.Operation().Type() {
 	case pipeline.OperationTypeTerraformPlan:
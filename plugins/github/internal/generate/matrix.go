@@ -0,0 +1,264 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	domainpkg "github.com/edelwud/terraci/plugins/github/internal/domain"
+)
+
+// maxMatrixEntries is the GitHub Actions limit on jobs generated by a single
+// matrix; larger buckets are split across several matrix jobs.
+const maxMatrixEntries = 256
+
+const (
+	matrixKeyJob      = "job"
+	matrixKeyModule   = "module"
+	matrixKeyPath     = "path"
+	matrixKeyArtifact = "artifact"
+	matrixInputPrefix = "input_"
+)
+
+// matrixBucket is a set of same-level module jobs that render to the same
+// job body once their module path is replaced by a matrix expression.
+type matrixBucket struct {
+	key  string
+	jobs []pipeline.Job
+}
+
+// transformMatrix renders the IR level by level, folding module jobs that
+// share a job config into one job with a strategy.matrix. Levels are
+// processed in order so every dependency alias exists before a dependent
+// job resolves its needs.
+func (g *Generator) transformMatrix(ir *pipeline.IR, workflow *domainpkg.WorkflowBuilder) error {
+	groups, err := pipeline.Schedule(ir)
+	if err != nil {
+		return err
+	}
+
+	builder := newJobBuilder(g.settings)
+	for _, group := range groups {
+		var singles []pipeline.Job
+		var buckets []*matrixBucket
+		for _, irJob := range group.Jobs() {
+			key, ok := matrixKey(irJob)
+			if !ok {
+				singles = append(singles, irJob)
+				continue
+			}
+			idx := slices.IndexFunc(buckets, func(b *matrixBucket) bool { return b.key == key })
+			if idx < 0 {
+				buckets = append(buckets, &matrixBucket{key: key})
+				idx = len(buckets) - 1
+			}
+			buckets[idx].jobs = append(buckets[idx].jobs, irJob)
+		}
+
+		names := make(map[string]int)
+		for _, bucket := range buckets {
+			if len(bucket.jobs) == 1 {
+				singles = append(singles, bucket.jobs[0])
+				continue
+			}
+			for chunk := range slices.Chunk(bucket.jobs, maxMatrixEntries) {
+				name := matrixJobName(bucket.jobs[0], group.Name(), names)
+				job, err := builder.renderMatrixJob(chunk)
+				if err != nil {
+					return err
+				}
+				if err := workflow.AddJob(name, job); err != nil {
+					return err
+				}
+				for i := range chunk {
+					builder.aliases[chunk[i].Name()] = name
+				}
+			}
+		}
+
+		for i := range singles {
+			job, err := builder.renderJob(singles[i])
+			if err != nil {
+				return err
+			}
+			if err := workflow.AddJob(singles[i].Name(), job); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matrixKey fingerprints everything about a module job that must be shared
+// by all entries of a matrix. Command jobs are never folded.
+func matrixKey(irJob pipeline.Job) (string, bool) {
+	path, ok := modulePath(irJob)
+	if !ok || irJob.Module() == nil {
+		return "", false
+	}
+	parts := []string{
+		string(irJob.Kind()),
+		string(jobOverwriteType(irJob)),
+		strconv.FormatBool(irJob.AllowFailure()),
+		strconv.FormatBool(irJob.RequiresApproval()),
+		strings.Join(templateModulePath(renderScript(irJob), path), "\n"),
+	}
+	envKeys := make([]string, 0, len(irJob.Env()))
+	for key := range irJob.Env() {
+		envKeys = append(envKeys, key)
+	}
+	slices.Sort(envKeys)
+	parts = append(parts, strings.Join(envKeys, ","))
+	if output := irJob.OutputArtifact(); output.Configured() {
+		parts = append(parts, "output:"+strings.Join(templateModulePath(output.Paths, path), ","))
+	}
+	for _, input := range irJob.InputArtifacts() {
+		if input.Configured() {
+			parts = append(parts, "input:"+strconv.FormatBool(input.Optional))
+		}
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+func matrixJobName(first pipeline.Job, level string, names map[string]int) string {
+	base := first.Kind().NamePrefix() + "-" + level
+	names[base]++
+	if n := names[base]; n > 1 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+	return base
+}
+
+// renderMatrixJob renders a bucket of compatible module jobs as one job.
+// Per-module values (module path, artifact names, differing env values) are
+// moved into matrix entries and referenced through ${{ matrix.* }}.
+func (b jobBuilder) renderMatrixJob(irJobs []pipeline.Job) (domainpkg.Job, error) {
+	first := irJobs[0]
+	profile, err := b.settings.jobProfile(jobOverwriteType(first))
+	if err != nil {
+		var zero domainpkg.Job
+		return zero, err
+	}
+
+	include := make([]map[string]string, len(irJobs))
+	for i := range irJobs {
+		path, _ := modulePath(irJobs[i])
+		entry := map[string]string{
+			matrixKeyJob:    irJobs[i].Name(),
+			matrixKeyModule: irJobs[i].Module().ID(),
+			matrixKeyPath:   path,
+		}
+		if output := irJobs[i].OutputArtifact(); output.Configured() {
+			entry[matrixKeyArtifact] = output.Name
+		}
+		inputIdx := 0
+		for _, input := range irJobs[i].InputArtifacts() {
+			if !input.Configured() {
+				continue
+			}
+			entry[matrixInputPrefix+strconv.Itoa(inputIdx)] = input.Artifact.Name
+			inputIdx++
+		}
+		include[i] = entry
+	}
+
+	env := first.Env()
+	for key, value := range env {
+		for i := range irJobs[1:] {
+			if irJobs[i+1].Env()[key] != value {
+				env[key] = matrixExpr(key)
+				for j := range irJobs {
+					include[j][key] = irJobs[j].Env()[key]
+				}
+				break
+			}
+		}
+	}
+
+	firstPath, _ := modulePath(first)
+	steps := []domainpkg.Step{checkoutStep()}
+	inputIdx := 0
+	for _, input := range first.InputArtifacts() {
+		if !input.Configured() {
+			continue
+		}
+		name := matrixExpr(matrixInputPrefix + strconv.Itoa(inputIdx))
+		steps = append(steps, downloadArtifactStep("Download "+name, name, input.Optional))
+		inputIdx++
+	}
+	steps = append(steps, profile.stepsBefore...)
+	script := templateModulePath(renderScript(first), firstPath)
+	steps = append(steps, runStep(matrixRunStepName(first), strings.Join(script, "\n")))
+	steps = append(steps, profile.stepsAfter...)
+	if output := first.OutputArtifact(); output.Configured() {
+		artifact := pipeline.Artifact{
+			Name:  matrixExpr(matrixKeyArtifact),
+			Paths: templateModulePath(output.Paths, firstPath),
+		}
+		stageName, uploadName := "Stage plan artifacts", "Upload plan artifacts"
+		if first.Operation().Type() != pipeline.OperationTypeTerraformPlan {
+			stageName = fmt.Sprintf("Stage %s artifacts", matrixExpr(matrixKeyJob))
+			uploadName = fmt.Sprintf("Upload %s artifacts", matrixExpr(matrixKeyJob))
+		}
+		steps = append(steps,
+			stageArtifactStep(stageName, artifact, artifactRequired(first)),
+			uploadArtifactStep(uploadName, artifact),
+		)
+	}
+
+	environment, err := b.environment(first, profile)
+	if err != nil {
+		var zero domainpkg.Job
+		return zero, err
+	}
+	job := domainpkg.JobOptions{
+		Name:        matrixExpr(matrixKeyJob),
+		RunsOn:      profile.runsOn,
+		Needs:       b.needs(irJobs...),
+		Env:         mergeJobEnv(env, profile.env),
+		Steps:       steps,
+		If:          profile.ifExpr,
+		Environment: environment,
+		Concurrency: &domainpkg.Concurrency{
+			Group:            matrixExpr(matrixKeyModule),
+			CancelInProgress: false,
+		},
+		Strategy: &domainpkg.Strategy{
+			FailFast: false,
+			Matrix:   domainpkg.Matrix{Include: include},
+		},
+	}
+	if profile.container != nil {
+		job.Container = profile.container
+	}
+	return domainpkg.NewJob(job)
+}
+
+func matrixRunStepName(irJob pipeline.Job) string {
+	if irJob.Operation().Type() == pipeline.OperationTypeTerraformApply {
+		return "Apply " + matrixExpr(matrixKeyModule)
+	}
+	return "Plan " + matrixExpr(matrixKeyModule)
+}
+
+func modulePath(irJob pipeline.Job) (string, bool) {
+	op := irJob.Operation().Terraform()
+	if op == nil || op.ModulePath() == "" {
+		return "", false
+	}
+	return op.ModulePath(), true
+}
+
+func templateModulePath(lines []string, path string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = strings.ReplaceAll(line, path, matrixExpr(matrixKeyPath))
+	}
+	return out
+}
+
+func matrixExpr(key string) string {
+	return "${{ matrix." + key + " }}"
+}
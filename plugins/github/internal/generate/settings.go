@@ -14,6 +14,10 @@ func newSettings(cfg *configpkg.Config) settings {
 	return settings{config: cfg}
 }
 
+// defaultApprovalEnvironment is the GitHub environment used for
+// approval-gated applies when approval_environment is not set.
+const defaultApprovalEnvironment = "approval"
+
 func (s settings) configOrDefault() *configpkg.Config {
 	if s.config == nil {
 		return &configpkg.Config{
@@ -39,3 +43,14 @@ func (s settings) permissions() map[string]string {
 		"pull-requests": "write",
 	}
 }
+
+func (s settings) matrix() bool {
+	return s.configOrDefault().Matrix
+}
+
+func (s settings) approvalEnvironment() string {
+	if env := s.configOrDefault().ApprovalEnvironment; env != "" {
+		return env
+	}
+	return defaultApprovalEnvironment
+}
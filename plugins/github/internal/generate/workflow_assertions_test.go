@@ -160,3 +160,11 @@ func (a *jobAssert) stepWith(stepName, key, expected string) *jobAssert {
 	a.t.Fatalf("expected job %q to have step named %q", a.name, stepName)
 	return a
 }
+
+func (a *jobAssert) condition(expected string) *jobAssert {
+	a.t.Helper()
+	if a.job.If() != expected {
+		a.t.Fatalf("expected job %q if=%q, got %q", a.name, expected, a.job.If())
+	}
+	return a
+}
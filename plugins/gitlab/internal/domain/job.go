@@ -78,20 +78,23 @@ type Reports struct {
 }
 
 type JobOptions struct {
-	Stage         string
-	Image         *ImageConfig
-	Script        []string
-	BeforeScript  []string
-	AfterScript   []string
-	Variables     map[string]string
-	Needs         []JobNeed
-	Rules         []Rule
-	Artifacts     *Artifacts
-	Cache         *Cache
-	Secrets       map[string]*Secret
-	IDTokens      map[string]*IDToken
-	When          string
-	AllowFailure  bool
+	Stage        string
+	Image        *ImageConfig
+	Script       []string
+	BeforeScript []string
+	AfterScript  []string
+	Variables    map[string]string
+	Needs        []JobNeed
+	Rules        []Rule
+	Artifacts    *Artifacts
+	Cache        *Cache
+	Secrets      map[string]*Secret
+	IDTokens     map[string]*IDToken
+	When         string
+	AllowFailure bool
+	// Blocking renders an explicit allow_failure: false so a manual job holds
+	// back its dependents until it is triggered.
+	Blocking      bool
 	Tags          []string
 	ResourceGroup string
 }
@@ -112,6 +115,7 @@ type Job struct {
 	idTokens      map[string]*IDToken
 	when          string
 	allowFailure  bool
+	blocking      bool
 	tags          []string
 	resourceGroup string
 }
@@ -138,6 +142,7 @@ func NewJob(opts JobOptions) (Job, error) {
 		idTokens:      cloneIDTokens(opts.IDTokens),
 		when:          opts.When,
 		allowFailure:  opts.AllowFailure,
+		blocking:      opts.Blocking,
 		tags:          append([]string(nil), opts.Tags...),
 		resourceGroup: opts.ResourceGroup,
 	}, nil
@@ -171,6 +176,8 @@ func (j Job) When() string { return j.when }
 
 func (j Job) AllowFailure() bool { return j.allowFailure }
 
+func (j Job) Blocking() bool { return j.blocking }
+
 func (j Job) Tags() []string { return append([]string(nil), j.tags...) }
 
 func (j Job) ResourceGroup() string { return j.resourceGroup }
@@ -200,6 +207,7 @@ func (j Job) clone() Job {
 		idTokens:      cloneIDTokens(j.idTokens),
 		when:          j.when,
 		allowFailure:  j.allowFailure,
+		blocking:      j.blocking,
 		tags:          append([]string(nil), j.tags...),
 		resourceGroup: j.resourceGroup,
 	}
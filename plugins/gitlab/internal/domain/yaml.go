@@ -81,7 +81,7 @@ func (j Job) MarshalYAML() (any, error) {
 		Secrets       map[string]*Secret  `yaml:"secrets,omitempty"`
 		IDTokens      map[string]*IDToken `yaml:"id_tokens,omitempty"`
 		When          string              `yaml:"when,omitempty"`
-		AllowFailure  bool                `yaml:"allow_failure,omitempty"`
+		AllowFailure  *bool               `yaml:"allow_failure,omitempty"`
 		Tags          []string            `yaml:"tags,omitempty"`
 		ResourceGroup string              `yaml:"resource_group,omitempty"`
 	}{
@@ -98,8 +98,18 @@ func (j Job) MarshalYAML() (any, error) {
 		Secrets:       cloneSecrets(j.secrets),
 		IDTokens:      cloneIDTokens(j.idTokens),
 		When:          j.when,
-		AllowFailure:  j.allowFailure,
+		AllowFailure:  j.allowFailureYAML(),
 		Tags:          append([]string(nil), j.tags...),
 		ResourceGroup: j.resourceGroup,
 	}, nil
 }
+
+// allowFailureYAML omits allow_failure unless the job allows failure or is a
+// blocking manual job, where GitLab's implicit default (true) must be overridden.
+func (j Job) allowFailureYAML() *bool {
+	if !j.allowFailure && !j.blocking {
+		return nil
+	}
+	allow := j.allowFailure
+	return &allow
+}
@@ -9,6 +9,7 @@ import (
 const (
 	DefaultStagesPrefix = "deploy"
 	WhenManual          = "manual"
+	WhenAlways          = "always"
 )
 
 // Generator transforms TerraCi IR into GitLab CI domain models. The IR is
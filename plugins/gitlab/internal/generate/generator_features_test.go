@@ -329,3 +329,51 @@ func TestGenerator_Generate_WithPolicyCheck(t *testing.T) {
 		t.Fatalf("policy-check artifact paths = %v, want policy report", artifacts.Paths)
 	}
 }
+
+func TestGenerator_Generate_AlwaysRunContribution(t *testing.T) {
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	p := newGeneratorScenario(t).
+		withModules(vpc).
+		withDependencies(map[string][]string{vpc.ID(): {}}).
+		withPlanOnly().
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "drift-collector",
+			Commands: []string{"terraci drift"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			AlwaysRun: true,
+		}))).
+		generate()
+
+	collector := mustJob(t, p, "drift-collector")
+	if collector.When() != WhenAlways {
+		t.Errorf("drift-collector when = %q, want %q", collector.When(), WhenAlways)
+	}
+	needs := collector.Needs()
+	if len(needs) != 1 || needs[0].Job != "plan-platform-stage-eu-central-1-vpc" || !needs[0].Optional {
+		t.Errorf("drift-collector needs = %+v, want one optional plan need", needs)
+	}
+	if plan := mustJob(t, p, "plan-platform-stage-eu-central-1-vpc"); plan.When() == WhenAlways {
+		t.Error("plan job should not run always")
+	}
+}
+
+func TestGenerator_Generate_TerragruntCachesTerragruntCache(t *testing.T) {
+	vpc := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
+	p := newGeneratorScenario(t).
+		withTerraformConfig(func(opts *pipeline.TerraformJobConfigOptions) {
+			opts.Binary = "terragrunt"
+		}).
+		withModules(vpc).
+		withDependencies(map[string][]string{vpc.ID(): {}}).
+		generate()
+
+	cache := mustJob(t, p, "plan-platform-stage-eu-central-1-vpc").Cache()
+	if cache == nil || !slices.Equal(cache.Paths, []string{"platform/stage/eu-central-1/vpc/.terragrunt-cache/"}) {
+		t.Errorf("cache = %+v, want the unit's .terragrunt-cache", cache)
+	}
+	assertPipeline(t, p).
+		job("plan-platform-stage-eu-central-1-vpc").
+		scriptContains("terragrunt plan -out=$PWD/plan.tfplan")
+}
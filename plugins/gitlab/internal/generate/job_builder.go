@@ -39,14 +39,21 @@ func (b jobBuilder) renderJob(irJob pipeline.Job) (domain.Job, error) {
 	}
 
 	if module := irJob.Module(); module != nil {
-		job.Cache = b.cache(module)
-		job.ResourceGroup = module.ID()
+		job.Cache = b.cache(module, cacheDir(irJob))
+		job.ResourceGroup = resourceGroup(module)
 	}
 
 	if err := applyResolvedJobConfig(b.settings, &job, jobOverwriteType(irJob)); err != nil {
 		var zero domain.Job
 		return zero, err
 	}
+	if irJob.AlwaysRun() {
+		job.When = WhenAlways
+	}
+	if irJob.RequiresApproval() {
+		job.When = WhenManual
+		job.Blocking = true
+	}
 	return domain.NewJob(job)
 }
 
@@ -63,14 +70,14 @@ func jobOverwriteType(irJob pipeline.Job) configpkg.JobOverwriteType {
 	}
 }
 
-func (b jobBuilder) cache(module *discovery.Module) *domain.Cache {
+func (b jobBuilder) cache(module *discovery.Module, dir string) *domain.Cache {
 	if !b.settings.cacheEnabled() {
 		return nil
 	}
 
 	return &domain.Cache{
 		Key:    renderCacheTemplate(b.settings.cacheKeyTemplate(), module, cacheKey(module)),
-		Paths:  cachePaths(module, b.settings.cachePathTemplates()),
+		Paths:  cachePaths(module, b.settings.cachePathTemplates(), dir),
 		Policy: b.settings.cachePolicy(),
 	}
 }
@@ -79,9 +86,26 @@ func cacheKey(module *discovery.Module) string {
 	return strings.ReplaceAll(module.ID(), "/", "-")
 }
 
-func cachePaths(module *discovery.Module, templates []string) []string {
+// resourceGroup serializes jobs of one module and workspace. GitLab does
+// not accept "@" in resource group names, so the workspace becomes a path
+// segment.
+func resourceGroup(module *discovery.Module) string {
+	return strings.ReplaceAll(module.ID(), discovery.WorkspaceSeparator, "/")
+}
+
+// cacheDir returns the module-local directory the job's binary downloads
+// providers and modules into.
+func cacheDir(irJob pipeline.Job) string {
+	if op := irJob.Operation().Terraform(); op != nil {
+		return op.CacheDir()
+	}
+	return pipeline.TerraformCacheDir
+}
+
+func cachePaths(module *discovery.Module, templates []string, dir string) []string {
+	defaultPaths := []string{module.RelativePath + "/" + dir + "/"}
 	if len(templates) == 0 {
-		return []string{module.ID() + "/.terraform/"}
+		return defaultPaths
 	}
 
 	paths := make([]string, 0, len(templates))
@@ -94,7 +118,7 @@ func cachePaths(module *discovery.Module, templates []string) []string {
 	}
 
 	if len(paths) == 0 {
-		return []string{module.ID() + "/.terraform/"}
+		return defaultPaths
 	}
 
 	return paths
@@ -106,7 +130,7 @@ func renderCacheTemplate(template string, module *discovery.Module, fallback str
 	}
 
 	replacer := strings.NewReplacer(
-		"{module_path}", module.ID(),
+		"{module_path}", module.RelativePath,
 		"{service}", module.Get("service"),
 		"{environment}", module.Get("environment"),
 		"{region}", module.Get("region"),
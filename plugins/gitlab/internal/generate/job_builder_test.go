@@ -10,6 +10,7 @@ import (
 	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
 	"github.com/edelwud/terraci/pkg/workflow"
 	configpkg "github.com/edelwud/terraci/plugins/gitlab/internal/config"
+	"go.yaml.in/yaml/v4"
 )
 
 func TestJobBuilderRenderJobBuildsModuleDefaults(t *testing.T) {
@@ -46,6 +47,40 @@ func TestJobBuilderRenderJobBuildsModuleDefaults(t *testing.T) {
 	}
 }
 
+func TestJobBuilderRenderJobGatesApprovalRequiredApply(t *testing.T) {
+	t.Parallel()
+
+	module := discovery.TestModule("platform", "prod", "eu-central-1", "vpc")
+	ir := pipelinetest.MustGatedSingleModuleIR(t, module)
+	builder := newJobBuilder(newSettings(&configpkg.Config{}), map[string]string{
+		"plan-platform-prod-eu-central-1-vpc":  "deploy-0",
+		"apply-platform-prod-eu-central-1-vpc": "deploy-1",
+	})
+
+	apply, err := builder.renderJob(pipelinetest.MustJobByKind(t, ir, pipeline.JobKindApply))
+	if err != nil {
+		t.Fatalf("renderJob(apply) error = %v", err)
+	}
+	if apply.When() != WhenManual || !apply.Blocking() {
+		t.Fatalf("apply when = %q, blocking = %v, want blocking manual job", apply.When(), apply.Blocking())
+	}
+	rendered, err := yaml.Marshal(apply)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(rendered), "allow_failure: false") {
+		t.Fatalf("rendered apply = %s, want explicit allow_failure: false", rendered)
+	}
+
+	plan, err := builder.renderJob(pipelinetest.MustJobByKind(t, ir, pipeline.JobKindPlan))
+	if err != nil {
+		t.Fatalf("renderJob(plan) error = %v", err)
+	}
+	if plan.When() != "" || plan.Blocking() {
+		t.Fatalf("plan when = %q, blocking = %v, want ungated plan", plan.When(), plan.Blocking())
+	}
+}
+
 func TestJobBuilderCacheSupportsAdvancedOptions(t *testing.T) {
 	t.Parallel()
 
@@ -63,7 +98,7 @@ func TestJobBuilderCacheSupportsAdvancedOptions(t *testing.T) {
 	)
 
 	module := discovery.TestModule("platform", "stage", "eu-central-1", "vpc")
-	cache := builder.cache(module)
+	cache := builder.cache(module, pipeline.TerraformCacheDir)
 	if cache == nil {
 		t.Fatal("expected cache to be populated")
 	}
@@ -32,6 +32,12 @@ func (s *generatorScenario) withConfig(apply func(*Config)) *generatorScenario {
 	return s
 }
 
+func (s *generatorScenario) withContributions(contributions pipeline.ContributionSet) *generatorScenario {
+	s.t.Helper()
+	s.cfg.Contributions = contributions
+	return s
+}
+
 func (s *generatorScenario) withTerraformConfig(apply func(*pipeline.TerraformJobConfigOptions)) *generatorScenario {
 	s.t.Helper()
 	opts := s.cfg.Terraform
@@ -0,0 +1,35 @@
+// Package parsecache opens the persistent parse cache for plugins.
+package parsecache
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/pkg/parser"
+	"github.com/edelwud/terraci/pkg/plugin"
+)
+
+// Open returns the parse cache configured by the parse_cache section of the
+// project config, or nil when it is disabled. Entries past
+// parse_cache.max_age are pruned first.
+func Open(ctx context.Context, appCtx *plugin.AppContext) (*parser.ModuleCache, error) {
+	cfg := appCtx.Config().ParseCache()
+	if cfg == nil || !cfg.Enabled() {
+		return nil, nil
+	}
+	provider, err := appCtx.BlobStoreResolver().ResolveBlobStoreProvider(cfg.Backend(), "set parse_cache.backend explicitly")
+	if err != nil {
+		return nil, fmt.Errorf("resolve blob backend: %w", err)
+	}
+	store, err := provider.NewBlobStore(ctx, appCtx, plugin.BlobStoreOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create blob backend %q: %w", provider.Name(), err)
+	}
+	cache := parser.NewModuleCache(store, appCtx.Version(), cfg.MaxAge())
+	if err := cache.Prune(ctx); err != nil {
+		log.WithError(err).Warn("prune parse cache")
+	}
+	return cache, nil
+}
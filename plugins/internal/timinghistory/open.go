@@ -0,0 +1,28 @@
+// Package timinghistory opens the job timing history for plugins.
+package timinghistory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/timings"
+)
+
+// Open returns the timing history configured by the timings section of the
+// project config, or nil when it is disabled.
+func Open(ctx context.Context, appCtx *plugin.AppContext) (*timings.History, error) {
+	cfg := appCtx.Config().Timings()
+	if cfg == nil || !cfg.Enabled() {
+		return nil, nil
+	}
+	provider, err := appCtx.BlobStoreResolver().ResolveBlobStoreProvider(cfg.Backend(), "set timings.backend explicitly")
+	if err != nil {
+		return nil, fmt.Errorf("resolve blob backend: %w", err)
+	}
+	store, err := provider.NewBlobStore(ctx, appCtx, plugin.BlobStoreOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create blob backend %q: %w", provider.Name(), err)
+	}
+	return timings.NewHistory(store, cfg.Samples()), nil
+}
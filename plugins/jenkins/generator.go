@@ -0,0 +1,27 @@
+package jenkins
+
+import (
+	"os"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	generatepkg "github.com/edelwud/terraci/plugins/jenkins/internal/generate"
+)
+
+// ProviderName returns the provider name.
+func (p *Plugin) ProviderName() string { return p.Name() }
+
+// DetectEnv returns true if running in a Jenkins build.
+func (p *Plugin) DetectEnv() bool {
+	return os.Getenv("JENKINS_URL") != ""
+}
+
+// PipelineID returns the Jenkins build tag, which is unique across jobs.
+func (p *Plugin) PipelineID() string { return os.Getenv("BUILD_TAG") }
+
+// CommitSHA returns the commit SHA checked out by the Jenkins git plugin.
+func (p *Plugin) CommitSHA() string { return os.Getenv("GIT_COMMIT") }
+
+// NewGenerator creates a new Jenkinsfile generator bound to the pre-built IR.
+func (p *Plugin) NewGenerator(ir *pipeline.IR) (pipeline.Generator, error) {
+	return generatepkg.NewGenerator(p.Config(), ir), nil
+}
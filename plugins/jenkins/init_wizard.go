@@ -0,0 +1,74 @@
+package jenkins
+
+import (
+	"github.com/edelwud/terraci/pkg/config"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+)
+
+// InitContributor — contributes Jenkins fields to the init wizard.
+
+const (
+	defaultTerraformImage = "hashicorp/terraform:1.6"
+	defaultTofuImage      = "ghcr.io/opentofu/opentofu:1.6"
+)
+
+var (
+	initConfigKey   = config.MustExtensionKey(pluginName)
+	keyJenkinsImage = initwiz.MustStateKey[string]("jenkins.image")
+)
+
+type initConfig struct {
+	Agent *configpkg.Agent `yaml:"agent,omitempty"`
+}
+
+// InitGroups returns the init wizard group specs for Jenkins.
+func (p *Plugin) InitGroups() ([]initwiz.InitGroup, error) {
+	showJenkins := func(s *initwiz.StateMap) bool {
+		return initwiz.ProviderKey.Get(s) == pluginName
+	}
+
+	image, err := initwiz.NewStringField(initwiz.StringFieldOptions{
+		Key:         keyJenkinsImage,
+		Title:       "Docker Image",
+		Description: "Docker image for the pipeline agent",
+		Default:     defaultTerraformImage,
+		Placeholder: defaultTerraformImage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	group, err := initwiz.NewInitGroup(initwiz.InitGroupOptions{
+		Title:    "Jenkins",
+		Category: initwiz.CategoryProvider,
+		Order:    100,
+		ShowWhen: showJenkins,
+		Fields:   []initwiz.InitField{image},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []initwiz.InitGroup{group}, nil
+}
+
+// BuildInitConfig builds the Jenkins init contribution. The image entrypoint
+// is cleared because the Terraform and OpenTofu images use the binary as
+// their entrypoint, which the docker agent cannot run.
+func (p *Plugin) BuildInitConfig(state *initwiz.StateMap) (*initwiz.InitContribution, error) {
+	if initwiz.ProviderKey.Get(state) != pluginName {
+		return nil, nil
+	}
+
+	binary := initwiz.BinaryKey.Get(state)
+	image := keyJenkinsImage.Get(state)
+	if image == "" || (binary == "tofu" && image == defaultTerraformImage) {
+		image = defaultTerraformImage
+		if binary == "tofu" {
+			image = defaultTofuImage
+		}
+	}
+
+	return initwiz.NewInitContribution(initConfigKey, initConfig{
+		Agent: &configpkg.Agent{Image: &configpkg.Image{Name: image, Entrypoint: []string{""}}},
+	})
+}
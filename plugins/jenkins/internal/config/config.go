@@ -0,0 +1,117 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/ci"
+)
+
+type Image = ci.Image
+
+// Config contains Jenkins specific settings.
+type Config struct {
+	Agent       *Agent            `yaml:"agent,omitempty" json:"agent,omitempty" jsonschema:"description=Pipeline-level agent (defaults to agent any)"`
+	Environment map[string]string `yaml:"environment,omitempty" json:"environment,omitempty" jsonschema:"description=Pipeline-level environment variables"`
+	InputApply  *bool             `yaml:"input_apply,omitempty" json:"input_apply,omitempty" jsonschema:"description=Ask for confirmation with an input directive before every apply stage,default=true"`
+	JobDefaults *JobDefaults      `yaml:"job_defaults,omitempty" json:"job_defaults,omitempty" jsonschema:"description=Default settings applied to all stages"`
+	Overwrites  []JobOverwrite    `yaml:"overwrites,omitempty" json:"overwrites,omitempty" jsonschema:"description=Stage-level overrides for plan or apply jobs"`
+}
+
+// Clone returns a deep copy of the Jenkins configuration.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Agent = c.Agent.clone()
+	out.Environment = maps.Clone(c.Environment)
+	if c.InputApply != nil {
+		inputApply := *c.InputApply
+		out.InputApply = &inputApply
+	}
+	out.JobDefaults = cloneJobDefaults(c.JobDefaults)
+	out.Overwrites = cloneJobOverwrites(c.Overwrites)
+	return &out
+}
+
+// InputApplyEnabled reports whether apply stages wait for a user to confirm.
+func (c *Config) InputApplyEnabled() bool {
+	if c == nil || c.InputApply == nil {
+		return true
+	}
+	return *c.InputApply
+}
+
+// Agent selects where Jenkins runs a stage: a labelled node, a docker
+// container, or a docker container on a labelled node.
+type Agent struct {
+	Label string `yaml:"label,omitempty" json:"label,omitempty" jsonschema:"description=Node label expression"`
+	Image *Image `yaml:"image,omitempty" json:"image,omitempty" jsonschema:"description=Docker image to run the stage in"`
+}
+
+func (a *Agent) clone() *Agent {
+	if a == nil {
+		return nil
+	}
+	out := *a
+	out.Image = cloneImagePointer(a.Image)
+	return &out
+}
+
+type JobDefaults struct {
+	Agent        *Agent            `yaml:"agent,omitempty" json:"agent,omitempty" jsonschema:"description=Stage agent override"`
+	Environment  map[string]string `yaml:"environment,omitempty" json:"environment,omitempty" jsonschema:"description=Additional environment variables"`
+	BeforeScript []string          `yaml:"before_script,omitempty" json:"before_script,omitempty" jsonschema:"description=Commands to run before terraform commands"`
+	AfterScript  []string          `yaml:"after_script,omitempty" json:"after_script,omitempty" jsonschema:"description=Commands to run in a post always block"`
+}
+
+func cloneJobDefaults(in *JobDefaults) *JobDefaults {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Agent = in.Agent.clone()
+	out.Environment = maps.Clone(in.Environment)
+	out.BeforeScript = append([]string(nil), in.BeforeScript...)
+	out.AfterScript = append([]string(nil), in.AfterScript...)
+	return &out
+}
+
+type JobOverwrite struct {
+	Type         JobOverwriteType  `yaml:"type" json:"type" jsonschema:"description=Type of jobs to override (plan\\, apply\\, or contributed job name),required"`
+	Agent        *Agent            `yaml:"agent,omitempty" json:"agent,omitempty" jsonschema:"description=Stage agent override"`
+	Environment  map[string]string `yaml:"environment,omitempty" json:"environment,omitempty" jsonschema:"description=Additional environment variables"`
+	BeforeScript []string          `yaml:"before_script,omitempty" json:"before_script,omitempty" jsonschema:"description=Commands to run before terraform commands"`
+	AfterScript  []string          `yaml:"after_script,omitempty" json:"after_script,omitempty" jsonschema:"description=Commands to run in a post always block"`
+}
+
+func cloneJobOverwrites(in []JobOverwrite) []JobOverwrite {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]JobOverwrite, len(in))
+	for i := range in {
+		out[i] = in[i]
+		out[i].Agent = in[i].Agent.clone()
+		out[i].Environment = maps.Clone(in[i].Environment)
+		out[i].BeforeScript = append([]string(nil), in[i].BeforeScript...)
+		out[i].AfterScript = append([]string(nil), in[i].AfterScript...)
+	}
+	return out
+}
+
+func cloneImagePointer(in *Image) *Image {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Entrypoint = append([]string(nil), in.Entrypoint...)
+	return &out
+}
+
+type JobOverwriteType string
+
+const (
+	OverwriteTypePlan  JobOverwriteType = "plan"
+	OverwriteTypeApply JobOverwriteType = "apply"
+)
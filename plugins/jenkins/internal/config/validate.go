@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// environmentName matches identifiers accepted on the left-hand side of a
+// declarative environment block.
+var environmentName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate runs the Jenkins plugin's config-shape sanity checks. Called from
+// the plugin's Preflight so malformed settings fail before any Jenkinsfile is
+// rendered.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if err := validateAgent(c.Agent); err != nil {
+		errs = append(errs, fmt.Errorf("agent: %w", err))
+	}
+	if err := validateEnvironment(c.Environment); err != nil {
+		errs = append(errs, fmt.Errorf("environment: %w", err))
+	}
+	if d := c.JobDefaults; d != nil {
+		if err := validateAgent(d.Agent); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.agent: %w", err))
+		}
+		if err := validateEnvironment(d.Environment); err != nil {
+			errs = append(errs, fmt.Errorf("job_defaults.environment: %w", err))
+		}
+	}
+	for i := range c.Overwrites {
+		o := &c.Overwrites[i]
+		if o.Type == "" {
+			errs = append(errs, fmt.Errorf("overwrites[%d]: type must be set (plan, apply, or a contributed job name)", i))
+		}
+		if err := validateAgent(o.Agent); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].agent: %w", i, err))
+		}
+		if err := validateEnvironment(o.Environment); err != nil {
+			errs = append(errs, fmt.Errorf("overwrites[%d].environment: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateAgent(agent *Agent) error {
+	if agent == nil || agent.Image == nil {
+		return nil
+	}
+	if agent.Image.Name == "" {
+		return errors.New("image name must be set")
+	}
+	if len(agent.Image.Entrypoint) > 1 {
+		return errors.New("image entrypoint supports at most one element")
+	}
+	return nil
+}
+
+func validateEnvironment(env map[string]string) error {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var errs []error
+	for _, key := range keys {
+		if !environmentName.MatchString(key) {
+			errs = append(errs, fmt.Errorf("%q is not a valid variable name", key))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+const indentUnit = "    "
+
+// ToYAML renders the declarative Jenkinsfile. The method name is dictated by
+// pipeline.GeneratedPipeline; the output is Groovy, not YAML.
+func (p *Pipeline) ToYAML() ([]byte, error) {
+	w := &groovyWriter{}
+	w.line(0, "// Generated by terraci — do not edit")
+	w.line(0, "pipeline {")
+	if p == nil {
+		w.line(1, "agent any")
+		w.line(1, "stages {")
+		w.line(1, "}")
+		w.line(0, "}")
+		return w.bytes(), nil
+	}
+	w.agent(1, p.agent, true)
+	w.environment(1, p.environment)
+	w.line(1, "stages {")
+	for _, level := range p.levels {
+		if len(level.stages) == 1 {
+			w.stage(2, level.stages[0])
+			continue
+		}
+		w.line(2, "stage("+quote(level.name)+") {")
+		w.line(3, "parallel {")
+		for _, stage := range level.stages {
+			w.stage(4, stage)
+		}
+		w.line(3, "}")
+		w.line(2, "}")
+	}
+	w.line(1, "}")
+	w.line(0, "}")
+	return w.bytes(), nil
+}
+
+// CommentPrefix implements pipeline.CommentPrefixer: Groovy uses '//' line
+// comments.
+func (p *Pipeline) CommentPrefix() string { return "//" }
+
+type groovyWriter struct {
+	sb strings.Builder
+}
+
+func (w *groovyWriter) bytes() []byte {
+	return []byte(w.sb.String())
+}
+
+func (w *groovyWriter) line(depth int, text string) {
+	w.sb.WriteString(strings.Repeat(indentUnit, depth))
+	w.sb.WriteString(text)
+	w.sb.WriteByte('\n')
+}
+
+func (w *groovyWriter) agent(depth int, agent *Agent, topLevel bool) {
+	switch {
+	case agent == nil || (agent.Label == "" && agent.Image == ""):
+		if topLevel {
+			w.line(depth, "agent any")
+		}
+	case agent.Image == "":
+		w.line(depth, "agent { label "+quote(agent.Label)+" }")
+	default:
+		w.line(depth, "agent {")
+		w.line(depth+1, "docker {")
+		w.line(depth+2, "image "+quote(agent.Image))
+		if agent.Label != "" {
+			w.line(depth+2, "label "+quote(agent.Label))
+		}
+		if agent.DockerArgs != "" {
+			w.line(depth+2, "args "+quote(agent.DockerArgs))
+		}
+		w.line(depth+1, "}")
+		w.line(depth, "}")
+	}
+}
+
+func (w *groovyWriter) environment(depth int, env map[string]string) {
+	if len(env) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	w.line(depth, "environment {")
+	for _, key := range keys {
+		w.line(depth+1, key+" = "+quote(env[key]))
+	}
+	w.line(depth, "}")
+}
+
+func (w *groovyWriter) stage(depth int, stage Stage) {
+	w.line(depth, "stage("+quote(stage.name)+") {")
+	body := depth + 1
+	w.agent(body, stage.agent, false)
+	w.environment(body, stage.environment)
+	if stage.input != nil {
+		w.line(body, "input {")
+		w.line(body+1, "message "+quote(stage.input.Message))
+		if stage.input.OK != "" {
+			w.line(body+1, "ok "+quote(stage.input.OK))
+		}
+		w.line(body, "}")
+	}
+
+	w.line(body, "steps {")
+	for _, unstash := range stage.unstash {
+		if !unstash.Optional {
+			w.line(body+1, "unstash "+quote(unstash.Name))
+			continue
+		}
+		w.line(body+1, "script {")
+		w.line(body+2, "try {")
+		w.line(body+3, "unstash "+quote(unstash.Name))
+		w.line(body+2, "} catch (err) {")
+		w.line(body+3, "echo "+quote("optional stash "+unstash.Name+" not found"))
+		w.line(body+2, "}")
+		w.line(body+1, "}")
+	}
+	switch {
+	case stage.catchError:
+		w.line(body+1, "catchError(buildResult: 'SUCCESS', stageResult: 'UNSTABLE') {")
+		w.sh(body+2, stage.script)
+		w.line(body+1, "}")
+	case stage.continueOn:
+		w.line(body+1, "catchError(buildResult: 'FAILURE', stageResult: 'FAILURE') {")
+		w.sh(body+2, stage.script)
+		w.line(body+1, "}")
+	default:
+		w.sh(body+1, stage.script)
+	}
+	if stage.stash != nil {
+		stash := "stash name: " + quote(stage.stash.Name) + ", includes: " + quote(strings.Join(stage.stash.Includes, ","))
+		if stage.stash.AllowEmpty {
+			stash += ", allowEmpty: true"
+		}
+		w.line(body+1, stash)
+	}
+	w.line(body, "}")
+
+	if len(stage.afterScript) > 0 {
+		w.line(body, "post {")
+		w.line(body+1, "always {")
+		w.sh(body+2, stage.afterScript)
+		w.line(body+1, "}")
+		w.line(body, "}")
+	}
+	w.line(depth, "}")
+}
+
+// sh renders script lines as one sh step so that directory changes made by
+// earlier lines carry over to later ones.
+func (w *groovyWriter) sh(depth int, script []string) {
+	w.line(depth, "sh '''")
+	for _, line := range script {
+		w.line(depth+1, escape(line))
+	}
+	w.line(depth, "'''")
+}
+
+// quote renders value as a single-quoted Groovy string, which performs no
+// interpolation.
+func quote(value string) string {
+	return "'" + escape(value) + "'"
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
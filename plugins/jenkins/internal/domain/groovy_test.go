@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineToYAMLRendersJenkinsfile(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{
+		Agent:       &Agent{Label: "terraform"},
+		Environment: map[string]string{"TF_IN_AUTOMATION": "true"},
+	})
+	plan, err := NewStage(StageOptions{
+		Name:        "plan-vpc",
+		Environment: map[string]string{"TF_MODULE": "vpc"},
+		Script:      []string{"cd vpc", "terraform plan -out=plan.tfplan"},
+		Stash:       &Stash{Name: "tfplan-vpc", Includes: []string{"vpc/plan.tfplan", "vpc/plan.json"}},
+	})
+	if err != nil {
+		t.Fatalf("NewStage() error = %v", err)
+	}
+	mustAddLevel(t, builder, "dag-level-0", plan, mustStage(t, "plan-eks"))
+	apply, err := NewStage(StageOptions{
+		Name:        "apply-vpc",
+		Agent:       &Agent{Image: "hashicorp/terraform:1.6", DockerArgs: "--entrypoint=''"},
+		Input:       &Input{Message: "Apply vpc?", OK: "Apply"},
+		Unstash:     []Unstash{{Name: "tfplan-vpc"}, {Name: "report", Optional: true}},
+		Script:      []string{"echo it's \\ fine"},
+		AfterScript: []string{"echo cleanup"},
+		CatchError:  true,
+	})
+	if err != nil {
+		t.Fatalf("NewStage() error = %v", err)
+	}
+	mustAddLevel(t, builder, "dag-level-1", apply)
+
+	data, err := mustBuildPipeline(t, builder).ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"// Generated by terraci",
+		"pipeline {\n    agent { label 'terraform' }\n    environment {\n        TF_IN_AUTOMATION = 'true'\n    }",
+		"        stage('dag-level-0') {\n            parallel {\n                stage('plan-vpc') {",
+		"stash name: 'tfplan-vpc', includes: 'vpc/plan.tfplan,vpc/plan.json'",
+		"        stage('apply-vpc') {\n            agent {\n                docker {\n                    image 'hashicorp/terraform:1.6'\n                    args '--entrypoint=\\'\\''",
+		"            input {\n                message 'Apply vpc?'\n                ok 'Apply'\n            }",
+		"                unstash 'tfplan-vpc'\n                script {\n                    try {\n                        unstash 'report'",
+		"catchError(buildResult: 'SUCCESS', stageResult: 'UNSTABLE') {\n                    sh '''\n                        echo it\\'s \\\\ fine\n",
+		"            post {\n                always {\n                    sh '''\n                        echo cleanup",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ToYAML() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "stage('dag-level-1')") {
+		t.Fatalf("single-stage level should not be wrapped:\n%s", out)
+	}
+}
+
+func TestEmptyPipelineToYAML(t *testing.T) {
+	t.Parallel()
+
+	data, err := EmptyPipeline().ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(data), "agent any") {
+		t.Fatalf("ToYAML() = %s, want agent any", data)
+	}
+	if got := EmptyPipeline().CommentPrefix(); got != "//" {
+		t.Fatalf("CommentPrefix() = %q, want //", got)
+	}
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+)
+
+// Pipeline represents a declarative Jenkinsfile. Top-level stages run
+// strictly in order; a level with several stages renders them as parallel
+// branches of one wrapping stage.
+type Pipeline struct {
+	agent       *Agent
+	environment map[string]string
+	levels      []stageLevel
+}
+
+type stageLevel struct {
+	name   string
+	stages []Stage
+}
+
+func (l stageLevel) clone() stageLevel {
+	return stageLevel{name: l.name, stages: cloneStages(l.stages)}
+}
+
+type PipelineOptions struct {
+	Agent       *Agent
+	Environment map[string]string
+}
+
+type PipelineBuilder struct {
+	opts   PipelineOptions
+	levels []stageLevel
+	stages map[string]struct{}
+}
+
+func EmptyPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+func NewPipelineBuilder(opts PipelineOptions) *PipelineBuilder {
+	return &PipelineBuilder{
+		opts: PipelineOptions{
+			Agent:       opts.Agent.clone(),
+			Environment: maps.Clone(opts.Environment),
+		},
+		stages: make(map[string]struct{}),
+	}
+}
+
+// AddLevel appends a level of stages that run after every earlier level.
+// The level name labels the wrapping stage when the level runs in parallel.
+func (b *PipelineBuilder) AddLevel(name string, stages ...Stage) error {
+	if b == nil {
+		return errors.New("jenkins pipeline builder is nil")
+	}
+	if len(stages) == 0 {
+		return fmt.Errorf("jenkins level %q requires at least one stage", name)
+	}
+	if len(stages) > 1 && name == "" {
+		return errors.New("jenkins parallel level name is required")
+	}
+	seen := make(map[string]struct{}, len(stages)+1)
+	if len(stages) > 1 {
+		seen[name] = struct{}{}
+	}
+	for _, stage := range stages {
+		if stage.name == "" {
+			return errors.New("jenkins stage name is required")
+		}
+		if _, exists := seen[stage.name]; exists {
+			return fmt.Errorf("duplicate jenkins stage %q", stage.name)
+		}
+		seen[stage.name] = struct{}{}
+	}
+	for stageName := range seen {
+		if _, exists := b.stages[stageName]; exists {
+			return fmt.Errorf("duplicate jenkins stage %q", stageName)
+		}
+	}
+	for stageName := range seen {
+		b.stages[stageName] = struct{}{}
+	}
+	b.levels = append(b.levels, stageLevel{name: name, stages: cloneStages(stages)})
+	return nil
+}
+
+func (b *PipelineBuilder) Build() (*Pipeline, error) {
+	if b == nil {
+		return nil, errors.New("jenkins pipeline builder is nil")
+	}
+	levels := make([]stageLevel, len(b.levels))
+	for i := range b.levels {
+		levels[i] = b.levels[i].clone()
+	}
+	return &Pipeline{
+		agent:       b.opts.Agent.clone(),
+		environment: maps.Clone(b.opts.Environment),
+		levels:      levels,
+	}, nil
+}
+
+func (p *Pipeline) Agent() *Agent {
+	if p == nil {
+		return nil
+	}
+	return p.agent.clone()
+}
+
+func (p *Pipeline) Environment() map[string]string {
+	if p == nil {
+		return nil
+	}
+	return maps.Clone(p.environment)
+}
+
+// LevelCount returns the number of sequential top-level entries.
+func (p *Pipeline) LevelCount() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.levels)
+}
+
+// LevelStages returns the names of the stages in the level at index.
+func (p *Pipeline) LevelStages(index int) []string {
+	if p == nil || index < 0 || index >= len(p.levels) {
+		return nil
+	}
+	names := make([]string, 0, len(p.levels[index].stages))
+	for _, stage := range p.levels[index].stages {
+		names = append(names, stage.name)
+	}
+	return names
+}
+
+// LevelOf returns the index of the level that owns stageName.
+func (p *Pipeline) LevelOf(stageName string) (int, bool) {
+	if p == nil {
+		return 0, false
+	}
+	for i, level := range p.levels {
+		for _, stage := range level.stages {
+			if stage.name == stageName {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (p *Pipeline) Stage(name string) (Stage, bool) {
+	index, ok := p.LevelOf(name)
+	if !ok {
+		return Stage{}, false
+	}
+	for _, stage := range p.levels[index].stages {
+		if stage.name == name {
+			return stage.clone(), true
+		}
+	}
+	return Stage{}, false
+}
+
+func (p *Pipeline) StageNames() []string {
+	if p == nil {
+		return nil
+	}
+	var names []string
+	for _, level := range p.levels {
+		for _, stage := range level.stages {
+			names = append(names, stage.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Pipeline) StageCount() int {
+	if p == nil {
+		return 0
+	}
+	count := 0
+	for _, level := range p.levels {
+		count += len(level.stages)
+	}
+	return count
+}
+
+// StageRunsAfter reports whether stageName belongs to a later level than
+// dependency, which is the only ordering sequential stages guarantee.
+func (p *Pipeline) StageRunsAfter(stageName, dependency string) bool {
+	from, ok := p.LevelOf(stageName)
+	if !ok {
+		return false
+	}
+	to, ok := p.LevelOf(dependency)
+	if !ok {
+		return false
+	}
+	return from > to
+}
@@ -0,0 +1,106 @@
+package domain
+
+import "testing"
+
+func TestPipelineGettersReturnDefensiveCopies(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{Environment: map[string]string{"TF_IN_AUTOMATION": "1"}})
+	mustAddLevel(t, builder, "dag-level-0", mustStage(t, "plan-vpc"), mustStage(t, "plan-eks"))
+	pipeline := mustBuildPipeline(t, builder)
+
+	stage, ok := pipeline.Stage("plan-vpc")
+	if !ok {
+		t.Fatal("plan-vpc stage not found")
+	}
+	script := stage.Script()
+	script[0] = "changed"
+	if stage.Script()[0] != "terraform plan" {
+		t.Fatalf("Stage.Script() leaked mutation: %#v", stage.Script())
+	}
+	env := pipeline.Environment()
+	env["TF_IN_AUTOMATION"] = "changed"
+	if pipeline.Environment()["TF_IN_AUTOMATION"] != "1" {
+		t.Fatalf("Pipeline.Environment() leaked mutation: %#v", pipeline.Environment())
+	}
+	if got := pipeline.StageNames(); len(got) != 2 || got[0] != "plan-eks" || got[1] != "plan-vpc" {
+		t.Fatalf("StageNames() = %v", got)
+	}
+}
+
+func TestPipelineBuilderValidatesLevels(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	if err := builder.AddLevel("dag-level-0"); err == nil {
+		t.Fatal("AddLevel() error = nil, want empty level error")
+	}
+	if err := builder.AddLevel("", mustStage(t, "plan-vpc"), mustStage(t, "plan-eks")); err == nil {
+		t.Fatal("AddLevel() error = nil, want missing parallel level name error")
+	}
+	mustAddLevel(t, builder, "dag-level-0", mustStage(t, "plan-vpc"))
+	if err := builder.AddLevel("dag-level-1", mustStage(t, "plan-vpc")); err == nil {
+		t.Fatal("AddLevel() error = nil, want duplicate stage error")
+	}
+	if err := builder.AddLevel("plan-eks", mustStage(t, "plan-eks"), mustStage(t, "apply-eks")); err == nil {
+		t.Fatal("AddLevel() error = nil, want level name clash error")
+	}
+}
+
+func TestNewStageValidates(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewStage(StageOptions{Script: []string{"true"}}); err == nil {
+		t.Fatal("NewStage() error = nil, want missing name error")
+	}
+	if _, err := NewStage(StageOptions{Name: "plan"}); err == nil {
+		t.Fatal("NewStage() error = nil, want missing script error")
+	}
+	if _, err := NewStage(StageOptions{Name: "plan", Script: []string{"true"}, Environment: map[string]string{"TF-MODULE": "vpc"}}); err == nil {
+		t.Fatal("NewStage() error = nil, want invalid environment name error")
+	}
+	if _, err := NewStage(StageOptions{Name: "plan", Script: []string{"true"}, Stash: &Stash{Name: "plan"}}); err == nil {
+		t.Fatal("NewStage() error = nil, want stash includes error")
+	}
+}
+
+func TestPipelineStageRunsAfter(t *testing.T) {
+	builder := NewPipelineBuilder(PipelineOptions{})
+	mustAddLevel(t, builder, "dag-level-0", mustStage(t, "plan-vpc"), mustStage(t, "plan-eks"))
+	mustAddLevel(t, builder, "dag-level-1", mustStage(t, "apply-vpc"))
+	pipeline := mustBuildPipeline(t, builder)
+
+	if !pipeline.StageRunsAfter("apply-vpc", "plan-vpc") {
+		t.Fatal("apply-vpc should run after plan-vpc")
+	}
+	if pipeline.StageRunsAfter("plan-eks", "plan-vpc") {
+		t.Fatal("parallel branches must not be ordered")
+	}
+	if pipeline.StageRunsAfter("missing", "plan-vpc") {
+		t.Fatal("unknown stages must not be ordered")
+	}
+	if got := pipeline.LevelCount(); got != 2 {
+		t.Fatalf("LevelCount() = %d, want 2", got)
+	}
+}
+
+func mustStage(t *testing.T, name string) Stage {
+	t.Helper()
+	stage, err := NewStage(StageOptions{Name: name, Script: []string{"terraform plan"}})
+	if err != nil {
+		t.Fatalf("NewStage() error = %v", err)
+	}
+	return stage
+}
+
+func mustAddLevel(t *testing.T, builder *PipelineBuilder, name string, stages ...Stage) {
+	t.Helper()
+	if err := builder.AddLevel(name, stages...); err != nil {
+		t.Fatalf("AddLevel() error = %v", err)
+	}
+}
+
+func mustBuildPipeline(t *testing.T, builder *PipelineBuilder) *Pipeline {
+	t.Helper()
+	pipeline, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return pipeline
+}
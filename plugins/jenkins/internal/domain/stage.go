@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+)
+
+// environmentName matches identifiers accepted on the left-hand side of a
+// declarative environment block.
+var environmentName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Agent selects where a stage runs. An empty agent renders as `agent any`
+// at pipeline level and is omitted at stage level, so the stage inherits it.
+type Agent struct {
+	Label      string
+	Image      string
+	DockerArgs string
+}
+
+func (a *Agent) clone() *Agent {
+	if a == nil {
+		return nil
+	}
+	out := *a
+	return &out
+}
+
+// Input is a declarative input directive that pauses a stage until a user
+// confirms it.
+type Input struct {
+	Message string
+	OK      string
+}
+
+// Stash saves workspace files for a later stage.
+type Stash struct {
+	Name       string
+	Includes   []string
+	AllowEmpty bool
+}
+
+// Unstash restores files saved by an earlier stage. Optional unstashes
+// tolerate a missing stash.
+type Unstash struct {
+	Name     string
+	Optional bool
+}
+
+type StageOptions struct {
+	Name        string
+	Agent       *Agent
+	Environment map[string]string
+	Input       *Input
+	Unstash     []Unstash
+	Script      []string
+	Stash       *Stash
+	AfterScript []string
+	CatchError  bool
+	// ContinueOnFailure fails the stage and the build but lets later
+	// stages run.
+	ContinueOnFailure bool
+}
+
+// Stage is a single Jenkins stage rendered from one IR job.
+type Stage struct {
+	name        string
+	agent       *Agent
+	environment map[string]string
+	input       *Input
+	unstash     []Unstash
+	script      []string
+	stash       *Stash
+	afterScript []string
+	catchError  bool
+	continueOn  bool
+}
+
+func NewStage(opts StageOptions) (Stage, error) {
+	if opts.Name == "" {
+		return Stage{}, errors.New("jenkins stage name is required")
+	}
+	if len(opts.Script) == 0 {
+		return Stage{}, fmt.Errorf("jenkins stage %q script is required", opts.Name)
+	}
+	for key := range opts.Environment {
+		if !environmentName.MatchString(key) {
+			return Stage{}, fmt.Errorf("jenkins stage %q environment variable %q is not a valid identifier", opts.Name, key)
+		}
+	}
+	if opts.Input != nil && opts.Input.Message == "" {
+		return Stage{}, fmt.Errorf("jenkins stage %q input message is required", opts.Name)
+	}
+	if opts.Stash != nil && (opts.Stash.Name == "" || len(opts.Stash.Includes) == 0) {
+		return Stage{}, fmt.Errorf("jenkins stage %q stash requires a name and include paths", opts.Name)
+	}
+	for _, unstash := range opts.Unstash {
+		if unstash.Name == "" {
+			return Stage{}, fmt.Errorf("jenkins stage %q unstash name is required", opts.Name)
+		}
+	}
+	stage := Stage{
+		name:        opts.Name,
+		agent:       opts.Agent.clone(),
+		environment: maps.Clone(opts.Environment),
+		unstash:     append([]Unstash(nil), opts.Unstash...),
+		script:      cloneStrings(opts.Script),
+		afterScript: cloneStrings(opts.AfterScript),
+		catchError:  opts.CatchError,
+		continueOn:  opts.ContinueOnFailure,
+	}
+	if opts.Input != nil {
+		input := *opts.Input
+		stage.input = &input
+	}
+	if opts.Stash != nil {
+		stage.stash = cloneStash(opts.Stash)
+	}
+	return stage, nil
+}
+
+func (s Stage) Name() string { return s.name }
+
+func (s Stage) Agent() *Agent { return s.agent.clone() }
+
+func (s Stage) Environment() map[string]string { return maps.Clone(s.environment) }
+
+func (s Stage) Input() *Input {
+	if s.input == nil {
+		return nil
+	}
+	input := *s.input
+	return &input
+}
+
+func (s Stage) Unstash() []Unstash { return append([]Unstash(nil), s.unstash...) }
+
+func (s Stage) Script() []string { return cloneStrings(s.script) }
+
+func (s Stage) Stash() *Stash { return cloneStash(s.stash) }
+
+func (s Stage) AfterScript() []string { return cloneStrings(s.afterScript) }
+
+// CatchError reports whether a failing script marks the stage unstable
+// instead of failing the build.
+func (s Stage) CatchError() bool { return s.catchError }
+
+// ContinueOnFailure reports whether a failing script fails the stage and the
+// build without stopping later stages.
+func (s Stage) ContinueOnFailure() bool { return s.continueOn }
+
+func (s Stage) clone() Stage {
+	out := s
+	out.agent = s.agent.clone()
+	out.environment = maps.Clone(s.environment)
+	out.input = s.Input()
+	out.unstash = s.Unstash()
+	out.script = cloneStrings(s.script)
+	out.stash = cloneStash(s.stash)
+	out.afterScript = cloneStrings(s.afterScript)
+	return out
+}
+
+func cloneStash(in *Stash) *Stash {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Includes = cloneStrings(in.Includes)
+	return &out
+}
+
+func cloneStrings(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	return append([]string(nil), in...)
+}
+
+func cloneStages(in []Stage) []Stage {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Stage, len(in))
+	for i := range in {
+		out[i] = in[i].clone()
+	}
+	return out
+}
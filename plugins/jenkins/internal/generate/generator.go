@@ -0,0 +1,74 @@
+package generate
+
+import (
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/jenkins/internal/domain"
+)
+
+// Generator transforms a pipeline IR into a declarative Jenkinsfile. The IR
+// is bound at construction time.
+type Generator struct {
+	settings settings
+	ir       *pipeline.IR
+}
+
+// NewGenerator creates a new Jenkins generator bound to the supplied IR.
+func NewGenerator(cfg *configpkg.Config, ir *pipeline.IR) *Generator {
+	return &Generator{
+		settings: newSettings(cfg),
+		ir:       ir,
+	}
+}
+
+func (g *Generator) Generate() (pipeline.GeneratedPipeline, error) {
+	if g.ir == nil {
+		return domainpkg.EmptyPipeline(), nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.transform(g.ir)
+}
+
+func (g *Generator) DryRun() (*pipeline.DryRunResult, error) {
+	if g.ir == nil {
+		return &pipeline.DryRunResult{}, nil
+	}
+	if err := g.ir.Validate(); err != nil {
+		return nil, err
+	}
+	return g.ir.DryRun(g.ir.ModuleCount()), nil
+}
+
+// transform renders one top-level entry per execution level. Declarative
+// stages run in order, so every job lands after all of its dependencies;
+// independent jobs of a level run as parallel branches.
+func (g *Generator) transform(ir *pipeline.IR) (*domainpkg.Pipeline, error) {
+	groups, err := pipeline.Schedule(ir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := domainpkg.NewPipelineBuilder(domainpkg.PipelineOptions{
+		Agent:       convertAgent(g.settings.agent()),
+		Environment: g.settings.environment(),
+	})
+	builder := newStageBuilder(g.settings, ir.AlwaysRunDependencies())
+	for _, group := range groups {
+		jobs := group.Jobs()
+		stages := make([]domainpkg.Stage, 0, len(jobs))
+		for i := range jobs {
+			stage, err := builder.renderStage(jobs[i])
+			if err != nil {
+				return nil, err
+			}
+			stages = append(stages, stage)
+		}
+		if err := out.AddLevel(group.Name(), stages...); err != nil {
+			return nil, err
+		}
+	}
+
+	return out.Build()
+}
@@ -0,0 +1,169 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+)
+
+func testContribution(tb testing.TB, opts ...pipeline.ContributedJobOptions) *pipeline.Contribution {
+	tb.Helper()
+	jobs := make([]pipeline.ContributedJob, 0, len(opts))
+	for _, opt := range opts {
+		job, err := pipeline.NewContributedJob(opt)
+		if err != nil {
+			tb.Fatalf("NewContributedJob() error = %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	contribution, err := pipeline.NewContribution(jobs...)
+	if err != nil {
+		tb.Fatalf("NewContribution() error = %v", err)
+	}
+	return contribution
+}
+
+func testContributionSet(tb testing.TB, contributions ...*pipeline.Contribution) pipeline.ContributionSet {
+	tb.Helper()
+	set, err := pipeline.NewContributionSet(contributions...)
+	if err != nil {
+		tb.Fatalf("NewContributionSet() error = %v", err)
+	}
+	return set
+}
+
+func costContribution(tb testing.TB) pipeline.ContributionSet {
+	tb.Helper()
+	return testContributionSet(tb, testContribution(tb, pipeline.ContributedJobOptions{
+		Name:     "cost-estimation",
+		Commands: []string{"terraci cost"},
+		Consumes: []pipeline.ResourceRequest{
+			pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+		},
+		Produces: []pipeline.ResourceSpec{
+			pipeline.PluginResource(pipeline.ResourceKindPluginResult, "cost", ".terraci/cost-results.json"),
+		},
+		AllowFailure: true,
+	}))
+}
+
+func TestGenerate_PlanAndApplyJobOverwrites(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.JobDefaults = &configpkg.JobDefaults{
+				Environment:  map[string]string{"DEFAULT": "true", "SHARED": "default"},
+				BeforeScript: []string{"echo default setup"},
+				AfterScript:  []string{"echo default cleanup"},
+			}
+			cfg.Overwrites = []configpkg.JobOverwrite{
+				{
+					Type:         configpkg.OverwriteTypePlan,
+					Agent:        &configpkg.Agent{Label: "docker", Image: &configpkg.Image{Name: "hashicorp/terraform:1.9", Entrypoint: []string{""}}},
+					Environment:  map[string]string{"SHARED": "plan"},
+					BeforeScript: []string{"echo plan setup"},
+				},
+				{
+					Type:        configpkg.OverwriteTypeApply,
+					Agent:       &configpkg.Agent{Label: "production"},
+					Environment: map[string]string{"APPLY": "true"},
+				},
+			}
+		}).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	planStage := assertPipeline(t, out).
+		stage("plan-platform-stage-eu-central-1-vpc").
+		agentLabel("docker").
+		agentImage("hashicorp/terraform:1.9").
+		environment("DEFAULT", "true").
+		environment("SHARED", "plan").
+		environment("TF_MODULE", "vpc").
+		scriptOrder("echo default setup", "echo plan setup").
+		scriptOrder("echo plan setup", "terraform plan").
+		afterScript("echo default cleanup")
+	if got := planStage.stage.Agent().DockerArgs; got != "--entrypoint=''" {
+		t.Fatalf("plan docker args = %q, want --entrypoint=''", got)
+	}
+
+	assertPipeline(t, out).
+		stage("apply-platform-stage-eu-central-1-vpc").
+		agentLabel("production").
+		environment("SHARED", "default").
+		environment("APPLY", "true")
+}
+
+func TestGenerate_ContributedJobStashContract(t *testing.T) {
+	module := createTestModule("vpc")
+	planName := "plan-platform-stage-eu-central-1-vpc"
+	resultArtifact := pipeline.ResultArtifact("cost-estimation", ".terraci/cost-results.json")
+	out := newGeneratorScenario(t).
+		withContributions(costContribution(t)).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stage(planName).
+		stash(pipeline.PlanArtifactName(planName), false, "platform/stage/eu-central-1/vpc/plan.json")
+	assertPipeline(t, out).
+		stage("cost-estimation").
+		runsAfter(planName).
+		catchError(true).
+		unstash(pipeline.PlanArtifactName(planName), false).
+		stash(resultArtifact.Name, true, ".terraci/cost-results.json").
+		scriptContains("terraci cost")
+}
+
+func TestGenerate_ContributedJobOverwriteByName(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) {
+			cfg.Overwrites = []configpkg.JobOverwrite{{
+				Type:  "cost-estimation",
+				Agent: &configpkg.Agent{Label: "cost"},
+			}}
+		}).
+		withContributions(costContribution(t)).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stage("cost-estimation").
+		agentLabel("cost")
+	assertPipeline(t, out).
+		stage("plan-platform-stage-eu-central-1-vpc").
+		inheritsAgent()
+}
+
+func TestGenerate_AlwaysRunContributionRunsAfterFailures(t *testing.T) {
+	module := createTestModule("vpc")
+	planName := "plan-platform-stage-eu-central-1-vpc"
+	out := newGeneratorScenario(t).
+		withContributions(testContributionSet(t, testContribution(t, pipeline.ContributedJobOptions{
+			Name:     "drift-collector",
+			Commands: []string{"terraci drift"},
+			Consumes: []pipeline.ResourceRequest{
+				pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON),
+			},
+			AlwaysRun: true,
+		}))).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		withPlanOnly().
+		generate()
+
+	assertPipeline(t, out).
+		stage(planName).
+		continueOnFailure(true).
+		stash(pipeline.PlanArtifactName(planName), true, "platform/stage/eu-central-1/vpc/plan.json")
+	assertPipeline(t, out).
+		stage("drift-collector").
+		runsAfter(planName).
+		continueOnFailure(false).
+		unstash(pipeline.PlanArtifactName(planName), true)
+}
@@ -0,0 +1,194 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci/citest"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/jenkins/internal/domain"
+)
+
+func TestGenerate_SingleModule(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stageCount(2).
+		levelCount(2).
+		hasStage("plan-platform-stage-eu-central-1-vpc").
+		hasStage("apply-platform-stage-eu-central-1-vpc")
+
+	planName := "plan-platform-stage-eu-central-1-vpc"
+	assertPipeline(t, out).
+		stage(planName).
+		inheritsAgent().
+		input(false).
+		environment("TF_MODULE", "vpc").
+		scriptContains("terraform init").
+		scriptContains("terraform plan").
+		stash(pipeline.PlanArtifactName(planName), false, "platform/stage/eu-central-1/vpc/plan.tfplan")
+	if agent := out.Agent(); agent == nil || agent.Label != "terraform" {
+		t.Fatalf("pipeline agent = %+v, want label terraform", agent)
+	}
+}
+
+func TestGenerate_RejectsInvalidIR(t *testing.T) {
+	t.Parallel()
+
+	generated, err := NewGenerator(nil, nil).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	out, ok := generated.(*domainpkg.Pipeline)
+	if !ok || out.StageCount() != 0 {
+		t.Fatalf("Generate() = %#v, want empty pipeline", generated)
+	}
+}
+
+func TestGenerate_IndependentJobsRunInParallel(t *testing.T) {
+	vpc := createTestModule("vpc")
+	dns := createTestModule("dns")
+	out := newGeneratorScenario(t).
+		withModules(vpc, dns).
+		withDependencies(map[string][]string{vpc.ID(): {}, dns.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		levelCount(2).
+		parallel("plan-platform-stage-eu-central-1-vpc", "plan-platform-stage-eu-central-1-dns").
+		parallel("apply-platform-stage-eu-central-1-vpc", "apply-platform-stage-eu-central-1-dns")
+	assertPipeline(t, out).
+		stage("apply-platform-stage-eu-central-1-dns").
+		notAfter("apply-platform-stage-eu-central-1-vpc").
+		runsAfter("plan-platform-stage-eu-central-1-dns")
+
+	data, err := out.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(data), "stage('dag-level-0') {\n            parallel {") {
+		t.Fatalf("expected parallel level stage:\n%s", data)
+	}
+}
+
+func TestGenerate_WithDependencies(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	out := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		generate()
+
+	assertPipeline(t, out).
+		stage("apply-platform-stage-eu-central-1-eks").
+		runsAfter("apply-platform-stage-eu-central-1-vpc").
+		runsAfter("plan-platform-stage-eu-central-1-eks")
+	assertPipeline(t, out).
+		stage("plan-platform-stage-eu-central-1-vpc").
+		notAfter("apply-platform-stage-eu-central-1-eks")
+}
+
+func TestGenerate_ApplyStagesAskForInput(t *testing.T) {
+	module := createTestModule("vpc")
+	planName := "plan-platform-stage-eu-central-1-vpc"
+	out := newGeneratorScenario(t).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stage("apply-platform-stage-eu-central-1-vpc").
+		input(true).
+		unstash(pipeline.PlanArtifactName(planName), false).
+		noStash()
+}
+
+func TestGenerate_InputApplyDisabled(t *testing.T) {
+	module := createTestModule("vpc")
+	inputApply := false
+	out := newGeneratorScenario(t).
+		withConfig(func(cfg *configpkg.Config) { cfg.InputApply = &inputApply }).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stage("apply-platform-stage-eu-central-1-vpc").
+		input(false)
+}
+
+func TestGenerate_ApprovalRuleForcesInput(t *testing.T) {
+	inputApply := false
+	ir := pipelinetest.MustGatedSingleModuleIR(t, citest.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	result, err := NewGenerator(&configpkg.Config{InputApply: &inputApply}, ir).Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	out, ok := result.(*domainpkg.Pipeline)
+	if !ok {
+		t.Fatal("expected *Pipeline type")
+	}
+
+	assertPipeline(t, out).
+		stage("plan-platform-prod-eu-central-1-vpc").
+		input(false)
+	assertPipeline(t, out).
+		stage("apply-platform-prod-eu-central-1-vpc").
+		input(true)
+}
+
+func TestGenerate_PlanOnly(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withPlanOnly().
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stageCount(1).
+		hasStage("plan-platform-stage-eu-central-1-vpc").
+		noStage("apply-platform-stage-eu-central-1-vpc")
+}
+
+func TestGenerate_CustomBinary(t *testing.T) {
+	module := createTestModule("vpc")
+	out := newGeneratorScenario(t).
+		withTerraformConfig(func(cfg *pipeline.TerraformJobConfigOptions) { cfg.Binary = "tofu" }).
+		withModules(module).
+		withDependencies(map[string][]string{module.ID(): {}}).
+		generate()
+
+	assertPipeline(t, out).
+		stage("plan-platform-stage-eu-central-1-vpc").
+		scriptContains("tofu plan")
+}
+
+func TestDryRun(t *testing.T) {
+	vpc := createTestModule("vpc")
+	eks := createTestModule("eks")
+	result := newGeneratorScenario(t).
+		withModules(vpc, eks).
+		withDependencies(map[string][]string{
+			vpc.ID(): {},
+			eks.ID(): {vpc.ID()},
+		}).
+		dryRun()
+
+	citest.AssertDryRun(t, result, citest.DryRunExpectation{
+		TotalModules:    2,
+		AffectedModules: 2,
+		Jobs:            4,
+		Stages:          4,
+		JobGroups:       4,
+	})
+}
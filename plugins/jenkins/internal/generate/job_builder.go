@@ -0,0 +1,95 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/pipeline/cishell"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/jenkins/internal/domain"
+)
+
+type stageBuilder struct {
+	settings settings
+	// continueOnFailure holds the jobs whose failure must not stop later
+	// stages.
+	continueOnFailure map[string]bool
+}
+
+func newStageBuilder(settings settings, continueOnFailure map[string]bool) stageBuilder {
+	return stageBuilder{settings: settings, continueOnFailure: continueOnFailure}
+}
+
+// renderStage converts one IR job into a Jenkins stage. Input artifacts are
+// unstashed before the script runs and the output artifact is stashed
+// afterwards, so later stages can restore it on any agent.
+func (b stageBuilder) renderStage(irJob pipeline.Job) (domainpkg.Stage, error) {
+	profile, err := b.settings.jobProfile(jobOverwriteType(irJob))
+	if err != nil {
+		return domainpkg.Stage{}, err
+	}
+
+	var input *domainpkg.Input
+	isApply := irJob.Operation().Type() == pipeline.OperationTypeTerraformApply
+	if isApply && (b.settings.inputApply() || irJob.RequiresApproval()) {
+		input = &domainpkg.Input{Message: "Run " + irJob.Name() + "?", OK: "Apply"}
+	}
+
+	var unstash []domainpkg.Unstash
+	for _, artifact := range irJob.InputArtifacts() {
+		if !artifact.Configured() {
+			continue
+		}
+		unstash = append(unstash, domainpkg.Unstash{Name: artifact.Artifact.Name, Optional: artifact.Optional})
+	}
+
+	script := append([]string(nil), profile.beforeScript...)
+	script = append(script, cishell.RenderOperation(irJob.Operation())...)
+
+	continueOnFailure := b.continueOnFailure[irJob.Name()]
+	var stash *domainpkg.Stash
+	if output := irJob.OutputArtifact(); output.Configured() {
+		stash = &domainpkg.Stash{
+			Name:       output.Name,
+			Includes:   output.Paths,
+			AllowEmpty: continueOnFailure || !artifactRequired(irJob),
+		}
+	}
+
+	return domainpkg.NewStage(domainpkg.StageOptions{
+		Name:              irJob.Name(),
+		Agent:             profile.agent,
+		Environment:       mergeEnvironment(irJob.Env(), profile.environment),
+		Input:             input,
+		Unstash:           unstash,
+		Script:            script,
+		Stash:             stash,
+		AfterScript:       profile.afterScript,
+		CatchError:        irJob.AllowFailure(),
+		ContinueOnFailure: continueOnFailure,
+	})
+}
+
+// artifactRequired reports whether a missing output artifact should fail the
+// stage. Only plan files are mandatory; plugin reports may legitimately be
+// absent when their job is allowed to fail.
+func artifactRequired(irJob pipeline.Job) bool {
+	return irJob.Operation().Type() == pipeline.OperationTypeTerraformPlan && !irJob.AllowFailure()
+}
+
+func jobOverwriteType(irJob pipeline.Job) configpkg.JobOverwriteType {
+	switch irJob.Operation().Type() {
+	case pipeline.OperationTypeTerraformPlan:
+		return configpkg.OverwriteTypePlan
+	case pipeline.OperationTypeTerraformApply:
+		return configpkg.OverwriteTypeApply
+	case pipeline.OperationTypeCommands:
+		return configpkg.JobOverwriteType(irJob.Name())
+	default:
+		return ""
+	}
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"maps"
+
+	"github.com/edelwud/terraci/pkg/config/overwrite"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+	domainpkg "github.com/edelwud/terraci/plugins/jenkins/internal/domain"
+)
+
+type jobProfile struct {
+	agent        *domainpkg.Agent
+	environment  map[string]string
+	beforeScript []string
+	afterScript  []string
+}
+
+// jobProfile resolves stage settings for jobType. Stages inherit the
+// pipeline agent unless job_defaults or an overwrite sets one.
+func (s settings) jobProfile(jobType configpkg.JobOverwriteType) (jobProfile, error) {
+	cfg := s.configOrDefault()
+	var profile jobProfile
+
+	if cfg.JobDefaults != nil {
+		applyJobDefaults(&profile, cfg.JobDefaults)
+	}
+
+	err := overwrite.ApplyMatching(
+		&profile,
+		jobType,
+		cfg.Overwrites,
+		overwrite.ByKey(func(ow *configpkg.JobOverwrite) configpkg.JobOverwriteType { return ow.Type }),
+		applyJobOverwrite,
+	)
+	if err != nil {
+		return jobProfile{}, err
+	}
+	return profile, nil
+}
+
+func applyJobDefaults(profile *jobProfile, defaults *configpkg.JobDefaults) {
+	if defaults.Agent != nil {
+		profile.agent = convertAgent(defaults.Agent)
+	}
+	mergeProfileEnvironment(profile, defaults.Environment)
+	profile.beforeScript = append(profile.beforeScript, defaults.BeforeScript...)
+	profile.afterScript = append(profile.afterScript, defaults.AfterScript...)
+}
+
+func applyJobOverwrite(profile *jobProfile, ow *configpkg.JobOverwrite) {
+	if ow.Agent != nil {
+		profile.agent = convertAgent(ow.Agent)
+	}
+	mergeProfileEnvironment(profile, ow.Environment)
+	profile.beforeScript = append(profile.beforeScript, ow.BeforeScript...)
+	profile.afterScript = append(profile.afterScript, ow.AfterScript...)
+}
+
+// convertAgent maps a config agent onto the domain. A single entrypoint
+// element becomes a docker --entrypoint argument; validation rejects longer
+// entrypoints.
+func convertAgent(agent *configpkg.Agent) *domainpkg.Agent {
+	if agent == nil {
+		return nil
+	}
+	out := &domainpkg.Agent{Label: agent.Label}
+	if img := agent.Image; img != nil && img.Name != "" {
+		out.Image = img.Name
+		if len(img.Entrypoint) > 0 {
+			out.DockerArgs = "--entrypoint=" + shellQuote(img.Entrypoint[0])
+		}
+	}
+	return out
+}
+
+func mergeProfileEnvironment(profile *jobProfile, env map[string]string) {
+	if len(env) == 0 {
+		return
+	}
+	if profile.environment == nil {
+		profile.environment = make(map[string]string, len(env))
+	}
+	maps.Copy(profile.environment, env)
+}
+
+// mergeEnvironment layers environment maps; later layers win.
+func mergeEnvironment(layers ...map[string]string) map[string]string {
+	var result map[string]string
+	for _, layer := range layers {
+		if len(layer) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		maps.Copy(result, layer)
+	}
+	return result
+}
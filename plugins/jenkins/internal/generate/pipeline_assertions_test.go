@@ -0,0 +1,235 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	domainpkg "github.com/edelwud/terraci/plugins/jenkins/internal/domain"
+)
+
+type pipelineAssert struct {
+	t        *testing.T
+	pipeline *domainpkg.Pipeline
+}
+
+func assertPipeline(t *testing.T, pipeline *domainpkg.Pipeline) *pipelineAssert {
+	t.Helper()
+	return &pipelineAssert{t: t, pipeline: pipeline}
+}
+
+func (a *pipelineAssert) stageCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.StageCount(); got != expected {
+		a.t.Fatalf("expected %d stages, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) levelCount(expected int) *pipelineAssert {
+	a.t.Helper()
+	if got := a.pipeline.LevelCount(); got != expected {
+		a.t.Fatalf("expected %d levels, got %d", expected, got)
+	}
+	return a
+}
+
+func (a *pipelineAssert) hasStage(name string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Stage(name); !ok {
+		a.t.Fatalf("expected stage %q to exist", name)
+	}
+	return a
+}
+
+func (a *pipelineAssert) noStage(name string) *pipelineAssert {
+	a.t.Helper()
+	if _, ok := a.pipeline.Stage(name); ok {
+		a.t.Fatalf("expected stage %q to not exist", name)
+	}
+	return a
+}
+
+func (a *pipelineAssert) parallel(names ...string) *pipelineAssert {
+	a.t.Helper()
+	level, ok := a.pipeline.LevelOf(names[0])
+	if !ok {
+		a.t.Fatalf("expected stage %q to exist", names[0])
+	}
+	stages := a.pipeline.LevelStages(level)
+	for _, name := range names {
+		if !slices.Contains(stages, name) {
+			a.t.Fatalf("expected stage %q in the same level as %q, level has %v", name, names[0], stages)
+		}
+	}
+	return a
+}
+
+func (a *pipelineAssert) stage(name string) *stageAssert {
+	a.t.Helper()
+	stage, ok := a.pipeline.Stage(name)
+	if !ok {
+		a.t.Fatalf("expected stage %q to exist", name)
+	}
+	return &stageAssert{t: a.t, name: name, pipeline: a.pipeline, stage: stage}
+}
+
+type stageAssert struct {
+	t        *testing.T
+	name     string
+	pipeline *domainpkg.Pipeline
+	stage    domainpkg.Stage
+}
+
+// runsAfter asserts that the sequential levels order the stage after dependency.
+func (a *stageAssert) runsAfter(dependency string) *stageAssert {
+	a.t.Helper()
+	if !a.pipeline.StageRunsAfter(a.name, dependency) {
+		a.t.Fatalf("expected stage %q to run after %q", a.name, dependency)
+	}
+	return a
+}
+
+func (a *stageAssert) notAfter(dependency string) *stageAssert {
+	a.t.Helper()
+	if a.pipeline.StageRunsAfter(a.name, dependency) {
+		a.t.Fatalf("expected stage %q not to run after %q", a.name, dependency)
+	}
+	return a
+}
+
+func (a *stageAssert) input(expected bool) *stageAssert {
+	a.t.Helper()
+	if got := a.stage.Input() != nil; got != expected {
+		a.t.Fatalf("expected stage %q input=%v, got %v", a.name, expected, got)
+	}
+	return a
+}
+
+func (a *stageAssert) environment(name, expected string) *stageAssert {
+	a.t.Helper()
+	if got := a.stage.Environment()[name]; got != expected {
+		a.t.Fatalf("expected stage %q environment %s=%q, got %q", a.name, name, expected, got)
+	}
+	return a
+}
+
+func (a *stageAssert) agentLabel(expected string) *stageAssert {
+	a.t.Helper()
+	agent := a.stage.Agent()
+	if agent == nil {
+		a.t.Fatalf("expected stage %q to declare an agent", a.name)
+	}
+	if agent.Label != expected {
+		a.t.Fatalf("expected stage %q agent label=%q, got %q", a.name, expected, agent.Label)
+	}
+	return a
+}
+
+func (a *stageAssert) agentImage(expected string) *stageAssert {
+	a.t.Helper()
+	agent := a.stage.Agent()
+	if agent == nil {
+		a.t.Fatalf("expected stage %q to declare an agent", a.name)
+	}
+	if agent.Image != expected {
+		a.t.Fatalf("expected stage %q agent image=%q, got %q", a.name, expected, agent.Image)
+	}
+	return a
+}
+
+func (a *stageAssert) inheritsAgent() *stageAssert {
+	a.t.Helper()
+	if agent := a.stage.Agent(); agent != nil {
+		a.t.Fatalf("expected stage %q to inherit the pipeline agent, got %+v", a.name, agent)
+	}
+	return a
+}
+
+func (a *stageAssert) catchError(expected bool) *stageAssert {
+	a.t.Helper()
+	if a.stage.CatchError() != expected {
+		a.t.Fatalf("expected stage %q catchError=%v, got %v", a.name, expected, a.stage.CatchError())
+	}
+	return a
+}
+
+func (a *stageAssert) stash(name string, allowEmpty bool, includes ...string) *stageAssert {
+	a.t.Helper()
+	stash := a.stage.Stash()
+	if stash == nil {
+		a.t.Fatalf("expected stage %q to stash %q", a.name, name)
+	}
+	if stash.Name != name || stash.AllowEmpty != allowEmpty {
+		a.t.Fatalf("expected stage %q stash %q allowEmpty=%v, got %+v", a.name, name, allowEmpty, stash)
+	}
+	for _, include := range includes {
+		if !slices.Contains(stash.Includes, include) {
+			a.t.Fatalf("expected stage %q stash to include %q, got %v", a.name, include, stash.Includes)
+		}
+	}
+	return a
+}
+
+func (a *stageAssert) noStash() *stageAssert {
+	a.t.Helper()
+	if stash := a.stage.Stash(); stash != nil {
+		a.t.Fatalf("expected stage %q not to stash, got %+v", a.name, stash)
+	}
+	return a
+}
+
+func (a *stageAssert) unstash(name string, optional bool) *stageAssert {
+	a.t.Helper()
+	for _, unstash := range a.stage.Unstash() {
+		if unstash.Name == name {
+			if unstash.Optional != optional {
+				a.t.Fatalf("expected stage %q unstash %q optional=%v", a.name, name, optional)
+			}
+			return a
+		}
+	}
+	a.t.Fatalf("expected stage %q to unstash %q, got %v", a.name, name, a.stage.Unstash())
+	return a
+}
+
+func (a *stageAssert) scriptContains(fragment string) *stageAssert {
+	a.t.Helper()
+	a.scriptIndex(fragment)
+	return a
+}
+
+func (a *stageAssert) scriptOrder(first, second string) *stageAssert {
+	a.t.Helper()
+	if a.scriptIndex(first) >= a.scriptIndex(second) {
+		a.t.Fatalf("expected stage %q script %q before %q: %v", a.name, first, second, a.stage.Script())
+	}
+	return a
+}
+
+func (a *stageAssert) afterScript(expected ...string) *stageAssert {
+	a.t.Helper()
+	if got := a.stage.AfterScript(); !slices.Equal(got, expected) {
+		a.t.Fatalf("expected stage %q after script %v, got %v", a.name, expected, got)
+	}
+	return a
+}
+
+func (a *stageAssert) scriptIndex(fragment string) int {
+	a.t.Helper()
+	for i, line := range a.stage.Script() {
+		if strings.Contains(line, fragment) {
+			return i
+		}
+	}
+	a.t.Fatalf("expected stage %q script to contain %q, got %v", a.name, fragment, a.stage.Script())
+	return -1
+}
+
+func (a *stageAssert) continueOnFailure(expected bool) *stageAssert {
+	a.t.Helper()
+	if a.stage.ContinueOnFailure() != expected {
+		a.t.Fatalf("expected stage %q continueOnFailure=%v, got %v", a.name, expected, a.stage.ContinueOnFailure())
+	}
+	return a
+}
@@ -0,0 +1,32 @@
+package generate
+
+import (
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+)
+
+type settings struct {
+	config *configpkg.Config
+}
+
+func newSettings(cfg *configpkg.Config) settings {
+	return settings{config: cfg}
+}
+
+func (s settings) configOrDefault() *configpkg.Config {
+	if s.config == nil {
+		return &configpkg.Config{}
+	}
+	return s.config
+}
+
+func (s settings) agent() *configpkg.Agent {
+	return s.configOrDefault().Agent
+}
+
+func (s settings) environment() map[string]string {
+	return s.configOrDefault().Environment
+}
+
+func (s settings) inputApply() bool {
+	return s.configOrDefault().InputApplyEnabled()
+}
@@ -0,0 +1,44 @@
+// Generated by terraci — do not edit
+pipeline {
+    agent { label 'terraform' }
+    stages {
+        stage('plan-platform-stage-eu-central-1-vpc') {
+            environment {
+                TF_ENVIRONMENT = 'stage'
+                TF_MODULE = 'vpc'
+                TF_MODULE_PATH = 'platform/stage/eu-central-1/vpc'
+                TF_REGION = 'eu-central-1'
+                TF_SERVICE = 'platform'
+            }
+            steps {
+                sh '''
+                    cd platform/stage/eu-central-1/vpc
+                    terraform init
+                    terraform plan -out=plan.tfplan
+                '''
+                stash name: 'terraci-plan-platform-stage-eu-central-1-vpc', includes: 'platform/stage/eu-central-1/vpc/plan.tfplan'
+            }
+        }
+        stage('apply-platform-stage-eu-central-1-vpc') {
+            environment {
+                TF_ENVIRONMENT = 'stage'
+                TF_MODULE = 'vpc'
+                TF_MODULE_PATH = 'platform/stage/eu-central-1/vpc'
+                TF_REGION = 'eu-central-1'
+                TF_SERVICE = 'platform'
+            }
+            input {
+                message 'Run apply-platform-stage-eu-central-1-vpc?'
+                ok 'Apply'
+            }
+            steps {
+                unstash 'terraci-plan-platform-stage-eu-central-1-vpc'
+                sh '''
+                    cd platform/stage/eu-central-1/vpc
+                    terraform init
+                    terraform apply plan.tfplan
+                '''
+            }
+        }
+    }
+}
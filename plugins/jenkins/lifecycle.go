@@ -0,0 +1,26 @@
+package jenkins
+
+import (
+	"context"
+	"os"
+
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/plugins/internal/ciplugin"
+)
+
+// Preflight validates the loaded plugin config and detects PR context when
+// running inside a Jenkins multibranch build.
+func (p *Plugin) Preflight(_ context.Context, _ *plugin.AppContext) error {
+	var cfg ciplugin.ConfigValidator
+	if c := p.Config(); c != nil {
+		cfg = c
+	}
+	return ciplugin.Preflight(cfg, p.DetectEnv, ciplugin.PreflightLog{
+		ProviderName: pluginName,
+		ContextLabel: "PR",
+		DetectInContext: func() (any, bool) {
+			id := os.Getenv("CHANGE_ID")
+			return id, id != ""
+		},
+	})
+}
@@ -0,0 +1,30 @@
+// Package jenkins provides the Jenkins plugin for TerraCi.
+// It registers a generator that renders declarative Jenkinsfiles.
+package jenkins
+
+import (
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/registry"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+)
+
+// pluginName is the canonical provider name of the Jenkins plugin.
+const pluginName = "jenkins"
+
+func init() {
+	registry.RegisterFactory(func() plugin.Plugin {
+		return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+			PluginName: pluginName,
+			PluginDesc: "Jenkins declarative pipeline generation",
+			EnableMode: plugin.EnabledWhenConfigured,
+			DefaultCfg: func() *configpkg.Config {
+				return &configpkg.Config{}
+			},
+		}}
+	})
+}
+
+// Plugin is the Jenkins plugin.
+type Plugin struct {
+	plugin.BasePlugin[*configpkg.Config]
+}
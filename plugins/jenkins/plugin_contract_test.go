@@ -0,0 +1,173 @@
+package jenkins
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/pipeline/pipelinetest"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/plugin/initwiz"
+	"github.com/edelwud/terraci/pkg/plugin/plugintest"
+	configpkg "github.com/edelwud/terraci/plugins/jenkins/internal/config"
+)
+
+func TestPlugin_SDKContracts(t *testing.T) {
+	p := newContractPlugin()
+	inputApply := false
+
+	t.Run("config", func(t *testing.T) {
+		plugintest.AssertBaseConfigPlugin[*configpkg.Config](t, plugintest.BaseConfigPluginContract[*configpkg.Config]{
+			Plugin:  p,
+			Default: &configpkg.Config{},
+			Configured: &configpkg.Config{
+				Agent:       &configpkg.Agent{Label: "linux", Image: &configpkg.Image{Name: "hashicorp/terraform:1.9"}},
+				Environment: map[string]string{"TF_INPUT": "false"},
+				JobDefaults: &configpkg.JobDefaults{
+					Environment:  map[string]string{"DEFAULT": "true"},
+					BeforeScript: []string{"echo setup"},
+				},
+			},
+			Decoded: &configpkg.Config{
+				InputApply:  &inputApply,
+				Environment: map[string]string{"DECODED": "true"},
+				Overwrites: []configpkg.JobOverwrite{{
+					Type:  configpkg.OverwriteTypeApply,
+					Agent: &configpkg.Agent{Label: "production"},
+				}},
+			},
+			Mutate: mutateJenkinsConfig,
+			Equal:  equalJenkinsConfig,
+		})
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		plugintest.AssertPreflightable(t, plugintest.PreflightableContract{
+			Plugin:     newContractPlugin(),
+			AppContext: plugintest.NewAppContext(t, t.TempDir()),
+		})
+	})
+
+	t.Run("init contributor", func(t *testing.T) {
+		state := initwiz.NewStateMap()
+		initwiz.ProviderKey.Set(state, pluginName)
+		plugintest.AssertInitContributor(t, plugintest.InitContributorContract{
+			Contributor:        newContractPlugin(),
+			State:              state,
+			ExpectedPluginKey:  pluginName,
+			ExpectContribution: true,
+			DecodeTarget:       &configpkg.Config{},
+		})
+	})
+
+	t.Run("ci provider", func(t *testing.T) {
+		t.Setenv("JENKINS_URL", "https://jenkins.example.com/")
+		p := newContractPlugin()
+		plugintest.AssertCIProvider(t, plugintest.CIProviderContract{
+			EnvDetector:  p,
+			InfoProvider: p,
+			Generator:    p,
+			AppContext:   plugintest.NewAppContext(t, t.TempDir()),
+			IR:           pipelinetest.MustCommandIR(t),
+			ExpectedName: pluginName,
+			AssertEnv: func(tb testing.TB, detected bool) {
+				tb.Helper()
+				if !detected {
+					tb.Fatal("DetectEnv() = false, want true")
+				}
+			},
+		})
+	})
+}
+
+func newContractPlugin() *Plugin {
+	return &Plugin{BasePlugin: plugin.BasePlugin[*configpkg.Config]{
+		PluginName: pluginName,
+		PluginDesc: "Jenkins declarative pipeline generation",
+		EnableMode: plugin.EnabledWhenConfigured,
+		DefaultCfg: func() *configpkg.Config {
+			return &configpkg.Config{}
+		},
+	}}
+}
+
+func mutateJenkinsConfig(c *configpkg.Config) {
+	if c == nil {
+		return
+	}
+	if c.Agent != nil {
+		c.Agent.Label = "mutated"
+		if c.Agent.Image != nil {
+			c.Agent.Image.Name = "mutated"
+		}
+	}
+	if c.Environment == nil {
+		c.Environment = map[string]string{}
+	}
+	c.Environment["MUTATED"] = "true"
+	if c.InputApply != nil {
+		*c.InputApply = !*c.InputApply
+	}
+	if c.JobDefaults != nil {
+		c.JobDefaults.BeforeScript = append(c.JobDefaults.BeforeScript, "mutated")
+		if c.JobDefaults.Environment == nil {
+			c.JobDefaults.Environment = map[string]string{}
+		}
+		c.JobDefaults.Environment["MUTATED"] = "true"
+	}
+	for i := range c.Overwrites {
+		if c.Overwrites[i].Agent != nil {
+			c.Overwrites[i].Agent.Label = "mutated"
+		}
+	}
+}
+
+func equalJenkinsConfig(got, want *configpkg.Config) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalJenkinsAgent(got.Agent, want.Agent) &&
+		maps.Equal(got.Environment, want.Environment) &&
+		equalBoolPointer(got.InputApply, want.InputApply) &&
+		equalJenkinsDefaults(got.JobDefaults, want.JobDefaults) &&
+		slices.EqualFunc(got.Overwrites, want.Overwrites, equalJenkinsOverwrite)
+}
+
+func equalBoolPointer(got, want *bool) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func equalJenkinsAgent(got, want *configpkg.Agent) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Label == want.Label && equalJenkinsImage(got.Image, want.Image)
+}
+
+func equalJenkinsImage(got, want *configpkg.Image) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Name == want.Name && slices.Equal(got.Entrypoint, want.Entrypoint)
+}
+
+func equalJenkinsDefaults(got, want *configpkg.JobDefaults) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return equalJenkinsAgent(got.Agent, want.Agent) &&
+		maps.Equal(got.Environment, want.Environment) &&
+		slices.Equal(got.BeforeScript, want.BeforeScript) &&
+		slices.Equal(got.AfterScript, want.AfterScript)
+}
+
+func equalJenkinsOverwrite(got, want configpkg.JobOverwrite) bool {
+	return got.Type == want.Type &&
+		equalJenkinsAgent(got.Agent, want.Agent) &&
+		maps.Equal(got.Environment, want.Environment) &&
+		slices.Equal(got.BeforeScript, want.BeforeScript) &&
+		slices.Equal(got.AfterScript, want.AfterScript)
+}
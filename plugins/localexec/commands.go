@@ -1,6 +1,8 @@
 package localexec
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/edelwud/terraci/pkg/filter"
@@ -14,6 +16,8 @@ type sharedFlags struct {
 	modulePath  string
 	parallelism int
 	filters     filter.Flags
+	autoApprove bool
+	irFile      string
 }
 
 func (sf *sharedFlags) toRequest(mode ExecutionMode) ExecuteRequest {
@@ -24,6 +28,8 @@ func (sf *sharedFlags) toRequest(mode ExecutionMode) ExecuteRequest {
 		ModulePath:  sf.modulePath,
 		Parallelism: sf.parallelism,
 		Filters:     &sf.filters,
+		AutoApprove: sf.autoApprove,
+		IRFile:      sf.irFile,
 	}
 }
 
@@ -36,6 +42,14 @@ func (p *Plugin) CommandSpecs() ([]plugin.CommandSpec, error) {
 	if err != nil {
 		return nil, err
 	}
+	destroyCmd, err := newDestroyCmd(p.Name())
+	if err != nil {
+		return nil, err
+	}
+	driftCmd, err := newDriftCmd(p.Name())
+	if err != nil {
+		return nil, err
+	}
 	cmd, err := plugin.NewCommandSpec(plugin.CommandSpecOptions{
 		Use:   "local-exec",
 		Short: "Execute the generated terraci flow locally",
@@ -43,20 +57,27 @@ func (p *Plugin) CommandSpecs() ([]plugin.CommandSpec, error) {
 
 Use "plan" to run plan jobs and contributed DAG jobs whose resource inputs are available.
 Use "run" to run the full local flow: plan, apply, and resource-dependent DAG jobs.
+Use "destroy" to destroy the selected modules in reverse dependency order.
+Use "drift" to plan every selected module and report drift without applying.
 After execution, local-exec always prints a local DAG/job summary.
 
 Target selection flags such as --module, --filter, --include, --exclude, and
---changed-only are available on the "plan" and "run" subcommands. If no modules
+--changed-only are available on the "plan" and "run" subcommands; "destroy"
+and "drift" accept the same flags except --changed-only. If no modules
 match, the command exits cleanly after logging "no modules to process".`,
 		Example: `  terraci local-exec plan
   terraci local-exec plan --changed-only
   terraci local-exec plan --filter environment=stage
   terraci local-exec run --changed-only
   terraci local-exec plan --module platform/stage/eu-central-1/vpc
-  terraci local-exec run --filter environment=stage --parallelism 2`,
+  terraci local-exec run --filter environment=stage --parallelism 2
+  terraci local-exec destroy --filter environment=stage
+  terraci local-exec drift --filter environment=prod`,
 		Subcommands: []plugin.CommandSpec{
 			planCmd,
 			runCmd,
+			destroyCmd,
+			driftCmd,
 		},
 	})
 	if err != nil {
@@ -107,11 +128,20 @@ func newRunCmd(pluginName string) (plugin.CommandSpec, error) {
 		Long: `Run the full local execution flow for the selected modules: plan, apply,
 and resource-dependent DAG jobs. local-exec always prints the execution
 summary. If target selection resolves to no modules, the command exits without
-error after logging "no modules to process".`,
+error after logging "no modules to process".
+
+Apply jobs gated by an approvals rule prompt for confirmation before they run;
+pass --auto-approve to skip the prompt in non-interactive sessions.
+
+With --ir, the pipeline IR exported by "terraci generate --format ir-json" is
+executed as-is instead of planning the project; target selection flags are
+rejected because the IR already encodes its jobs.`,
 		Example: `  terraci local-exec run
   terraci local-exec run --changed-only
   terraci local-exec run --module platform/stage/eu-central-1/vpc
-  terraci local-exec run --filter environment=stage --parallelism 2`,
+  terraci local-exec run --filter environment=stage --parallelism 2
+  terraci local-exec run --filter environment=prod --auto-approve
+  terraci local-exec run --ir pipeline-ir.json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cmdCtx, _, err := plugin.CommandPlugin[*Plugin](cmd, pluginName)
 			if err != nil {
@@ -124,6 +154,81 @@ error after logging "no modules to process".`,
 			).Run(cmd.Context(), sf.toRequest(ExecutionModeRun))
 			return renderLocalExecResult(result, err)
 		},
+		Configure: func(cmd *cobra.Command) error {
+			registerSharedFlags(cmd, &sf)
+			cmd.Flags().BoolVar(&sf.autoApprove, "auto-approve", false, "apply approval-gated modules without prompting")
+			cmd.Flags().StringVar(&sf.irFile, "ir", "", "execute a pipeline IR exported by terraci generate --format ir-json")
+			return nil
+		},
+	})
+}
+
+func newDestroyCmd(pluginName string) (plugin.CommandSpec, error) {
+	var sf sharedFlags
+	return plugin.NewCommandSpec(plugin.CommandSpecOptions{
+		Use:   cmdDestroy,
+		Short: "Destroy modules locally in reverse dependency order",
+		Long: `Destroy the selected modules: each module runs "plan -destroy" and then
+applies that plan, consumers before the producers whose remote state they read.
+Every destroy apply requires approval and prompts for confirmation; pass
+--auto-approve to skip the prompts in non-interactive sessions.
+
+--changed-only is not supported. If target selection resolves to no modules,
+the command exits without error after logging "no modules to process".`,
+		Example: `  terraci local-exec destroy --module platform/stage/eu-central-1/eks
+  terraci local-exec destroy --filter environment=stage
+  terraci local-exec destroy --filter environment=stage --auto-approve`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmdCtx, _, err := plugin.CommandPlugin[*Plugin](cmd, pluginName)
+			if err != nil {
+				return err
+			}
+			result, err := NewExecutor(
+				cmdCtx.AppContext(),
+				WithEventSink(render.NewProgressReporter()),
+				WithPipelineContributions(cmdCtx.PipelineContributions()),
+			).Run(cmd.Context(), sf.toRequest(ExecutionModeDestroy))
+			return renderLocalExecResult(result, err)
+		},
+		Configure: func(cmd *cobra.Command) error {
+			registerSharedFlags(cmd, &sf)
+			cmd.Flags().BoolVar(&sf.autoApprove, "auto-approve", false, "destroy modules without prompting")
+			return nil
+		},
+	})
+}
+
+func newDriftCmd(pluginName string) (plugin.CommandSpec, error) {
+	var sf sharedFlags
+	return plugin.NewCommandSpec(plugin.CommandSpecOptions{
+		Use:   cmdDrift,
+		Short: "Detect drift by planning modules locally",
+		Long: `Run a detailed plan for every selected module without applying anything,
+then publish a drift report that lists drifted, clean and errored modules with
+their resource counts. The command exits non-zero when any module drifted.
+
+--changed-only is not supported. If target selection resolves to no modules,
+the command exits without error after logging "no modules to process".`,
+		Example: `  terraci local-exec drift
+  terraci local-exec drift --filter environment=prod
+  terraci local-exec drift --module platform/prod/eu-central-1/vpc`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmdCtx, _, err := plugin.CommandPlugin[*Plugin](cmd, pluginName)
+			if err != nil {
+				return err
+			}
+			result, err := NewExecutor(
+				cmdCtx.AppContext(),
+				WithEventSink(render.NewProgressReporter()),
+			).Run(cmd.Context(), sf.toRequest(ExecutionModeDrift))
+			if err := renderLocalExecResult(result, err); err != nil {
+				return err
+			}
+			if drift := result.Drift(); drift.HasDrift() {
+				return fmt.Errorf("drift detected in %d module(s)", drift.Drifted)
+			}
+			return nil
+		},
 		Configure: func(cmd *cobra.Command) error {
 			registerSharedFlags(cmd, &sf)
 			return nil
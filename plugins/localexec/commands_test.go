@@ -87,6 +87,31 @@ func TestPlugin_Commands_Registration(t *testing.T) {
 		}
 	}
 
+	destroyCmd, _, err := root.Find([]string{"destroy"})
+	if err != nil {
+		t.Fatalf("Find(destroy) error = %v", err)
+	}
+	if destroyCmd == nil || destroyCmd.Use != "destroy" {
+		t.Fatalf("destroy command = %#v, want destroy", destroyCmd)
+	}
+	if !strings.Contains(destroyCmd.Long, "consumers before the producers") {
+		t.Fatalf("destroy command long help should describe destroy order:\n%s", destroyCmd.Long)
+	}
+	if destroyCmd.Flags().Lookup("auto-approve") == nil {
+		t.Fatal("auto-approve flag should be registered on destroy command")
+	}
+
+	driftCmd, _, err := root.Find([]string{"drift"})
+	if err != nil {
+		t.Fatalf("Find(drift) error = %v", err)
+	}
+	if driftCmd == nil || driftCmd.Use != "drift" {
+		t.Fatalf("drift command = %#v, want drift", driftCmd)
+	}
+	if !strings.Contains(driftCmd.Long, "exits non-zero when any module drifted") {
+		t.Fatalf("drift command long help should describe exit code:\n%s", driftCmd.Long)
+	}
+
 	applyCmd, _, err := root.Find([]string{"apply"})
 	if err == nil && applyCmd != nil && applyCmd.Use == "apply" {
 		t.Fatal("apply command should not be registered")
@@ -9,6 +9,7 @@ import (
 	"github.com/edelwud/terraci/pkg/execution"
 	"github.com/edelwud/terraci/pkg/filter"
 	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/planresults"
 	"github.com/edelwud/terraci/pkg/plugin"
 	localexecinternal "github.com/edelwud/terraci/plugins/localexec/internal"
 	"github.com/edelwud/terraci/plugins/localexec/internal/flow"
@@ -22,13 +23,19 @@ const (
 	ExecutionModeRun ExecutionMode = iota
 	// ExecutionModePlan executes plan jobs and resource-dependent DAG jobs.
 	ExecutionModePlan
+	// ExecutionModeDestroy destroys modules in reverse dependency order.
+	ExecutionModeDestroy
+	// ExecutionModeDrift plans every module and reports drift without applying.
+	ExecutionModeDrift
 )
 
 // CLI subcommand names exposed by local-exec. Centralized so cobra
 // definitions, mode strings, and tests share one source of truth.
 const (
-	cmdRun  = "run"
-	cmdPlan = "plan"
+	cmdRun     = "run"
+	cmdPlan    = "plan"
+	cmdDestroy = "destroy"
+	cmdDrift   = "drift"
 )
 
 func (m ExecutionMode) String() string {
@@ -37,6 +44,10 @@ func (m ExecutionMode) String() string {
 		return cmdRun
 	case ExecutionModePlan:
 		return cmdPlan
+	case ExecutionModeDestroy:
+		return cmdDestroy
+	case ExecutionModeDrift:
+		return cmdDrift
 	default:
 		return fmt.Sprintf("ExecutionMode(%d)", m)
 	}
@@ -48,7 +59,7 @@ type ExecuteRequest struct {
 	ChangedOnly bool
 	// BaseRef controls the comparison base for change detection.
 	BaseRef string
-	// Mode must be either ExecutionModeRun or ExecutionModePlan.
+	// Mode must be one of the ExecutionMode constants.
 	Mode ExecutionMode
 	// ModulePath selects a single module after filter resolution when set.
 	ModulePath string
@@ -56,12 +67,18 @@ type ExecuteRequest struct {
 	Parallelism int
 	// Filters may be nil and is normalized to an empty filter set.
 	Filters *filter.Flags
+	// AutoApprove skips the confirmation prompt before approval-gated applies.
+	AutoApprove bool
+	// IRFile executes a pipeline IR exported by `terraci generate --format
+	// ir-json` instead of planning the project. Run mode only.
+	IRFile string
 }
 
 // Result describes one local execution invocation.
 type Result struct {
 	execution     *execution.Result
 	summaryReport *ci.Report
+	drift         *planresults.DriftSummary
 	skipped       bool
 	diagnostics   diagnostic.List
 }
@@ -73,6 +90,7 @@ func newResult(result *flow.Result) *Result {
 	return &Result{
 		execution:     result.Execution(),
 		summaryReport: result.SummaryReport(),
+		drift:         result.Drift(),
 		skipped:       result.Skipped(),
 		diagnostics:   result.Diagnostics(),
 	}
@@ -94,6 +112,14 @@ func (r *Result) SummaryReport() *ci.Report {
 	return r.summaryReport.Clone()
 }
 
+// Drift returns the drift summary of a drift-mode run, if one was collected.
+func (r *Result) Drift() *planresults.DriftSummary {
+	if r == nil {
+		return nil
+	}
+	return r.drift
+}
+
 // Skipped reports whether target selection resolved to no modules.
 func (r *Result) Skipped() bool {
 	return r != nil && r.skipped
@@ -164,7 +190,7 @@ func (e executorAdapter) Run(ctx context.Context, req ExecuteRequest) (*Result,
 
 func mapExecuteRequest(req ExecuteRequest) (localexecinternal.Request, error) {
 	switch req.Mode {
-	case ExecutionModeRun, ExecutionModePlan:
+	case ExecutionModeRun, ExecutionModePlan, ExecutionModeDestroy, ExecutionModeDrift:
 	default:
 		return localexecinternal.Request{}, fmt.Errorf("invalid local-exec mode %q", req.Mode.String())
 	}
@@ -175,6 +201,8 @@ func mapExecuteRequest(req ExecuteRequest) (localexecinternal.Request, error) {
 		ModulePath:  req.ModulePath,
 		Parallelism: req.Parallelism,
 		Filters:     req.Filters,
+		AutoApprove: req.AutoApprove,
+		IRFile:      req.IRFile,
 	}
 	if mapped.Filters == nil {
 		mapped.Filters = &filter.Flags{}
@@ -185,6 +213,10 @@ func mapExecuteRequest(req ExecuteRequest) (localexecinternal.Request, error) {
 		mapped.Mode = localexecinternal.ExecutionModeRun
 	case ExecutionModePlan:
 		mapped.Mode = localexecinternal.ExecutionModePlan
+	case ExecutionModeDestroy:
+		mapped.Mode = localexecinternal.ExecutionModeDestroy
+	case ExecutionModeDrift:
+		mapped.Mode = localexecinternal.ExecutionModeDrift
 	}
 
 	return mapped, nil
@@ -29,6 +29,8 @@ func TestMapExecuteRequest_ModeMapping(t *testing.T) {
 	}{
 		{name: "run", mode: ExecutionModeRun},
 		{name: "plan", mode: ExecutionModePlan},
+		{name: "destroy", mode: ExecutionModeDestroy},
+		{name: "drift", mode: ExecutionModeDrift},
 	}
 
 	for _, tt := range tests {
@@ -14,8 +14,10 @@ import (
 type ExecutionMode = spec.ExecutionMode
 
 const (
-	ExecutionModeRun  = spec.ExecutionModeRun
-	ExecutionModePlan = spec.ExecutionModePlan
+	ExecutionModeRun     = spec.ExecutionModeRun
+	ExecutionModePlan    = spec.ExecutionModePlan
+	ExecutionModeDestroy = spec.ExecutionModeDestroy
+	ExecutionModeDrift   = spec.ExecutionModeDrift
 )
 
 type Request = spec.Request
@@ -0,0 +1,51 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/planresults"
+	"github.com/edelwud/terraci/pkg/plugin"
+)
+
+// collectDrift classifies the targets' fresh plan.json files and publishes
+// the drift report so the local summary includes it.
+func collectDrift(ctx context.Context, appCtx *plugin.AppContext, targets []*discovery.Module) (*planresults.DriftSummary, error) {
+	modulePaths := make([]string, 0, len(targets))
+	for _, module := range targets {
+		modulePaths = append(modulePaths, module.ID())
+	}
+	summary, err := planresults.ScanDrift(appCtx.WorkDir(), modulePaths)
+	if err != nil {
+		return nil, fmt.Errorf("scan drift: %w", err)
+	}
+	structure := appCtx.Config().Structure()
+	collection, err := planresults.Scan(appCtx.WorkDir(), structure.Segments())
+	if err != nil {
+		return nil, fmt.Errorf("scan plan results: %w", err)
+	}
+
+	publication, err := ci.NewArtifactPublication(ci.ArtifactPublicationOptions{
+		Producer: planresults.DriftReportProducer,
+		Results:  ci.RawResults(summary),
+		BuildReport: func() (*ci.Report, error) {
+			run, runErr := plugin.NewArtifactRun(appCtx, plugin.ArtifactRunOptions{
+				Producer:   planresults.DriftReportProducer,
+				Collection: collection,
+			})
+			if runErr != nil {
+				return nil, fmt.Errorf("artifact run: %w", runErr)
+			}
+			return planresults.BuildDriftReport(summary, run)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := appCtx.Reports().PublishArtifacts(ctx, publication); err != nil {
+		return nil, fmt.Errorf("publish drift report: %w", err)
+	}
+	return summary, nil
+}
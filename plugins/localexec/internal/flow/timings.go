@@ -0,0 +1,30 @@
+package flow
+
+import (
+	"context"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/pkg/execution"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/timings"
+	"github.com/edelwud/terraci/plugins/internal/timinghistory"
+)
+
+// recordTimings adds the durations of the executed module jobs to the
+// timing history when it is enabled. The history only feeds estimates, so
+// failures are logged and never fail the run.
+func recordTimings(ctx context.Context, appCtx *plugin.AppContext, ir *pipeline.IR, result *execution.Result) {
+	history, err := timinghistory.Open(ctx, appCtx)
+	if err != nil {
+		log.WithError(err).Warn("job timings not recorded")
+		return
+	}
+	if history == nil {
+		return
+	}
+	if err := history.Record(ctx, timings.SamplesFromExecution(ir, result)); err != nil {
+		log.WithError(err).Warn("job timings not recorded")
+	}
+}
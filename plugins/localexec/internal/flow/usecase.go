@@ -3,14 +3,18 @@ package flow
 import (
 	"context"
 	"fmt"
+	"os"
 
 	log "github.com/caarlos0/log"
 
 	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/config"
 	"github.com/edelwud/terraci/pkg/diagnostic"
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/execution"
 	"github.com/edelwud/terraci/pkg/filter"
 	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/planresults"
 	"github.com/edelwud/terraci/pkg/plugin"
 	"github.com/edelwud/terraci/pkg/terraformrun"
 	"github.com/edelwud/terraci/pkg/workflow"
@@ -24,6 +28,7 @@ type Request = spec.Request
 type Result struct {
 	execution     *execution.Result
 	summaryReport *ci.Report
+	drift         *planresults.DriftSummary
 	skipped       bool
 	diagnostics   diagnostic.List
 }
@@ -54,6 +59,13 @@ func (r *Result) SummaryReport() *ci.Report {
 	return r.summaryReport.Clone()
 }
 
+func (r *Result) Drift() *planresults.DriftSummary {
+	if r == nil {
+		return nil
+	}
+	return r.drift
+}
+
 func (r *Result) Skipped() bool {
 	return r != nil && r.skipped
 }
@@ -166,6 +178,10 @@ func withDefaults(deps, defaults Dependencies) Dependencies {
 }
 
 func (u *UseCase) Run(ctx context.Context, req Request) (*Result, error) {
+	if req.IRFile != "" {
+		return u.runExportedIR(ctx, req)
+	}
+
 	project, err := u.projects.Plan(ctx, req)
 	if err != nil {
 		return nil, err
@@ -181,15 +197,50 @@ func (u *UseCase) Run(ctx context.Context, req Request) (*Result, error) {
 		return nil, fmt.Errorf("terraform profile: %w", err)
 	}
 
-	plan, err := buildExecutionIR(project, profile, req.Mode, u.contributions)
+	contributions := u.contributions
+	if req.Mode == spec.ExecutionModeDrift {
+		// Drift runs only plan; plugin jobs are left to regular runs.
+		contributions = pipeline.EmptyContributionSet()
+	}
+	plan, err := buildExecutionIR(project, profile, req.Mode, u.appCtx.Config().Approvals(), contributions)
 	if err != nil {
 		return nil, err
 	}
+	return u.execute(ctx, req, profile, plan, project.Targets)
+}
+
+// runExportedIR executes an IR written by `terraci generate --format ir-json`
+// as-is; target selection and approvals are already encoded in its jobs.
+func (u *UseCase) runExportedIR(ctx context.Context, req Request) (*Result, error) {
+	data, err := os.ReadFile(req.IRFile)
+	if err != nil {
+		return nil, fmt.Errorf("read pipeline IR: %w", err)
+	}
+	plan, err := pipeline.UnmarshalIRJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", req.IRFile, err)
+	}
+	if len(plan.Jobs()) == 0 {
+		log.Info("no jobs to process")
+		return skippedResult(), nil
+	}
+	profile, err := profileForRequest(u.appCtx, req)
+	if err != nil {
+		return nil, fmt.Errorf("terraform profile: %w", err)
+	}
+	return u.execute(ctx, req, profile, plan, nil)
+}
+
+func (u *UseCase) execute(ctx context.Context, req Request, profile terraformrun.Profile, plan *pipeline.IR, targets []*discovery.Module) (*Result, error) {
+	if err := requireTerraformCLI(plan); err != nil {
+		return nil, err
+	}
 
 	execRuntime, err := u.runtimeFactory.Build(runner.RuntimeOptions{
 		WorkDir:         u.appCtx.WorkDir(),
 		ServiceDir:      u.appCtx.ServiceDir(),
 		PlanParallelism: profile.Parallelism(),
+		AutoApprove:     req.AutoApprove,
 	})
 	if err != nil {
 		return nil, err
@@ -200,10 +251,19 @@ func (u *UseCase) Run(ctx context.Context, req Request) (*Result, error) {
 		execution.WithParallelism(profile.Parallelism()),
 		execution.WithEventSink(u.eventSink),
 	).Execute(ctx, plan)
+	recordTimings(ctx, u.appCtx, plan, resultExec)
 	if err != nil {
 		return completedResult(resultExec, nil, diagnostic.List{}), err
 	}
 
+	var drift *planresults.DriftSummary
+	if req.Mode == spec.ExecutionModeDrift {
+		drift, err = collectDrift(ctx, u.appCtx, targets)
+		if err != nil {
+			return completedResult(resultExec, nil, diagnostic.List{}), err
+		}
+	}
+
 	summaryResult, err := u.summaryReports.Load(ctx)
 	if err != nil {
 		return completedResult(resultExec, nil, diagnostic.List{}), fmt.Errorf("load summary report: %w", err)
@@ -216,7 +276,9 @@ func (u *UseCase) Run(ctx context.Context, req Request) (*Result, error) {
 		summaryReport = summaryResult.Report()
 		diagnostics = summaryResult.Diagnostics()
 	}
-	return completedResult(resultExec, summaryReport, diagnostics), nil
+	result := completedResult(resultExec, summaryReport, diagnostics)
+	result.drift = drift
+	return result, nil
 }
 
 type noopEventSink struct{}
@@ -257,6 +319,18 @@ func derefFilters(filters *filter.Flags) filter.Flags {
 	return *filters
 }
 
+// requireTerraformCLI rejects Terragrunt jobs: local execution drives the
+// binary through terraform-exec, which only speaks the Terraform CLI.
+func requireTerraformCLI(plan *pipeline.IR) error {
+	for _, job := range plan.Jobs() {
+		op := job.Operation().Terraform()
+		if op != nil && op.Binary() == terraformrun.BinaryTerragrunt.String() {
+			return fmt.Errorf("%s: local-exec does not support execution.binary %q; run Terragrunt units through a generated CI pipeline", job.Name(), op.Binary())
+		}
+	}
+	return nil
+}
+
 func profileForRequest(appCtx *plugin.AppContext, req Request) (terraformrun.Profile, error) {
 	profile, err := terraformrun.ProfileFromConfig(appCtx.Config())
 	if err != nil {
@@ -268,11 +342,12 @@ func profileForRequest(appCtx *plugin.AppContext, req Request) (terraformrun.Pro
 	return profile, nil
 }
 
-func buildExecutionIR(project *workflow.ProjectResult, profile terraformrun.Profile, mode spec.ExecutionMode, contributions pipeline.ContributionSet) (*pipeline.IR, error) {
+func buildExecutionIR(project *workflow.ProjectResult, profile terraformrun.Profile, mode spec.ExecutionMode, approvals []config.ApprovalRule, contributions pipeline.ContributionSet) (*pipeline.IR, error) {
 	intent, err := intentForMode(mode)
 	if err != nil {
 		return nil, fmt.Errorf("build local execution intent: %w", err)
 	}
+	intent = intent.WithApprovals(approvals...)
 	terraformConfig, err := pipeline.NewTerraformJobConfigFromProfile(profile)
 	if err != nil {
 		return nil, fmt.Errorf("terraform job config: %w", err)
@@ -296,6 +371,10 @@ func intentForMode(mode spec.ExecutionMode) (pipeline.BuildIntent, error) {
 		return pipeline.ApplyBuildIntent()
 	case spec.ExecutionModePlan:
 		return pipeline.PlanBuildIntent(pipeline.AllPlanResources(pipeline.ResourceKindPlanBinary))
+	case spec.ExecutionModeDestroy:
+		return pipeline.DestroyBuildIntent()
+	case spec.ExecutionModeDrift:
+		return pipeline.DriftBuildIntent(pipeline.AllPlanResources(pipeline.ResourceKindPlanJSON))
 	default:
 		var zero pipeline.BuildIntent
 		return zero, fmt.Errorf("unsupported local execution mode %q", mode)
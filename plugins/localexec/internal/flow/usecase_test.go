@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
 	"testing"
 
@@ -327,6 +328,94 @@ func TestUseCase_RunBuildsTerraformIntentFromConfig(t *testing.T) {
 	}
 }
 
+func TestUseCase_RunRejectsTerragrunt(t *testing.T) {
+	workDir, module := testWorkDirWithModule(t)
+	execCfg, err := config.NewExecutionConfig(config.ExecutionConfigOptions{
+		Binary: config.ExecutionBinaryTerragrunt,
+	})
+	if err != nil {
+		t.Fatalf("NewExecutionConfig() error = %v", err)
+	}
+	cfg, err := config.Build(config.BuildOptions{Execution: &execCfg})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	jobRunner := &fakeJobRunner{}
+	_, err = New(
+		testAppContextWithConfig(workDir, cfg),
+		WithProjectPlanner(fakeProjectWithTargets(module)),
+		WithRuntimeFactory(&fakeRuntimeFactory{runtime: &runner.Runtime{JobRunner: jobRunner}}),
+		WithSummaryReports(&fakeSummaryReportLoader{}),
+	).Run(context.Background(), spec.Request{Mode: spec.ExecutionModePlan})
+	if err == nil || !strings.Contains(err.Error(), `local-exec does not support execution.binary "terragrunt"`) {
+		t.Fatalf("Run() error = %v, want terragrunt rejection", err)
+	}
+	if ran := jobRunner.Jobs(); len(ran) != 0 {
+		t.Fatalf("executed jobs = %v, want none", ran)
+	}
+}
+
+func TestUseCase_RunDestroyBuildsGatedDestroyJobs(t *testing.T) {
+	workDir, module := testWorkDirWithModule(t)
+	jobRunner := &fakeJobRunner{}
+
+	_, err := New(
+		plugintest.NewAppContext(t, workDir),
+		WithProjectPlanner(fakeProjectWithTargets(module)),
+		WithRuntimeFactory(&fakeRuntimeFactory{runtime: &runner.Runtime{JobRunner: jobRunner}}),
+		WithSummaryReports(&fakeSummaryReportLoader{}),
+	).Run(context.Background(), spec.Request{Mode: spec.ExecutionModeDestroy})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	planJob := findRanJobByKind(t, jobRunner.RanJobs(), pipeline.JobKindPlan)
+	if op := planJob.Operation().Terraform(); op == nil || !op.Destroy() {
+		t.Fatalf("plan job operation = %#v, want destroy plan", op)
+	}
+	applyJob := findRanJobByKind(t, jobRunner.RanJobs(), pipeline.JobKindApply)
+	if !applyJob.RequiresApproval() {
+		t.Fatal("destroy apply job should require approval")
+	}
+}
+
+func TestUseCase_RunDriftCollectsDriftSummary(t *testing.T) {
+	workDir, module := testWorkDirWithModule(t)
+	planJSON := `{"format_version":"1.2","terraform_version":"1.6.0","resource_changes":[` +
+		`{"address":"aws_vpc.main","mode":"managed","type":"aws_vpc","name":"main",` +
+		`"change":{"actions":["update"],"before":{"cidr_block":"10.0.0.0/16"},"after":{"cidr_block":"10.1.0.0/16"}}}]}`
+	if err := os.WriteFile(filepath.Join(workDir, module.RelativePath, pipeline.PlanJSONFilename), []byte(planJSON), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	jobRunner := &fakeJobRunner{}
+
+	result, err := New(
+		plugintest.NewAppContext(t, workDir),
+		WithProjectPlanner(fakeProjectWithTargets(module)),
+		WithPipelineContributions(mustContributionSet(t,
+			mustContribution(t, testCommandJob("contributed")),
+		)),
+		WithRuntimeFactory(&fakeRuntimeFactory{runtime: &runner.Runtime{JobRunner: jobRunner}}),
+		WithSummaryReports(&fakeSummaryReportLoader{}),
+	).Run(context.Background(), spec.Request{Mode: spec.ExecutionModeDrift})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	planJob := findRanJobByKind(t, jobRunner.RanJobs(), pipeline.JobKindPlan)
+	if op := planJob.Operation().Terraform(); op == nil || !op.DetailedPlan() {
+		t.Fatalf("plan job operation = %#v, want detailed plan", op)
+	}
+	if containsJob(jobRunner.Jobs(), "contributed") {
+		t.Fatal("drift mode should not run contributed jobs")
+	}
+	drift := result.Drift()
+	if !drift.HasDrift() || drift.Modules[0].ModulePath != module.ID() || drift.Modules[0].ToChange != 1 {
+		t.Fatalf("Drift() = %+v, want drifted module with one change", drift)
+	}
+}
+
 func TestUseCase_RunNoTargetsSkipsExecutionDependencies(t *testing.T) {
 	workDir, module := testWorkDirWithModule(t)
 	appCtx := plugintest.NewAppContext(t, workDir)
@@ -356,6 +445,39 @@ func TestUseCase_RunNoTargetsSkipsExecutionDependencies(t *testing.T) {
 	}
 }
 
+func TestUseCase_RunExecutesExportedIR(t *testing.T) {
+	workDir, module := testWorkDirWithModule(t)
+	appCtx := plugintest.NewAppContext(t, workDir)
+	exported := pipelinetest.MustSingleModuleIR(t, module)
+	data, err := pipeline.MarshalIRJSON(exported)
+	if err != nil {
+		t.Fatalf("MarshalIRJSON() error = %v", err)
+	}
+	irFile := filepath.Join(t.TempDir(), "pipeline-ir.json")
+	if err := os.WriteFile(irFile, data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	jobRunner := &fakeJobRunner{}
+
+	_, err = New(
+		appCtx,
+		WithProjectPlanner(fakeProjectPlanner{err: errors.New("project should not be planned")}),
+		WithRuntimeFactory(&fakeRuntimeFactory{runtime: &runner.Runtime{JobRunner: jobRunner}}),
+		WithSummaryReports(&fakeSummaryReportLoader{}),
+	).Run(context.Background(), spec.Request{Mode: spec.ExecutionModeRun, IRFile: irFile})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var want []string
+	for _, job := range exported.Jobs() {
+		want = append(want, job.Name())
+	}
+	if got := jobRunner.Jobs(); !slices.Equal(got, want) {
+		t.Fatalf("executed jobs = %v, want exported jobs %v", got, want)
+	}
+}
+
 func TestUseCase_RunReturnsTargetResolverError(t *testing.T) {
 	workDir, _ := testWorkDirWithModule(t)
 	appCtx := plugintest.NewAppContext(t, workDir)
@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+
+	"github.com/edelwud/terraci/pkg/pipeline"
+)
+
+// approver decides whether an approval-gated job may run.
+type approver interface {
+	Approve(ctx context.Context, job pipeline.Job) error
+}
+
+// autoApprover accepts every gated job (local-exec run --auto-approve).
+type autoApprover struct{}
+
+func (autoApprover) Approve(context.Context, pipeline.Job) error { return nil }
+
+// promptApprover asks on the terminal before each gated job. Prompts are
+// serialized so parallel applies never interleave their questions.
+type promptApprover struct {
+	mu          sync.Mutex
+	in          *bufio.Reader
+	out         io.Writer
+	interactive func() bool
+}
+
+func newPromptApprover() *promptApprover {
+	return &promptApprover{
+		in:  bufio.NewReader(os.Stdin),
+		out: os.Stderr,
+		interactive: func() bool {
+			return term.IsTerminal(int(os.Stdin.Fd()))
+		},
+	}
+}
+
+func (a *promptApprover) Approve(ctx context.Context, job pipeline.Job) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if a.interactive != nil && !a.interactive() {
+		return fmt.Errorf("%s: requires approval but stdin is not a TTY — rerun with --auto-approve", job.Name())
+	}
+
+	target := job.Name()
+	if module := job.Module(); module != nil {
+		target = module.ID()
+	}
+	action := "Apply"
+	if op := job.Operation().Terraform(); op != nil && op.Destroy() {
+		action = "Destroy"
+	}
+	if _, err := fmt.Fprintf(a.out, "%s %s? [y/N]: ", action, target); err != nil {
+		return err
+	}
+	answer, err := a.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: read approval: %w", job.Name(), err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("%s: apply not approved", job.Name())
+	}
+}
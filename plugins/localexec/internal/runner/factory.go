@@ -11,6 +11,8 @@ type RuntimeOptions struct {
 	WorkDir         string
 	ServiceDir      string
 	PlanParallelism int
+	// AutoApprove skips the interactive prompt before approval-gated applies.
+	AutoApprove bool
 }
 
 type Factory interface {
@@ -53,12 +55,18 @@ func (f defaultFactory) Build(opts RuntimeOptions) (*Runtime, error) {
 		planParallelism: opts.PlanParallelism,
 	}
 
+	var gate approver = newPromptApprover()
+	if opts.AutoApprove {
+		gate = autoApprover{}
+	}
+
 	return &Runtime{
 		Workspace: workspace,
 		JobRunner: &jobRunner{
 			main: operationDispatcher{
 				terraform: terraformRunner,
 				commands:  commandRunner,
+				approver:  gate,
 			},
 		},
 	}, nil
@@ -15,6 +15,7 @@ type operationRunner interface {
 type operationDispatcher struct {
 	terraform terraformRunner
 	commands  commandRunner
+	approver  approver
 }
 
 func (r operationDispatcher) Run(ctx context.Context, job pipeline.Job) error {
@@ -37,6 +38,14 @@ func (r operationDispatcher) Run(ctx context.Context, job pipeline.Job) error {
 		if terraformOp == nil {
 			return fmt.Errorf("%s: terraform apply operation is nil", job.Name())
 		}
+		if job.RequiresApproval() {
+			if r.approver == nil {
+				return fmt.Errorf("%s: requires approval but no approver is configured", job.Name())
+			}
+			if err := r.approver.Approve(ctx, job); err != nil {
+				return err
+			}
+		}
 		return r.terraform.RunApply(ctx, job, terraformOp)
 	case pipeline.OperationTypeCommands:
 		if r.commands == nil {
@@ -1,8 +1,11 @@
 package runner
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/edelwud/terraci/pkg/discovery"
@@ -156,3 +159,101 @@ func TestOperationDispatcherRejectsMissingCollaborators(t *testing.T) {
 		})
 	}
 }
+
+type recordApprover struct {
+	approved []string
+	err      error
+}
+
+func (a *recordApprover) Approve(_ context.Context, job pipeline.Job) error {
+	a.approved = append(a.approved, job.Name())
+	return a.err
+}
+
+func TestOperationDispatcherGatesApprovalRequiredApplies(t *testing.T) {
+	t.Parallel()
+
+	ir := pipelinetest.MustGatedSingleModuleIR(t, discovery.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	plan := pipelinetest.MustJobByKind(t, ir, pipeline.JobKindPlan)
+	apply := pipelinetest.MustJobByKind(t, ir, pipeline.JobKindApply)
+
+	terraform := &recordTerraformRunner{}
+	gate := &recordApprover{}
+	dispatcher := operationDispatcher{terraform: terraform, approver: gate}
+	if err := dispatcher.Run(context.Background(), plan); err != nil {
+		t.Fatalf("Run(plan) error = %v", err)
+	}
+	if err := dispatcher.Run(context.Background(), apply); err != nil {
+		t.Fatalf("Run(apply) error = %v", err)
+	}
+	if !reflect.DeepEqual(gate.approved, []string{apply.Name()}) {
+		t.Fatalf("approved = %v, want [%s]", gate.approved, apply.Name())
+	}
+
+	declined := &recordTerraformRunner{}
+	dispatcher = operationDispatcher{terraform: declined, approver: &recordApprover{err: errors.New("declined")}}
+	if err := dispatcher.Run(context.Background(), apply); err == nil {
+		t.Fatal("Run(apply) error = nil, want declined approval")
+	}
+	if len(declined.applies) != 0 {
+		t.Fatalf("applies = %v, want none after declined approval", declined.applies)
+	}
+}
+
+func TestPromptApproverReadsAnswer(t *testing.T) {
+	t.Parallel()
+
+	ir := pipelinetest.MustGatedSingleModuleIR(t, discovery.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	apply := pipelinetest.MustJobByKind(t, ir, pipeline.JobKindApply)
+
+	tests := []struct {
+		name        string
+		input       string
+		interactive bool
+		wantErr     bool
+	}{
+		{name: "yes", input: "y\n", interactive: true},
+		{name: "full yes", input: "YES\n", interactive: true},
+		{name: "no", input: "n\n", interactive: true, wantErr: true},
+		{name: "empty", input: "", interactive: true, wantErr: true},
+		{name: "not a tty", input: "y\n", interactive: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out strings.Builder
+			approver := &promptApprover{
+				in:          bufio.NewReader(strings.NewReader(tt.input)),
+				out:         &out,
+				interactive: func() bool { return tt.interactive },
+			}
+			err := approver.Approve(context.Background(), apply)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Approve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.interactive && !strings.Contains(out.String(), "platform/prod/eu-central-1/vpc") {
+				t.Fatalf("prompt = %q, want module id", out.String())
+			}
+		})
+	}
+}
+
+func TestPromptApproverNamesDestroy(t *testing.T) {
+	t.Parallel()
+
+	ir := pipelinetest.MustDestroySingleModuleIR(t, discovery.TestModule("platform", "prod", "eu-central-1", "vpc"))
+	apply := pipelinetest.MustJobByKind(t, ir, pipeline.JobKindApply)
+
+	var out strings.Builder
+	approver := &promptApprover{
+		in:          bufio.NewReader(strings.NewReader("y\n")),
+		out:         &out,
+		interactive: func() bool { return true },
+	}
+	if err := approver.Approve(context.Background(), apply); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "Destroy platform/prod/eu-central-1/vpc?") {
+		t.Fatalf("prompt = %q, want destroy prompt", out.String())
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 
@@ -22,9 +24,38 @@ type terraformOperationRunner struct {
 	workspace       execution.Workspace
 	binaryResolver  binaryResolver
 	planParallelism int
+	// dirs serializes jobs of one module directory: selecting a workspace
+	// rewrites the directory's .terraform/environment, and tfexec offers no
+	// per-process TF_WORKSPACE.
+	dirs dirLocks
+}
+
+// dirLocks hands out one mutex per module directory.
+type dirLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock blocks until dir is free and returns the matching unlock.
+func (l *dirLocks) lock(dir string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	dirLock, ok := l.locks[dir]
+	if !ok {
+		dirLock = &sync.Mutex{}
+		l.locks[dir] = dirLock
+	}
+	l.mu.Unlock()
+
+	dirLock.Lock()
+	return dirLock.Unlock
 }
 
 func (r *terraformOperationRunner) RunPlan(ctx context.Context, job pipeline.Job, op *pipeline.TerraformOperation) error {
+	defer r.dirs.lock(r.workspace.ModuleDir(op.ModulePath()))()
+
 	tf, err := r.prepare(ctx, job, op)
 	if err != nil {
 		return err
@@ -34,6 +65,9 @@ func (r *terraformOperationRunner) RunPlan(ctx context.Context, job pipeline.Job
 	if r.planParallelism > 0 {
 		opts = append(opts, tfexec.Parallelism(r.planParallelism))
 	}
+	if op.Destroy() {
+		opts = append(opts, tfexec.Destroy(true))
+	}
 	if _, err = tf.Plan(ctx, opts...); err != nil {
 		return fmt.Errorf("%s: plan: %w", job.Name(), err)
 	}
@@ -46,7 +80,7 @@ func (r *terraformOperationRunner) RunPlan(ctx context.Context, job pipeline.Job
 	if err != nil {
 		return fmt.Errorf("%s: show plan text: %w", job.Name(), err)
 	}
-	if err = os.WriteFile(r.workspace.PlanTextFile(op.ModulePath()), []byte(raw), 0o600); err != nil {
+	if err = os.WriteFile(r.planFile(op, pipeline.PlanTextFilename), []byte(raw), 0o600); err != nil {
 		return fmt.Errorf("%s: write plan.txt: %w", job.Name(), err)
 	}
 
@@ -58,7 +92,7 @@ func (r *terraformOperationRunner) RunPlan(ctx context.Context, job pipeline.Job
 	if err != nil {
 		return fmt.Errorf("%s: marshal plan.json: %w", job.Name(), err)
 	}
-	if err = os.WriteFile(r.workspace.PlanJSONFile(op.ModulePath()), data, 0o600); err != nil {
+	if err = os.WriteFile(r.planFile(op, pipeline.PlanJSONFilename), data, 0o600); err != nil {
 		return fmt.Errorf("%s: write plan.json: %w", job.Name(), err)
 	}
 
@@ -66,14 +100,19 @@ func (r *terraformOperationRunner) RunPlan(ctx context.Context, job pipeline.Job
 }
 
 func (r *terraformOperationRunner) RunApply(ctx context.Context, job pipeline.Job, op *pipeline.TerraformOperation) error {
+	defer r.dirs.lock(r.workspace.ModuleDir(op.ModulePath()))()
+
 	tf, err := r.prepare(ctx, job, op)
 	if err != nil {
 		return err
 	}
 
 	var opts []tfexec.ApplyOption
-	if op.UsePlanFile() {
+	switch {
+	case op.UsePlanFile():
 		opts = append(opts, tfexec.DirOrPlan(filepath.Base(op.PlanFile())))
+	case op.Destroy():
+		opts = append(opts, tfexec.Destroy(true))
 	}
 	if err := tf.Apply(ctx, opts...); err != nil {
 		return fmt.Errorf("%s: apply: %w", job.Name(), err)
@@ -99,6 +138,30 @@ func (r *terraformOperationRunner) prepare(ctx context.Context, job pipeline.Job
 			return nil, fmt.Errorf("%s: init: %w", job.Name(), err)
 		}
 	}
+	if op.Workspace() != "" {
+		if err = selectWorkspace(ctx, tf, op.Workspace()); err != nil {
+			return nil, fmt.Errorf("%s: select workspace %s: %w", job.Name(), op.Workspace(), err)
+		}
+	}
 
 	return tf, nil
 }
+
+// selectWorkspace switches to workspace, creating it when it does not exist
+// yet, like "workspace select -or-create" in rendered CI scripts.
+func selectWorkspace(ctx context.Context, tf *tfexec.Terraform, workspace string) error {
+	existing, _, err := tf.WorkspaceList(ctx)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(existing, workspace) {
+		return tf.WorkspaceSelect(ctx, workspace)
+	}
+	return tf.WorkspaceNew(ctx, workspace)
+}
+
+// planFile returns the absolute path of a plan output file of op, named
+// after its workspace.
+func (r *terraformOperationRunner) planFile(op *pipeline.TerraformOperation, name string) string {
+	return filepath.Join(r.workspace.ModuleDir(op.ModulePath()), pipeline.PlanFilename(name, op.Workspace()))
+}
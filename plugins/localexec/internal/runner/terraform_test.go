@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/execution"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/workflow"
+)
+
+// fakeTerraform stands in for the terraform binary. Workspace commands write
+// .terraform/environment like terraform does, slowly enough for concurrent
+// jobs to interleave, and plan and apply record the workspace they ran in
+// next to their plan file.
+const fakeTerraform = `#!/bin/sh
+case "$1" in
+version)
+  echo '{"terraform_version":"1.9.0","platform":"linux_amd64","provider_selections":{},"terraform_outdated":false}'
+  ;;
+workspace)
+  case "$2" in
+  list) echo "* default" ;;
+  *)
+    for arg in "$@"; do ws="$arg"; done
+    mkdir -p .terraform
+    sleep 0.2
+    printf '%s' "$ws" > .terraform/environment
+    ;;
+  esac
+  ;;
+plan|apply)
+  for arg in "$@"; do
+    case "$arg" in -out=*) planfile="${arg#-out=}" ;; *.tfplan) planfile="$arg" ;; esac
+  done
+  sleep 0.2
+  echo "$1 $planfile $(cat .terraform/environment)" >> "$FAKE_TERRAFORM_LOG"
+  ;;
+esac
+`
+
+type fixedBinaryResolver string
+
+func (r fixedBinaryResolver) Resolve(string) (string, error) { return string(r), nil }
+
+func TestTerraformRunnerSerializesWorkspacesOfOneDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform is a shell script")
+	}
+	binDir := t.TempDir()
+	binary := filepath.Join(binDir, "terraform")
+	if err := os.WriteFile(binary, []byte(fakeTerraform), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logPath := filepath.Join(binDir, "runs.log")
+	t.Setenv("FAKE_TERRAFORM_LOG", logPath)
+
+	workDir := t.TempDir()
+	app := discovery.TestModule("platform", "prod", "eu-central-1", "app")
+	modules := []*discovery.Module{app.WithWorkspace("tenant-a"), app.WithWorkspace("tenant-b")}
+	if err := os.MkdirAll(filepath.Join(workDir, app.RelativePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	depGraph := graph.NewDependencyGraph()
+	for _, module := range modules {
+		depGraph.AddNode(module)
+	}
+	terraformConfig, err := pipeline.NewTerraformJobConfig(pipeline.TerraformJobConfigOptions{Binary: "terraform"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	intent, err := pipeline.ApplyBuildIntent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir, err := pipeline.BuildProjectIR(pipeline.ProjectIRRequest{
+		Project: &workflow.ProjectResult{
+			Workflow: &workflow.Result{
+				Filtered: workflow.NewModuleSet(modules),
+				Graph:    depGraph,
+			},
+			Targets: modules,
+		},
+		Terraform: terraformConfig,
+		Intent:    intent,
+	})
+	if err != nil {
+		t.Fatalf("BuildProjectIR() error = %v", err)
+	}
+
+	rt, err := defaultFactory{binaryResolver: fixedBinaryResolver(binary)}.Build(RuntimeOptions{
+		WorkDir:    workDir,
+		ServiceDir: filepath.Join(workDir, ".terraci"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := execution.NewExecutor(rt.JobRunner, execution.WithParallelism(2)).Execute(context.Background(), ir)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if failed, ok := result.Failed(); ok {
+		t.Fatalf("job %s failed: %v", failed.Name(), failed.Err())
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2*len(modules) {
+		t.Fatalf("runs = %q, want a plan and an apply per workspace", lines)
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			t.Fatalf("run %q, want command, plan file and workspace", line)
+		}
+		if fields[1] != pipeline.PlanFilename(pipeline.PlanBinaryFilename, fields[2]) {
+			t.Errorf("%s of %s ran in workspace %q", fields[0], fields[1], fields[2])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/edelwud/terraci/pkg/filter"
@@ -14,6 +15,10 @@ const (
 	ExecutionModeRun ExecutionMode = iota
 	// ExecutionModePlan runs the plan-only DAG.
 	ExecutionModePlan
+	// ExecutionModeDestroy runs the destroy DAG in reverse dependency order.
+	ExecutionModeDestroy
+	// ExecutionModeDrift runs detailed plans and reports drifted modules.
+	ExecutionModeDrift
 )
 
 func (m ExecutionMode) String() string {
@@ -22,6 +27,10 @@ func (m ExecutionMode) String() string {
 		return "run"
 	case ExecutionModePlan:
 		return "plan"
+	case ExecutionModeDestroy:
+		return "destroy"
+	case ExecutionModeDrift:
+		return "drift"
 	default:
 		return fmt.Sprintf("ExecutionMode(%d)", m)
 	}
@@ -35,6 +44,10 @@ type Request struct {
 	ModulePath  string
 	Parallelism int
 	Filters     *filter.Flags
+	AutoApprove bool
+	// IRFile executes a previously exported pipeline IR instead of planning
+	// the project. Only run mode accepts it, without target selection.
+	IRFile string
 }
 
 // NormalizeRequest validates boundary semantics and fills safe defaults.
@@ -45,11 +58,28 @@ func NormalizeRequest(req Request) (Request, error) {
 	if req.Filters == nil {
 		req.Filters = &filter.Flags{}
 	}
+	if req.IRFile != "" {
+		if req.Mode != ExecutionModeRun {
+			return Request{}, fmt.Errorf("local-exec %s does not support --ir", req.Mode.String())
+		}
+		if req.ChangedOnly || req.ModulePath != "" || hasFilters(req.Filters) {
+			return Request{}, errors.New("--ir cannot be combined with target selection flags")
+		}
+	}
 
 	switch req.Mode {
 	case ExecutionModeRun, ExecutionModePlan:
 		return req, nil
+	case ExecutionModeDestroy, ExecutionModeDrift:
+		if req.ChangedOnly {
+			return Request{}, fmt.Errorf("local-exec %s does not support --changed-only", req.Mode.String())
+		}
+		return req, nil
 	default:
 		return Request{}, fmt.Errorf("invalid local-exec mode %q", req.Mode.String())
 	}
 }
+
+func hasFilters(filters *filter.Flags) bool {
+	return len(filters.Excludes) > 0 || len(filters.Includes) > 0 || len(filters.SegmentArgs) > 0
+}
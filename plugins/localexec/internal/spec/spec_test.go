@@ -27,6 +27,52 @@ func TestNormalizeRequest(t *testing.T) {
 				Filters: &filter.Flags{Excludes: []string{"*/test/*"}},
 			},
 		},
+		{
+			name: "destroy mode",
+			req: Request{
+				Mode: ExecutionModeDestroy,
+			},
+		},
+		{
+			name: "destroy mode rejects changed-only",
+			req: Request{
+				Mode:        ExecutionModeDestroy,
+				ChangedOnly: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "drift mode rejects changed-only",
+			req: Request{
+				Mode:        ExecutionModeDrift,
+				ChangedOnly: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "run mode with exported IR",
+			req: Request{
+				Mode:   ExecutionModeRun,
+				IRFile: "pipeline-ir.json",
+			},
+		},
+		{
+			name: "plan mode rejects exported IR",
+			req: Request{
+				Mode:   ExecutionModePlan,
+				IRFile: "pipeline-ir.json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "exported IR rejects target selection",
+			req: Request{
+				Mode:    ExecutionModeRun,
+				IRFile:  "pipeline-ir.json",
+				Filters: &filter.Flags{SegmentArgs: []string{"environment=stage"}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid mode",
 			req: Request{
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/pipeline"
 	"github.com/edelwud/terraci/pkg/planresults"
 	policyengine "github.com/edelwud/terraci/plugins/policy/internal"
@@ -138,10 +139,12 @@ func checkPlan(ctx context.Context, runtime CheckRuntime, evaluator Evaluator, p
 	}
 
 	namespaces := effective.NamespacesOrDefault()
-	planJSONPath := filepath.Join(runtime.WorkDir, filepath.FromSlash(modulePath), pipeline.PlanJSONFilename)
+	_, workspace := discovery.SplitID(plan.ModuleID())
+	planJSONName := pipeline.PlanFilename(pipeline.PlanJSONFilename, workspace)
+	planJSONPath := filepath.Join(runtime.WorkDir, filepath.FromSlash(modulePath), planJSONName)
 	envelope, err := policyinput.Build(policyinput.Request{
 		PlanJSONPath:    planJSONPath,
-		PlanDisplayPath: filepath.ToSlash(filepath.Join(modulePath, pipeline.PlanJSONFilename)),
+		PlanDisplayPath: filepath.ToSlash(filepath.Join(modulePath, planJSONName)),
 		ModulePath:      modulePath,
 		Components:      plan.Components(),
 		Namespaces:      namespaces,
@@ -6,7 +6,14 @@ import (
 	"github.com/edelwud/terraci/pkg/ci"
 )
 
-func composeSummaryBody(runtime Runtime, snapshot SummarySnapshot, provider Provider, labels []string) (string, error) {
+// summaryExtras are optional Markdown blocks rendered after the report
+// sections.
+type summaryExtras struct {
+	diagram      string
+	criticalPath string
+}
+
+func composeSummaryBody(runtime Runtime, snapshot SummarySnapshot, provider Provider, labels []string, extras summaryExtras) (string, error) {
 	collection := snapshot.PlanResults()
 	body, err := ComposeCommentWithOptions(
 		snapshot,
@@ -15,7 +22,11 @@ func composeSummaryBody(runtime Runtime, snapshot SummarySnapshot, provider Prov
 			PipelineID:  provider.PipelineID(),
 			GeneratedAt: collection.GeneratedAt(),
 		},
-		CommentOptions{IncludeDetails: runtime.Config.IncludeDetailsEnabled()},
+		CommentOptions{
+			IncludeDetails:  runtime.Config.IncludeDetailsEnabled(),
+			DependencyGraph: extras.diagram,
+			CriticalPath:    extras.criticalPath,
+		},
 	)
 	if err != nil {
 		return "", fmt.Errorf("compose summary comment: %w", err)
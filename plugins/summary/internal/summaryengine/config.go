@@ -2,10 +2,12 @@ package summaryengine
 
 // Config holds summary plugin settings.
 type Config struct {
-	Enabled        *bool    `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	OnChangesOnly  bool     `yaml:"on_changes_only,omitempty" json:"on_changes_only,omitempty"`
-	IncludeDetails *bool    `yaml:"include_details,omitempty" json:"include_details,omitempty"`
-	Labels         []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Enabled             *bool    `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	OnChangesOnly       bool     `yaml:"on_changes_only,omitempty" json:"on_changes_only,omitempty"`
+	IncludeDetails      *bool    `yaml:"include_details,omitempty" json:"include_details,omitempty"`
+	IncludeGraph        bool     `yaml:"include_graph,omitempty" json:"include_graph,omitempty"`
+	IncludeCriticalPath bool     `yaml:"include_critical_path,omitempty" json:"include_critical_path,omitempty"`
+	Labels              []string `yaml:"labels,omitempty" json:"labels,omitempty"`
 }
 
 // Normalized returns a value copy with stable defaults and owned slices.
@@ -0,0 +1,99 @@
+package summaryengine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/pipeline"
+	"github.com/edelwud/terraci/pkg/timings"
+)
+
+// TimingsLoader opens the job timing history.
+type TimingsLoader func(ctx context.Context) (*timings.History, error)
+
+// loadTimingHistory returns the timing history, or nil when it is disabled
+// or unavailable; timings never fail the summary.
+func loadTimingHistory(ctx context.Context, runtime Runtime) *timings.History {
+	if runtime.TimingsLoader == nil {
+		return nil
+	}
+	history, err := runtime.TimingsLoader(ctx)
+	if err != nil {
+		log.WithError(err).Warn("job timing history unavailable")
+		return nil
+	}
+	return history
+}
+
+// recordPlanTimings adds the plan durations carried by the plan results to
+// the timing history.
+func recordPlanTimings(ctx context.Context, history *timings.History, collection *ci.PlanResultCollection) {
+	if history == nil {
+		return
+	}
+	if err := history.Record(ctx, timings.SamplesFromPlanResults(collection)); err != nil {
+		log.WithError(err).Warn("plan timings not recorded")
+	}
+}
+
+// affectedCriticalPath renders the critical path of the planned modules,
+// weighted by their historical plan durations, as a collapsed Markdown
+// table. It returns "" when the section is disabled or no planned module
+// has a recorded duration.
+func affectedCriticalPath(ctx context.Context, runtime Runtime, depGraph *graph.DependencyGraph, history *timings.History, collection *ci.PlanResultCollection) string {
+	if !runtime.Config.IncludeCriticalPath || history == nil || depGraph == nil || collection == nil {
+		return ""
+	}
+
+	var ids []string
+	for _, result := range collection.Results() {
+		if depGraph.GetNode(result.ModuleID()) != nil {
+			ids = append(ids, result.ModuleID())
+		}
+	}
+	durations, err := history.Estimates(ctx, ids, pipeline.JobKindPlan)
+	if err != nil {
+		log.WithError(err).Warn("failed to load job timings, omitting critical path")
+		return ""
+	}
+	if len(durations) == 0 {
+		return ""
+	}
+	cp, err := depGraph.Subgraph(ids).CriticalPath(durations)
+	if err != nil {
+		log.WithError(err).Warn("failed to compute critical path")
+		return ""
+	}
+	return renderCriticalPath(cp)
+}
+
+func renderCriticalPath(cp *graph.CriticalPath) string {
+	timingsByID := make(map[string]graph.ModuleTiming, len(cp.Timings))
+	for _, timing := range cp.Timings {
+		timingsByID[timing.ID] = timing
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<details>\n<summary>Critical path: ~%s</summary>\n\n", formatEstimate(cp.Total))
+	sb.WriteString("| Module | Plan time | Finishes at | Slack cost |\n|--------|-----------|-------------|------------|\n")
+	for _, id := range cp.Modules {
+		timing := timingsByID[id]
+		fmt.Fprintf(&sb, "| `%s` | %s | %s | %s |\n", id,
+			formatEstimate(timing.Duration), formatEstimate(timing.Finish), formatEstimate(timing.SlackCost))
+	}
+	if len(cp.Unmeasured) > 0 {
+		fmt.Fprintf(&sb, "\n%d planned modules have no recorded duration and count as zero.\n", len(cp.Unmeasured))
+	}
+	sb.WriteString("\n</details>\n")
+	return sb.String()
+}
+
+func formatEstimate(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
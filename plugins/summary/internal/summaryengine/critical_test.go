@@ -0,0 +1,62 @@
+package summaryengine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/timings"
+)
+
+func TestAffectedCriticalPath(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	vpc := discovery.TestModule("svc", "prod", "us", "vpc")
+	eks := discovery.TestModule("svc", "prod", "us", "eks")
+	dns := discovery.TestModule("svc", "prod", "us", "dns")
+	depGraph := graph.NewDependencyGraph()
+	for _, m := range []*discovery.Module{vpc, eks, dns} {
+		depGraph.AddNode(m)
+	}
+	depGraph.AddEdge(eks.ID(), vpc.ID())
+	depGraph.AddEdge(dns.ID(), vpc.ID())
+
+	collection := testSummaryPlanCollection(t, ci.PlanResultCollectionOptions{
+		GeneratedAt: time.Date(2026, 5, 18, 12, 0, 0, 0, time.UTC),
+		Results: []ci.PlanResult{
+			testPlanResult(t, ci.PlanResultOptions{ModuleID: vpc.ID(), Duration: 2 * time.Minute}),
+			testPlanResult(t, ci.PlanResultOptions{ModuleID: eks.ID(), Duration: 3 * time.Minute}),
+		},
+	})
+	history := timings.NewHistory(blobtest.NewMemoryStore(""), 5)
+	recordPlanTimings(ctx, history, collection)
+
+	runtime := Runtime{Config: Config{IncludeCriticalPath: true}}
+	section := affectedCriticalPath(ctx, runtime, depGraph, history, collection)
+	for _, want := range []string{
+		"<summary>Critical path: ~5m0s</summary>",
+		"| `svc/prod/us/vpc` | 2m0s | 2m0s | 2m0s |",
+		"| `svc/prod/us/eks` | 3m0s | 5m0s | 3m0s |",
+	} {
+		if !strings.Contains(section, want) {
+			t.Errorf("critical path missing %q:\n%s", want, section)
+		}
+	}
+	if strings.Contains(section, dns.ID()) || strings.Contains(section, "no recorded duration") {
+		t.Errorf("critical path includes a module without a plan:\n%s", section)
+	}
+
+	if got := affectedCriticalPath(ctx, Runtime{}, depGraph, history, collection); got != "" {
+		t.Errorf("critical path rendered while include_critical_path is off:\n%s", got)
+	}
+	empty := timings.NewHistory(blobtest.NewMemoryStore(""), 5)
+	if got := affectedCriticalPath(ctx, runtime, depGraph, empty, collection); got != "" {
+		t.Errorf("critical path rendered without recorded durations:\n%s", got)
+	}
+}
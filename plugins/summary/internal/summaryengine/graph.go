@@ -0,0 +1,65 @@
+package summaryengine
+
+import (
+	"context"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/timings"
+)
+
+// maxGraphModules caps the dependency graph diagram; larger diagrams are
+// unreadable in a comment and exceed Mermaid's rendering limits.
+const maxGraphModules = 50
+
+// GraphLoader builds the project dependency graph for the summary diagram
+// and critical path.
+type GraphLoader func(ctx context.Context) (*graph.DependencyGraph, error)
+
+// loadGraph builds the dependency graph shared by the diagram and the
+// critical path. It returns nil when neither section needs it or the graph
+// cannot be built; the comment is posted without them.
+func loadGraph(ctx context.Context, runtime Runtime, history *timings.History) *graph.DependencyGraph {
+	needed := runtime.Config.IncludeGraph || (runtime.Config.IncludeCriticalPath && history != nil)
+	if !needed || runtime.GraphLoader == nil {
+		return nil
+	}
+	depGraph, err := runtime.GraphLoader(ctx)
+	if err != nil {
+		log.WithError(err).Warn("failed to build dependency graph, omitting diagram and critical path")
+		return nil
+	}
+	return depGraph
+}
+
+// affectedGraphDiagram renders the dependency subgraph of the planned
+// modules as a Mermaid flowchart. It returns "" when the diagram is disabled
+// or no graph is available.
+func affectedGraphDiagram(runtime Runtime, depGraph *graph.DependencyGraph, collection *ci.PlanResultCollection) string {
+	if !runtime.Config.IncludeGraph || depGraph == nil || collection == nil {
+		return ""
+	}
+
+	seen := make(map[string]bool, collection.Len())
+	for _, result := range collection.Results() {
+		if depGraph.GetNode(result.ModuleID()) != nil {
+			seen[result.ModuleID()] = true
+		}
+	}
+	if len(seen) == 0 {
+		return ""
+	}
+	if len(seen) > maxGraphModules {
+		log.WithField("modules", len(seen)).WithField("max", maxGraphModules).
+			Info("too many planned modules, omitting dependency graph diagram")
+		return ""
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	return depGraph.Subgraph(ids).ToMermaid()
+}
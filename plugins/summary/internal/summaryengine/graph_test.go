@@ -0,0 +1,102 @@
+package summaryengine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edelwud/terraci/pkg/cache/blobcache/blobtest"
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/discovery"
+	"github.com/edelwud/terraci/pkg/graph"
+	"github.com/edelwud/terraci/pkg/timings"
+)
+
+func TestAffectedGraphDiagram(t *testing.T) {
+	t.Parallel()
+
+	vpc := discovery.TestModule("svc", "prod", "us", "vpc")
+	eks := discovery.TestModule("svc", "prod", "us", "eks")
+	dns := discovery.TestModule("svc", "prod", "us", "dns")
+	depGraph := graph.NewDependencyGraph()
+	for _, m := range []*discovery.Module{vpc, eks, dns} {
+		depGraph.AddNode(m)
+	}
+	depGraph.AddEdge(eks.ID(), vpc.ID())
+	depGraph.AddEdge(dns.ID(), vpc.ID())
+
+	collection := testSummaryPlanCollection(t, ci.PlanResultCollectionOptions{
+		GeneratedAt: time.Date(2026, 5, 18, 12, 0, 0, 0, time.UTC),
+		Results: []ci.PlanResult{
+			testPlanResult(t, ci.PlanResultOptions{ModuleID: vpc.ID()}),
+			testPlanResult(t, ci.PlanResultOptions{ModuleID: eks.ID()}),
+			testPlanResult(t, ci.PlanResultOptions{ModuleID: "svc/prod/us/removed"}),
+		},
+	})
+	diagram := affectedGraphDiagram(Runtime{Config: Config{IncludeGraph: true}}, depGraph, collection)
+	for _, want := range []string{"flowchart LR", `"us/vpc"`, `"us/eks"`, "-->"} {
+		if !strings.Contains(diagram, want) {
+			t.Errorf("diagram missing %q:\n%s", want, diagram)
+		}
+	}
+	if strings.Contains(diagram, "us/dns") {
+		t.Errorf("diagram includes a module without a plan:\n%s", diagram)
+	}
+
+	if got := affectedGraphDiagram(Runtime{}, depGraph, collection); got != "" {
+		t.Errorf("diagram rendered while include_graph is off:\n%s", got)
+	}
+	if got := affectedGraphDiagram(Runtime{Config: Config{IncludeGraph: true}}, nil, collection); got != "" {
+		t.Errorf("diagram rendered without a graph:\n%s", got)
+	}
+}
+
+func TestLoadGraph(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	depGraph := graph.NewDependencyGraph()
+	calls := 0
+	loader := func(context.Context) (*graph.DependencyGraph, error) {
+		calls++
+		return depGraph, nil
+	}
+	history := timings.NewHistory(blobtest.NewMemoryStore(""), 5)
+
+	if got := loadGraph(ctx, Runtime{GraphLoader: loader}, history); got != nil || calls != 0 {
+		t.Fatalf("loadGraph() = %v after %d calls, want no load when both sections are off", got, calls)
+	}
+	if got := loadGraph(ctx, Runtime{Config: Config{IncludeCriticalPath: true}, GraphLoader: loader}, nil); got != nil || calls != 0 {
+		t.Fatalf("loadGraph() = %v after %d calls, want no load for a critical path without history", got, calls)
+	}
+	runtime := Runtime{Config: Config{IncludeGraph: true, IncludeCriticalPath: true}, GraphLoader: loader}
+	if got := loadGraph(ctx, runtime, history); got != depGraph || calls != 1 {
+		t.Fatalf("loadGraph() = %v after %d calls, want the loaded graph", got, calls)
+	}
+	runtime.GraphLoader = func(context.Context) (*graph.DependencyGraph, error) { return nil, errors.New("boom") }
+	if got := loadGraph(ctx, runtime, history); got != nil {
+		t.Fatalf("loadGraph() = %v, want nil when the graph cannot be built", got)
+	}
+}
+
+func TestComposeCommentWithOptions_DependencyGraph(t *testing.T) {
+	t.Parallel()
+
+	generatedAt := time.Date(2026, 5, 18, 12, 0, 0, 0, time.UTC)
+	body, err := ComposeCommentWithOptions(
+		mustSummarySnapshot(t, []ci.PlanResult{testPlanResult(t, ci.PlanResultOptions{})}, nil, generatedAt),
+		CommentMetadata{GeneratedAt: generatedAt},
+		CommentOptions{DependencyGraph: "flowchart LR\n  m0[\"us/vpc\"]\n"},
+	)
+	if err != nil {
+		t.Fatalf("ComposeCommentWithOptions() error = %v", err)
+	}
+	if !strings.Contains(body, "<summary>Dependency graph</summary>\n\n```mermaid\nflowchart LR\n  m0[\"us/vpc\"]\n```") {
+		t.Errorf("comment missing mermaid block:\n%s", body)
+	}
+	if strings.Index(body, "```mermaid") > strings.Index(body, "Generated by") {
+		t.Errorf("diagram rendered after the footer:\n%s", body)
+	}
+}
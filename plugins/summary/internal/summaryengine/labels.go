@@ -11,6 +11,7 @@ import (
 	tfplan "github.com/edelwud/terraci/internal/terraform/plan"
 	"github.com/edelwud/terraci/pkg/ci"
 	"github.com/edelwud/terraci/pkg/diagnostic"
+	"github.com/edelwud/terraci/pkg/discovery"
 	"github.com/edelwud/terraci/pkg/pipeline"
 	"github.com/edelwud/terraci/pkg/planresults"
 )
@@ -280,7 +281,7 @@ func (c *resourcePlanCache) changedResources(result ci.PlanResult) ([]tfplan.Res
 	if err, ok := c.errs[key]; ok {
 		return nil, err
 	}
-	parsed, err := c.parser.ParsePlan(planJSONPath(c.workDir, result.ModulePath()))
+	parsed, err := c.parser.ParsePlan(planJSONPath(c.workDir, result))
 	if err != nil {
 		c.errs[key] = err
 		return nil, err
@@ -290,8 +291,11 @@ func (c *resourcePlanCache) changedResources(result ci.PlanResult) ([]tfplan.Res
 	return resources, nil
 }
 
-func planJSONPath(workDir, modulePath string) string {
-	return filepath.Join(workDir, filepath.FromSlash(modulePath), pipeline.PlanJSONFilename)
+// planJSONPath locates the plan JSON of a result; workspace modules write
+// plan.<workspace>.json next to the default workspace's plan.json.
+func planJSONPath(workDir string, result ci.PlanResult) string {
+	_, workspace := discovery.SplitID(result.ModuleID())
+	return filepath.Join(workDir, filepath.FromSlash(result.ModulePath()), pipeline.PlanFilename(pipeline.PlanJSONFilename, workspace))
 }
 
 func copyStringMap(in map[string]string) map[string]string {
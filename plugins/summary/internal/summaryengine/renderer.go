@@ -30,6 +30,12 @@ type CommentMetadata struct {
 // CommentOptions controls generic summary comment rendering.
 type CommentOptions struct {
 	IncludeDetails bool
+	// DependencyGraph is a Mermaid diagram rendered in a collapsed section
+	// after the report sections; empty omits it.
+	DependencyGraph string
+	// CriticalPath is a Markdown critical path table rendered in a
+	// collapsed section after the dependency graph; empty omits it.
+	CriticalPath string
 }
 
 func encodeRenderSection(title, sectionSummary string, status ci.ReportStatus, blocks ...ci.RenderBlock) (ci.ReportSection, error) {
@@ -52,7 +58,7 @@ func ComposeComment(snapshot SummarySnapshot, metadata CommentMetadata) (string,
 
 // ComposeCommentWithOptions builds the final markdown comment with explicit rendering options.
 func ComposeCommentWithOptions(snapshot SummarySnapshot, metadata CommentMetadata, opts CommentOptions) (string, error) {
-	sections, err := BuildSummarySectionsWithOptions(snapshot, SummarySectionOptions(opts))
+	sections, err := BuildSummarySectionsWithOptions(snapshot, SummarySectionOptions{IncludeDetails: opts.IncludeDetails})
 	if err != nil {
 		return "", err
 	}
@@ -74,6 +80,16 @@ func ComposeCommentWithOptions(snapshot SummarySnapshot, metadata CommentMetadat
 		sb.WriteString("\n\n")
 	}
 
+	if opts.DependencyGraph != "" {
+		sb.WriteString("<details>\n<summary>Dependency graph</summary>\n\n```mermaid\n")
+		sb.WriteString(opts.DependencyGraph)
+		sb.WriteString("```\n\n</details>\n\n")
+	}
+	if opts.CriticalPath != "" {
+		sb.WriteString(opts.CriticalPath)
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("---\n")
 	fmt.Fprintf(&sb, "Generated by [terraci](https://github.com/edelwud/terraci) at %s", metadata.GeneratedAt.Format("2006-01-02 15:04:05 UTC"))
 	if metadata.PipelineID != "" {
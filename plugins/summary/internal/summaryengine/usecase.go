@@ -23,6 +23,12 @@ type Runtime struct {
 	PlanScanner      PlanScanner
 	ReportStore      ci.ReportStore
 	LabelParser      PlanParser
+	// GraphLoader builds the dependency graph when Config.IncludeGraph or
+	// Config.IncludeCriticalPath is set. Run calls it at most once.
+	GraphLoader GraphLoader
+	// TimingsLoader opens the job timing history; it returns nil when the
+	// history is disabled.
+	TimingsLoader TimingsLoader
 }
 
 // Request is reserved for command-time options. The summary command currently
@@ -59,6 +65,8 @@ func Run(ctx context.Context, runtime Runtime, _ Request) (*Result, error) {
 	}
 
 	log.WithField("count", collection.Len()).Info("found plan results")
+	history := loadTimingHistory(ctx, runtime)
+	recordPlanTimings(ctx, history, collection)
 
 	selection, err := loadReportSelection(ctx, runtime, collection)
 	if err != nil {
@@ -90,7 +98,13 @@ func Run(ctx context.Context, runtime Runtime, _ Request) (*Result, error) {
 	result.LabelDiagnostics = labelResult.Diagnostics
 	diagnosticlog.Log(labelResult.Diagnostics)
 
-	body, err := composeSummaryBody(runtime, result.Snapshot, provider, result.Labels)
+	depGraph := loadGraph(ctx, runtime, history)
+	diagram := affectedGraphDiagram(runtime, depGraph, result.Snapshot.PlanResults())
+	criticalPath := affectedCriticalPath(ctx, runtime, depGraph, history, result.Snapshot.PlanResults())
+	body, err := composeSummaryBody(runtime, result.Snapshot, provider, result.Labels, summaryExtras{
+		diagram:      diagram,
+		criticalPath: criticalPath,
+	})
 	if err != nil {
 		return result, err
 	}
@@ -1,7 +1,16 @@
 package summary
 
 import (
+	"context"
+
+	log "github.com/caarlos0/log"
+
+	"github.com/edelwud/terraci/pkg/graph"
 	"github.com/edelwud/terraci/pkg/plugin"
+	"github.com/edelwud/terraci/pkg/timings"
+	"github.com/edelwud/terraci/pkg/workflow"
+	"github.com/edelwud/terraci/plugins/internal/parsecache"
+	"github.com/edelwud/terraci/plugins/internal/timinghistory"
 	summaryengine "github.com/edelwud/terraci/plugins/summary/internal/summaryengine"
 )
 
@@ -18,6 +27,31 @@ func newRuntime(appCtx *plugin.AppContext, cfg *summaryengine.Config) *summaryRu
 		Segments:         segments,
 		ProviderResolver: resolveSummaryProvider(appCtx),
 		ReportStore:      appCtx.Reports(),
+		GraphLoader:      loadSummaryGraph(appCtx),
+		TimingsLoader: func(ctx context.Context) (*timings.History, error) {
+			return timinghistory.Open(ctx, appCtx)
+		},
+	}
+}
+
+// loadSummaryGraph scans the project through the configured parse cache, as
+// the core commands do; the cache only saves time, so a backend failure falls
+// back to parsing every module.
+func loadSummaryGraph(appCtx *plugin.AppContext) summaryengine.GraphLoader {
+	return func(ctx context.Context) (*graph.DependencyGraph, error) {
+		cache, err := parsecache.Open(ctx, appCtx)
+		if err != nil {
+			log.WithError(err).Warn("parse cache disabled")
+		}
+		project, err := workflow.PlanProject(ctx, workflow.ProjectRequest{
+			WorkDir:    appCtx.WorkDir(),
+			Config:     appCtx.Config(),
+			ParseCache: cache,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return project.Workflow.Graph, nil
 	}
 }
 
@@ -1515,7 +1515,7 @@ func TestArchitecture_ProviderOutputValueBoundaries(t *testing.T) {
 	root := repoRoot(t)
 	var violations []string
 
-	for _, rel := range goFiles(t, root, "plugins/gitlab", "plugins/github") {
+	for _, rel := range goFiles(t, root, "plugins/gitlab", "plugins/github", "plugins/azuredevops", "plugins/bitbucket", "plugins/buildkite", "plugins/jenkins") {
 		if !isProductionFile(rel) {
 			continue
 		}
@@ -1742,7 +1742,11 @@ func isPolicyEnvelopeLiteral(expr ast.Expr, aliases map[string]bool) bool {
 
 func isProviderDomainPackageFile(rel string) bool {
 	return strings.HasPrefix(rel, "plugins/gitlab/internal/domain/") ||
-		strings.HasPrefix(rel, "plugins/github/internal/domain/")
+		strings.HasPrefix(rel, "plugins/github/internal/domain/") ||
+		strings.HasPrefix(rel, "plugins/azuredevops/internal/domain/") ||
+		strings.HasPrefix(rel, "plugins/bitbucket/internal/domain/") ||
+		strings.HasPrefix(rel, "plugins/buildkite/internal/domain/") ||
+		strings.HasPrefix(rel, "plugins/jenkins/internal/domain/")
 }
 
 func isProviderOutputLiteral(expr ast.Expr, aliasSets ...map[string]bool) bool {
@@ -2095,7 +2099,12 @@ func isCoreConfigLiteralAllowed(rel string) bool {
 }
 
 func isCIProviderPlugin(rel string) bool {
-	return strings.HasPrefix(rel, "plugins/gitlab/") || strings.HasPrefix(rel, "plugins/github/")
+	return strings.HasPrefix(rel, "plugins/gitlab/") ||
+		strings.HasPrefix(rel, "plugins/github/") ||
+		strings.HasPrefix(rel, "plugins/azuredevops/") ||
+		strings.HasPrefix(rel, "plugins/bitbucket/") ||
+		strings.HasPrefix(rel, "plugins/buildkite/") ||
+		strings.HasPrefix(rel, "plugins/jenkins/")
 }
 
 func isLocalExecRunnerFile(rel string) bool {
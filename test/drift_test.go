@@ -0,0 +1,115 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edelwud/terraci/pkg/ci"
+	"github.com/edelwud/terraci/pkg/planresults"
+)
+
+func TestGenerate_DriftModeEmitsPlansAndCollector(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+
+	output, err := captureTerraCi(t, dir, "generate", "--mode", "drift")
+	if err != nil {
+		t.Fatalf("generate --mode drift failed: %v", err)
+	}
+	assertContains(t, output, "plan-platform-prod-eu-central-1-vpc")
+	assertContains(t, output, "-detailed-exitcode")
+	assertContains(t, output, "terraci drift")
+	assertNotContains(t, output, "apply-platform-prod-eu-central-1-vpc")
+
+	pipeline := parseYAML(t, output)
+	collector, ok := pipeline["terraci-drift"].(map[string]any)
+	if !ok {
+		t.Fatal("missing terraci-drift collector job")
+	}
+	if needs, _ := collector["needs"].([]any); len(needs) != 4 {
+		t.Errorf("collector needs = %v, want all 4 plan jobs", needs)
+	}
+	if collector["when"] != "always" {
+		t.Errorf("collector when = %v, want always", collector["when"])
+	}
+	assertContains(t, output, "terraci drift --discover")
+	assertNotContains(t, output, "--module")
+	for name, job := range pipeline {
+		if !strings.HasPrefix(name, "plan-") {
+			continue
+		}
+		if needs, _ := job.(map[string]any)["needs"].([]any); len(needs) != 0 {
+			t.Errorf("%s needs = %v, want no plan-to-plan dependencies in drift mode", name, needs)
+		}
+	}
+
+	filtered, err := captureTerraCi(t, dir, "generate", "--mode", "drift", "--filter", "environment=prod")
+	if err != nil {
+		t.Fatalf("generate --mode drift --filter failed: %v", err)
+	}
+	assertContains(t, filtered, "terraci drift --discover --filter environment=prod")
+
+	if _, err := captureTerraCi(t, dir, "generate", "--mode", "drift", "--changed-only"); err == nil {
+		t.Error("generate --mode drift --changed-only should fail")
+	}
+	if _, err := captureTerraCi(t, dir, "generate", "--mode", "bogus"); err == nil {
+		t.Error("generate --mode bogus should fail")
+	}
+}
+
+func TestDrift_PublishesReport(t *testing.T) {
+	dir := copyFixtureToTemp(t, "with-reports")
+
+	if err := runTerraCi(t, dir, "drift"); err != nil {
+		t.Fatalf("drift failed: %v", err)
+	}
+
+	report, err := ci.LoadReport(filepath.Join(dir, ".terraci", ci.ReportFilename(planresults.DriftReportProducer)))
+	if err != nil {
+		t.Fatalf("load drift report: %v", err)
+	}
+	if report.Status() != ci.ReportStatusWarn {
+		t.Errorf("drift report status = %q, want warn", report.Status())
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".terraci", ci.ResultFilename(planresults.DriftReportProducer)))
+	if err != nil {
+		t.Fatalf("read drift results: %v", err)
+	}
+	var summary planresults.DriftSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("decode drift results: %v", err)
+	}
+	if summary.Drifted != 1 || summary.Modules[0].ModulePath != "platform/prod/eu-central-1/vpc" {
+		t.Errorf("drift results = %+v, want drifted vpc", summary)
+	}
+
+	if err := runTerraCi(t, dir, "drift", "--fail-on-drift"); err == nil {
+		t.Error("drift --fail-on-drift should fail when a module drifted")
+	}
+}
+
+func TestDrift_ReportsExpectedModulesWithoutPlanAsErrored(t *testing.T) {
+	dir := copyFixtureToTemp(t, "with-reports")
+
+	err := runTerraCi(t, dir, "drift",
+		"--module", "platform/prod/eu-central-1/vpc",
+		"--module", "platform/prod/eu-central-1/eks")
+	if err == nil {
+		t.Fatal("drift should fail when an expected module has no plan.json")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".terraci", ci.ResultFilename(planresults.DriftReportProducer)))
+	if err != nil {
+		t.Fatalf("read drift results: %v", err)
+	}
+	var summary planresults.DriftSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("decode drift results: %v", err)
+	}
+	if summary.Errored != 1 || summary.Drifted != 1 {
+		t.Errorf("drift results = %+v, want one drifted and one errored module", summary)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -273,6 +274,44 @@ func TestGenerate_PlanOnly_NoApplyJobs(t *testing.T) {
 	assertNotContains(t, output, "apply-")
 }
 
+func TestGenerate_DestroyReversesDependencies(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+
+	output, err := captureTerraCi(t, dir, "generate", "--destroy")
+	if err != nil {
+		t.Fatalf("generate --destroy failed: %v", err)
+	}
+	assertContains(t, output, "plan -destroy -out=plan.tfplan")
+
+	pipeline := parseYAML(t, output)
+	vpcPlan, ok := pipeline["plan-platform-prod-eu-central-1-vpc"].(map[string]any)
+	if !ok {
+		t.Fatal("missing plan-platform-prod-eu-central-1-vpc job")
+	}
+	needs, _ := vpcPlan["needs"].([]any)
+	foundEksDep := false
+	for _, need := range needs {
+		if needMap, ok := need.(map[string]any); ok && needMap["job"] == "apply-platform-prod-eu-central-1-eks" {
+			foundEksDep = true
+		}
+	}
+	if !foundEksDep {
+		t.Errorf("vpc destroy plan needs = %v, want eks destroyed first", needs)
+	}
+
+	eksApply, ok := pipeline["apply-platform-prod-eu-central-1-eks"].(map[string]any)
+	if !ok {
+		t.Fatal("missing apply-platform-prod-eu-central-1-eks job")
+	}
+	if eksApply["when"] != "manual" {
+		t.Errorf("eks destroy apply when = %v, want manual", eksApply["when"])
+	}
+
+	if _, err := captureTerraCi(t, dir, "generate", "--destroy", "--plan-only"); err == nil {
+		t.Error("generate --destroy --plan-only should fail")
+	}
+}
+
 func TestGenerate_StructuralNeedsCorrectness(t *testing.T) {
 	dir := fixtureDir(t, "basic")
 
@@ -383,3 +422,99 @@ func TestGenerate_SchemaValidJSON(t *testing.T) {
 		t.Error("schema missing 'properties' key")
 	}
 }
+
+func TestGenerate_Check(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+	outFile := filepath.Join(t.TempDir(), "pipeline.yaml")
+
+	if err := runTerraCi(t, dir, "generate", "-o", outFile); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if err := runTerraCi(t, dir, "generate", "--check", "-o", outFile); err != nil {
+		t.Fatalf("generate --check on fresh output failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	stale := strings.Replace(string(data), "apply-platform-prod-eu-central-1-vpc", "apply-platform-prod-eu-central-1-vpc-old", 1)
+	if err := os.WriteFile(outFile, []byte(stale), 0o644); err != nil {
+		t.Fatalf("failed to rewrite output: %v", err)
+	}
+
+	output, err := captureTerraCi(t, dir, "generate", "--check", "-o", outFile)
+	if err == nil {
+		t.Fatal("expected --check to fail for stale output")
+	}
+	assertContains(t, err.Error(), "out of date")
+	assertContains(t, output, "@@ ")
+	assertContains(t, output, "job: apply-platform-prod-eu-central-1-vpc-old")
+	assertContains(t, output, "+          job: apply-platform-prod-eu-central-1-vpc\n")
+}
+
+func TestGenerate_CheckRequiresOutput(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+
+	err := runTerraCi(t, dir, "generate", "--check")
+	if err == nil {
+		t.Fatal("expected --check without --output to fail")
+	}
+	assertContains(t, err.Error(), "--output")
+}
+
+func TestGenerate_IRJSON(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+	outFile := filepath.Join(t.TempDir(), "pipeline-ir.json")
+
+	if err := runTerraCi(t, dir, "generate", "--format", "ir-json", "-o", outFile); err != nil {
+		t.Fatalf("generate --format ir-json failed: %v", err)
+	}
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	var doc struct {
+		Version int `json:"version"`
+		Jobs    []struct {
+			Name         string   `json:"name"`
+			Kind         string   `json:"kind"`
+			Dependencies []string `json:"dependencies"`
+			Operation    struct {
+				Type string `json:"type"`
+			} `json:"operation"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, data)
+	}
+	if doc.Version != 1 {
+		t.Fatalf("version = %d, want 1", doc.Version)
+	}
+	jobs := make(map[string][]string, len(doc.Jobs))
+	for _, job := range doc.Jobs {
+		jobs[job.Name] = job.Dependencies
+	}
+	eksPlan, ok := jobs["plan-platform-prod-eu-central-1-eks"]
+	if !ok {
+		t.Fatalf("IR missing eks plan job: %v", jobs)
+	}
+	if !slices.Contains(eksPlan, "apply-platform-prod-eu-central-1-vpc") {
+		t.Fatalf("eks plan dependencies = %v, want vpc apply", eksPlan)
+	}
+
+	if err := runTerraCi(t, dir, "generate", "--format", "ir-json", "--check", "-o", outFile); err != nil {
+		t.Fatalf("generate --format ir-json --check failed: %v", err)
+	}
+}
+
+func TestGenerate_UnsupportedFormat(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+
+	err := runTerraCi(t, dir, "generate", "--format", "xml")
+	if err == nil {
+		t.Fatal("expected unsupported --format to fail")
+	}
+	assertContains(t, err.Error(), "ir-json")
+}
@@ -205,3 +205,16 @@ func TestGraph_DOTEdgeCorrectness(t *testing.T) {
 		t.Error("DOT output should contain an edge from eks to vpc")
 	}
 }
+
+func TestGraph_TFJSONModules(t *testing.T) {
+	dir := fixtureDir(t, "tf-json")
+
+	output, err := captureTerraCi(t, dir, "graph", "--format", "list")
+	if err != nil {
+		t.Fatalf("graph failed: %v", err)
+	}
+
+	// eks is JSON-only, app mixes a native and a JSON remote_state.
+	assertContains(t, output, "eu-central-1/eks → eu-central-1/vpc")
+	assertContains(t, output, "eu-central-1/app → eu-central-1/eks, eu-central-1/vpc")
+}
@@ -19,7 +19,7 @@ func TestHelp_RootListsCoreCommands(t *testing.T) {
 
 	// Each core command must appear in root help output.
 	for _, name := range []string{
-		"generate", "graph", "validate", "init", "version", "schema",
+		"generate", "graph", "validate", "drift", "init", "version", "schema",
 	} {
 		if !strings.Contains(output, name) {
 			t.Errorf("root --help missing core command %q\noutput:\n%s", name, output)
@@ -12,12 +12,16 @@ import (
 	"github.com/edelwud/terraci/cmd/terraci/cmd"
 
 	// Register all built-in plugins via init()
+	_ "github.com/edelwud/terraci/plugins/azuredevops"
+	_ "github.com/edelwud/terraci/plugins/bitbucket"
+	_ "github.com/edelwud/terraci/plugins/buildkite"
 	_ "github.com/edelwud/terraci/plugins/cost"
 	_ "github.com/edelwud/terraci/plugins/diskblob"
 	_ "github.com/edelwud/terraci/plugins/git"
 	_ "github.com/edelwud/terraci/plugins/github"
 	_ "github.com/edelwud/terraci/plugins/gitlab"
 	_ "github.com/edelwud/terraci/plugins/inmemcache"
+	_ "github.com/edelwud/terraci/plugins/jenkins"
 	_ "github.com/edelwud/terraci/plugins/localexec"
 	_ "github.com/edelwud/terraci/plugins/policy"
 	_ "github.com/edelwud/terraci/plugins/summary"
@@ -30,7 +34,7 @@ import (
 // to win provider resolution over the gitlab plugin configured in fixtures.
 func clearCIEnv(t *testing.T) {
 	t.Helper()
-	for _, key := range []string{"GITHUB_ACTIONS", "GITLAB_CI", "CI_SERVER_URL"} {
+	for _, key := range []string{"GITHUB_ACTIONS", "GITLAB_CI", "CI_SERVER_URL", "TF_BUILD", "BITBUCKET_BUILD_NUMBER", "BUILDKITE", "JENKINS_URL"} {
 		t.Setenv(key, "")
 	}
 }
@@ -14,8 +14,8 @@ import (
 func TestPluginRegistration(t *testing.T) {
 	plugins := registry.New()
 	inventory := plugins.Inventory().Plugins()
-	if len(inventory) != 10 {
-		t.Fatalf("expected 10 plugins, got %d", len(inventory))
+	if len(inventory) != 14 {
+		t.Fatalf("expected 14 plugins, got %d", len(inventory))
 	}
 
 	names := make(map[string]bool)
@@ -29,7 +29,7 @@ func TestPluginRegistration(t *testing.T) {
 		names[p.Name()] = true
 	}
 
-	expected := []string{"cost", "diskblob", "git", "github", "gitlab", "inmemcache", "local-exec", "policy", "summary", "tfupdate"}
+	expected := []string{"azuredevops", "bitbucket", "buildkite", "cost", "diskblob", "git", "github", "gitlab", "inmemcache", "jenkins", "local-exec", "policy", "summary", "tfupdate"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("missing plugin: %s", name)
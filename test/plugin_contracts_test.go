@@ -22,6 +22,18 @@ func TestBuiltInPluginContractMatrix(t *testing.T) {
 		preflight    bool
 		pipeline     bool
 	}{
+		"azuredevops": {
+			configLoader: true,
+			preflight:    true,
+		},
+		"bitbucket": {
+			configLoader: true,
+			preflight:    true,
+		},
+		"buildkite": {
+			configLoader: true,
+			preflight:    true,
+		},
 		"cost": {
 			configLoader: true,
 			command:      true,
@@ -42,6 +54,10 @@ func TestBuiltInPluginContractMatrix(t *testing.T) {
 			configLoader: true,
 			preflight:    true,
 		},
+		"jenkins": {
+			configLoader: true,
+			preflight:    true,
+		},
 		"inmemcache": {
 			configLoader: true,
 		},
@@ -58,3 +58,20 @@ func TestSchema_ToStdout(t *testing.T) {
 		t.Fatalf("invalid JSON from stdout: %v", jsonErr)
 	}
 }
+
+func TestSchema_IR(t *testing.T) {
+	dir := fixtureDir(t, "basic")
+
+	output, err := captureTerraCi(t, dir, "schema", "--ir")
+	if err != nil {
+		t.Fatalf("schema --ir failed: %v", err)
+	}
+
+	var schema map[string]any
+	if jsonErr := json.Unmarshal([]byte(output), &schema); jsonErr != nil {
+		t.Fatalf("invalid JSON from stdout: %v", jsonErr)
+	}
+	if schema["title"] != "TerraCi Pipeline IR" {
+		t.Fatalf("title = %v, want TerraCi Pipeline IR", schema["title"])
+	}
+}
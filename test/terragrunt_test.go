@@ -0,0 +1,57 @@
+package test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTerragrunt_GraphFromDependencyBlocks(t *testing.T) {
+	dir := fixtureDir(t, "terragrunt")
+
+	output, err := captureTerraCi(t, dir, "graph", "--format", "list")
+	if err != nil {
+		t.Fatalf("graph failed: %v", err)
+	}
+
+	assertContains(t, output, "eu-central-1/eks → eu-central-1/vpc")
+	assertContains(t, output, "eu-central-1/app → eu-central-1/vpc, eu-central-1/eks")
+	if strings.Contains(output, "modules/vpc") {
+		t.Errorf("plain Terraform module listed as a unit:\n%s", output)
+	}
+}
+
+func TestTerragrunt_GenerateRunsTerragrunt(t *testing.T) {
+	dir := fixtureDir(t, "terragrunt")
+
+	output, err := captureTerraCi(t, dir, "generate")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	assertContains(t, output, "terragrunt plan")
+	assertContains(t, output, "terragrunt apply")
+
+	pipeline := parseYAML(t, output)
+	appPlan, ok := pipeline["plan-platform-prod-eu-central-1-app"].(map[string]any)
+	if !ok {
+		t.Fatal("missing plan-platform-prod-eu-central-1-app job")
+	}
+	needs, _ := appPlan["needs"].([]any)
+	want := map[string]bool{
+		"apply-platform-prod-eu-central-1-vpc": false,
+		"apply-platform-prod-eu-central-1-eks": false,
+	}
+	for _, need := range needs {
+		if needMap, ok := need.(map[string]any); ok {
+			if job, ok := needMap["job"].(string); ok {
+				if _, tracked := want[job]; tracked {
+					want[job] = true
+				}
+			}
+		}
+	}
+	for job, found := range want {
+		if !found {
+			t.Errorf("app plan needs = %v, missing %s", needs, job)
+		}
+	}
+}
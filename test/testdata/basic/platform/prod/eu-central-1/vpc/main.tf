@@ -9,3 +9,7 @@ terraform {
 resource "aws_vpc" "main" {
   cidr_block = "10.0.0.0/16"
 }
+
+output "subnet_ids" {
+  value = []
+}
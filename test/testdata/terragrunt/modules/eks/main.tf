@@ -0,0 +1 @@
+# eks module
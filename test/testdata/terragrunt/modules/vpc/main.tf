@@ -0,0 +1 @@
+# vpc module
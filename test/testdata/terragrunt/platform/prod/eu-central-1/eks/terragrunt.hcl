@@ -0,0 +1,15 @@
+include "root" {
+  path = find_in_parent_folders()
+}
+
+terraform {
+  source = "../../../../modules/eks"
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+inputs = {
+  subnet_ids = dependency.vpc.outputs.subnet_ids
+}
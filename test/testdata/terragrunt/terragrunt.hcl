@@ -0,0 +1,12 @@
+locals {
+  env = read_terragrunt_config(find_in_parent_folders("env.hcl"))
+}
+
+remote_state {
+  backend = "s3"
+  config = {
+    bucket = "terraform-state"
+    key    = "${path_relative_to_include()}/terraform.tfstate"
+    region = "eu-central-1"
+  }
+}
@@ -0,0 +1,8 @@
+data "terraform_remote_state" "vpc" {
+  backend = "s3"
+  config = {
+    bucket = "terraform-state"
+    key    = "platform/prod/eu-central-1/vpc/terraform.tfstate"
+    region = "eu-central-1"
+  }
+}